@@ -0,0 +1,241 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// listDiskBuckets - lists the buckets on each disk of an erasure set,
+// nil for the disks which are offline.
+func listDiskBuckets(disks []StorageAPI) []map[string]struct{} {
+	buckets := make([]map[string]struct{}, len(disks))
+	var wg sync.WaitGroup
+	for i, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, disk StorageAPI) {
+			defer wg.Done()
+			vols, err := disk.ListVols()
+			if err != nil {
+				return
+			}
+			names := make(map[string]struct{}, len(vols))
+			for _, vol := range vols {
+				// Skip volumes which are not buckets, like the
+				// meta-bucket.
+				if !IsValidBucketName(vol.Name) || isMinioMetaBucketName(vol.Name) {
+					continue
+				}
+				names[vol.Name] = struct{}{}
+			}
+			buckets[i] = names
+		}(i, disk)
+	}
+	wg.Wait()
+	return buckets
+}
+
+// listBucketConfigDirs - lists the buckets having config files in the
+// meta-bucket of any of the given disks.
+func listBucketConfigDirs(disks []StorageAPI) map[string]struct{} {
+	buckets := make(map[string]struct{})
+	for _, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		entries, err := disk.ListDir(minioMetaBucket, bucketConfigPrefix, -1)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry, slashSeparator) {
+				continue
+			}
+			if bucket := strings.TrimSuffix(entry, slashSeparator); IsValidBucketName(bucket) {
+				buckets[bucket] = struct{}{}
+			}
+		}
+	}
+	return buckets
+}
+
+// healedBucketMetadataFiles - returns the config files which were
+// missing or corrupt on some disks among the given heal results.
+func healedBucketMetadataFiles(results []madmin.HealResultItem) (files []string) {
+	for _, result := range results {
+		for _, drive := range result.Before.Drives {
+			if drive.State == madmin.DriveStateMissing || drive.State == madmin.DriveStateCorrupt {
+				files = append(files, path.Base(result.Object))
+				break
+			}
+		}
+	}
+	return files
+}
+
+// verifyBucket - verifies that the bucket exists on all online disks
+// of the given erasure sets, which hold the buckets listed in
+// setBuckets, along with its config files. Missing buckets and config
+// files are recreated from quorum and config files of buckets which
+// no longer exist are removed, unless on a dry run.
+func verifyBucket(ctx context.Context, objAPI ObjectLayer, sets []*xlObjects, setDisks [][]StorageAPI,
+	setBuckets [][]map[string]struct{}, bucket string, dryRun bool) (result madmin.BucketVerifyResult) {
+
+	result.Bucket = bucket
+
+	bucketLock := globalNSMutex.NewNSLock(bucket, "")
+	if err := bucketLock.GetLock(globalHealingTimeout); err != nil {
+		result.State = madmin.BucketVerifyFailed
+		result.Detail = err.Error()
+		return result
+	}
+	defer bucketLock.Unlock()
+
+	// A bucket exists when the disks of one of the erasure sets hold
+	// it in quorum, buckets being created on all sets at once.
+	present := 0
+	inQuorum := false
+	for i := range setDisks {
+		count := 0
+		for j, buckets := range setBuckets[i] {
+			if buckets == nil {
+				continue
+			}
+			if _, ok := buckets[bucket]; ok {
+				count++
+			} else {
+				result.MissingDisks = append(result.MissingDisks, setDisks[i][j].String())
+			}
+		}
+		present += count
+		if count > 0 && count >= len(setDisks[i])/2 {
+			inQuorum = true
+		}
+	}
+
+	switch {
+	case !inQuorum && present == 0:
+		// Config files of a deleted bucket, which a new bucket of
+		// the same name would inherit.
+		result.State = madmin.BucketVerifyOrphaned
+		result.MissingDisks = nil
+		if !dryRun {
+			deleteBucketMetadata(ctx, bucket, objAPI)
+			result.Repaired = true
+		}
+		return result
+	case !inQuorum:
+		result.State = madmin.BucketVerifyNoQuorum
+		result.Detail = fmt.Sprintf("bucket found on %d disks only, too few to recover it from quorum", present)
+		return result
+	}
+
+	var errs []string
+	for i, set := range sets {
+		missing := false
+		for _, buckets := range setBuckets[i] {
+			if _, ok := buckets[bucket]; buckets != nil && !ok {
+				missing = true
+				break
+			}
+		}
+		if missing && !dryRun {
+			writeQuorum := len(setDisks[i])/2 + 1
+			if _, err := healBucket(ctx, setDisks[i], bucket, writeQuorum, false); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+
+		// Config files are held by a single erasure set, the
+		// others find none.
+		results, err := healBucketMetadata(*set, bucket, dryRun)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		result.MetadataFiles = append(result.MetadataFiles, healedBucketMetadataFiles(results)...)
+	}
+
+	switch {
+	case len(result.MissingDisks) == 0 && len(result.MetadataFiles) == 0 && len(errs) == 0:
+		result.State = madmin.BucketVerifyOK
+	case len(errs) > 0:
+		result.State = madmin.BucketVerifyFailed
+		result.Detail = strings.Join(errs, ", ")
+	default:
+		result.State = madmin.BucketVerifyInconsistent
+		result.Repaired = !dryRun
+	}
+	return result
+}
+
+// verifyBuckets - verifies every bucket found on any disk of the given
+// erasure sets or having config files, see verifyBucket.
+func verifyBuckets(ctx context.Context, objAPI ObjectLayer, sets []*xlObjects, dryRun bool) madmin.BucketVerifyReport {
+	report := madmin.BucketVerifyReport{
+		DryRun:  dryRun,
+		Buckets: []madmin.BucketVerifyResult{},
+	}
+
+	var allDisks []StorageAPI
+	setDisks := make([][]StorageAPI, len(sets))
+	setBuckets := make([][]map[string]struct{}, len(sets))
+	names := make(map[string]struct{})
+	for i, set := range sets {
+		setDisks[i] = set.getDisks()
+		setBuckets[i] = listDiskBuckets(setDisks[i])
+		allDisks = append(allDisks, setDisks[i]...)
+		for _, buckets := range setBuckets[i] {
+			if buckets == nil {
+				report.OfflineDisks++
+				continue
+			}
+			for bucket := range buckets {
+				names[bucket] = struct{}{}
+			}
+		}
+	}
+	for bucket := range listBucketConfigDirs(allDisks) {
+		names[bucket] = struct{}{}
+	}
+
+	buckets := make([]string, 0, len(names))
+	for bucket := range names {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+
+	for _, bucket := range buckets {
+		report.Buckets = append(report.Buckets, verifyBucket(ctx, objAPI, sets, setDisks, setBuckets, bucket, dryRun))
+	}
+	return report
+}
+
+// verifyBuckets - verifies the buckets on the disks of all erasure
+// sets.
+func (s *xlSets) verifyBuckets(ctx context.Context, dryRun bool) madmin.BucketVerifyReport {
+	return verifyBuckets(ctx, s, s.sets, dryRun)
+}
@@ -0,0 +1,131 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests that buckets missing on some disks and their config files are
+// recreated, that config files of deleted buckets are removed and that
+// buckets without quorum are only reported.
+func TestVerifyBuckets(t *testing.T) {
+	objLayer, fsDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	s := objLayer.(*xlSets)
+	ctx := context.Background()
+
+	putConfig := func(bucket string) {
+		data := []byte(`{"Version":"2012-10-17","Statement":[]}`)
+		configFile := path.Join(bucketConfigPrefix, bucket, bucketPolicyConfig)
+		if _, err := objLayer.PutObject(ctx, minioMetaBucket, configFile,
+			mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+			t.Fatalf("Failed to save config of %s - %v", bucket, err)
+		}
+	}
+	states := func(report madmin.BucketVerifyReport) map[string]string {
+		m := make(map[string]string)
+		for _, result := range report.Buckets {
+			m[result.Bucket] = result.State
+		}
+		return m
+	}
+
+	for _, bucket := range []string{"bucket", "gone"} {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("Failed to make bucket %s - %v", bucket, err)
+		}
+		putConfig(bucket)
+	}
+
+	report := s.verifyBuckets(ctx, false)
+	expected := map[string]string{"bucket": madmin.BucketVerifyOK, "gone": madmin.BucketVerifyOK}
+	if got := states(report); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %v but got %v", expected, got)
+	}
+
+	disks := s.sets[0].getDisks()
+
+	// Remove the bucket from the first disk and its config file
+	// from the next disks.
+	if err = disks[0].DeleteVol("bucket"); err != nil {
+		t.Fatal(err)
+	}
+	for _, disk := range disks[1:4] {
+		if err = disk.DeleteFile(minioMetaBucket, path.Join(bucketConfigPrefix, "bucket", bucketPolicyConfig, xlMetaJSONFile)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Remove the other bucket from all disks, leaving its config.
+	for _, disk := range disks {
+		if err = disk.DeleteVol("gone"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Create a bucket on a single disk.
+	if err = disks[3].MakeVol("dangling"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = map[string]string{
+		"bucket":   madmin.BucketVerifyInconsistent,
+		"gone":     madmin.BucketVerifyOrphaned,
+		"dangling": madmin.BucketVerifyNoQuorum,
+	}
+	for _, dryRun := range []bool{true, false} {
+		report = s.verifyBuckets(ctx, dryRun)
+		if got := states(report); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Expected %v but got %v", expected, got)
+		}
+		for _, result := range report.Buckets {
+			if result.Bucket == "dangling" && result.Repaired {
+				t.Errorf("Expected bucket without quorum not to be repaired")
+			} else if result.Bucket != "dangling" && result.Repaired == dryRun {
+				t.Errorf("Expected %s to be repaired %v but got %v", result.Bucket, !dryRun, result)
+			}
+			if result.Bucket != "bucket" {
+				continue
+			}
+			if !reflect.DeepEqual(result.MissingDisks, []string{disks[0].String()}) {
+				t.Errorf("Expected the first disk to miss the bucket but got %v", result.MissingDisks)
+			}
+			if !reflect.DeepEqual(result.MetadataFiles, []string{bucketPolicyConfig}) {
+				t.Errorf("Expected the policy to be missing but got %v", result.MetadataFiles)
+			}
+		}
+	}
+
+	report = s.verifyBuckets(ctx, false)
+	expected = map[string]string{
+		"bucket":   madmin.BucketVerifyOK,
+		"dangling": madmin.BucketVerifyNoQuorum,
+	}
+	if got := states(report); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected %v after repair but got %v", expected, got)
+	}
+}
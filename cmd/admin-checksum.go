@@ -0,0 +1,320 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	sha256 "github.com/minio/sha256-simd"
+)
+
+const (
+	// Maximum number of checksums returned by a single status
+	// request of a checksum scan.
+	maxChecksumsPerReply = 1000
+
+	// Maximum number of checksums computed but not yet received
+	// by the client, the scan waits for the client beyond it.
+	maxPendingChecksums = 10000
+
+	// Time after which a scan waiting for its client to receive
+	// checksums is stopped.
+	checksumScanIdleTimeout = 30 * time.Minute
+)
+
+var (
+	errChecksumScanStopSignalled = errors.New("checksum scan stop signaled")
+	errChecksumScanIdle          = errors.New("checksum scan stopped as checksums were not received")
+	errUnknownChecksumAlgorithm  = errors.New("unknown checksum algorithm")
+	errChecksumOffsetTooOld      = errors.New("checksums before the given offset were already received")
+)
+
+// newChecksumHash - returns a hash computing checksums with the given
+// algorithm, MD5 by default.
+func newChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "", madmin.ChecksumMD5:
+		return md5.New(), nil
+	case madmin.ChecksumSHA256:
+		return sha256.New(), nil
+	case madmin.ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	}
+	return nil, errUnknownChecksumAlgorithm
+}
+
+// checksumScanSequence - state of a read-only scan computing the
+// checksum of each object under a bucket and prefix. Checksums are
+// kept until the client received them, see Status.
+type checksumScanSequence struct {
+	// bucket and prefix on which the scan was initiated
+	bucket, objPrefix string
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.ChecksumScanStatus
+
+	// checksums not yet received by the client, the first one
+	// being at offset in all the checksums computed
+	checksums []madmin.ObjectChecksum
+	offset    int64
+
+	// channel to signal that the client received checksums
+	receivedCh chan struct{}
+
+	// channel to signal the scan to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// Time the status of a checksum scan, along with the checksums not
+// yet received, is kept once the scan ended.
+const keepChecksumScanStatusDuration = 10 * time.Minute
+
+// checksumScanState - holds the checksum scan running on this server
+// and the ended ones until their status expires. Only one scan may
+// run at a time.
+type checksumScanState struct {
+	*adminScanRegistry
+}
+
+var globalChecksumScanState = checksumScanState{newAdminScanRegistry(keepChecksumScanStatusDuration)}
+
+// newChecksumScanSequence - creates a checksum scan, assumes bucket,
+// objPrefix and algorithm are already validated.
+func newChecksumScanSequence(bucket, objPrefix, algorithm, clientAddr string) *checksumScanSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "Checksum", BucketName: bucket}
+	reqInfo.AppendTags("prefix", objPrefix)
+
+	if algorithm == "" {
+		algorithm = madmin.ChecksumMD5
+	}
+	return &checksumScanSequence{
+		bucket:    bucket,
+		objPrefix: objPrefix,
+		status: madmin.ChecksumScanStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.ChecksumScanRunning,
+			StartTime:   UTCNow(),
+			Bucket:      bucket,
+			Prefix:      objPrefix,
+			Algorithm:   algorithm,
+		},
+		receivedCh:   make(chan struct{}, 1),
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the scan unless another one is running. A running
+// scan is stopped first if forceStart is set.
+func (s *checksumScanState) Launch(seq *checksumScanSequence, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, seq.run)
+}
+
+// Status - returns the status of the scan identified by clientToken
+// along with at most limit checksums starting at the given offset.
+// Checksums before the offset are considered received by the client
+// and are dropped, they cannot be requested again.
+func (s *checksumScanState) Status(clientToken string, offset int64, limit int) (madmin.ChecksumScanStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.ChecksumScanStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*checksumScanSequence)
+
+	status, err := seq.receive(offset, limit)
+	if err != nil {
+		return status, ErrAdminInvalidArgument
+	}
+	return status, ErrNone
+}
+
+// receive - drops the checksums before offset and returns the status
+// of the scan with at most limit checksums from offset.
+func (seq *checksumScanSequence) receive(offset int64, limit int) (madmin.ChecksumScanStatus, error) {
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	if offset < seq.offset {
+		return madmin.ChecksumScanStatus{}, errChecksumOffsetTooOld
+	}
+	if offset > seq.status.ObjectsChecksummed {
+		offset = seq.status.ObjectsChecksummed
+	}
+	if received := offset - seq.offset; received > 0 {
+		seq.checksums = append([]madmin.ObjectChecksum(nil), seq.checksums[received:]...)
+		seq.offset = offset
+
+		// Wake up the scan if it waits for the client.
+		select {
+		case seq.receivedCh <- struct{}{}:
+		default:
+		}
+	}
+
+	if limit <= 0 || limit > maxChecksumsPerReply {
+		limit = maxChecksumsPerReply
+	}
+	start, end := pageBounds(len(seq.checksums), 0, limit)
+
+	status := seq.status
+	status.Offset = offset
+	status.Checksums = append([]madmin.ObjectChecksum{}, seq.checksums[start:end]...)
+	return status, nil
+}
+
+func (seq *checksumScanSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *checksumScanSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.ChecksumScanRunning
+}
+
+// stop - stops the scan, safe to call multiple times.
+func (seq *checksumScanSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *checksumScanSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// pending - returns the number of checksums not yet received.
+func (seq *checksumScanSequence) pending() int {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return len(seq.checksums)
+}
+
+// waitForClient - waits until the client received enough checksums
+// for the scan to go on.
+func (seq *checksumScanSequence) waitForClient() error {
+	for seq.pending() >= maxPendingChecksums {
+		select {
+		case <-seq.receivedCh:
+		case <-seq.stopSignalCh:
+			return errChecksumScanStopSignalled
+		case <-time.After(checksumScanIdleTimeout):
+			return errChecksumScanIdle
+		}
+	}
+	return nil
+}
+
+// run - walks the namespace and computes the checksum of each object.
+func (seq *checksumScanSequence) run() {
+	err := seq.scan()
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.ChecksumScanStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.ChecksumScanFinished
+}
+
+func (seq *checksumScanSequence) scan() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	marker := ""
+	for {
+		if seq.isQuitting() {
+			return errChecksumScanStopSignalled
+		}
+		result, err := objectAPI.ListObjects(seq.ctx, seq.bucket, seq.objPrefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range result.Objects {
+			if err = seq.waitForClient(); err != nil {
+				return err
+			}
+			if seq.isQuitting() {
+				return errChecksumScanStopSignalled
+			}
+
+			checksum, err := seq.checksumObject(objectAPI, obj.Bucket, obj.Name)
+			if err != nil {
+				// Objects may be removed while scanning.
+				if isErrObjectNotFound(err) {
+					continue
+				}
+				return err
+			}
+
+			seq.mu.Lock()
+			seq.checksums = append(seq.checksums, madmin.ObjectChecksum{
+				Object:   obj.Name,
+				Size:     obj.Size,
+				Checksum: checksum,
+			})
+			seq.status.ObjectsChecksummed++
+			seq.mu.Unlock()
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// checksumObject - returns the hex encoded checksum of the object
+// content.
+func (seq *checksumScanSequence) checksumObject(objectAPI ObjectLayer, bucket, object string) (string, error) {
+	h, err := newChecksumHash(seq.status.Algorithm)
+	if err != nil {
+		return "", err
+	}
+	if err = objectAPI.GetObject(seq.ctx, bucket, object, 0, -1, h, ""); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Header selecting how configs are encrypted in transit.
+	configEncryptionHeader = "X-Minio-Config-Encryption"
+
+	// Configs are encrypted with a key wrapped by the KMS, instead
+	// of a key derived from the secret key.
+	configEncryptionKMS = "kms"
+)
+
+var errConfigKMSNotConfigured = errors.New("KMS is not configured")
+
+// configKMS - adapts the KMS of the server to the interface used to
+// encrypt configs.
+type configKMS struct {
+	kms crypto.KMS
+}
+
+func (k configKMS) GenerateKey(keyID string, context map[string]string) ([32]byte, []byte, error) {
+	return k.kms.GenerateKey(keyID, crypto.Context(context))
+}
+
+func (k configKMS) UnsealKey(keyID string, sealedKey []byte, context map[string]string) ([32]byte, error) {
+	return k.kms.UnsealKey(keyID, sealedKey, crypto.Context(context))
+}
+
+// usesConfigKMS - returns true if the client exchanges configs
+// encrypted with keys wrapped by the KMS.
+func usesConfigKMS(r *http.Request) bool {
+	return r.Header.Get(configEncryptionHeader) == configEncryptionKMS
+}
+
+// encryptConfigData - encrypts a config sent to the client, with a key
+// wrapped by the KMS if the client asked for it, or derived from the
+// password otherwise.
+func encryptConfigData(r *http.Request, password string, data []byte) ([]byte, error) {
+	if !usesConfigKMS(r) {
+		return madmin.EncryptServerConfigData(password, data)
+	}
+	if globalKMS == nil {
+		return nil, errConfigKMSNotConfigured
+	}
+	return madmin.EncryptServerConfigDataKMS(configKMS{globalKMS}, globalKMSKeyID, data)
+}
+
+// decryptConfigData - decrypts a config sent by the client, the
+// counterpart of encryptConfigData.
+func decryptConfigData(r *http.Request, password string, data io.Reader) ([]byte, error) {
+	if !usesConfigKMS(r) {
+		return madmin.DecryptServerConfigData(password, data)
+	}
+	if globalKMS == nil {
+		return nil, errConfigKMSNotConfigured
+	}
+	return madmin.DecryptServerConfigDataKMS(configKMS{globalKMS}, data)
+}
@@ -0,0 +1,235 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Time to wait for peers to come back up with a new config.
+	configReadyTimeout = 2 * time.Minute
+
+	// Interval between two checks of the config loaded by a peer.
+	configReadyInterval = time.Second
+
+	// Maximum delay of the restart applying a new config, during
+	// which the restart can be cancelled.
+	maxConfigRestartDelay = 5 * time.Minute
+)
+
+var errConfigNotLoaded = errors.New("server has not loaded the new config")
+
+// waitForPeersConfig - waits until the given peers run with the config
+// of the given checksum or the timeout elapses, and returns the
+// readiness of each peer in the same order.
+func waitForPeersConfig(peers adminPeers, checksum string, timeout, interval time.Duration) []madmin.ConfigReadiness {
+	readiness := make([]madmin.ConfigReadiness, len(peers))
+	deadline := UTCNow().Add(timeout)
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			readiness[idx].Addr = peer.addr
+			for {
+				// Peers are unreachable while restarting and
+				// run with the previous config until then.
+				info, err := peer.cmdRunner.ServerInfo()
+				if err == nil && info.Properties.ConfigChecksum != checksum {
+					err = errConfigNotLoaded
+				}
+				if err == nil {
+					readiness[idx].Ready = true
+					readiness[idx].Error = ""
+					return
+				}
+				readiness[idx].Error = err.Error()
+
+				if UTCNow().Add(interval).After(deadline) {
+					return
+				}
+				time.Sleep(interval)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return readiness
+}
+
+// getConfigConsistency - compares the checksum of the config loaded by
+// each peer with the given checksum of the saved config. Peers which
+// cannot be reached are reported with an error, but not as divergent.
+func getConfigConsistency(peers adminPeers, checksum string) madmin.ConfigConsistency {
+	servers := make([]madmin.ServerConfigChecksum, len(peers))
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx].Addr = peer.addr
+			info, err := peer.cmdRunner.ServerInfo()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+			servers[idx].Checksum = info.Properties.ConfigChecksum
+			servers[idx].Consistent = servers[idx].Checksum == checksum
+		}(i, p)
+	}
+	wg.Wait()
+
+	consistency := madmin.ConfigConsistency{
+		Consistent: true,
+		Checksum:   checksum,
+		Servers:    servers,
+		Divergent:  []string{},
+	}
+	for _, server := range servers {
+		if server.Consistent {
+			continue
+		}
+		consistency.Consistent = false
+		if server.Error == "" {
+			consistency.Divergent = append(consistency.Divergent, server.Addr)
+		}
+	}
+	return consistency
+}
+
+// extractConfigRestartDelay - returns the delay of the restart applying
+// a new config, zero to restart immediately. A delayed restart cannot
+// be waited for.
+func extractConfigRestartDelay(qParms url.Values) (time.Duration, APIErrorCode) {
+	v := qParms.Get(string(mgmtRestartDelay))
+	if v == "" {
+		return 0, ErrNone
+	}
+	if _, waitForReady := qParms[string(mgmtWaitForReady)]; waitForReady {
+		return 0, ErrAdminInvalidArgument
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < time.Second || d > maxConfigRestartDelay {
+		return 0, ErrAdminInvalidArgument
+	}
+	return d, ErrNone
+}
+
+// scheduleConfigRestart - schedules the restart of all servers applying
+// the config which was just saved, and replies with the time of the
+// restart along with the token cancelling it. If any server fails to
+// schedule it, the restart is cancelled on all servers.
+func scheduleConfigRestart(ctx context.Context, w http.ResponseWriter, r *http.Request, delay time.Duration) {
+	restart := madmin.ScheduledRestart{
+		RestartTime: UTCNow().Add(delay),
+		CancelToken: mustGetUUID(),
+	}
+
+	var details []string
+	for i, err := range schedulePeersRestart(globalAdminPeers, delay, restart.CancelToken) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		cancelPeersRestart(globalAdminPeers, restart.CancelToken)
+		writeCustomErrorResponseJSON(w, ErrInternalError, "Config was saved but the restart applying it could not be scheduled", r.URL, details...)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(restart)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// restartForConfig - restarts all servers so that they run with the
+// config which was just saved.
+//
+// By default the reply holds whether each server acknowledged the
+// restart. With the `waitForReady` flag, it holds whether each of them
+// came back up with the new config before a timeout instead. Either
+// way, this server restarts once the reply is sent.
+//
+// With a restart delay, the restart is scheduled instead, see
+// scheduleConfigRestart.
+func restartForConfig(ctx context.Context, w http.ResponseWriter, r *http.Request, config *serverConfig, restartDelay time.Duration) {
+	if restartDelay > 0 {
+		scheduleConfigRestart(ctx, w, r, restartDelay)
+		return
+	}
+
+	if _, waitForReady := r.URL.Query()[string(mgmtWaitForReady)]; !waitForReady {
+		signalServiceAndReply(ctx, w, r, globalAdminPeers, serviceRestart)
+		return
+	}
+
+	// The first peer is the local server.
+	localPeers, remotePeers := globalAdminPeers[:1], globalAdminPeers[1:]
+
+	// Servers which failed to restart are not waited for.
+	readiness := []madmin.ConfigReadiness{}
+	var restarted adminPeers
+	for i, err := range signalPeers(remotePeers, serviceRestart) {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", remotePeers[i].addr)
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+			readiness = append(readiness, madmin.ConfigReadiness{
+				Addr:  remotePeers[i].addr,
+				Error: err.Error(),
+			})
+			continue
+		}
+		restarted = append(restarted, remotePeers[i])
+	}
+
+	checksum := configChecksum(config)
+	readiness = append(readiness, waitForPeersConfig(restarted, checksum, configReadyTimeout, configReadyInterval)...)
+
+	jsonBytes, err := json.Marshal(readiness)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+	} else {
+		writeSuccessResponseJSON(w, jsonBytes)
+	}
+
+	sendServiceCmd(localPeers, serviceRestart)
+}
@@ -0,0 +1,114 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// configReadyTestRunner - admin command runner whose server info
+// reports a sequence of config checksums, an empty checksum standing
+// for a restarting server.
+type configReadyTestRunner struct {
+	localAdminClient
+	sync.Mutex
+	checksums []string
+}
+
+func (runner *configReadyTestRunner) ServerInfo() (sid ServerInfoData, err error) {
+	runner.Lock()
+	defer runner.Unlock()
+
+	checksum := runner.checksums[0]
+	if len(runner.checksums) > 1 {
+		runner.checksums = runner.checksums[1:]
+	}
+	if checksum == "" {
+		return sid, errServerNotInitialized
+	}
+	sid.Properties.ConfigChecksum = checksum
+	return sid, nil
+}
+
+// Tests waiting for peers to come back up with a new config.
+func TestWaitForPeersConfig(t *testing.T) {
+	peers := adminPeers{
+		{addr: "ready", cmdRunner: &configReadyTestRunner{checksums: []string{"new"}}},
+		{addr: "restarted", cmdRunner: &configReadyTestRunner{checksums: []string{"old", "", "new"}}},
+		{addr: "down", cmdRunner: &configReadyTestRunner{checksums: []string{""}}},
+		{addr: "stale", cmdRunner: &configReadyTestRunner{checksums: []string{"old"}}},
+	}
+
+	readiness := waitForPeersConfig(peers, "new", 100*time.Millisecond, 10*time.Millisecond)
+	if len(readiness) != len(peers) {
+		t.Fatalf("Expected readiness of %d peers but got %d", len(peers), len(readiness))
+	}
+
+	testCases := []struct {
+		ready bool
+		err   string
+	}{
+		{true, ""},
+		{true, ""},
+		{false, errServerNotInitialized.Error()},
+		{false, errConfigNotLoaded.Error()},
+	}
+	for i, testCase := range testCases {
+		if readiness[i].Addr != peers[i].addr {
+			t.Errorf("Test %d: Expected address %s but got %s", i+1, peers[i].addr, readiness[i].Addr)
+		}
+		if readiness[i].Ready != testCase.ready || readiness[i].Error != testCase.err {
+			t.Errorf("Test %d: Unexpected readiness %#v", i+1, readiness[i])
+		}
+	}
+}
+
+// Tests comparing the config of peers with the saved config.
+func TestGetConfigConsistency(t *testing.T) {
+	peers := adminPeers{
+		{addr: "current", cmdRunner: &configReadyTestRunner{checksums: []string{"new"}}},
+		{addr: "down", cmdRunner: &configReadyTestRunner{checksums: []string{""}}},
+		{addr: "stale", cmdRunner: &configReadyTestRunner{checksums: []string{"old"}}},
+	}
+
+	consistency := getConfigConsistency(peers, "new")
+	if consistency.Consistent || consistency.Checksum != "new" {
+		t.Errorf("Unexpected consistency %#v", consistency)
+	}
+	if !reflect.DeepEqual(consistency.Divergent, []string{"stale"}) {
+		t.Errorf("Expected stale to be divergent, got %v", consistency.Divergent)
+	}
+
+	expected := []madmin.ServerConfigChecksum{
+		{Addr: "current", Checksum: "new", Consistent: true},
+		{Addr: "down", Error: errServerNotInitialized.Error()},
+		{Addr: "stale", Checksum: "old"},
+	}
+	if !reflect.DeepEqual(consistency.Servers, expected) {
+		t.Errorf("Expected servers %#v, got %#v", expected, consistency.Servers)
+	}
+
+	consistency = getConfigConsistency(peers[:1], "new")
+	if !consistency.Consistent || len(consistency.Divergent) != 0 {
+		t.Errorf("Expected a consistent config, got %#v", consistency)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Environment variable listing the destructive admin actions
+	// which require a confirmation token, such as
+	// "service-stop,set-credentials".
+	adminConfirmActionsEnv = "MINIO_ADMIN_CONFIRM_ACTIONS"
+
+	// Header carrying the confirmation token of a destructive admin
+	// request.
+	adminConfirmationHeader = "X-Minio-Admin-Confirmation"
+
+	// Time a confirmation token is valid for once issued.
+	adminConfirmationValidity = 2 * time.Minute
+)
+
+// Destructive admin actions which may require a confirmation token.
+var confirmableAdminActions = map[string]struct{}{
+	madmin.ConfirmServiceStop:    {},
+	madmin.ConfirmServiceRestart: {},
+	madmin.ConfirmSetCredentials: {},
+}
+
+// Destructive admin actions requiring a confirmation token on this
+// server, none by default.
+var globalConfirmedAdminActions = map[string]bool{}
+
+// parseConfirmedAdminActions - parses a comma separated list of
+// destructive admin actions requiring a confirmation token.
+func parseConfirmedAdminActions(s string) (map[string]bool, error) {
+	actions := make(map[string]bool)
+	for _, action := range strings.Split(s, ",") {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		if _, ok := confirmableAdminActions[action]; !ok {
+			return nil, fmt.Errorf("unknown admin action `%s`", action)
+		}
+		actions[action] = true
+	}
+	return actions, nil
+}
+
+// adminConfirmationMAC - returns the signature of a token confirming
+// the given action for the credential with the given access key until
+// the given expiry, made with the secret key of the server.
+func adminConfirmationMAC(secretKey, accessKey, action string, expiry int64) []byte {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(strings.Join([]string{accessKey, action, strconv.FormatInt(expiry, 10)}, "\n")))
+	return mac.Sum(nil)
+}
+
+// newAdminConfirmation - issues a token confirming the given action
+// for the credential with the given access key. Tokens hold their
+// expiry and are signed with the secret key of the server, shared by
+// all servers, so that any server accepts a token issued by another
+// one without keeping track of the issued tokens.
+func newAdminConfirmation(secretKey, accessKey, action string, now time.Time) madmin.AdminConfirmation {
+	expiry := now.Add(adminConfirmationValidity).Truncate(time.Second)
+	mac := adminConfirmationMAC(secretKey, accessKey, action, expiry.Unix())
+	return madmin.AdminConfirmation{
+		Action:   action,
+		Token:    strconv.FormatInt(expiry.Unix(), 10) + "." + hex.EncodeToString(mac),
+		Expiry:   expiry,
+		Required: globalConfirmedAdminActions[action],
+	}
+}
+
+// isAdminConfirmationValid - returns true if the token confirms the
+// given action for the credential with the given access key and has
+// not expired. A token may confirm several requests until it expires.
+func isAdminConfirmationValid(secretKey, accessKey, action, token string, now time.Time) bool {
+	tokens := strings.SplitN(token, ".", 2)
+	if len(tokens) != 2 {
+		return false
+	}
+	expiry, err := strconv.ParseInt(tokens[0], 10, 64)
+	if err != nil || !now.Before(time.Unix(expiry, 0)) {
+		return false
+	}
+	mac, err := hex.DecodeString(tokens[1])
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac, adminConfirmationMAC(secretKey, accessKey, action, expiry))
+}
+
+// checkAdminConfirmation - returns ErrAdminConfirmationRequired if the
+// given action requires a confirmation token and the authenticated
+// request r does not carry a valid one.
+//
+// Confirmation tokens guard against destructive requests sent by
+// mistake, such as a script stopping the wrong cluster. They are no
+// second factor: anyone holding the key of a credential can request a
+// token for it.
+func checkAdminConfirmation(r *http.Request, action string) APIErrorCode {
+	if !globalConfirmedAdminActions[action] {
+		return ErrNone
+	}
+	cred, _, errCode := getAdminRequestCredential(r, "")
+	if errCode != ErrNone {
+		return errCode
+	}
+	secretKey := globalServerConfig.GetCredential().SecretKey
+	if !isAdminConfirmationValid(secretKey, cred.AccessKey, action, r.Header.Get(adminConfirmationHeader), UTCNow()) {
+		return ErrAdminConfirmationRequired
+	}
+	return ErrNone
+}
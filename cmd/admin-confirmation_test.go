@@ -0,0 +1,91 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseConfirmedAdminActions(t *testing.T) {
+	testCases := []struct {
+		s               string
+		expectedActions map[string]bool
+		expectErr       bool
+	}{
+		{"", map[string]bool{}, false},
+		{"service-stop", map[string]bool{madmin.ConfirmServiceStop: true}, false},
+		{"service-stop, set-credentials,", map[string]bool{madmin.ConfirmServiceStop: true, madmin.ConfirmSetCredentials: true}, false},
+		{"service-stop,format-disks", nil, true},
+	}
+	for i, testCase := range testCases {
+		actions, err := parseConfirmedAdminActions(testCase.s)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if !testCase.expectErr && !reflect.DeepEqual(actions, testCase.expectedActions) {
+			t.Errorf("Test %d: Expected %v but got %v", i+1, testCase.expectedActions, actions)
+		}
+	}
+}
+
+func TestAdminConfirmationToken(t *testing.T) {
+	secretKey := "minio123"
+	now := time.Unix(1500000000, 0)
+	issue := func(accessKey, action string) string {
+		confirmation := newAdminConfirmation(secretKey, accessKey, action, now)
+		if !confirmation.Expiry.Equal(now.Add(adminConfirmationValidity)) {
+			t.Fatalf("Unexpected expiry %s", confirmation.Expiry)
+		}
+		return confirmation.Token
+	}
+
+	token := issue("minio", madmin.ConfirmServiceStop)
+	expiry := strings.SplitN(token, ".", 2)[0]
+	testCases := []struct {
+		secretKey string
+		accessKey string
+		action    string
+		token     string
+		now       time.Time
+		expected  bool
+	}{
+		{secretKey, "minio", madmin.ConfirmServiceStop, "", now, false},
+		{secretKey, "minio", madmin.ConfirmServiceStop, token, now, true},
+		// A token confirms requests until it expires, on any
+		// server sharing the secret key.
+		{secretKey, "minio", madmin.ConfirmServiceStop, token, now.Add(time.Minute), true},
+		{secretKey, "minio", madmin.ConfirmServiceStop, token, now.Add(adminConfirmationValidity), false},
+		{"rotated123", "minio", madmin.ConfirmServiceStop, token, now, false},
+		{secretKey, "minio", madmin.ConfirmServiceRestart, token, now, false},
+		{secretKey, "other", madmin.ConfirmServiceStop, token, now, false},
+		// The expiry cannot be extended.
+		{secretKey, "minio", madmin.ConfirmServiceStop, strings.Replace(token, expiry, "9999999999", 1), now, false},
+		{secretKey, "minio", madmin.ConfirmServiceStop, expiry + ".zz", now, false},
+		{secretKey, "minio", madmin.ConfirmServiceStop, "token", now, false},
+	}
+	for i, testCase := range testCases {
+		valid := isAdminConfirmationValid(testCase.secretKey, testCase.accessKey, testCase.action, testCase.token, testCase.now)
+		if valid != testCase.expected {
+			t.Errorf("Test %d: Expected %v but got %v", i+1, testCase.expected, valid)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Prefix under minioMetaBucket of the objects written by
+	// consistency tests.
+	consistencyTestPrefix = "consistency-test"
+
+	// Size of the objects written by consistency tests, and default
+	// and maximum number of objects.
+	consistencyTestObjectSize     = 64 * humanize.KiByte
+	defaultConsistencyTestObjects = 10
+	maxConsistencyTestObjects     = 100
+)
+
+// isConsistencyTestObject - returns whether the object was written
+// by a consistency test, peers only read back such objects.
+func isConsistencyTestObject(object string) bool {
+	return strings.HasPrefix(object, consistencyTestPrefix+slashSeparator)
+}
+
+// readConsistencyTestObject - returns the hex encoded SHA256 of the
+// data of a consistency test object, or an empty string if the object
+// does not exist.
+func readConsistencyTestObject(ctx context.Context, objAPI ObjectLayer, object string) (string, error) {
+	if !isConsistencyTestObject(object) {
+		return "", errInvalidArgument
+	}
+	h := sha256.New()
+	err := objAPI.GetObject(ctx, minioMetaBucket, object, 0, -1, h, "")
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// runConsistencyTest - writes the given number of objects one after
+// the other, and right after each write reads it back from all
+// servers, reporting the servers which served missing or stale data.
+// The objects are deleted afterwards.
+func runConsistencyTest(ctx context.Context, objAPI ObjectLayer, peers adminPeers, objects int) (result madmin.ConsistencyTestResult, err error) {
+	prefix := pathJoin(consistencyTestPrefix, mustGetUUID())
+
+	result.Nodes = make([]madmin.ConsistencyNodeResult, len(peers))
+	for i, peer := range peers {
+		result.Nodes[i].Addr = peer.addr
+	}
+
+	var written []string
+	defer func() {
+		for _, object := range written {
+			if err := objAPI.DeleteObject(ctx, minioMetaBucket, object); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+	}()
+
+	data := make([]byte, consistencyTestObjectSize)
+	for n := 0; n < objects; n++ {
+		if _, err = io.ReadFull(rand.Reader, data); err != nil {
+			return result, err
+		}
+		sum := sha256.Sum256(data)
+		expected := hex.EncodeToString(sum[:])
+
+		object := pathJoin(prefix, fmt.Sprintf("%d", n))
+		reader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", expected)
+		if err != nil {
+			return result, err
+		}
+		// Record the object before writing it so that a partially
+		// written one is deleted as well.
+		written = append(written, object)
+		if _, err = objAPI.PutObject(ctx, minioMetaBucket, object, reader, nil); err != nil {
+			return result, err
+		}
+		result.Objects++
+
+		var wg sync.WaitGroup
+		for i, p := range peers {
+			wg.Add(1)
+			go func(node *madmin.ConsistencyNodeResult, peer adminPeer) {
+				defer wg.Done()
+
+				node.Reads++
+				got, err := peer.cmdRunner.ReadConsistencyTestObject(object)
+				switch {
+				case err != nil:
+					reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+					logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+					node.Failed++
+					node.Error = err.Error()
+				case got == "":
+					node.Missing++
+				case got != expected:
+					node.Stale++
+				}
+			}(&result.Nodes[i], p)
+		}
+		wg.Wait()
+	}
+
+	result.Consistent = true
+	for _, node := range result.Nodes {
+		if node.Failed+node.Missing+node.Stale > 0 {
+			result.Consistent = false
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+type consistencyTestRunner struct {
+	localAdminClient
+	sum string
+	err error
+}
+
+func (runner *consistencyTestRunner) ReadConsistencyTestObject(object string) (string, error) {
+	if runner.sum == "local" {
+		return runner.localAdminClient.ReadConsistencyTestObject(object)
+	}
+	return runner.sum, runner.err
+}
+
+// Tests that servers serving missing or stale data, or failing, are
+// reported as inconsistent.
+func TestRunConsistencyTest(t *testing.T) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	globalObjectAPI = objLayer
+
+	peers := adminPeers{
+		{addr: "server1", cmdRunner: &consistencyTestRunner{sum: "local"}},
+		{addr: "server2", cmdRunner: &consistencyTestRunner{}},
+		{addr: "server3", cmdRunner: &consistencyTestRunner{sum: "stale"}},
+		{addr: "server4", cmdRunner: &consistencyTestRunner{err: errors.New("unreachable")}},
+	}
+
+	result, err := runConsistencyTest(context.Background(), objLayer, peers, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Consistent || result.Objects != 2 || len(result.Nodes) != 4 {
+		t.Fatalf("Unexpected result %#v", result)
+	}
+	for i, node := range result.Nodes {
+		if node.Addr != peers[i].addr || node.Reads != 2 {
+			t.Errorf("Unexpected result of %s %#v", peers[i].addr, node)
+		}
+	}
+	if node := result.Nodes[0]; node.Failed+node.Missing+node.Stale != 0 {
+		t.Errorf("Expected consistent reads, got %#v", node)
+	}
+	if node := result.Nodes[1]; node.Missing != 2 {
+		t.Errorf("Expected missing reads, got %#v", node)
+	}
+	if node := result.Nodes[2]; node.Stale != 2 {
+		t.Errorf("Expected stale reads, got %#v", node)
+	}
+	if node := result.Nodes[3]; node.Failed != 2 || node.Error != "unreachable" {
+		t.Errorf("Expected failed reads, got %#v", node)
+	}
+
+	result, err = runConsistencyTest(context.Background(), objLayer, peers[:1], 2)
+	if err != nil || !result.Consistent {
+		t.Fatalf("Expected consistent result, got %#v, %v", result, err)
+	}
+}
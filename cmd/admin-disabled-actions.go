@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Section of config.json listing the admin actions whose endpoints are
+// disabled, such as ["admin:UpdateCredentials", "admin:Heal*"]. Admin
+// APIs cannot change it, so that it is only lifted by editing
+// config.json on the servers.
+const configSectionAdminDisabledActions = "adminDisabledActions"
+
+// Environment variable overriding the adminDisabledActions section of
+// config.json with a comma separated list of admin actions.
+const adminDisabledActionsEnv = "MINIO_ADMIN_DISABLED_ACTIONS"
+
+// parseDisabledAdminActions - parses a comma separated list of admin
+// actions to disable.
+func parseDisabledAdminActions(s string) ([]string, error) {
+	var actions []string
+	for _, action := range strings.Split(s, ",") {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	if err := validateDisabledAdminActions(actions); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// validateDisabledAdminActions - checks the admin actions to disable.
+// Entries ending with "*" are patterns disabling all actions with the
+// same prefix, as in admin policies.
+func validateDisabledAdminActions(actions []string) error {
+	for _, action := range actions {
+		if _, ok := supportedAdminActions[adminAction(action)]; !ok && !strings.HasSuffix(action, "*") {
+			return fmt.Errorf("unknown admin action `%s`", action)
+		}
+	}
+	return nil
+}
+
+// disabledAdminActions - admin actions whose endpoints are disabled
+// on this server, whichever credential signs the request.
+type disabledAdminActions struct {
+	sync.RWMutex
+	actions []string
+}
+
+// Admin actions disabled by config.json or MINIO_ADMIN_DISABLED_ACTIONS.
+var globalDisabledAdminActions = &disabledAdminActions{}
+
+// Set when the disabled admin actions are set by
+// MINIO_ADMIN_DISABLED_ACTIONS rather than config.json.
+var globalIsEnvAdminDisabledActions bool
+
+// set - replaces the disabled admin actions.
+func (d *disabledAdminActions) set(actions []string) {
+	d.Lock()
+	defer d.Unlock()
+	d.actions = append([]string(nil), actions...)
+}
+
+// isDisabled - returns whether the endpoints requiring the given
+// admin action are disabled.
+func (d *disabledAdminActions) isDisabled(action adminAction) bool {
+	d.RLock()
+	defer d.RUnlock()
+	for _, pattern := range d.actions {
+		if wildcard.MatchSimple(pattern, string(action)) {
+			return true
+		}
+	}
+	return false
+}
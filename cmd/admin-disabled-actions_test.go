@@ -0,0 +1,100 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseDisabledAdminActions(t *testing.T) {
+	testCases := []struct {
+		s          string
+		expected   []string
+		shouldPass bool
+	}{
+		{"", nil, true},
+		{"admin:UpdateCredentials", []string{"admin:UpdateCredentials"}, true},
+		{" admin:UpdateCredentials, admin:Heal* ,", []string{"admin:UpdateCredentials", "admin:Heal*"}, true},
+		{"admin:RotateEverything", nil, false},
+		{"admin:UpdateCredentials,update-credentials", nil, false},
+	}
+	for i, testCase := range testCases {
+		actions, err := parseDisabledAdminActions(testCase.s)
+		if (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: expected to pass %v, got %v", i+1, testCase.shouldPass, err)
+			continue
+		}
+		if !reflect.DeepEqual(actions, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, actions)
+		}
+	}
+}
+
+// Tests that disabled actions match exactly or by prefix pattern and
+// that their requests are rejected before their signature is checked.
+func TestDisabledAdminActions(t *testing.T) {
+	defer globalDisabledAdminActions.set(nil)
+	globalDisabledAdminActions.set([]string{"admin:UpdateCredentials", "admin:Heal*"})
+
+	testCases := []struct {
+		action   adminAction
+		disabled bool
+	}{
+		{adminUpdateCredentialsAction, true},
+		{adminHealAction, true},
+		{adminSetConfigAction, false},
+		{adminServerInfoAction, false},
+	}
+	for i, testCase := range testCases {
+		if disabled := globalDisabledAdminActions.isDisabled(testCase.action); disabled != testCase.disabled {
+			t.Errorf("Test %d: Expected %s disabled to be %v, got %v", i+1, testCase.action, testCase.disabled, disabled)
+		}
+	}
+
+	// An anonymous request is rejected as disabled, not as unsigned.
+	req, err := http.NewRequest(http.MethodPut, "http://localhost:9000/minio/admin/v1/config/credential", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errCode := checkAdminRequestAuthType(req, adminUpdateCredentialsAction, ""); errCode != ErrAdminActionDisabled {
+		t.Errorf("Expected ErrAdminActionDisabled, got %v", errCode)
+	}
+	if errCode := checkAdminRequestAuthType(req, adminSetConfigAction, ""); errCode != ErrAccessDenied {
+		t.Errorf("Expected ErrAccessDenied, got %v", errCode)
+	}
+}
+
+// Tests that the disabled actions of config.json are validated.
+func TestValidateConfigDisabledAdminActions(t *testing.T) {
+	testCases := []struct {
+		actions    []string
+		shouldPass bool
+	}{
+		{nil, true},
+		{[]string{"admin:UpdateCredentials", "admin:Heal*"}, true},
+		{[]string{"admin:RotateEverything"}, false},
+	}
+	for i, testCase := range testCases {
+		config := newServerConfig()
+		config.AdminDisabledActions = testCase.actions
+		if _, err := config.Validate(); (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: expected to pass %v, got %v", i+1, testCase.shouldPass, err)
+		}
+	}
+}
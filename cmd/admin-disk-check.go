@@ -0,0 +1,147 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Size of the data written and read back by a disk test.
+	diskTestSize = 1 * humanize.MiByte
+
+	// Prefix under minioMetaTmpBucket of files written by disk
+	// tests.
+	diskTestPrefix = "disktest"
+)
+
+var errDiskTestDataMismatch = errors.New("data read back does not match data written")
+
+// getLocalDisk - returns the storage of the given local disk, nil if
+// the disk is not connected. Remote disks are named after their
+// endpoint URL, so only local disks are named after their path.
+func (s *xlSets) getLocalDisk(diskPath string) StorageAPI {
+	s.xlDisksMu.RLock()
+	defer s.xlDisksMu.RUnlock()
+
+	for i := 0; i < s.setCount; i++ {
+		for j := 0; j < s.drivesPerSet; j++ {
+			disk := s.xlDisks[i][j]
+			if disk != nil && disk.String() == diskPath {
+				return disk
+			}
+		}
+	}
+	return nil
+}
+
+// getEndpointDisk - returns the storage of the disk of the given
+// endpoint, nil if the disk is not connected.
+func (s *xlSets) getEndpointDisk(endpoint Endpoint) StorageAPI {
+	if endpoint.IsLocal {
+		return s.getLocalDisk(endpoint.Path)
+	}
+
+	s.xlDisksMu.RLock()
+	defer s.xlDisksMu.RUnlock()
+
+	for i := 0; i < s.setCount; i++ {
+		for j := 0; j < s.drivesPerSet; j++ {
+			disk := s.xlDisks[i][j]
+			if disk != nil && disk.String() == endpoint.String() {
+				return disk
+			}
+		}
+	}
+	return nil
+}
+
+// isDiskOfPeer - returns true if the disk is one of the drives of the
+// given peer.
+func isDiskOfPeer(peer adminPeer, diskPath string) bool {
+	_, ok := getPeerDiskEndpoint(peer, diskPath)
+	return ok
+}
+
+// getPeerDiskEndpoint - returns the endpoint of the given disk of the
+// given peer.
+func getPeerDiskEndpoint(peer adminPeer, diskPath string) (Endpoint, bool) {
+	for _, endpoint := range globalEndpoints {
+		if endpoint.Path == diskPath && isEndpointOfPeer(peer, endpoint) {
+			return endpoint, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// isEndpointOfPeer - returns true if the endpoint is served by the
+// given peer.
+func isEndpointOfPeer(peer adminPeer, endpoint Endpoint) bool {
+	return peer.isLocal && endpoint.IsLocal || !peer.isLocal && endpoint.Host == peer.addr
+}
+
+// testDisk - writes data to the disk, reads it back and deletes it,
+// recording the latency of each step. Failures of the disk are
+// reported in the result and not as an error.
+func testDisk(disk StorageAPI) (result madmin.DiskTestResult, err error) {
+	data := make([]byte, diskTestSize)
+	if _, err = io.ReadFull(rand.Reader, data); err != nil {
+		return result, err
+	}
+
+	diskErr := func(err error) (madmin.DiskTestResult, error) {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	filePath := pathJoin(diskTestPrefix, mustGetUUID())
+
+	start := UTCNow()
+	if err = disk.AppendFile(minioMetaTmpBucket, filePath, data); err != nil {
+		// Remove any partially written data.
+		disk.DeleteFile(minioMetaTmpBucket, filePath)
+		return diskErr(err)
+	}
+	result.WriteLatency = UTCNow().Sub(start)
+
+	start = UTCNow()
+	readData, err := disk.ReadAll(minioMetaTmpBucket, filePath)
+	if err != nil {
+		disk.DeleteFile(minioMetaTmpBucket, filePath)
+		return diskErr(err)
+	}
+	result.ReadLatency = UTCNow().Sub(start)
+	if !bytes.Equal(data, readData) {
+		disk.DeleteFile(minioMetaTmpBucket, filePath)
+		return diskErr(errDiskTestDataMismatch)
+	}
+
+	start = UTCNow()
+	if err = disk.DeleteFile(minioMetaTmpBucket, filePath); err != nil {
+		return diskErr(err)
+	}
+	result.DeleteLatency = UTCNow().Sub(start)
+
+	result.Success = true
+	return result, nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// diskDrain - drain of a disk before its removal. Objects cannot move
+// to another erasure set, so a drain heals the erasure set of the disk
+// until all its objects are fully redundant on the other disks of the
+// set, which then tolerate the removal of the disk.
+type diskDrain struct {
+	node, disk string
+	endpoint   Endpoint
+	set        int
+	seq        *healSequence
+}
+
+// diskDrainState - drains started on this server, keyed by the client
+// token of their heal sequence.
+type diskDrainState struct {
+	sync.Mutex
+	drains map[string]*diskDrain
+}
+
+var globalDiskDrainState = &diskDrainState{drains: make(map[string]*diskDrain)}
+
+// diskErasureSet - returns the index of the erasure set of the disk of
+// the given endpoint, from the format of the disks found in sets, or
+// from the position of the endpoint if the disk is not formatted.
+// Returns -1 if the endpoint is not part of the object layer.
+func diskErasureSet(s *xlSets, sets [][]madmin.DriveInfo, endpoint Endpoint) int {
+	for i, set := range sets {
+		for _, drive := range set {
+			if drive.Endpoint == endpoint.String() && drive.UUID != "" {
+				return i
+			}
+		}
+	}
+	for i := range s.endpoints {
+		if s.endpoints.GetString(i) == endpoint.String() {
+			return i / s.drivesPerSet
+		}
+	}
+	return -1
+}
+
+// start - launches the heal of the erasure set of the disk of the
+// given endpoint, the given disk of the given node. Heal sequences of
+// overlapping paths do not run at once, so a drain is not started
+// while another heal is running.
+func (d *diskDrainState) start(ctx context.Context, s *xlSets, node, diskPath string, endpoint Endpoint, clientAddr string) (*diskDrain, APIErrorCode, string) {
+	set := diskErasureSet(s, s.StorageInfo(ctx).Backend.Sets, endpoint)
+	if set < 0 {
+		return nil, ErrAdminInvalidArgument, fmt.Sprintf("Disk `%s` of node `%s` is not part of any erasure set", diskPath, node)
+	}
+
+	seq := newHealSequence("", "", clientAddr, s.drivesPerSet,
+		madmin.HealOpts{Recursive: true, ErasureSet: &set}, false)
+	seq.discardResults = true
+
+	_, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(seq)
+	if errCode != ErrNone {
+		return nil, errCode, errMsg
+	}
+
+	dd := &diskDrain{
+		node:     node,
+		disk:     diskPath,
+		endpoint: endpoint,
+		set:      set,
+		seq:      seq,
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	// Forget drains whose heal state was purged.
+	for token := range d.drains {
+		if _, exists := globalAllHealState.getHealSequenceByToken(token); !exists {
+			delete(d.drains, token)
+		}
+	}
+	d.drains[seq.clientToken] = dd
+	return dd, ErrNone, ""
+}
+
+// get - returns the drain of the given client token, which must be the
+// drain of the given disk of the given node.
+func (d *diskDrainState) get(clientToken, node, diskPath string) (*diskDrain, APIErrorCode) {
+	d.Lock()
+	defer d.Unlock()
+	dd, ok := d.drains[clientToken]
+	if !ok {
+		return nil, ErrHealNoSuchProcess
+	}
+	if _, exists := globalAllHealState.getHealSequenceByToken(clientToken); !exists {
+		delete(d.drains, clientToken)
+		return nil, ErrHealNoSuchProcess
+	}
+	if dd.node != node || dd.disk != diskPath {
+		return nil, ErrHealInvalidClientToken
+	}
+	return dd, ErrNone
+}
+
+// status - returns the progress of the drain, and whether the disk is
+// safe to remove given the drives of each erasure set.
+func (dd *diskDrain) status(sets [][]madmin.DriveInfo) madmin.DiskDrainStatus {
+	dd.seq.currentStatus.updateLock.RLock()
+	status := madmin.DiskDrainStatus{
+		ClientToken:    dd.seq.clientToken,
+		Node:           dd.node,
+		Disk:           dd.disk,
+		ErasureSet:     dd.set,
+		Summary:        string(dd.seq.currentStatus.Summary),
+		FailureDetail:  dd.seq.currentStatus.FailureDetail,
+		StartTime:      dd.seq.currentStatus.StartTime,
+		ItemsHealed:    dd.seq.lastSentResultIndex,
+		ItemsFailed:    dd.seq.itemsFailed,
+		ObjectsSkipped: dd.seq.currentStatus.ObjectsSkipped,
+	}
+	dd.seq.currentStatus.updateLock.RUnlock()
+
+	if dd.set < len(sets) {
+		for _, drive := range sets[dd.set] {
+			if drive.Endpoint != dd.endpoint.String() && drive.State != madmin.DriveStateOk {
+				status.OfflineSetDrives = append(status.OfflineSetDrives, drive.Endpoint)
+			}
+		}
+	}
+
+	switch {
+	case status.Summary == healStoppedStatus:
+		status.Reason = "heal of the erasure set stopped before completion"
+	case status.Summary != healFinishedStatus:
+		status.Reason = "heal of the erasure set is in progress"
+	case status.ItemsFailed > 0:
+		status.Reason = fmt.Sprintf("%d items of the erasure set could not be fully healed", status.ItemsFailed)
+	case len(status.OfflineSetDrives) > 0:
+		status.Reason = "other disks of the erasure set are offline"
+	default:
+		status.SafeToRemove = true
+	}
+	return status
+}
@@ -0,0 +1,101 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Test that a disk is only safe to remove once the heal of its erasure
+// set finished without failures and the other disks of the set are
+// online.
+func TestDiskDrainStatus(t *testing.T) {
+	endpoint := Endpoint{URL: &url.URL{Path: "/data2"}, IsLocal: true}
+	sets := [][]madmin.DriveInfo{
+		{{Endpoint: "/data1", State: madmin.DriveStateOk}, {Endpoint: "/data2", State: madmin.DriveStateOk}},
+		{{Endpoint: "/data3", State: madmin.DriveStateOk}, {Endpoint: "/data4", State: madmin.DriveStateOffline}},
+	}
+	offlineSets := [][]madmin.DriveInfo{
+		{{Endpoint: "/data1", State: madmin.DriveStateOffline}, {Endpoint: "/data2", State: madmin.DriveStateOk}},
+	}
+	drainedSets := [][]madmin.DriveInfo{
+		{{Endpoint: "/data1", State: madmin.DriveStateOk}, {Endpoint: "/data2", State: madmin.DriveStateOffline}},
+	}
+
+	testCases := []struct {
+		summary         healStatusSummary
+		itemsFailed     int64
+		sets            [][]madmin.DriveInfo
+		expectedSafe    bool
+		expectedOffline []string
+	}{
+		{healRunningStatus, 0, sets, false, nil},
+		{healStoppedStatus, 0, sets, false, nil},
+		{healFinishedStatus, 1, sets, false, nil},
+		{healFinishedStatus, 0, offlineSets, false, []string{"/data1"}},
+		{healFinishedStatus, 0, sets, true, nil},
+		// The drained disk itself may already be offline.
+		{healFinishedStatus, 0, drainedSets, true, nil},
+	}
+	for i, testCase := range testCases {
+		seq := newHealSequence("", "", "127.0.0.1", 2, madmin.HealOpts{Recursive: true}, false)
+		seq.currentStatus.Summary = testCase.summary
+		seq.itemsFailed = testCase.itemsFailed
+		dd := &diskDrain{node: "127.0.0.1:9000", disk: "/data2", endpoint: endpoint, seq: seq}
+
+		status := dd.status(testCase.sets)
+		if status.SafeToRemove != testCase.expectedSafe {
+			t.Errorf("Test %d: Expected safe to remove %v but got %v", i+1, testCase.expectedSafe, status.SafeToRemove)
+		}
+		if status.SafeToRemove == (status.Reason != "") {
+			t.Errorf("Test %d: Unexpected reason `%s`", i+1, status.Reason)
+		}
+		if !reflect.DeepEqual(status.OfflineSetDrives, testCase.expectedOffline) {
+			t.Errorf("Test %d: Expected offline drives %v but got %v", i+1, testCase.expectedOffline, status.OfflineSetDrives)
+		}
+		if status.ClientToken != seq.clientToken || status.ItemsFailed != testCase.itemsFailed {
+			t.Errorf("Test %d: Unexpected drain status %#v", i+1, status)
+		}
+	}
+}
+
+// Test that the erasure set of a disk is found from its format, or
+// from its position if it is not formatted.
+func TestDiskErasureSet(t *testing.T) {
+	objLayer, fsDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	s := objLayer.(*xlSets)
+
+	endpoint := s.endpoints[5]
+	sets := [][]madmin.DriveInfo{{}, {{Endpoint: endpoint.String(), UUID: mustGetUUID()}}}
+	if set := diskErasureSet(s, sets, endpoint); set != 1 {
+		t.Errorf("Expected erasure set 1 from the format but got %d", set)
+	}
+	if set := diskErasureSet(s, nil, endpoint); set != 0 {
+		t.Errorf("Expected erasure set 0 from the position but got %d", set)
+	}
+	if set := diskErasureSet(s, nil, Endpoint{URL: &url.URL{Path: "/no/such/disk"}, IsLocal: true}); set != -1 {
+		t.Errorf("Expected no erasure set but got %d", set)
+	}
+}
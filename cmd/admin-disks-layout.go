@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errDiskOfOtherDeployment = errors.New("disk belongs to another deployment")
+
+// readLocalFormatXL - reads the format.json of the local disk at the
+// given path, whether or not the disk is connected.
+func readLocalFormatXL(diskPath string) (*formatXLV3, error) {
+	data, err := ioutil.ReadFile(pathJoin(diskPath, minioMetaBucket, formatConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errUnformattedDisk
+		}
+		return nil, err
+	}
+	format := &formatXLV3{}
+	if err = json.Unmarshal(data, format); err != nil {
+		return nil, err
+	}
+	return format, nil
+}
+
+// getDiskLayout - returns the expected and actual position in the
+// erasure sets of the local disk at the given path, the index-th
+// endpoint of the command line. Endpoints fill the sets in order, so
+// the disk is expected to carry the identifier of that position in the
+// reference format.
+func getDiskLayout(refFormat *formatXLV3, diskPath string, index, drivesPerSet int) madmin.DiskLayout {
+	layout := madmin.DiskLayout{
+		Path:         diskPath,
+		ExpectedSet:  index / drivesPerSet,
+		ExpectedDisk: index % drivesPerSet,
+		ActualSet:    -1,
+		ActualDisk:   -1,
+	}
+	layout.ExpectedUUID = refFormat.XL.Sets[layout.ExpectedSet][layout.ExpectedDisk]
+
+	format, err := readLocalFormatXL(diskPath)
+	if err != nil {
+		layout.Error = err.Error()
+		return layout
+	}
+	layout.UUID = format.XL.This
+	if format.ID != "" && refFormat.ID != "" && format.ID != refFormat.ID {
+		layout.Error = errDiskOfOtherDeployment.Error()
+		return layout
+	}
+
+	i, j, err := findDiskIndex(refFormat, format)
+	if err != nil {
+		layout.Error = err.Error()
+		return layout
+	}
+	layout.ActualSet, layout.ActualDisk = i, j
+	layout.Misplaced = i != layout.ExpectedSet || j != layout.ExpectedDisk
+	return layout
+}
+
+// getLocalDisksLayout - returns the expected and actual position in
+// the erasure sets of each local disk.
+func getLocalDisksLayout(objectAPI ObjectLayer) ([]madmin.DiskLayout, error) {
+	s, ok := objectAPI.(*xlSets)
+	if !ok {
+		return nil, NotImplemented{}
+	}
+	disks := []madmin.DiskLayout{}
+	for k, endpoint := range s.endpoints {
+		if !endpoint.IsLocal {
+			continue
+		}
+		disks = append(disks, getDiskLayout(s.format, endpoint.Path, k, s.drivesPerSet))
+	}
+	return disks, nil
+}
+
+// getDisksLayout - returns the layout of the disks of each server,
+// consistent if every disk carries the identifier of its position.
+func getDisksLayout(peers adminPeers) madmin.DisksLayout {
+	layout := madmin.DisksLayout{
+		Consistent: true,
+		Servers:    make([]madmin.ServerDisksLayout, len(peers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			layout.Servers[idx].Addr = peer.addr
+			disks, err := peer.cmdRunner.DisksLayout()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				layout.Servers[idx].Error = err.Error()
+				return
+			}
+			layout.Servers[idx].Disks = disks
+		}(i, p)
+	}
+	wg.Wait()
+
+	for _, server := range layout.Servers {
+		for _, disk := range server.Disks {
+			if disk.Misplaced {
+				layout.Consistent = false
+			}
+		}
+	}
+	return layout
+}
+
+// logMisplacedDisks - warns about the local disks carrying the
+// identifier of another position in the erasure sets, usually disks
+// mounted in the wrong order or on the wrong server.
+func logMisplacedDisks(objectAPI ObjectLayer) {
+	disks, err := getLocalDisksLayout(objectAPI)
+	if err != nil {
+		return
+	}
+	for _, disk := range disks {
+		if disk.Misplaced {
+			logger.Info("Disk %s is expected at position %d:%d of the erasure sets but holds the disk of position %d:%d, check the mount order of the disks",
+				disk.Path, disk.ExpectedSet, disk.ExpectedDisk, disk.ActualSet, disk.ActualDisk)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type disksLayoutTestRunner struct {
+	localAdminClient
+	disks []madmin.DiskLayout
+	err   error
+}
+
+func (runner *disksLayoutTestRunner) DisksLayout() ([]madmin.DiskLayout, error) {
+	return runner.disks, runner.err
+}
+
+// Writes the format.json of the given disk of the reference format to
+// the disk at the given path.
+func writeTestFormatXL(t *testing.T, refFormat *formatXLV3, diskPath string, set, disk int) {
+	format := *refFormat
+	format.XL.This = refFormat.XL.Sets[set][disk]
+	data, err := json.Marshal(&format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(pathJoin(diskPath, minioMetaBucket), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(pathJoin(diskPath, minioMetaBucket, formatConfigFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests that disks holding the format of another position are flagged
+// as misplaced.
+func TestGetDiskLayout(t *testing.T) {
+	disks, err := getRandomDisks(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	refFormat := newFormatXLV3(2, 2)
+	writeTestFormatXL(t, refFormat, disks[0], 0, 0)
+	// Second and third disks swapped.
+	writeTestFormatXL(t, refFormat, disks[1], 1, 0)
+	writeTestFormatXL(t, refFormat, disks[2], 0, 1)
+	// Fourth disk of another deployment.
+	writeTestFormatXL(t, newFormatXLV3(2, 2), disks[3], 1, 1)
+	// Fifth disk left unformatted.
+
+	testCases := []struct {
+		index                     int
+		expectedSet, expectedDisk int
+		actualSet, actualDisk     int
+		misplaced                 bool
+		err                       error
+	}{
+		{0, 0, 0, 0, 0, false, nil},
+		{1, 0, 1, 1, 0, true, nil},
+		{2, 1, 0, 0, 1, true, nil},
+		{3, 1, 1, -1, -1, false, errDiskOfOtherDeployment},
+	}
+	for i, testCase := range testCases {
+		layout := getDiskLayout(refFormat, disks[testCase.index], testCase.index, 2)
+		if layout.ExpectedSet != testCase.expectedSet || layout.ExpectedDisk != testCase.expectedDisk ||
+			layout.ExpectedUUID != refFormat.XL.Sets[testCase.expectedSet][testCase.expectedDisk] {
+			t.Errorf("Test %d: Unexpected expected position %v", i+1, layout)
+		}
+		if layout.ActualSet != testCase.actualSet || layout.ActualDisk != testCase.actualDisk || layout.Misplaced != testCase.misplaced {
+			t.Errorf("Test %d: Unexpected actual position %v", i+1, layout)
+		}
+		if testCase.err != nil && layout.Error != testCase.err.Error() || testCase.err == nil && layout.Error != "" {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, testCase.err, layout.Error)
+		}
+	}
+
+	layout := getDiskLayout(refFormat, disks[4], 0, 2)
+	if layout.Error != errUnformattedDisk.Error() || layout.Misplaced {
+		t.Errorf("Expected unformatted disk, got %v", layout)
+	}
+}
+
+// Tests that the layout is inconsistent once a disk is misplaced and
+// that unreachable servers are reported.
+func TestGetDisksLayout(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server1", cmdRunner: &disksLayoutTestRunner{disks: []madmin.DiskLayout{
+			{Path: "/data1"},
+			{Path: "/data2", ExpectedDisk: 1, ActualDisk: 1},
+		}}},
+		{addr: "server2", cmdRunner: &disksLayoutTestRunner{err: errors.New("unreachable")}},
+	}
+
+	layout := getDisksLayout(peers)
+	if !layout.Consistent || len(layout.Servers) != 2 || layout.Servers[0].Addr != "server1" || len(layout.Servers[0].Disks) != 2 {
+		t.Fatalf("Unexpected layout %v", layout)
+	}
+	if layout.Servers[1].Error != "unreachable" || layout.Servers[1].Disks != nil {
+		t.Errorf("Unexpected layout of unreachable server %v", layout.Servers[1])
+	}
+
+	peers[0].cmdRunner.(*disksLayoutTestRunner).disks[1].Misplaced = true
+	if layout = getDisksLayout(peers); layout.Consistent {
+		t.Errorf("Expected inconsistent layout, got %v", layout)
+	}
+}
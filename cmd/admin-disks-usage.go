@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Percentage of the capacity of a disk above which it is reported as
+// approaching full, unless the request sets another threshold.
+const defaultDiskFullThreshold = 90
+
+// newDiskUsage - returns the usage of the disk at the given path from
+// its info, or the error getting it.
+func newDiskUsage(diskPath string, info DiskInfo, err error) madmin.DiskUsage {
+	usage := madmin.DiskUsage{Path: diskPath}
+	if err != nil {
+		usage.Error = err.Error()
+		return usage
+	}
+	usage.Total = info.Total
+	usage.Used = info.Used
+	usage.Free = info.Free
+	if info.Total > 0 {
+		usage.UsedPercent = float64(info.Total-info.Free) * 100 / float64(info.Total)
+	}
+	return usage
+}
+
+// getLocalDisksUsage - returns the usage of the local disks, or of the
+// backend directory of a single node setup. Disks which are offline
+// are reported with an error.
+func getLocalDisksUsage(objectAPI ObjectLayer) ([]madmin.DiskUsage, error) {
+	switch objLayer := objectAPI.(type) {
+	case *FSObjects:
+		di, err := getDiskInfo(objLayer.fsPath)
+		info := DiskInfo{Total: di.Total, Free: di.Free, Used: di.Total - di.Free}
+		return []madmin.DiskUsage{newDiskUsage(objLayer.fsPath, info, err)}, nil
+	case *xlSets:
+		disks := []madmin.DiskUsage{}
+		for _, endpoint := range globalEndpoints {
+			if !endpoint.IsLocal {
+				continue
+			}
+			storage := objLayer.getLocalDisk(endpoint.Path)
+			if storage == nil {
+				disks = append(disks, newDiskUsage(endpoint.Path, DiskInfo{}, errDiskNotFound))
+				continue
+			}
+			info, err := storage.DiskInfo()
+			disks = append(disks, newDiskUsage(endpoint.Path, info, err))
+		}
+		return disks, nil
+	}
+	return nil, NotImplemented{}
+}
+
+// getDisksUsage - returns the usage of the disks of each server,
+// flagging those whose used space reached threshold percent of their
+// capacity.
+func getDisksUsage(peers adminPeers, threshold int) []madmin.ServerDisksUsage {
+	servers := make([]madmin.ServerDisksUsage, len(peers))
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx].Addr = peer.addr
+			disks, err := peer.cmdRunner.DisksUsage()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+			for j := range disks {
+				disks[j].ApproachingFull = disks[j].Error == "" && disks[j].UsedPercent >= float64(threshold)
+			}
+			servers[idx].Disks = disks
+		}(i, p)
+	}
+	wg.Wait()
+
+	return servers
+}
@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type disksUsageTestRunner struct {
+	localAdminClient
+	disks []madmin.DiskUsage
+	err   error
+}
+
+func (runner *disksUsageTestRunner) DisksUsage() ([]madmin.DiskUsage, error) {
+	return runner.disks, runner.err
+}
+
+// Tests the usage of a disk computed from its info.
+func TestNewDiskUsage(t *testing.T) {
+	usage := newDiskUsage("/data1", DiskInfo{Total: 1000, Free: 250, Used: 700}, nil)
+	expected := madmin.DiskUsage{Path: "/data1", Total: 1000, Used: 700, Free: 250, UsedPercent: 75}
+	if usage != expected {
+		t.Errorf("Expected %v, got %v", expected, usage)
+	}
+
+	usage = newDiskUsage("/data2", DiskInfo{}, errDiskNotFound)
+	if usage.Error != errDiskNotFound.Error() || usage.Total != 0 {
+		t.Errorf("Expected disk not found, got %v", usage)
+	}
+}
+
+// Tests that disks crossing the threshold are flagged as approaching
+// full and that unreachable servers are reported.
+func TestGetDisksUsage(t *testing.T) {
+	peers := adminPeers{
+		{addr: "server1", cmdRunner: &disksUsageTestRunner{disks: []madmin.DiskUsage{
+			{Path: "/data1", Total: 100, Free: 5, UsedPercent: 95},
+			{Path: "/data2", Total: 100, Free: 50, UsedPercent: 50},
+			{Path: "/data3", Error: errDiskNotFound.Error()},
+		}}},
+		{addr: "server2", cmdRunner: &disksUsageTestRunner{err: errors.New("unreachable")}},
+	}
+
+	servers := getDisksUsage(peers, 90)
+	if len(servers) != 2 || servers[0].Addr != "server1" || servers[1].Addr != "server2" {
+		t.Fatalf("Unexpected servers %v", servers)
+	}
+	disks := servers[0].Disks
+	if len(disks) != 3 || !disks[0].ApproachingFull || disks[1].ApproachingFull || disks[2].ApproachingFull {
+		t.Errorf("Unexpected disks %v", disks)
+	}
+	if servers[1].Error != "unreachable" || servers[1].Disks != nil {
+		t.Errorf("Unexpected usage of unreachable server %v", servers[1])
+	}
+
+	servers = getDisksUsage(peers[:1], 50)
+	if disks = servers[0].Disks; !disks[0].ApproachingFull || !disks[1].ApproachingFull {
+		t.Errorf("Expected disks to be approaching full at 50%%, got %v", disks)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errDupScanStopSignalled = errors.New("duplicates scan stop signaled")
+
+// dupScanSequence - state of a read-only scan finding objects with
+// identical content.
+type dupScanSequence struct {
+	// bucket and prefix on which the scan was initiated
+	bucket, objPrefix string
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.DuplicateScanStatus
+
+	// channel to signal the scan to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// Time the status of a duplicates scan, along with its duplicate
+// groups, is kept once the scan ended.
+const keepDupScanStatusDuration = time.Hour
+
+// dupScanState - holds the duplicates scan running on this server and
+// the ended ones until their status expires. Only one scan may run at
+// a time.
+type dupScanState struct {
+	*adminScanRegistry
+}
+
+var globalDupScanState = dupScanState{newAdminScanRegistry(keepDupScanStatusDuration)}
+
+// newDupScanSequence - creates a duplicates scan, assumes bucket and
+// objPrefix are already validated.
+func newDupScanSequence(bucket, objPrefix, clientAddr string) *dupScanSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "ScanDuplicates", BucketName: bucket}
+	reqInfo.AppendTags("prefix", objPrefix)
+
+	return &dupScanSequence{
+		bucket:    bucket,
+		objPrefix: objPrefix,
+		status: madmin.DuplicateScanStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.DuplicateScanRunning,
+			StartTime:   UTCNow(),
+		},
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the scan unless another one is running. A running
+// scan is stopped first if forceStart is set.
+func (s *dupScanState) Launch(seq *dupScanSequence, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, seq.run)
+}
+
+// Status - returns the status of the scan identified by clientToken.
+func (s *dupScanState) Status(clientToken string) (madmin.DuplicateScanStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.DuplicateScanStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*dupScanSequence)
+
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status, ErrNone
+}
+
+func (seq *dupScanSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *dupScanSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.DuplicateScanRunning
+}
+
+// stop - stops the scan, safe to call multiple times.
+func (seq *dupScanSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *dupScanSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// run - walks the namespace and records groups of objects with the
+// same content. Objects are first grouped by size, and only objects
+// sharing their size with another one are read to hash their content.
+func (seq *dupScanSequence) run() {
+	groups, err := seq.scan()
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.DuplicateScanStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.DuplicateScanFinished
+	seq.status.Groups = groups
+}
+
+func (seq *dupScanSequence) scan() ([]madmin.DuplicateGroup, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	buckets := []string{seq.bucket}
+	if seq.bucket == "" {
+		bucketsInfo, err := objectAPI.ListBuckets(seq.ctx)
+		if err != nil {
+			return nil, err
+		}
+		buckets = buckets[:0]
+		for _, bucket := range bucketsInfo {
+			buckets = append(buckets, bucket.Name)
+		}
+	}
+
+	// Group objects by size.
+	bySize := make(map[int64][]madmin.DuplicateObject)
+	for _, bucket := range buckets {
+		marker := ""
+		for {
+			if seq.isQuitting() {
+				return nil, errDupScanStopSignalled
+			}
+			result, err := objectAPI.ListObjects(seq.ctx, bucket, seq.objPrefix, marker, "", maxObjectList)
+			if err != nil {
+				return nil, err
+			}
+			for _, obj := range result.Objects {
+				// Empty objects are trivially identical.
+				if obj.Size > 0 {
+					bySize[obj.Size] = append(bySize[obj.Size], madmin.DuplicateObject{Bucket: obj.Bucket, Object: obj.Name})
+				}
+			}
+
+			seq.mu.Lock()
+			seq.status.ObjectsScanned += int64(len(result.Objects))
+			seq.mu.Unlock()
+
+			if !result.IsTruncated {
+				break
+			}
+			marker = result.NextMarker
+		}
+	}
+
+	// Hash objects which share their size with others.
+	groups := []madmin.DuplicateGroup{}
+	for size, objects := range bySize {
+		if len(objects) < 2 {
+			continue
+		}
+
+		byHash := make(map[string][]madmin.DuplicateObject)
+		for _, obj := range objects {
+			if seq.isQuitting() {
+				return nil, errDupScanStopSignalled
+			}
+			hash, err := seq.hashObject(objectAPI, obj)
+			if err != nil {
+				// Objects may be removed while scanning.
+				if isErrObjectNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			byHash[hash] = append(byHash[hash], obj)
+
+			seq.mu.Lock()
+			seq.status.ObjectsHashed++
+			seq.mu.Unlock()
+		}
+
+		for hash, objects := range byHash {
+			if len(objects) > 1 {
+				groups = append(groups, madmin.DuplicateGroup{Hash: hash, Size: size, Objects: objects})
+			}
+		}
+	}
+
+	// Report groups wasting the most space first.
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Size*int64(len(groups[i].Objects)-1) > groups[j].Size*int64(len(groups[j].Objects)-1)
+	})
+	return groups, nil
+}
+
+// hashObject - returns the hex encoded SHA256 of the object content.
+func (seq *dupScanSequence) hashObject(objectAPI ObjectLayer, obj madmin.DuplicateObject) (string, error) {
+	h := sha256.New()
+	if err := objectAPI.GetObject(seq.ctx, obj.Bucket, obj.Object, 0, -1, h, ""); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,366 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Maximum number of findings listed in the status of a fsck, all of
+// them are counted.
+const maxReportedFsckFindings = 1000
+
+var errFsckStopSignalled = errors.New("fsck stop signaled")
+
+// fsckSequence - state of a read-only consistency check of the disks
+// of the backend, verifying the format of each disk, the directory
+// structure of buckets and the metadata and shards of each object.
+// Nothing is repaired. Every server of a distributed setup has access
+// to all disks, so a single check covers the disks of all servers.
+type fsckSequence struct {
+	// bucket on which the check was initiated, empty for all
+	// buckets
+	bucket string
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.FsckStatus
+
+	// channel to signal the check to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// Time the status of a fsck is kept once it ended.
+const keepFsckStatusDuration = time.Hour
+
+// fsckState - holds the fsck running on this server and the ended
+// ones until their status expires. Only one fsck may run at a time.
+type fsckState struct {
+	*adminScanRegistry
+}
+
+var globalFsckState = fsckState{newAdminScanRegistry(keepFsckStatusDuration)}
+
+// newFsckSequence - creates a fsck, assumes bucket is already
+// validated.
+func newFsckSequence(bucket string, clientAddr string) *fsckSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "Fsck", BucketName: bucket}
+
+	return &fsckSequence{
+		bucket: bucket,
+		status: madmin.FsckStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.FsckRunning,
+			StartTime:   UTCNow(),
+		},
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the fsck unless another one is running. A running
+// fsck is stopped first if forceStart is set.
+func (s *fsckState) Launch(seq *fsckSequence, sets *xlSets, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, func() { seq.run(sets) })
+}
+
+// Status - returns the status of the fsck identified by clientToken.
+func (s *fsckState) Status(clientToken string) (madmin.FsckStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.FsckStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*fsckSequence)
+
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	status := seq.status
+	status.Findings = append([]madmin.FsckFinding(nil), seq.status.Findings...)
+	return status, ErrNone
+}
+
+func (seq *fsckSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *fsckSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.FsckRunning
+}
+
+// stop - stops the fsck, safe to call multiple times.
+func (seq *fsckSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *fsckSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// report - records a finding of the given severity.
+func (seq *fsckSequence) report(severity, disk, bucket, object, detail string) {
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	switch severity {
+	case madmin.FsckCritical:
+		seq.status.CriticalCount++
+	case madmin.FsckWarning:
+		seq.status.WarningCount++
+	default:
+		seq.status.InfoCount++
+	}
+	if len(seq.status.Findings) < maxReportedFsckFindings {
+		seq.status.Findings = append(seq.status.Findings, madmin.FsckFinding{
+			Severity: severity,
+			Disk:     disk,
+			Bucket:   bucket,
+			Object:   object,
+			Detail:   detail,
+		})
+	}
+}
+
+// run - checks the disks of all erasure sets.
+func (seq *fsckSequence) run(sets *xlSets) {
+	err := seq.check(sets)
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.FsckStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.FsckFinished
+}
+
+func (seq *fsckSequence) check(sets *xlSets) error {
+	buckets := []string{seq.bucket}
+	if seq.bucket != "" {
+		if _, err := sets.GetBucketInfo(seq.ctx, seq.bucket); err != nil {
+			return err
+		}
+	} else {
+		bucketsInfo, err := sets.ListBuckets(seq.ctx)
+		if err != nil {
+			return err
+		}
+		buckets = buckets[:0]
+		for _, bucket := range bucketsInfo {
+			buckets = append(buckets, bucket.Name)
+		}
+	}
+
+	for i, set := range sets.sets {
+		for j, disk := range set.getDisks() {
+			if disk == nil {
+				endpoint := sets.endpoints[i*sets.drivesPerSet+j]
+				seq.report(madmin.FsckWarning, endpoint.String(), "", "", "disk is offline")
+				continue
+			}
+			if err := seq.checkDisk(set, disk, buckets); err != nil {
+				return err
+			}
+			seq.mu.Lock()
+			seq.status.DisksScanned++
+			seq.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// checkDisk - checks the format of a disk and the given buckets on it.
+func (seq *fsckSequence) checkDisk(set *xlObjects, disk StorageAPI, buckets []string) error {
+	if _, err := loadFormatXL(disk); err != nil {
+		seq.report(madmin.FsckCritical, disk.String(), minioMetaBucket, formatConfigFile,
+			fmt.Sprintf("unreadable format: %v", err))
+		// The content of an unformatted disk is not trusted.
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		if _, err := disk.StatVol(bucket); err != nil {
+			if err == errVolumeNotFound {
+				seq.report(madmin.FsckWarning, disk.String(), bucket, "", "bucket is missing")
+				continue
+			}
+			seq.report(madmin.FsckCritical, disk.String(), bucket, "", fmt.Sprintf("unreadable bucket: %v", err))
+			continue
+		}
+		if err := seq.checkDir(set, disk, bucket, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDir - walks a directory of a disk, checking the directories of
+// objects found on the way.
+func (seq *fsckSequence) checkDir(set *xlObjects, disk StorageAPI, bucket, dir string) error {
+	if seq.isQuitting() {
+		return errFsckStopSignalled
+	}
+
+	entries, err := disk.ListDir(bucket, dir, -1)
+	if err != nil {
+		// The directory may have just been deleted.
+		if err == errFileNotFound {
+			return nil
+		}
+		seq.report(madmin.FsckCritical, disk.String(), bucket, dir, fmt.Sprintf("unreadable directory: %v", err))
+		return nil
+	}
+
+	var parts, subDirs []string
+	for _, entry := range entries {
+		switch {
+		case hasSuffix(entry, slashSeparator):
+			subDirs = append(subDirs, entry)
+		case isPartFile(entry):
+			parts = append(parts, entry)
+		}
+	}
+
+	if contains(entries, xlMetaJSONFile) {
+		object := strings.TrimSuffix(dir, slashSeparator)
+		seq.mu.Lock()
+		seq.status.ObjectsScanned++
+		seq.mu.Unlock()
+		if len(subDirs) > 0 {
+			seq.report(madmin.FsckWarning, disk.String(), bucket, object, "object directory contains sub-directories")
+		}
+		return seq.checkObject(set, disk, bucket, object)
+	}
+
+	if len(parts) > 0 {
+		seq.report(madmin.FsckWarning, disk.String(), bucket, strings.TrimSuffix(dir, slashSeparator),
+			fmt.Sprintf("%d shards without %s", len(parts), xlMetaJSONFile))
+	}
+	if len(entries) == 0 && dir != "" {
+		seq.report(madmin.FsckInfo, disk.String(), bucket, dir, "empty directory")
+	}
+
+	for _, subDir := range subDirs {
+		if err = seq.checkDir(set, disk, bucket, dir+subDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkObject - verifies that the metadata of an object on a disk is
+// complete and valid, and that each of its shards has the expected
+// size and can be read back entirely with a matching checksum.
+func (seq *fsckSequence) checkObject(set *xlObjects, disk StorageAPI, bucket, object string) error {
+	objectLock := set.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.RUnlock()
+
+	report := func(severity, detail string) {
+		seq.report(severity, disk.String(), bucket, object, detail)
+	}
+
+	xlMetaBuf, err := disk.ReadAll(bucket, path.Join(object, xlMetaJSONFile))
+	if err != nil {
+		// The object may have just been deleted.
+		if err != errFileNotFound {
+			report(madmin.FsckCritical, fmt.Sprintf("unreadable %s: %v", xlMetaJSONFile, err))
+		}
+		return nil
+	}
+	if len(xlMetaBuf) == 0 {
+		report(madmin.FsckCritical, fmt.Sprintf("empty %s", xlMetaJSONFile))
+		return nil
+	}
+	if !json.Valid(xlMetaBuf) {
+		report(madmin.FsckCritical, fmt.Sprintf("truncated or malformed %s", xlMetaJSONFile))
+		return nil
+	}
+	xlMeta, err := xlMetaV1UnmarshalJSON(seq.ctx, xlMetaBuf)
+	if err != nil || !xlMeta.IsValid() {
+		report(madmin.FsckCritical, fmt.Sprintf("invalid %s", xlMetaJSONFile))
+		return nil
+	}
+
+	erasure := xlMeta.Erasure
+	for _, part := range xlMeta.Parts {
+		if seq.isQuitting() {
+			return errFsckStopSignalled
+		}
+
+		partPath := path.Join(object, part.Name)
+		fi, err := disk.StatFile(bucket, partPath)
+		if err != nil {
+			if err == errFileNotFound {
+				report(madmin.FsckWarning, fmt.Sprintf("missing shard %s", part.Name))
+			} else {
+				report(madmin.FsckCritical, fmt.Sprintf("unreadable shard %s: %v", part.Name, err))
+			}
+			continue
+		}
+		if fi.Size != getErasureShardFileSize(erasure.BlockSize, part.Size, erasure.DataBlocks) {
+			report(madmin.FsckCritical, fmt.Sprintf("truncated shard %s", part.Name))
+			continue
+		}
+
+		checksumInfo := erasure.GetChecksumInfo(part.Name)
+		if !checksumInfo.Algorithm.Available() {
+			report(madmin.FsckWarning, fmt.Sprintf("no checksum for shard %s", part.Name))
+			continue
+		}
+		// The whole shard is read and verified even if a
+		// 0-length buffer is passed.
+		verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
+		if _, err = disk.ReadFile(bucket, partPath, 0, []byte{}, verifier); err != nil {
+			if isBitrotMismatchErr(err) {
+				report(madmin.FsckCritical, fmt.Sprintf("corrupted shard %s", part.Name))
+			} else {
+				report(madmin.FsckCritical, fmt.Sprintf("unreadable shard %s: %v", part.Name, err))
+			}
+		}
+	}
+	return nil
+}
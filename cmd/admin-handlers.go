@@ -19,10 +19,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +37,7 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/quick"
 )
 
@@ -43,10 +50,59 @@ type mgmtQueryKey string
 
 // Only valid query params for mgmt admin APIs.
 const (
-	mgmtBucket      mgmtQueryKey = "bucket"
-	mgmtPrefix      mgmtQueryKey = "prefix"
-	mgmtClientToken mgmtQueryKey = "clientToken"
-	mgmtForceStart  mgmtQueryKey = "forceStart"
+	mgmtBucket       mgmtQueryKey = "bucket"
+	mgmtPrefix       mgmtQueryKey = "prefix"
+	mgmtClientToken  mgmtQueryKey = "clientToken"
+	mgmtForceStart   mgmtQueryKey = "forceStart"
+	mgmtSortBy       mgmtQueryKey = "sortBy"
+	mgmtOffset       mgmtQueryKey = "offset"
+	mgmtLimit        mgmtQueryKey = "limit"
+	mgmtForce        mgmtQueryKey = "force"
+	mgmtSubsystem    mgmtQueryKey = "subsystem"
+	mgmtLevel        mgmtQueryKey = "level"
+	mgmtNode         mgmtQueryKey = "node"
+	mgmtDisk         mgmtQueryKey = "disk"
+	mgmtRequestID    mgmtQueryKey = "id"
+	mgmtLines        mgmtQueryKey = "lines"
+	mgmtSince        mgmtQueryKey = "since"
+	mgmtWaitForReady mgmtQueryKey = "waitForReady"
+
+	mgmtRequestsPerSecond mgmtQueryKey = "requestsPerSecond"
+	mgmtObject            mgmtQueryKey = "object"
+	mgmtAction            mgmtQueryKey = "action"
+	mgmtReclaim           mgmtQueryKey = "reclaim"
+	mgmtAccessKey         mgmtQueryKey = "accessKey"
+	mgmtAlgorithm         mgmtQueryKey = "algorithm"
+	mgmtRollup            mgmtQueryKey = "rollup"
+	mgmtStorageClass      mgmtQueryKey = "storageClass"
+	mgmtDryRun            mgmtQueryKey = "dryRun"
+	mgmtFrom              mgmtQueryKey = "from"
+	mgmtTo                mgmtQueryKey = "to"
+	mgmtFilter            mgmtQueryKey = "filter"
+	mgmtSize              mgmtQueryKey = "size"
+	mgmtConcurrency       mgmtQueryKey = "concurrency"
+	mgmtDuration          mgmtQueryKey = "duration"
+	mgmtPrincipal         mgmtQueryKey = "principal"
+	mgmtThreshold         mgmtQueryKey = "threshold"
+	mgmtRestartDelay      mgmtQueryKey = "restartDelay"
+	mgmtToken             mgmtQueryKey = "token"
+	mgmtObjects           mgmtQueryKey = "objects"
+	mgmtParity            mgmtQueryKey = "parity"
+	mgmtMaxRequests       mgmtQueryKey = "maxRequests"
+	mgmtSection           mgmtQueryKey = "section"
+	mgmtMinPartSize       mgmtQueryKey = "minPartSize"
+	mgmtMaxSkew           mgmtQueryKey = "maxSkew"
+	mgmtEnable            mgmtQueryKey = "enable"
+)
+
+const (
+	// Maximum number of buckets returned by a single buckets
+	// usage request.
+	maxBucketsUsageLimit = 1000
+
+	// Header of the reply to a config update holding the warnings
+	// of the new config as a JSON array.
+	configWarningsHeader = "X-Minio-Config-Warnings"
 )
 
 var (
@@ -61,7 +117,7 @@ var (
 // -----------
 // Returns Administration API version
 func (a adminAPIHandlers) VersionHandler(w http.ResponseWriter, r *http.Request) {
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminVersionAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
@@ -79,9 +135,13 @@ func (a adminAPIHandlers) VersionHandler(w http.ResponseWriter, r *http.Request)
 
 // ServiceStatusHandler - GET /minio/admin/v1/service
 // ----------
-// Returns server version and uptime.
+// Returns server version and uptime, along with whether minio runs as
+// a server or as a gateway. Gateways also report whether their backend
+// is reachable. Unlike the handlers needing the object layer, it
+// replies while the server is still starting, reporting it as such
+// without uptime, so that a cluster can be polled until it is up.
 func (a adminAPIHandlers) ServiceStatusHandler(w http.ResponseWriter, r *http.Request) {
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminServiceStatusAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
@@ -93,19 +153,29 @@ func (a adminAPIHandlers) ServiceStatusHandler(w http.ResponseWriter, r *http.Re
 		CommitID: CommitID,
 	}
 
-	// Fetch uptimes from all peers. This may fail to due to lack
-	// of read-quorum availability.
-	uptime, err := getPeerUptimes(globalAdminPeers)
-	if err != nil {
-		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
-		logger.LogIf(context.Background(), err)
-		return
-	}
-
 	// Create API response
 	serverStatus := madmin.ServiceStatus{
 		ServerVersion: serverVersion,
-		Uptime:        uptime,
+		Mode:          madmin.ServiceModeServer,
+		Starting:      isServerStarting(),
+	}
+
+	if !serverStatus.Starting {
+		// Fetch uptimes from all peers. This may fail to due to
+		// lack of read-quorum availability.
+		uptime, err := getPeerUptimes(globalAdminPeers)
+		if err != nil {
+			writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+			logger.LogIf(context.Background(), err)
+			return
+		}
+		serverStatus.Uptime = uptime
+		serverStatus.UptimeSeconds = uptime.Seconds()
+	}
+	if globalIsGateway {
+		backend := probeGatewayBackend(context.Background(), newObjectLayerFn())
+		serverStatus.Mode = madmin.ServiceModeGateway
+		serverStatus.Backend = &backend
 	}
 
 	// Marshal API response
@@ -121,12 +191,16 @@ func (a adminAPIHandlers) ServiceStatusHandler(w http.ResponseWriter, r *http.Re
 }
 
 // ServiceStopNRestartHandler - POST /minio/admin/v1/service
-// Body: {"action": <restart-action>}
+// Body: {"action": <restart-action>, "delaySeconds": <delay>}
 // ----------
 // Restarts/Stops minio server gracefully. In a distributed setup,
 // restarts all the servers in the cluster.
+//
+// A restart with a delay is scheduled on all servers and the time of
+// the restart is returned, a pending scheduled restart is aborted by
+// the cancel-restart action.
 func (a adminAPIHandlers) ServiceStopNRestartHandler(w http.ResponseWriter, r *http.Request) {
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminServiceStopRestartAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
@@ -140,32 +214,188 @@ func (a adminAPIHandlers) ServiceStopNRestartHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	if sa.DelaySeconds < 0 || sa.DelaySeconds > 0 && sa.Action != madmin.ServiceActionValueRestart {
+		writeErrorResponseJSON(w, ErrMalformedPOSTRequest, r.URL)
+		logger.LogIf(context.Background(), errors.New("Invalid service action delay received"))
+		return
+	}
+
+	var confirmAction string
+	switch sa.Action {
+	case madmin.ServiceActionValueRestart:
+		confirmAction = madmin.ConfirmServiceRestart
+	case madmin.ServiceActionValueStop:
+		confirmAction = madmin.ConfirmServiceStop
+	}
+	if adminAPIErr = checkAdminConfirmation(r, confirmAction); adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
 	var serviceSig serviceSignal
 	switch sa.Action {
 	case madmin.ServiceActionValueRestart:
+		if sa.DelaySeconds > 0 {
+			scheduleRestart(w, r, time.Duration(sa.DelaySeconds)*time.Second)
+			return
+		}
 		serviceSig = serviceRestart
 	case madmin.ServiceActionValueStop:
 		serviceSig = serviceStop
+	case madmin.ServiceActionValueCancelRestart:
+		cancelRestart(w, r)
+		return
 	default:
 		writeErrorResponseJSON(w, ErrMalformedPOSTRequest, r.URL)
 		logger.LogIf(context.Background(), errors.New("Invalid service action received"))
 		return
 	}
 
-	// Reply to the client before restarting minio server.
-	writeSuccessResponseHeadersOnly(w)
+	signalServiceAndReply(context.Background(), w, r, globalAdminPeers, serviceSig)
+}
+
+// ConfirmActionHandler - POST /minio/admin/v1/confirm?action={action}
+// ----------
+// Issues a token confirming the given destructive action, such as
+// service-stop, valid for two minutes. Actions listed in
+// MINIO_ADMIN_CONFIRM_ACTIONS are rejected unless their request
+// carries a confirmation token of the same credential in the
+// X-Minio-Admin-Confirmation header, a deliberate second step against
+// destructive requests sent by mistake. Since anyone holding the key
+// of a credential can get a token for it, tokens do not protect
+// against a leaked key. Tokens are signed with the secret key of the
+// server, accepted by all servers and confirm any number of requests
+// until they expire. Tokens are issued for any destructive action,
+// whether it requires one is reported.
+func (a adminAPIHandlers) ConfirmActionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConfirmAction")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminConfirmAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	action := r.URL.Query().Get(string(mgmtAction))
+	if _, ok := confirmableAdminActions[action]; !ok {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown admin action `%s`", action), r.URL)
+		return
+	}
+
+	cred, _, adminAPIErr := getAdminRequestCredential(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	secretKey := globalServerConfig.GetCredential().SecretKey
+	confirmation := newAdminConfirmation(secretKey, cred.AccessKey, action, UTCNow())
+
+	jsonBytes, err := json.Marshal(confirmation)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// signalServiceAndReply - sends the service command to the remote
+// servers and replies with whether each server acknowledged it, so
+// that clients know whether the command reached the whole cluster.
+// This server, the first of peers, receives the command once the reply
+// is sent and is reported as acknowledged.
+func signalServiceAndReply(ctx context.Context, w http.ResponseWriter, r *http.Request, peers adminPeers, cmd serviceSignal) {
+	localPeers, remotePeers := peers[:1], peers[1:]
+
+	acks := []madmin.ServiceSignalAck{{Addr: localPeers[0].addr, Acknowledged: true}}
+	for i, err := range signalPeers(remotePeers, cmd) {
+		ack := madmin.ServiceSignalAck{Addr: remotePeers[i].addr, Acknowledged: err == nil}
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", remotePeers[i].addr)
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+			ack.Error = err.Error()
+		}
+		acks = append(acks, ack)
+	}
 
-	sendServiceCmd(globalAdminPeers, serviceSig)
+	jsonBytes, err := json.Marshal(acks)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+	} else {
+		writeSuccessResponseJSON(w, jsonBytes)
+	}
+
+	sendServiceCmd(localPeers, cmd)
+}
+
+// scheduleRestart - schedules the restart of all servers after the
+// delay and replies with the time of the restart. If any server fails
+// to schedule it, the restart is cancelled on all servers.
+func scheduleRestart(w http.ResponseWriter, r *http.Request, delay time.Duration) {
+	restartTime := UTCNow().Add(delay)
+
+	var details []string
+	for i, err := range schedulePeersRestart(globalAdminPeers, delay, "") {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		cancelPeersRestart(globalAdminPeers, "")
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(madmin.ScheduledRestart{RestartTime: restartTime})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// cancelRestart - aborts the scheduled restart of all servers.
+func cancelRestart(w http.ResponseWriter, r *http.Request) {
+	cancelled, errs := cancelPeersRestart(globalAdminPeers, "")
+
+	var details []string
+	for i, err := range errs {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+	if !cancelled {
+		writeErrorResponseJSON(w, ErrAdminNoScheduledRestart, r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
 }
 
 // ServerProperties holds some server information such as, version, region
 // uptime, etc..
 type ServerProperties struct {
-	Uptime   time.Duration `json:"uptime"`
-	Version  string        `json:"version"`
-	CommitID string        `json:"commitID"`
-	Region   string        `json:"region"`
-	SQSARN   []string      `json:"sqsARN"`
+	Uptime         time.Duration `json:"uptime"`
+	UptimeSeconds  float64       `json:"uptimeSeconds"`
+	Version        string        `json:"version"`
+	CommitID       string        `json:"commitID"`
+	Region         string        `json:"region"`
+	SQSARN         []string      `json:"sqsARN"`
+	ConfigChecksum string        `json:"configChecksum,omitempty"`
+	SafeMode       bool          `json:"safeMode,omitempty"`
 }
 
 // ServerConnStats holds transferred bytes from/to the server
@@ -178,8 +408,9 @@ type ServerConnStats struct {
 // ServerHTTPMethodStats holds total number of HTTP operations from/to the server,
 // including the average duration the call was spent.
 type ServerHTTPMethodStats struct {
-	Count       uint64 `json:"count"`
-	AvgDuration string `json:"avgDuration"`
+	Count             uint64  `json:"count"`
+	AvgDuration       string  `json:"avgDuration"`
+	AvgDurationMillis float64 `json:"avgDurationMillis"`
 }
 
 // ServerHTTPStats holds all type of http operations performed to/from the server
@@ -197,6 +428,16 @@ type ServerHTTPStats struct {
 	SuccessDELETEStats ServerHTTPMethodStats `json:"successDELETEs"`
 }
 
+// ServerLoad holds the current load of a server, synthesized into a
+// score comparable across servers to weight traffic between them.
+type ServerLoad struct {
+	ActiveRequests int     `json:"activeRequests"`
+	CPUs           int     `json:"cpus"`
+	LoadAverage    float64 `json:"loadAverage"`
+	DiskQueueDepth float64 `json:"diskQueueDepth"`
+	Score          float64 `json:"score"`
+}
+
 // ServerInfoData holds storage, connections and other
 // information of a given server.
 type ServerInfoData struct {
@@ -204,6 +445,7 @@ type ServerInfoData struct {
 	ConnStats   ServerConnStats  `json:"network"`
 	HTTPStats   ServerHTTPStats  `json:"http"`
 	Properties  ServerProperties `json:"server"`
+	Load        ServerLoad       `json:"load"`
 }
 
 // ServerInfo holds server information result of one node
@@ -213,49 +455,130 @@ type ServerInfo struct {
 	Data  *ServerInfoData `json:"data"`
 }
 
-// ServerInfoHandler - GET /minio/admin/v1/info
-// ----------
-// Get server information
-func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
-	// Authenticate request
+// ServerInfoRollup holds the server information of all nodes
+// aggregated into cluster totals.
+type ServerInfoRollup struct {
+	Servers        int             `json:"servers"`
+	OnlineServers  int             `json:"onlineServers"`
+	OfflineServers int             `json:"offlineServers"`
+	ConnStats      ServerConnStats `json:"network"`
+	HTTPStats      ServerHTTPStats `json:"http"`
+	MinUptime      time.Duration   `json:"minUptime"`
+	MaxUptime      time.Duration   `json:"maxUptime"`
+	AvgUptime      time.Duration   `json:"avgUptime"`
+	OnlineDisks    int             `json:"onlineDisks"`
+	OfflineDisks   int             `json:"offlineDisks"`
+}
 
-	// Setting the region as empty so as the mc server info command is irrespective to the region.
-	adminAPIErr := checkAdminRequestAuthType(r, "")
-	if adminAPIErr != ErrNone {
-		writeErrorResponseJSON(w, adminAPIErr, r.URL)
-		return
+// rollupHTTPMethodStats - sums the counts of the given stats, the
+// average duration being weighted by the counts.
+func rollupHTTPMethodStats(stats []ServerHTTPMethodStats) ServerHTTPMethodStats {
+	var count uint64
+	var totalSeconds float64
+	for _, st := range stats {
+		count += st.Count
+		totalSeconds += float64(st.Count) * st.AvgDurationMillis / 1000
 	}
+	return ServerHTTPMethodStats{
+		Count:             count,
+		AvgDuration:       durationStr(totalSeconds, float64(count)),
+		AvgDurationMillis: durationMillis(totalSeconds, float64(count)),
+	}
+}
 
-	// Web service response
-	reply := make([]ServerInfo, len(globalAdminPeers))
+// rollupServerInfo - aggregates the server information of all nodes,
+// nodes which replied with an error are only counted as offline.
+func rollupServerInfo(serversInfo []ServerInfo) ServerInfoRollup {
+	rollup := ServerInfoRollup{Servers: len(serversInfo)}
 
-	var wg sync.WaitGroup
+	// Stats of each HTTP method, in the order of ServerHTTPStats.
+	var methodStats [10][]ServerHTTPMethodStats
+	var totalUptime time.Duration
+	for _, serverInfo := range serversInfo {
+		if serverInfo.Error != "" || serverInfo.Data == nil {
+			rollup.OfflineServers++
+			continue
+		}
+		data := serverInfo.Data
+		rollup.OnlineServers++
 
-	// Gather server information for all nodes
-	for i, p := range globalAdminPeers {
-		wg.Add(1)
+		rollup.ConnStats.TotalInputBytes += data.ConnStats.TotalInputBytes
+		rollup.ConnStats.TotalOutputBytes += data.ConnStats.TotalOutputBytes
+		rollup.ConnStats.Throughput += data.ConnStats.Throughput
 
-		// Gather information from a peer in a goroutine
-		go func(idx int, peer adminPeer) {
-			defer wg.Done()
+		h := data.HTTPStats
+		for i, st := range []ServerHTTPMethodStats{
+			h.TotalHEADStats, h.SuccessHEADStats,
+			h.TotalGETStats, h.SuccessGETStats,
+			h.TotalPUTStats, h.SuccessPUTStats,
+			h.TotalPOSTStats, h.SuccessPOSTStats,
+			h.TotalDELETEStats, h.SuccessDELETEStats,
+		} {
+			methodStats[i] = append(methodStats[i], st)
+		}
 
-			// Initialize server info at index
-			reply[idx] = ServerInfo{Addr: peer.addr}
+		uptime := data.Properties.Uptime
+		if rollup.OnlineServers == 1 || uptime < rollup.MinUptime {
+			rollup.MinUptime = uptime
+		}
+		if uptime > rollup.MaxUptime {
+			rollup.MaxUptime = uptime
+		}
+		totalUptime += uptime
 
-			serverInfoData, err := peer.cmdRunner.ServerInfo()
-			if err != nil {
-				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
-				ctx := logger.SetReqInfo(context.Background(), reqInfo)
-				logger.LogIf(ctx, err)
-				reply[idx].Error = err.Error()
-				return
-			}
+		// Every node reports the disks of the whole cluster,
+		// they must not be summed.
+		backend := data.StorageInfo.Backend
+		if backend.OnlineDisks > rollup.OnlineDisks {
+			rollup.OnlineDisks = backend.OnlineDisks
+		}
+		if backend.OfflineDisks > rollup.OfflineDisks {
+			rollup.OfflineDisks = backend.OfflineDisks
+		}
+	}
+	if rollup.OnlineServers == 0 {
+		return rollup
+	}
+	rollup.AvgUptime = totalUptime / time.Duration(rollup.OnlineServers)
 
-			reply[idx].Data = &serverInfoData
-		}(i, p)
+	rollup.HTTPStats = ServerHTTPStats{
+		TotalHEADStats:     rollupHTTPMethodStats(methodStats[0]),
+		SuccessHEADStats:   rollupHTTPMethodStats(methodStats[1]),
+		TotalGETStats:      rollupHTTPMethodStats(methodStats[2]),
+		SuccessGETStats:    rollupHTTPMethodStats(methodStats[3]),
+		TotalPUTStats:      rollupHTTPMethodStats(methodStats[4]),
+		SuccessPUTStats:    rollupHTTPMethodStats(methodStats[5]),
+		TotalPOSTStats:     rollupHTTPMethodStats(methodStats[6]),
+		SuccessPOSTStats:   rollupHTTPMethodStats(methodStats[7]),
+		TotalDELETEStats:   rollupHTTPMethodStats(methodStats[8]),
+		SuccessDELETEStats: rollupHTTPMethodStats(methodStats[9]),
 	}
+	return rollup
+}
 
-	wg.Wait()
+// ServerInfoHandler - GET /minio/admin/v1/info?rollup
+// ----------
+// Get server information, per node or, if the rollup flag is
+// provided, aggregated into cluster totals. The response is signed
+// with the secret key of the server if MINIO_ADMIN_SIGN_RESPONSES is
+// on.
+func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
+	// Authenticate request
+
+	// Setting the region as empty so as the mc server info command is irrespective to the region.
+	adminAPIErr := checkAdminRequestAuthType(r, adminServerInfoAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var reply interface{}
+	serversInfo := getServerInfo(globalAdminPeers)
+	if _, rollup := r.URL.Query()[string(mgmtRollup)]; rollup {
+		reply = rollupServerInfo(serversInfo)
+	} else {
+		reply = serversInfo
+	}
 
 	// Marshal API response
 	jsonBytes, err := json.Marshal(reply)
@@ -265,124 +588,3407 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if globalAdminSignResponses {
+		signAdminResponse(w, r, jsonBytes)
+	}
+
 	// Reply with storage information (across nodes in a
 	// distributed setup) as json.
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// extractHealInitParams - Validates params for heal init API.
-func extractHealInitParams(r *http.Request) (bucket, objPrefix string,
-	hs madmin.HealOpts, clientToken string, forceStart bool,
-	err APIErrorCode) {
-
-	vars := mux.Vars(r)
-	bucket = vars[string(mgmtBucket)]
-	objPrefix = vars[string(mgmtPrefix)]
+// SetServerInfoExportHandler - POST /minio/admin/v1/info/export
+// ----------
+// Enables, disables or changes the periodic export of the server
+// information of all servers to objects of a bucket, each snapshot
+// being written to an object named after the time it was taken. The
+// configuration is saved and loaded by all servers, the server of the
+// first endpoint takes the snapshots.
+func (a adminAPIHandlers) SetServerInfoExportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetServerInfoExport")
 
-	if bucket == "" {
-		if objPrefix != "" {
-			// Bucket is required if object-prefix is given
-			err = ErrHealMissingBucket
-			return
-		}
-	} else if !IsValidBucketName(bucket) {
-		err = ErrInvalidBucketName
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
 		return
 	}
 
-	// empty prefix is valid.
-	if !IsValidObjectPrefix(objPrefix) {
-		err = ErrInvalidObjectName
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetServerInfoExportAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	qParms := r.URL.Query()
-	if len(qParms[string(mgmtClientToken)]) > 0 {
-		clientToken = qParms[string(mgmtClientToken)][0]
+	var config madmin.ServerInfoExportConfig
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxConfigJSONSize)).Decode(&config); err != nil {
+		writeErrorResponseJSON(w, ErrMalformedJSON, r.URL)
+		return
 	}
-	if _, ok := qParms[string(mgmtForceStart)]; ok {
-		forceStart = true
+	if err := validateServerInfoExport(config); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
 	}
-
-	// ignore body if clientToken is provided
-	if clientToken == "" {
-		jerr := json.NewDecoder(r.Body).Decode(&hs)
-		if jerr != nil {
-			logger.LogIf(context.Background(), jerr)
-			err = ErrRequestBodyParse
+	if config.Enabled {
+		if _, err := objectAPI.GetBucketInfo(ctx, config.Bucket); err != nil {
+			writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
 			return
 		}
 	}
 
-	err = ErrNone
-	return
-}
-
-// HealHandler - POST /minio/admin/v1/heal/
-// -----------
-// Start heal processing and return heal status items.
-//
-// On a successful heal sequence start, a unique client token is
-// returned. Subsequent requests to this endpoint providing the client
-// token will receive heal status records from the running heal
-// sequence.
-//
-// If no client token is provided, and a heal sequence is in progress
-// an error is returned with information about the running heal
-// sequence. However, if the force-start flag is provided, the server
-// aborts the running heal sequence and starts a new one.
-func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "Heal")
+	if err := saveServerInfoExport(objectAPI, config); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
 
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
-		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+	var details []string
+	for i, err := range loadPeersServerInfoExport(globalAdminPeers) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
 		return
 	}
 
-	// Validate request signature.
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ServerInfoExportStatusHandler - GET /minio/admin/v1/info/export
+// ----------
+// Returns the configuration of the periodic export of the server
+// information along with the time and object of the last snapshot
+// taken by each server.
+func (a adminAPIHandlers) ServerInfoExportStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServerInfoExportStatus")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetServerInfoExportAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Check if this setup has an erasure coded backend.
-	if !globalIsXL {
-		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
-		return
+	status := madmin.ServerInfoExportStatus{
+		Servers: make([]madmin.ServerInfoExporterState, len(globalAdminPeers)),
 	}
+	status.Config, _ = globalServerInfoExporter.getConfig()
 
-	bucket, objPrefix, hs, clientToken, forceStart, apiErr := extractHealInitParams(r)
-	if apiErr != ErrNone {
-		writeErrorResponseJSON(w, apiErr, r.URL)
-		return
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			state, err := peer.cmdRunner.ServerInfoExporterState()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				state.Error = err.Error()
+			}
+			state.Addr = peer.addr
+			status.Servers[idx] = state
+		}(i, p)
 	}
+	wg.Wait()
 
-	type healResp struct {
-		respBytes []byte
-		errCode   APIErrorCode
-		errBody   string
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
 	}
 
-	// Define a closure to start sending whitespace to client
-	// after 10s unless a response item comes in
-	keepConnLive := func(w http.ResponseWriter, respCh chan healResp) {
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
-		started := false
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// getServerInfo - gathers the server information of all peers.
+func getServerInfo(peers adminPeers) []ServerInfo {
+	reply := make([]ServerInfo, len(peers))
+
+	var wg sync.WaitGroup
+
+	// Gather server information for all nodes
+	for i, p := range peers {
+		wg.Add(1)
+
+		// Gather information from a peer in a goroutine
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			// Initialize server info at index
+			reply[idx] = ServerInfo{Addr: peer.addr}
+
+			serverInfoData, err := peer.cmdRunner.ServerInfo()
+			if err == nil {
+				// Isolate a peer replying with malformed data
+				// so it does not corrupt the whole reply.
+				err = validateServerInfoData(serverInfoData)
+			}
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+
+			reply[idx].Data = &serverInfoData
+		}(i, p)
+	}
+
+	wg.Wait()
+	return reply
+}
+
+// validateServerInfoData - returns an error if the server information
+// replied by a peer is structurally invalid or cannot be marshaled.
+func validateServerInfoData(data ServerInfoData) error {
+	malformed := func(detail string) error {
+		return fmt.Errorf("malformed response: %s", detail)
+	}
+
+	backend := data.StorageInfo.Backend
+	switch backend.Type {
+	case Unknown, BackendFS, BackendErasure:
+	default:
+		return malformed(fmt.Sprintf("unknown backend type %d", backend.Type))
+	}
+	for _, n := range []int{backend.OnlineDisks, backend.OfflineDisks,
+		backend.StandardSCData, backend.StandardSCParity,
+		backend.RRSCData, backend.RRSCParity} {
+		if n < 0 {
+			return malformed("negative disk count")
+		}
+	}
+	if data.Properties.Uptime < 0 || data.Properties.UptimeSeconds < 0 {
+		return malformed("negative uptime")
+	}
+
+	// Values such as NaN cannot be marshaled, and would fail the
+	// reply of all peers.
+	if _, err := json.Marshal(data); err != nil {
+		return malformed(err.Error())
+	}
+	return nil
+}
+
+// getBucketUsage - walks all objects in a bucket and returns the
+// number of objects and their total size.
+func getBucketUsage(ctx context.Context, objectAPI ObjectLayer, bucket string) (count, size uint64, err error) {
+	marker := ""
+	for {
+		result, err := objectAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, object := range result.Objects {
+			count++
+			size += uint64(object.Size)
+		}
+		if !result.IsTruncated {
+			return count, size, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// extractBucketsUsageParams - Validates params for buckets usage API.
+func extractBucketsUsageParams(r *http.Request) (sortBy string, offset, limit int, err APIErrorCode) {
+	qParms := r.URL.Query()
+
+	sortBy = qParms.Get(string(mgmtSortBy))
+	switch sortBy {
+	case "":
+		sortBy = madmin.BucketsSortByName
+	case madmin.BucketsSortByName, madmin.BucketsSortBySize:
+	default:
+		return "", 0, 0, ErrAdminInvalidArgument
+	}
+
+	limit = maxBucketsUsageLimit
+	if v := qParms.Get(string(mgmtOffset)); v != "" {
+		n, perr := strconv.Atoi(v)
+		if perr != nil || n < 0 {
+			return "", 0, 0, ErrAdminInvalidArgument
+		}
+		offset = n
+	}
+	if v := qParms.Get(string(mgmtLimit)); v != "" {
+		n, perr := strconv.Atoi(v)
+		if perr != nil || n <= 0 || n > maxBucketsUsageLimit {
+			return "", 0, 0, ErrAdminInvalidArgument
+		}
+		limit = n
+	}
+
+	return sortBy, offset, limit, ErrNone
+}
+
+// APIErrorStatsHandler - GET /minio/admin/v1/stats/errors
+// ----------
+// Returns the count of error responses sent by each server, indexed
+// by API error code.
+func (a adminAPIHandlers) APIErrorStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "APIErrorStats")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminAPIErrorStatsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	writeAPIErrorStats(ctx, w, r, false)
+}
+
+// ResetAPIErrorStatsHandler - POST /minio/admin/v1/stats/errors
+// ----------
+// Resets the counters of error responses of all servers to zero, and
+// returns the counts they had, so that no error goes uncounted between
+// reading and resetting them.
+func (a adminAPIHandlers) ResetAPIErrorStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ResetAPIErrorStats")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminResetAPIErrorStatsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	writeAPIErrorStats(ctx, w, r, true)
+}
+
+// writeAPIErrorStats - writes the count of error responses sent by
+// each server, resetting the counters once read if reset is set.
+func writeAPIErrorStats(ctx context.Context, w http.ResponseWriter, r *http.Request, reset bool) {
+	reply := make([]madmin.ServerAPIErrorStats, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx] = madmin.ServerAPIErrorStats{Addr: peer.addr}
+			counts, err := peer.cmdRunner.APIErrorStats(reset)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Counts = counts
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// serverMetricsHistory - metrics samples of a server.
+type serverMetricsHistory struct {
+	Error   string          `json:"error"`
+	Addr    string          `json:"addr"`
+	Samples []metricsSample `json:"samples"`
+}
+
+// extractMetricsHistoryParams - returns the time range of the metrics
+// samples to export, both given in RFC3339 format.
+func extractMetricsHistoryParams(qParms url.Values) (from, to time.Time, apiErr APIErrorCode) {
+	var err error
+	if v := qParms.Get(string(mgmtFrom)); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, ErrAdminInvalidArgument
+		}
+	}
+	if v := qParms.Get(string(mgmtTo)); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, ErrAdminInvalidArgument
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return from, to, ErrAdminInvalidArgument
+	}
+	return from, to, ErrNone
+}
+
+// MetricsHistoryHandler - GET /minio/admin/v1/metrics/history?from=<time>&to=<time>
+// ----------
+// Returns the connection and HTTP statistics of each server sampled
+// between `from` and `to`, oldest first. Servers keep samples for the
+// retention set with MINIO_METRICS_HISTORY.
+func (a adminAPIHandlers) MetricsHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "MetricsHistory")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminMetricsHistoryAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	from, to, apiErr := extractMetricsHistoryParams(r.URL.Query())
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
+		return
+	}
+
+	reply := make([]serverMetricsHistory, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx] = serverMetricsHistory{Addr: peer.addr}
+			samples, err := peer.cmdRunner.MetricsHistory(from, to)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Samples = samples
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// extractLogsBundleParams - returns the number of log entries of each
+// server and the start time of the logs to include in a bundle.
+func extractLogsBundleParams(qParms url.Values) (lines int, since time.Time, apiErr APIErrorCode) {
+	lines = defaultBundleLogLines
+	if v := qParms.Get(string(mgmtSince)); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return 0, since, ErrAdminInvalidArgument
+		}
+		since = UTCNow().Add(-d)
+		// All logs since the given time, unless a number
+		// of lines is also provided.
+		lines = 0
+	}
+	if v := qParms.Get(string(mgmtLines)); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxRecentLogEntries {
+			return 0, since, ErrAdminInvalidArgument
+		}
+		lines = n
+	}
+	return lines, since, ErrNone
+}
+
+// LogsBundleHandler - GET /minio/admin/v1/logs/bundle?lines=<n>&since=<duration>
+// ----------
+// Returns a zip archive meant to be attached to support cases, with
+// the most recent log entries of each server, the server information
+// of each server and the config, whose secrets are redacted.
+//
+// By default the last 1000 log entries of each server are included,
+// `lines` sets their number and `since`, such as `30m`, includes the
+// entries logged during that duration only.
+func (a adminAPIHandlers) LogsBundleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "LogsBundle")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminLogsBundleAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	lines, since, apiErr := extractLogsBundleParams(r.URL.Query())
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
+		return
+	}
+
+	config, err := redactConfig(globalServerConfig)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	logs := getPeersRecentLogs(globalAdminPeers, lines, since)
+	bundle, err := createLogsBundle(logs, getServerInfo(globalAdminPeers), config)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", logsBundleName(UTCNow())))
+	writeResponse(w, http.StatusOK, bundle, mimeZip)
+}
+
+// SlowRequestsHandler - GET /minio/admin/v1/stats/slow
+// ----------
+// Returns the most recent requests of each server which exceeded the
+// latency budget of their method.
+func (a adminAPIHandlers) SlowRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SlowRequests")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSlowRequestsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	reply := make([]madmin.ServerSlowRequests, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx] = madmin.ServerSlowRequests{Addr: peer.addr}
+			requests, err := peer.cmdRunner.SlowRequests()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Requests = requests
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ActiveRequestsHandler - GET /minio/admin/v1/requests/active
+// ----------
+// Returns the requests being served by each server, with the time
+// spent on them so far.
+func (a adminAPIHandlers) ActiveRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ActiveRequests")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminActiveRequestsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	reply := make([]madmin.ServerActiveRequests, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx] = madmin.ServerActiveRequests{Addr: peer.addr}
+			requests, err := peer.cmdRunner.ActiveRequests()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Requests = requests
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// CancelRequestHandler - DELETE /minio/admin/v1/requests/active?id={requestID}
+// ----------
+// Cancels the request with the given ID on the server serving it.
+// Reading the request body and writing its response fail from then
+// on, so that the request ends with an error.
+func (a adminAPIHandlers) CancelRequestHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, adminCancelRequestAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	requestID := r.URL.Query().Get(string(mgmtRequestID))
+	if requestID == "" {
+		writeErrorResponseJSON(w, ErrInvalidQueryParams, r.URL)
+		return
+	}
+
+	cancelled, errs := cancelPeersRequest(globalAdminPeers, requestID)
+
+	var details []string
+	for i, err := range errs {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if !cancelled && len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+	if !cancelled {
+		writeErrorResponseJSON(w, ErrAdminNoSuchRequest, r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// SimulatePolicyHandler - POST /minio/admin/v1/policy/simulate
+// ----------
+// Evaluates a candidate bucket policy against a request, given by its
+// principal, action and resource, without applying the policy. Returns
+// whether the request is allowed along with the deciding statement.
+func (a adminAPIHandlers) SimulatePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SimulatePolicy")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSimulatePolicyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var args madmin.PolicySimulationArgs
+	if err := json.NewDecoder(io.LimitReader(r.Body, 2*maxBucketPolicySize)).Decode(&args); err != nil {
+		writeErrorResponseJSON(w, ErrMalformedJSON, r.URL)
+		return
+	}
+	if len(args.Policy) > maxBucketPolicySize {
+		writeErrorResponseJSON(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	action := policy.Action(args.Action)
+	if !action.IsValid() {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unsupported action `%s`", args.Action), r.URL)
+		return
+	}
+
+	bucket, object := path2BucketAndObject(strings.TrimPrefix(args.Resource, policy.ResourceARNPrefix))
+	if bucket == "" {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Resource `%s` has no bucket", args.Resource), r.URL)
+		return
+	}
+
+	bucketPolicy, err := policy.ParseConfig(bytes.NewReader(args.Policy), bucket)
+	if err != nil {
+		writeCustomErrorResponseJSON(w, ErrMalformedPolicy, err.Error(), r.URL)
+		return
+	}
+
+	result, err := evaluateBucketPolicy(*bucketPolicy, policy.Args{
+		AccountName:     args.Principal,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: args.ConditionValues,
+		IsOwner:         isOwnerPrincipal(args.Principal),
+		ObjectName:      object,
+	})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// isOwnerPrincipal - returns whether requests of the given access key
+// are evaluated as the owner's, as in checkRequestAuthType. Only the
+// root account is the owner.
+func isOwnerPrincipal(principal string) bool {
+	return principal != "" && principal == globalServerConfig.GetCredential().AccessKey
+}
+
+// evaluateBucketPolicy - evaluates a bucket policy against a request,
+// returns whether it is allowed along with the deciding statement.
+func evaluateBucketPolicy(bucketPolicy policy.Policy, args policy.Args) (result madmin.PolicySimulationResult, err error) {
+	result.Allowed, result.StatementIndex = bucketPolicy.Evaluate(args)
+	if result.StatementIndex >= 0 {
+		result.Statement, err = json.Marshal(bucketPolicy.Statements[result.StatementIndex])
+	}
+	return result, err
+}
+
+// ExplainAccessHandler - GET /minio/admin/v1/access/explain?principal={accessKey}&bucket={bucket}&object={object}&action={action}
+// ----------
+// Evaluates a request, given by its principal, action and resource,
+// against the policy currently applied to its bucket, as the server
+// would authorize it. Returns whether the request is allowed along
+// with the deciding statement. Without a bucket policy, only the owner
+// is allowed. Condition values, such as the source IP, are those of
+// this admin request.
+func (a adminAPIHandlers) ExplainAccessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExplainAccess")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminExplainAccessAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	principal := vars.Get(string(mgmtPrincipal))
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+	action := policy.Action(vars.Get(string(mgmtAction)))
+	if !IsValidBucketName(bucket) {
+		writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+		return
+	}
+	if !action.IsValid() {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unsupported action `%s`", action), r.URL)
+		return
+	}
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	args := policy.Args{
+		AccountName:     principal,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         isOwnerPrincipal(principal),
+		ObjectName:      object,
+	}
+	explanation := madmin.AccessExplanation{IsOwner: args.IsOwner}
+	bucketPolicy, found := globalPolicySys.Get(bucket)
+	if found {
+		result, err := evaluateBucketPolicy(bucketPolicy, args)
+		if err != nil {
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
+			logger.LogIf(ctx, err)
+			return
+		}
+		explanation.HasBucketPolicy = true
+		explanation.Allowed = result.Allowed
+		explanation.StatementIndex = result.StatementIndex
+		explanation.Statement = result.Statement
+	} else {
+		explanation.Allowed = args.IsOwner
+		explanation.StatementIndex = -1
+	}
+
+	jsonBytes, err := json.Marshal(explanation)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ValidatePostPolicyHandler - POST /minio/admin/v1/presign/validate
+// ----------
+// Validates a POST policy, sent as the JSON document or its base64
+// encoding as in the form of presigned POST uploads, parsing it the
+// same way uploads do. Returns whether uploads using the policy can
+// succeed along with the errors making them fail, without issuing
+// any upload.
+func (a adminAPIHandlers) ValidatePostPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ValidatePostPolicy")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminValidatePostPolicyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	policyBuf := make([]byte, maxBucketPolicySize+1)
+	n, err := io.ReadFull(r.Body, policyBuf)
+	if err == nil {
+		// More than maxBucketPolicySize bytes were available
+		writeErrorResponseJSON(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+	if err != io.ErrUnexpectedEOF && err != io.EOF {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// The policy is base64 encoded in the form of POST uploads,
+	// accept it both ways.
+	policyBytes := bytes.TrimSpace(policyBuf[:n])
+	if !json.Valid(policyBytes) {
+		if decoded, derr := base64.StdEncoding.DecodeString(string(policyBytes)); derr == nil {
+			policyBytes = decoded
+		}
+	}
+
+	var validation madmin.PostPolicyValidation
+	postPolicyForm, err := parsePostPolicyForm(string(policyBytes))
+	if err != nil {
+		validation.Errors = []string{err.Error()}
+	} else {
+		validation.Expiration = postPolicyForm.Expiration
+		validation.Errors, validation.Warnings = validatePostPolicyForm(postPolicyForm, UTCNow())
+		validation.Valid = len(validation.Errors) == 0
+	}
+
+	jsonBytes, err := json.Marshal(validation)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// UploadsUsageHandler - GET /minio/admin/v1/uploads/usage
+// ----------
+// Returns the count and size of the parts of the in-progress multipart
+// uploads per bucket, whose storage is in use until the uploads are
+// completed, aborted or cleaned up once stale. Uploads are walked on
+// demand, only FS and erasure coded backends are supported.
+func (a adminAPIHandlers) UploadsUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UploadsUsage")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminUploadsUsageAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	usage, err := getUploadsUsage(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(usage)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DisksUsageHandler - GET /minio/admin/v1/disks/usage?threshold={percent}
+// ----------
+// Returns the capacity, used and free space of the disks of each
+// server. Disks whose used space reached `threshold` percent of their
+// capacity, 90 by default, are flagged as approaching full so that
+// they can be replaced or rebalanced before writes fail.
+func (a adminAPIHandlers) DisksUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DisksUsage")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminDisksUsageAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	threshold := defaultDiskFullThreshold
+	if v := r.URL.Query().Get(string(mgmtThreshold)); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 100 {
+			writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+			return
+		}
+		threshold = n
+	}
+
+	jsonBytes, err := json.Marshal(getDisksUsage(globalAdminPeers, threshold))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DisksLayoutHandler - GET /minio/admin/v1/disks/layout
+// ----------
+// Returns the expected and actual position in the erasure sets of the
+// disks of each server, from the identifiers of their format.json.
+// Disks carrying the identifier of another position are flagged as
+// misplaced, which catches disks mounted in the wrong order or on the
+// wrong server.
+func (a adminAPIHandlers) DisksLayoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DisksLayout")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminDisksLayoutAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(getDisksLayout(globalAdminPeers))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// NodeStatusHandler - GET /minio/admin/v1/node/{node}/status
+// ----------
+// Returns whether the given node answers admin RPCs and whether each
+// of its disks is online, as seen by this server. Offline disks come
+// with the reason they are offline, the last error observed on them
+// and the time they have been offline since. Connected disks are
+// probed, so that disk I/O errors are reported right away.
+func (a adminAPIHandlers) NodeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NodeStatus")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminNodeStatusAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only tracked individually on erasure coded setups.
+	s, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	node := mux.Vars(r)["node"]
+	var peer *adminPeer
+	for i := range globalAdminPeers {
+		if globalAdminPeers[i].addr == node {
+			peer = &globalAdminPeers[i]
+			break
+		}
+	}
+	if peer == nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown node `%s`", node), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(getNodeStatus(s, *peer))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// BitrotStatsHandler - GET /minio/admin/v1/bitrot
+// ----------
+// Returns the number of reads of each disk which detected bitrot since
+// the counters of each server were last reset, a rising count flags a
+// disk to replace before it fails.
+func (a adminAPIHandlers) BitrotStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BitrotStats")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminBitrotStatsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	servers := make([]madmin.ServerBitrotStats, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			stats, err := peer.cmdRunner.BitrotStats()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				stats.Error = err.Error()
+			}
+			stats.Addr = peer.addr
+			servers[idx] = stats
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(servers)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ResetBitrotStatsHandler - POST /minio/admin/v1/bitrot
+// ----------
+// Resets the bitrot counters of all servers, for instance once a disk
+// was replaced.
+func (a adminAPIHandlers) ResetBitrotStatsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, adminResetBitrotStatsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	errs := make([]error, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.ResetBitrotStats()
+		}(i, p)
+	}
+	wg.Wait()
+
+	var details []string
+	for i, err := range errs {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ConsistencyTestHandler - POST /minio/admin/v1/consistency/test?objects={n}
+// ----------
+// Writes the given number of temporary objects (10 by default, at
+// most 100) one after the other, and right after each write reads it
+// back from every server. The servers which did not find an object or
+// returned other data than written are reported, showing empirically
+// whether the current setup provides read-after-write consistency.
+// The objects are deleted afterwards.
+func (a adminAPIHandlers) ConsistencyTestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConsistencyTest")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminConsistencyTestAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	objects := defaultConsistencyTestObjects
+	if v := r.URL.Query().Get(string(mgmtObjects)); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxConsistencyTestObjects {
+			writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+			return
+		}
+		objects = n
+	}
+
+	result, err := runConsistencyTest(ctx, objectAPI, globalAdminPeers, objects)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ConnectionsHandler - GET /minio/admin/v1/connections
+// ----------
+// Returns the open connections of each client IP of each server, the
+// clients with the most connections first, along with the maximum
+// number of connections per client IP and the number of connections
+// rejected for reaching it.
+func (a adminAPIHandlers) ConnectionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Connections")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminConnectionsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	servers := make([]madmin.ServerConnections, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			connections, err := peer.cmdRunner.Connections()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				connections.Error = err.Error()
+			}
+			connections.Addr = peer.addr
+			servers[idx] = connections
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(servers)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// AdmissionStatusHandler - GET /minio/admin/v1/admission
+// ----------
+// Returns the state of the admission control of each server: the
+// maximum number of requests served at once, the requests being
+// served and waiting for a slot, and the number of requests rejected
+// for exceeding the limit.
+func (a adminAPIHandlers) AdmissionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AdmissionStatus")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminAdmissionStatusAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	servers := make([]madmin.ServerAdmissionStatus, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx].Addr = peer.addr
+			status, err := peer.cmdRunner.AdmissionStatus()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+			servers[idx].AdmissionStatus = status
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(servers)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMaxRequestsHandler - POST /minio/admin/v1/admission?maxRequests={n}
+// ----------
+// Sets the maximum number of requests served at once by each server,
+// 0 disables the limit. Requests over the limit are rejected with a
+// 503 status once the queue of waiting requests is full. The limit is
+// not saved, servers restart with the limit of MINIO_MAX_REQUESTS.
+func (a adminAPIHandlers) SetMaxRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetMaxRequestsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	limit, err := parseMaxRequests(r.URL.Query().Get(string(mgmtMaxRequests)))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	var details []string
+	for i, err := range setPeersMaxRequests(globalAdminPeers, limit) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// BucketsUsageHandler - GET /minio/admin/v1/buckets?sortBy={name|size}&offset={n}&limit={n}
+// ----------
+// Lists buckets along with their creation time, object count and
+// total size. Sorting by size requires walking every bucket, while
+// sorting by name only walks the buckets in the requested page.
+func (a adminAPIHandlers) BucketsUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BucketsUsage")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminBucketsUsageAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	sortBy, offset, limit, apiErr := extractBucketsUsageParams(r)
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
+		return
+	}
+
+	buckets, err := objectAPI.ListBuckets(ctx)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	usage := madmin.BucketsUsage{Total: len(buckets)}
+
+	// When sorting by name only the requested page needs to be
+	// walked, otherwise usage of all the buckets is needed.
+	start, end := pageBounds(len(buckets), offset, limit)
+	if sortBy == madmin.BucketsSortByName {
+		buckets = buckets[start:end]
+	}
+	for _, bucket := range buckets {
+		count, size, err := getBucketUsage(ctx, objectAPI, bucket.Name)
+		if err != nil {
+			writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+			return
+		}
+		usage.Buckets = append(usage.Buckets, madmin.BucketUsageInfo{
+			Name:         bucket.Name,
+			Created:      bucket.Created,
+			ObjectsCount: count,
+			Size:         size,
+		})
+	}
+	if sortBy == madmin.BucketsSortBySize {
+		sort.SliceStable(usage.Buckets, func(i, j int) bool {
+			return usage.Buckets[i].Size > usage.Buckets[j].Size
+		})
+		usage.Buckets = usage.Buckets[start:end]
+	}
+	usage.IsTruncated = end < usage.Total
+
+	jsonBytes, err := json.Marshal(usage)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// TestDiskHandler - POST /minio/admin/v1/disk/test?node={addr}&disk={path}
+// ----------
+// Writes data to the given disk of the given node, reads it back and
+// deletes it, and reports whether each step succeeded along with its
+// latency.
+func (a adminAPIHandlers) TestDiskHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TestDisk")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminTestDiskAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only accessible individually on erasure coded
+	// setups.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	node := vars.Get(string(mgmtNode))
+	disk := vars.Get(string(mgmtDisk))
+
+	var peer *adminPeer
+	for i := range globalAdminPeers {
+		if globalAdminPeers[i].addr == node {
+			peer = &globalAdminPeers[i]
+			break
+		}
+	}
+	if peer == nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown node `%s`", node), r.URL)
+		return
+	}
+	if !isDiskOfPeer(*peer, disk) {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown disk `%s` of node `%s`", disk, node), r.URL)
+		return
+	}
+
+	result, err := peer.cmdRunner.TestDisk(disk)
+	if err != nil {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+		logger.LogIf(logger.SetReqInfo(context.Background(), reqInfo), err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	result.Node = node
+	result.Disk = disk
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DrainDiskHandler - POST /minio/admin/v1/disk/drain?node={addr}&disk={path}[&clientToken={token}]
+// ----------
+// Prepares the removal of the given disk of the given node. Objects
+// cannot move to another erasure set, so the erasure set of the disk
+// is healed until all its objects are fully redundant on the other
+// disks of the set. Without a client token a drain is started and its
+// client token returned, with one the progress of that drain is
+// returned along with whether the disk is safe to remove.
+func (a adminAPIHandlers) DrainDiskHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DrainDisk")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminDiskDrainAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only drained by healing their erasure set.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	node := vars.Get(string(mgmtNode))
+	disk := vars.Get(string(mgmtDisk))
+	clientToken := vars.Get(string(mgmtClientToken))
+
+	var peer *adminPeer
+	for i := range globalAdminPeers {
+		if globalAdminPeers[i].addr == node {
+			peer = &globalAdminPeers[i]
+			break
+		}
+	}
+	if peer == nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown node `%s`", node), r.URL)
+		return
+	}
+	endpoint, ok := getPeerDiskEndpoint(*peer, disk)
+	if !ok {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown disk `%s` of node `%s`", disk, node), r.URL)
+		return
+	}
+
+	var drain *diskDrain
+	if clientToken == "" {
+		var errCode APIErrorCode
+		var errMsg string
+		drain, errCode, errMsg = globalDiskDrainState.start(ctx, sets, node, disk, endpoint, handlers.GetSourceIP(r))
+		switch {
+		case errCode == ErrNone:
+		case errMsg == "":
+			writeErrorResponseJSON(w, errCode, r.URL)
+			return
+		default:
+			writeCustomErrorResponseJSON(w, errCode, errMsg, r.URL)
+			return
+		}
+	} else {
+		var errCode APIErrorCode
+		if drain, errCode = globalDiskDrainState.get(clientToken, node, disk); errCode != ErrNone {
+			writeErrorResponseJSON(w, errCode, r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(drain.status(sets.StorageInfo(ctx).Backend.Sets))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SimulateNodeOfflineHandler - POST /minio/admin/v1/chaos?node={addr}&duration={duration}
+// ----------
+// Makes the given node simulate being offline for the given duration,
+// at most an hour, after which it recovers by itself. A duration of 0s
+// makes it recover right away. While offline, the node refuses the
+// storage, lock and peer RPCs of the other nodes and reports its disks
+// offline in StorageInfo, while still serving admin requests. Only
+// available when chaos testing is enabled with MINIO_CHAOS_TESTING, so
+// that failures are never simulated in production.
+func (a adminAPIHandlers) SimulateNodeOfflineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SimulateNodeOffline")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminChaosAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if !globalChaosTestingEnabled {
+		writeErrorResponseJSON(w, ErrAdminChaosTestingDisabled, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	node := vars.Get(string(mgmtNode))
+	duration, err := parseSimulatedOfflineDuration(vars.Get(string(mgmtDuration)))
+	if err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	var peer *adminPeer
+	for i := range globalAdminPeers {
+		if globalAdminPeers[i].addr == node {
+			peer = &globalAdminPeers[i]
+			break
+		}
+	}
+	if peer == nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument,
+			fmt.Sprintf("Unknown node `%s`", node), r.URL)
+		return
+	}
+
+	until, err := peer.cmdRunner.SimulateOffline(duration)
+	if err != nil {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+		logger.LogIf(logger.SetReqInfo(context.Background(), reqInfo), err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(madmin.SimulatedNodeFailure{
+		Node:         node,
+		OfflineUntil: until,
+	})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// extractSpeedtestParams - returns the object size, concurrency and
+// duration of a speedtest, the defaults if they are not given.
+func extractSpeedtestParams(qParms url.Values) (size int64, concurrency int, duration time.Duration, apiErr APIErrorCode) {
+	size, concurrency, duration = defaultSpeedtestSize, defaultSpeedtestConcurrency, defaultSpeedtestDuration
+	if v := qParms.Get(string(mgmtSize)); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 || n > maxSpeedtestSize {
+			return 0, 0, 0, ErrAdminInvalidArgument
+		}
+		size = n
+	}
+	if v := qParms.Get(string(mgmtConcurrency)); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxSpeedtestConcurrency {
+			return 0, 0, 0, ErrAdminInvalidArgument
+		}
+		concurrency = n
+	}
+	if v := qParms.Get(string(mgmtDuration)); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Second || d > maxSpeedtestDuration {
+			return 0, 0, 0, ErrAdminInvalidArgument
+		}
+		duration = d
+	}
+	return size, concurrency, duration, ErrNone
+}
+
+// SpeedtestHandler - POST /minio/admin/v1/speedtest?size={bytes}&concurrency={n}&duration={duration}&clientToken={token}&forceStart
+// ----------
+// Starts a benchmark run by all servers at the same time. Each server
+// writes temporary objects of the given size (4MiB by default) with
+// the given number of concurrent workers (8 by default) during the
+// given duration (10s by default, at most 20s), then reads them back
+// during the same duration and deletes them. The write and read
+// throughput and latency of each server are reported along with the
+// aggregate of all servers.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the speedtest. Only one speedtest may run at a time,
+// unless the force-start flag is provided.
+func (a adminAPIHandlers) SpeedtestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Speedtest")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSpeedtestAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.SpeedtestStatus
+	if clientToken != "" {
+		status, adminAPIErr = globalSpeedtestState.Status(clientToken)
+	} else {
+		size, concurrency, duration, apiErr := extractSpeedtestParams(vars)
+		if apiErr != ErrNone {
+			writeErrorResponseJSON(w, apiErr, r.URL)
+			return
+		}
+
+		seq := newSpeedtestSequence(size, concurrency, duration, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalSpeedtestState.Launch(seq, globalAdminPeers, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// NetPerfHandler - GET /minio/admin/v1/netperf?size={bytes}
+// ----------
+// Has each server measure the round trip latency of its links to all
+// other servers over the admin RPC, then their bandwidth by sending
+// size bytes over each link. Returns the resulting matrix with the
+// links anomalously slower than the median flagged as slow.
+func (a adminAPIHandlers) NetPerfHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NetPerf")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminNetPerfAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	size := int64(defaultNetPerfSize)
+	if v := r.URL.Query().Get(string(mgmtSize)); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 || n > maxNetPerfSize {
+			writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+			return
+		}
+		size = n
+	}
+
+	jsonBytes, err := json.Marshal(getNetPerf(globalAdminPeers, size))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// VerifyObjectHandler - GET /minio/admin/v1/object/verify?bucket={bucket}&object={object}
+// ----------
+// Reads the metadata and the data of an object from every disk of its
+// erasure set and reports whether they agree, along with the disks
+// which diverge and the checksums found on each disk. Unlike heal,
+// nothing is repaired.
+func (a adminAPIHandlers) VerifyObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VerifyObject")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminVerifyObjectAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only accessible individually on erasure coded
+	// setups.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+	if object == "" {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	result, err := sets.verifyObject(ctx, bucket, object)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// VerifyBucketsHandler - POST /minio/admin/v1/bucket/verify[?dryRun]
+// ----------
+// Checks that every bucket exists on all disks along with its config
+// files, recreates the missing ones from quorum and removes the config
+// files of buckets which no longer exist, reporting the findings on
+// each bucket. Nothing is repaired on a dry run. Unlike heal, objects
+// are not inspected.
+func (a adminAPIHandlers) VerifyBucketsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VerifyBuckets")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminBucketVerifyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only accessible individually on erasure coded
+	// setups.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	_, dryRun := r.URL.Query()[string(mgmtDryRun)]
+	report := sets.verifyBuckets(ctx, dryRun)
+
+	jsonBytes, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// HealObjectDetailHandler - GET /minio/admin/v1/heal/detail?bucket={bucket}&object={object}
+// ----------
+// Inspects an object on every disk of its erasure set as
+// VerifyObjectHandler does, and reports the erasure layout of the
+// object, the disks heal would rebuild it from and whether it can be
+// repaired, with the reason it cannot. Nothing is repaired.
+func (a adminAPIHandlers) HealObjectDetailHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "HealObjectDetail")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminHealDetailAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Disks are only accessible individually on erasure coded
+	// setups.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	object := vars.Get(string(mgmtObject))
+	if object == "" {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	detail, err := sets.healObjectDetail(ctx, bucket, object)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(detail)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// StorageClassInfoHandler - GET /minio/admin/v1/storageclass
+// ----------
+// Returns the data and parity shard counts in effect for each storage
+// class, and whether they were set through the environment, the config
+// or are defaults.
+func (a adminAPIHandlers) StorageClassInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StorageClassInfo")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminStorageClassInfoAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Storage classes only apply to erasure coded setups.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	info := madmin.StorageClassInfo{
+		SetDriveCount: globalXLSetDriveCount,
+		Classes: []madmin.StorageClassParity{
+			getStorageClassParity(standardStorageClass, globalXLSetDriveCount),
+			getStorageClassParity(reducedRedundancyStorageClass, globalXLSetDriveCount),
+		},
+	}
+
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ParityInfoHandler - GET /minio/admin/v1/parity
+// ----------
+// Returns the parity of the standard storage class applied to new
+// writes, along with the minimum and maximum parity the erasure sets
+// allow.
+func (a adminAPIHandlers) ParityInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ParityInfo")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminParityInfoAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Parity only applies to erasure coded setups.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(getParityInfo())
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// updateConfigSection - updates a section of the saved config.json with
+// the given function, makes all servers load it and writes the
+// response. Servers failing to load the section are reported while
+// the section stays saved.
+func updateConfigSection(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer,
+	section string, update func(config *serverConfig)) {
+	if readOnlyConfigSections.Contains(section) {
+		writeErrorResponseJSON(w, ErrAdminConfigSectionReadOnly, r.URL)
+		return
+	}
+
+	unlock, err := lockConfigSections(section)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	defer unlock()
+
+	if err = saveConfigSection(ctx, objectAPI, update); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	var details []string
+	for i, err := range loadPeersConfigSection(globalAdminPeers, section) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// SetParityHandler - POST /minio/admin/v1/parity?parity={n}
+// ----------
+// Sets the parity of the standard storage class applied to new writes
+// on all servers, a parity of 0 restores the default. Existing objects
+// keep their parity until they are rewritten or healed. The parity
+// cannot be changed when set through the environment.
+func (a adminAPIHandlers) SetParityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetParity")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetParityAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Parity only applies to erasure coded setups.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	parity, err := strconv.Atoi(r.URL.Query().Get(string(mgmtParity)))
+	if err != nil || parity < 0 {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+	if globalIsStorageClass {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, errParityFromEnv.Error(), r.URL)
+		return
+	}
+	if err = validateParity(parity, globalRRStorageClass.Parity); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionStorageClass, func(config *serverConfig) {
+		setStandardParity(config, parity)
+	})
+}
+
+// SetBucketStorageClassHandler - POST /minio/admin/v1/bucket/storageclass?bucket={bucket}&storageClass={storageClass}
+// ----------
+// Sets the default storage class of the objects written to a bucket
+// without a storage class, on all servers. An empty storage class
+// removes the default.
+func (a adminAPIHandlers) SetBucketStorageClassHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetBucketStorageClass")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetBucketStorageClassAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Storage classes only apply to erasure coded setups.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	sc := vars.Get(string(mgmtStorageClass))
+	if !IsValidBucketName(bucket) {
+		writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+		return
+	}
+	if sc != "" && !isValidStorageClassMeta(sc) {
+		writeErrorResponseJSON(w, ErrInvalidStorageClass, r.URL)
+		return
+	}
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionBucketStorageClasses, func(config *serverConfig) {
+		setBucketStorageClass(config, bucket, sc)
+	})
+}
+
+// BucketStorageClassesHandler - GET /minio/admin/v1/bucket/storageclass
+// ----------
+// Returns the default storage class of each bucket which has one.
+func (a adminAPIHandlers) BucketStorageClassesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BucketStorageClasses")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetBucketStorageClassAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalBucketStorageClasses.list())
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// NotifyReplayHandler - POST /minio/admin/v1/notify/replay
+// ----------
+// Redrives events queued while their notification targets were
+// unreachable, and reports how many were replayed and how many are
+// still queued because they failed again.
+func (a adminAPIHandlers) NotifyReplayHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NotifyReplay")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminNotifyReplayAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	replayed, failed, err := globalNotificationSys.ReplayEvents(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(madmin.NotifyReplayResult{
+		Replayed: replayed,
+		Failed:   failed,
+	})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// NotifyQueuesHandler - GET /minio/admin/v1/notify/queues
+// ----------
+// Returns, for each server, the number of events being sent to each
+// notification target, the age of the oldest of them and the rate of
+// failed deliveries, showing whether events flow or back up.
+func (a adminAPIHandlers) NotifyQueuesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NotifyQueues")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminNotifyQueuesAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	reply := make([]madmin.ServerNotifyQueues, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx].Addr = peer.addr
+			queues, err := peer.cmdRunner.NotifyQueues()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Queues = queues
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// GetLogLevelHandler - GET /minio/admin/v1/loglevel
+// ----------
+// Returns the default log level of this server and the levels of the
+// subsystems which override it.
+func (a adminAPIHandlers) GetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetLogLevel")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetLogLevelAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	levels := madmin.LogLevels{Subsystems: make(map[string]string)}
+	for subsystem, level := range logger.GetLevels() {
+		if subsystem == "" {
+			levels.Default = level.String()
+		} else {
+			levels.Subsystems[subsystem] = level.String()
+		}
+	}
+
+	jsonBytes, err := json.Marshal(levels)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetLogLevelHandler - POST /minio/admin/v1/loglevel?subsystem={subsystem}&level={level}
+// ----------
+// Sets the log level of a subsystem, or the default level if no
+// subsystem is given, on all servers. An empty level removes the
+// level of the subsystem, or restores the ERROR default level. The
+// change takes effect immediately and lasts until the server
+// restarts.
+func (a adminAPIHandlers) SetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetLogLevelAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	subsystem := vars.Get(string(mgmtSubsystem))
+	var level logger.Level
+	var err error
+	if levelStr := vars.Get(string(mgmtLevel)); levelStr != "" {
+		level, err = logger.ParseLevel(levelStr)
+	}
+	if err != nil || !isValidLogSubsystem(subsystem) {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	var details []string
+	for i, err := range setPeersLogLevel(globalAdminPeers, subsystem, level) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ScanDuplicatesHandler - POST /minio/admin/v1/scan/duplicates?bucket={bucket}&prefix={prefix}&clientToken={token}&forceStart
+// ----------
+// Starts a read-only scan of the given (possibly empty) bucket and
+// prefix which reports groups of objects with identical content.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the scan, and the duplicate groups once it finished.
+// Only one scan may run at a time, unless the force-start flag is
+// provided in which case the running scan is stopped.
+func (a adminAPIHandlers) ScanDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScanDuplicates")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminScanDuplicatesAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	prefix := vars.Get(string(mgmtPrefix))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.DuplicateScanStatus
+	if clientToken != "" {
+		status, adminAPIErr = globalDupScanState.Status(clientToken)
+	} else {
+		if bucket == "" && prefix != "" {
+			writeErrorResponseJSON(w, ErrHealMissingBucket, r.URL)
+			return
+		}
+		if bucket != "" && !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+		if !IsValidObjectPrefix(prefix) {
+			writeErrorResponseJSON(w, ErrInvalidObjectName, r.URL)
+			return
+		}
+
+		seq := newDupScanSequence(bucket, prefix, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalDupScanState.Launch(seq, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ScanOrphansHandler - POST /minio/admin/v1/scan/orphans?bucket={bucket}&clientToken={token}&reclaim&forceStart
+// ----------
+// Starts a scan of the disks of the given (possibly empty) bucket
+// which reports data shards not referenced by the metadata of any
+// object, such as shards left behind by a crash. With the reclaim
+// flag the orphaned shards are deleted to recover their space.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the scan. Only one scan may run at a time, unless the
+// force-start flag is provided in which case the running scan is
+// stopped.
+func (a adminAPIHandlers) ScanOrphansHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScanOrphans")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminScanOrphansAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Shards are only stored on erasure coded setups.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, reclaim := vars[string(mgmtReclaim)]
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.OrphanScanStatus
+	if clientToken != "" {
+		status, adminAPIErr = globalOrphanScanState.Status(clientToken)
+	} else {
+		if bucket != "" && !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+
+		seq := newOrphanScanSequence(bucket, reclaim, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalOrphanScanState.Launch(seq, sets, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// FsckHandler - POST /minio/admin/v1/fsck?bucket={bucket}&clientToken={token}&forceStart
+// ----------
+// Starts a read-only consistency check of the disks of the given
+// (possibly empty) bucket, more thorough than heal: the format of
+// each disk, the directory structure of buckets, the metadata of each
+// object and the full content of its shards are verified. Findings
+// are reported by severity, nothing is repaired.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the check. Only one check may run at a time, unless the
+// force-start flag is provided in which case the running check is
+// stopped.
+func (a adminAPIHandlers) FsckHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Fsck")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminFsckAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Only erasure coded setups have a backend structure to check.
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.FsckStatus
+	if clientToken != "" {
+		status, adminAPIErr = globalFsckState.Status(clientToken)
+	} else {
+		if bucket != "" && !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+
+		seq := newFsckSequence(bucket, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalFsckState.Launch(seq, sets, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ChecksumHandler - POST /minio/admin/v1/checksum?bucket={bucket}&prefix={prefix}&algorithm={algorithm}&clientToken={token}&offset={offset}&limit={limit}&forceStart
+// ----------
+// Starts computing the checksum of each object of the given bucket
+// and (possibly empty) prefix, to validate a migration against the
+// checksums computed at the source. The algorithm is one of md5
+// (default), sha256 or crc32c.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the scan along with the checksums computed from the
+// given offset. Checksums before the offset are dropped as received,
+// and the scan waits while too many checksums were not received.
+// Only one scan may run at a time, unless the force-start flag is
+// provided in which case the running scan is stopped.
+func (a adminAPIHandlers) ChecksumHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Checksum")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminChecksumAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	prefix := vars.Get(string(mgmtPrefix))
+	algorithm := vars.Get(string(mgmtAlgorithm))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.ChecksumScanStatus
+	if clientToken != "" {
+		var offset int64
+		if v := vars.Get(string(mgmtOffset)); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+				return
+			}
+			offset = n
+		}
+		limit := maxChecksumsPerReply
+		if v := vars.Get(string(mgmtLimit)); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > maxChecksumsPerReply {
+				writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+				return
+			}
+			limit = n
+		}
+		status, adminAPIErr = globalChecksumScanState.Status(clientToken, offset, limit)
+	} else {
+		if bucket == "" {
+			writeErrorResponseJSON(w, ErrHealMissingBucket, r.URL)
+			return
+		}
+		if !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+		if !IsValidObjectPrefix(prefix) {
+			writeErrorResponseJSON(w, ErrInvalidObjectName, r.URL)
+			return
+		}
+		if _, err := newChecksumHash(algorithm); err != nil {
+			writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+			return
+		}
+
+		seq := newChecksumScanSequence(bucket, prefix, algorithm, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalChecksumScanState.Launch(seq, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SnapshotHandler - POST /minio/admin/v1/snapshot?bucket={bucket}&clientToken={token}&offset={offset}&limit={limit}&forceStart
+// ----------
+// Starts a point-in-time manifest of the objects of the given bucket,
+// listing the name, size, ETag and modification time of each object
+// as they were when the snapshot started. Objects modified after the
+// snapshot time are left out of the manifest and counted as changed,
+// as their content at the snapshot time is no longer available.
+//
+// The response is newline delimited JSON. On a successful start, it
+// holds the status with a unique client token. Subsequent requests
+// providing the client token receive the progress of the snapshot
+// followed by the manifest entries from the given offset, one per
+// line. Entries before the offset are dropped as received, and the
+// snapshot waits while too many entries were not received. Only one
+// snapshot may run at a time, unless the force-start flag is
+// provided in which case the running snapshot is stopped.
+func (a adminAPIHandlers) SnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Snapshot")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSnapshotAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.SnapshotStatus
+	var entries []madmin.SnapshotEntry
+	if clientToken != "" {
+		var offset int64
+		if v := vars.Get(string(mgmtOffset)); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+				return
+			}
+			offset = n
+		}
+		limit := maxSnapshotEntriesPerReply
+		if v := vars.Get(string(mgmtLimit)); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > maxSnapshotEntriesPerReply {
+				writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+				return
+			}
+			limit = n
+		}
+		status, entries, adminAPIErr = globalSnapshotState.Status(clientToken, offset, limit)
+	} else {
+		if bucket == "" {
+			writeErrorResponseJSON(w, ErrHealMissingBucket, r.URL)
+			return
+		}
+		if !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+		if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+			writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+
+		seq := newSnapshotSequence(bucket, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalSnapshotState.Launch(seq, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	data, err := encodeSnapshotNDJSON(status, entries)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, data, mimeNDJSON)
+}
+
+// CountObjectsHandler - GET /minio/admin/v1/count?bucket={bucket}&clientToken={token}&forceStart
+// ----------
+// Starts counting the objects of the cluster, or of the given bucket,
+// by walking the namespace. Unlike the usage reported by ServerInfo,
+// which is cached per server, the count is computed on demand and is
+// the same whichever server serves the request.
+//
+// On a successful start, a unique client token is returned.
+// Subsequent requests providing the client token receive the
+// progress of the count, which is final once it finished. Only one
+// count may run at a time, unless the force-start flag is provided
+// in which case the running count is stopped.
+func (a adminAPIHandlers) CountObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CountObjects")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminCountObjectsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	bucket := vars.Get(string(mgmtBucket))
+	clientToken := vars.Get(string(mgmtClientToken))
+	_, forceStart := vars[string(mgmtForceStart)]
+
+	var status madmin.ObjectCountStatus
+	if clientToken != "" {
+		status, adminAPIErr = globalObjectCountState.Status(clientToken)
+	} else {
+		if bucket != "" && !IsValidBucketName(bucket) {
+			writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+			return
+		}
+
+		seq := newObjectCountSequence(bucket, handlers.GetSourceIP(r))
+		status = seq.status
+		adminAPIErr = globalObjectCountState.Launch(seq, forceStart)
+	}
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetRateLimitHandler - POST /minio/admin/v1/ratelimit?bucket={bucket}&prefix={prefix}&requestsPerSecond={rate}
+// ----------
+// Sets the maximum number of requests per second on a bucket, or on a
+// prefix of a bucket. Requests over the limit are rejected with a 429
+// status by each server. The limit applies to each server on its own,
+// requests are not counted across servers. The most specific prefix
+// applies to an object and a zero rate removes the limit. Limits are
+// saved and loaded by all servers.
+func (a adminAPIHandlers) SetRateLimitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetRateLimit")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetRateLimitAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	limit := madmin.RateLimit{
+		Bucket: vars.Get(string(mgmtBucket)),
+		Prefix: vars.Get(string(mgmtPrefix)),
+	}
+	var err error
+	limit.RequestsPerSecond, err = strconv.ParseFloat(vars.Get(string(mgmtRequestsPerSecond)), 64)
+	if err != nil || validateTenantRateLimit(limit) != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	if err = setTenantRateLimit(ctx, objectAPI, limit); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	var details []string
+	for i, err := range loadPeersRateLimits(globalAdminPeers) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RateLimitsHandler - GET /minio/admin/v1/ratelimit
+// ----------
+// Returns the rate limits of tenants along with the request rate
+// observed by each server over the last few seconds.
+func (a adminAPIHandlers) RateLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RateLimits")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetRateLimitsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	reply := make([]madmin.ServerRateLimits, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx].Addr = peer.addr
+			limits, err := peer.cmdRunner.RateLimits()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+			reply[idx].Limits = limits
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetScannerScheduleHandler - POST /minio/admin/v1/scanner
+// ----------
+// Sets the schedule of the background scans computing the disk usage
+// reported by StorageInfo: the interval between two scans, a pause
+// after each entry scanned and the hours of the day, in UTC, during
+// which scans run. The schedule is saved and loaded by all servers,
+// waiting and running scans follow it right away.
+func (a adminAPIHandlers) SetScannerScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetScannerAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var schedule madmin.ScannerSchedule
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxConfigJSONSize)).Decode(&schedule); err != nil {
+		writeErrorResponseJSON(w, ErrMalformedJSON, r.URL)
+		return
+	}
+	if err := validateScannerSchedule(schedule); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	if err := saveScannerSchedule(objectAPI, schedule); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	var details []string
+	for i, err := range loadPeersScannerSchedule(globalAdminPeers) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ScannerStatusHandler - GET /minio/admin/v1/scanner
+// ----------
+// Returns the schedule of the background usage scans along with the
+// progress and last completion time of the scans of each server.
+func (a adminAPIHandlers) ScannerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScannerStatus")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetScannerAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	status := madmin.ScannerStatus{
+		Servers: make([]madmin.ServerScannerProgress, len(globalAdminPeers)),
+	}
+	status.Schedule, _ = globalUsageScanner.getSchedule()
+
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			status.Servers[idx].Addr = peer.addr
+			scans, err := peer.cmdRunner.ScannerProgress()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				status.Servers[idx].Error = err.Error()
+				return
+			}
+			status.Servers[idx].Scans = scans
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RefreshUsageHandler - POST /minio/admin/v1/usage/refresh
+// ----------
+// Makes the background usage scans of all servers run again right
+// away, regardless of their schedule, so that the disk usage reflects
+// recent deletes. Replies with the disk usage once the scans completed
+// or a timeout elapsed, along with whether each server refreshed its
+// usage.
+func (a adminAPIHandlers) RefreshUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RefreshUsage")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminRefreshUsageAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	refresh := madmin.UsageRefresh{
+		Servers: refreshPeersUsage(globalAdminPeers, usageRefreshTimeout, usageRefreshInterval),
+	}
+	refresh.Used = objectAPI.StorageInfo(ctx).Used
+
+	jsonBytes, err := json.Marshal(refresh)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// FederationStatusHandler - GET /minio/admin/v1/federation
+// ----------
+// Returns the state of the federation seen by this server: whether
+// the federation store is reachable, which cluster serves each bucket
+// and the buckets of this cluster missing from the store or recorded
+// but missing from this cluster, which other clusters report as not
+// found.
+func (a adminAPIHandlers) FederationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "FederationStatus")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminFederationAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	status := getFederationStatus(ctx, objectAPI, globalDNSConfig, globalDomainIPs)
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetResponseHeadersHandler - POST /minio/admin/v1/headers
+// ----------
+// Sets the custom headers added to all responses, such as
+// Strict-Transport-Security, replacing the previous ones. Headers set
+// by the server or carrying the S3 protocol cannot be overridden. The
+// headers are saved in config.json and loaded by all servers, an
+// empty set removes them.
+func (a adminAPIHandlers) SetResponseHeadersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetResponseHeaders")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetResponseHeadersAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var headers map[string]string
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxConfigJSONSize)).Decode(&headers); err != nil {
+		writeErrorResponseJSON(w, ErrMalformedJSON, r.URL)
+		return
+	}
+	if err := validateResponseHeaders(headers); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionHeaders, func(config *serverConfig) {
+		config.Headers = nil
+		if len(headers) > 0 {
+			config.Headers = headers
+		}
+	})
+}
+
+// ResponseHeadersHandler - GET /minio/admin/v1/headers
+// ----------
+// Returns the custom headers added to all responses.
+func (a adminAPIHandlers) ResponseHeadersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ResponseHeaders")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetResponseHeadersAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalResponseHeaders.get())
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// MultipartPolicyHandler - GET /minio/admin/v1/multipart/policy
+// ----------
+// Returns the minimum size of the parts of multipart uploads apart
+// from the last one, along with the number of parts uploaded to each
+// server per part size range.
+func (a adminAPIHandlers) MultipartPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "MultipartPolicy")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminMultipartPolicyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	policy := madmin.MultipartPolicy{
+		MinPartSize:        globalMultipartPolicy.getMinPartSize(),
+		DefaultMinPartSize: globalMinPartSize,
+		Servers:            make([]madmin.ServerPartSizeStats, len(globalAdminPeers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			stats, err := peer.cmdRunner.PartSizeStats()
+			policy.Servers[idx] = madmin.ServerPartSizeStats{Addr: peer.addr, PartSizeStats: stats}
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				policy.Servers[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(policy)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetMinPartSizeHandler - POST /minio/admin/v1/multipart/policy?minPartSize={bytes}
+// ----------
+// Sets the minimum size of the parts of multipart uploads apart from
+// the last one on all servers, a size of 0 restores the default of
+// 5MiB. Uploads with a smaller part fail to complete with the
+// EntityTooSmall error.
+func (a adminAPIHandlers) SetMinPartSizeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetMinPartSize")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetMinPartSizeAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	size, err := strconv.ParseInt(r.URL.Query().Get(string(mgmtMinPartSize)), 10, 64)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+	if err = validateMinPartSize(size); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionMinPartSize, func(config *serverConfig) {
+		config.MinPartSize = size
+	})
+}
+
+// CompressionPolicyHandler - GET /minio/admin/v1/compression
+// ----------
+// Returns the compression of GET responses in effect, along with the
+// number of responses each server compressed and their sizes before
+// and after compression.
+func (a adminAPIHandlers) CompressionPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CompressionPolicy")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminCompressionPolicyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	policy := madmin.CompressionPolicy{
+		CompressionConfig: madmin.CompressionConfig(globalResponseCompression.get()),
+		Servers:           make([]madmin.ServerCompressionStats, len(globalAdminPeers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			stats, err := peer.cmdRunner.CompressionStats()
+			policy.Servers[idx] = madmin.ServerCompressionStats{Addr: peer.addr, CompressionStats: stats}
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				policy.Servers[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(policy)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetCompressionHandler - POST /minio/admin/v1/compression
+// ----------
+// Sets the compression of GET responses of all servers from the JSON
+// encoded config in the request body. Objects of the configured
+// content types and sizes are gzip compressed for the clients
+// accepting it.
+func (a adminAPIHandlers) SetCompressionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetCompression")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetCompressionAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var config compressionConfig
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxConfigJSONSize)).Decode(&config); err != nil {
+		writeErrorResponseJSON(w, ErrMalformedJSON, r.URL)
+		return
+	}
+	if err := validateCompressionConfig(&config); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionCompression, func(srvCfg *serverConfig) {
+		srvCfg.Compression = &config
+	})
+}
+
+// ClockSkewHandler - GET /minio/admin/v1/clockskew
+// ----------
+// Returns the maximum difference allowed between the time a request
+// was signed at and the time of the server, the bounds it may be set
+// within and the current time of the server, to diagnose requests
+// failing with RequestTimeTooSkewed.
+func (a adminAPIHandlers) ClockSkewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClockSkew")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminClockSkewAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	tolerance := madmin.ClockSkewTolerance{
+		MaxSkew:     globalClockSkewTolerance.get(),
+		DefaultSkew: globalMaxSkewTime,
+		MinAllowed:  minMaxClockSkew,
+		MaxAllowed:  maxMaxClockSkew,
+		ServerTime:  UTCNow(),
+	}
+
+	jsonBytes, err := json.Marshal(tolerance)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetClockSkewHandler - POST /minio/admin/v1/clockskew?maxSkew={duration}
+// ----------
+// Sets the maximum difference allowed between the time a request was
+// signed at and the time of all servers, such as "5m", a duration of
+// 0 restores the default of 15 minutes.
+func (a adminAPIHandlers) SetClockSkewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetClockSkew")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetClockSkewAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	maxSkew, err := parseMaxClockSkew(r.URL.Query().Get(string(mgmtMaxSkew)))
+	if err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionMaxClockSkew, func(config *serverConfig) {
+		config.MaxClockSkew = ""
+		if maxSkew != 0 {
+			config.MaxClockSkew = maxSkew.String()
+		}
+	})
+}
+
+// ReloadTLSCertsHandler - POST /minio/admin/v1/tls/reload
+// ----------
+// Makes all servers reload their TLS certificate and private key
+// files without restarting, returns the certificate chain each server
+// serves from now on. Servers which fail to load the files keep
+// serving their current certificate and report the error.
+func (a adminAPIHandlers) ReloadTLSCertsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReloadTLSCerts")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminReloadTLSCertsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if !globalIsSSL {
+		writeErrorResponseJSON(w, ErrAdminTLSNotConfigured, r.URL)
+		return
+	}
+
+	reloads := make([]madmin.ServerTLSReload, len(globalAdminPeers))
+	var wg sync.WaitGroup
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reloads[idx].Addr = peer.addr
+			chain, err := peer.cmdRunner.ReloadTLSCerts()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reloads[idx].Error = err.Error()
+				return
+			}
+			reloads[idx].Certificates = chain
+		}(i, p)
+	}
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(reloads)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// pageBounds - returns slice bounds of a page of at most limit
+// entries starting at offset, in a list of n entries.
+func pageBounds(n, offset, limit int) (start, end int) {
+	if offset > n {
+		offset = n
+	}
+	end = offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
+
+// extractHealInitParams - Validates params for heal init API.
+func extractHealInitParams(r *http.Request) (bucket, objPrefix string,
+	hs madmin.HealOpts, clientToken string, forceStart bool,
+	err APIErrorCode) {
+
+	vars := mux.Vars(r)
+	bucket = vars[string(mgmtBucket)]
+	objPrefix = vars[string(mgmtPrefix)]
+
+	if bucket == "" {
+		if objPrefix != "" {
+			// Bucket is required if object-prefix is given
+			err = ErrHealMissingBucket
+			return
+		}
+	} else if !IsValidBucketName(bucket) {
+		err = ErrInvalidBucketName
+		return
+	}
+
+	// empty prefix is valid.
+	if !IsValidObjectPrefix(objPrefix) {
+		err = ErrInvalidObjectName
+		return
+	}
+
+	qParms := r.URL.Query()
+	if len(qParms[string(mgmtClientToken)]) > 0 {
+		clientToken = qParms[string(mgmtClientToken)][0]
+	}
+	if _, ok := qParms[string(mgmtForceStart)]; ok {
+		forceStart = true
+	}
+
+	// ignore body if clientToken is provided
+	if clientToken == "" {
+		jerr := json.NewDecoder(r.Body).Decode(&hs)
+		if jerr != nil {
+			logger.LogIf(context.Background(), jerr)
+			err = ErrRequestBodyParse
+			return
+		}
+		if hs.MaxConcurrency < 0 || hs.MaxConcurrency > maxHealConcurrency {
+			err = ErrHealInvalidConcurrency
+			return
+		}
+		if hs.NotifyInterval < 0 {
+			err = ErrHealInvalidNotifyTarget
+			return
+		}
+		if hs.NotifyARN != "" {
+			var found bool
+			if globalNotificationSys != nil {
+				_, found = globalNotificationSys.targetIDForARN(hs.NotifyARN)
+			}
+			if !found {
+				err = ErrHealInvalidNotifyTarget
+				return
+			}
+		}
+	}
+
+	err = ErrNone
+	return
+}
+
+// HealHandler - POST /minio/admin/v1/heal/
+// -----------
+// Start heal processing and return heal status items.
+//
+// On a successful heal sequence start, a unique client token is
+// returned. Subsequent requests to this endpoint providing the client
+// token will receive heal status records from the running heal
+// sequence, gzip compressed if the client accepts it.
+//
+// If no client token is provided, and a heal sequence is in progress
+// an error is returned with information about the running heal
+// sequence. However, if the force-start flag is provided, the server
+// aborts the running heal sequence and starts a new one.
+func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Heal")
+
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, adminHealAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+		return
+	}
+
+	bucket, objPrefix, hs, clientToken, forceStart, apiErr := extractHealInitParams(r)
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
+		return
+	}
+
+	if hs.ErasureSet != nil && (*hs.ErasureSet < 0 || *hs.ErasureSet >= erasureSetCount(objLayer)) {
+		writeErrorResponseJSON(w, ErrHealInvalidErasureSet, r.URL)
+		return
+	}
+
+	type healResp struct {
+		respBytes []byte
+		errCode   APIErrorCode
+		errBody   string
+	}
+
+	// Define a closure to start sending whitespace to client
+	// after healKeepAliveInterval unless a response item comes in.
+	// The status and headers are sent right away with early
+	// headers, errors are then sent with the 200 status as well.
+	keepConnLive := func(w http.ResponseWriter, respCh chan healResp) {
+		ticker := time.NewTicker(healKeepAliveInterval)
+		defer ticker.Stop()
+		started := false
+		startResponse := func() {
+			// Start writing response to client
+			started = true
+			setCommonHeaders(w)
+			w.Header().Set("Content-Type", string(mimeJSON))
+			// Set 200 OK status
+			w.WriteHeader(200)
+			w.(http.Flusher).Flush()
+		}
+		if globalHealEarlyHeaders {
+			startResponse()
+		}
 	forLoop:
 		for {
 			select {
 			case <-ticker.C:
 				if !started {
-					// Start writing response to client
-					started = true
-					setCommonHeaders(w)
-					w.Header().Set("Content-Type", string(mimeJSON))
-					// Set 200 OK status
-					w.WriteHeader(200)
+					startResponse()
 				}
 				// Send whitespace and keep connection open
 				w.Write([]byte("\n\r"))
@@ -401,44 +4007,596 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// find number of disks in the setup
-	info := objLayer.StorageInfo(ctx)
-	numDisks := info.Backend.OfflineDisks + info.Backend.OnlineDisks
+	// find number of disks in the setup, or in the erasure set
+	// the heal is restricted to
+	info := objLayer.StorageInfo(ctx)
+	numDisks := info.Backend.OfflineDisks + info.Backend.OnlineDisks
+	if hs.ErasureSet != nil {
+		numDisks /= erasureSetCount(objLayer)
+	}
+
+	if clientToken == "" {
+		// Not a status request
+		nh := newHealSequence(bucket, objPrefix, handlers.GetSourceIP(r),
+			numDisks, hs, forceStart)
+
+		respCh := make(chan healResp)
+		go func() {
+			respBytes, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(nh)
+			hr := healResp{respBytes, errCode, errMsg}
+			respCh <- hr
+		}()
+
+		// Due to the force-starting functionality, the Launch
+		// call above can take a long time - to keep the
+		// connection alive, we start sending whitespace
+		keepConnLive(w, respCh)
+	} else {
+		// Since clientToken is given, fetch heal status from running
+		// heal sequence.
+		filter := madmin.HealResultFilter(r.URL.Query().Get(string(mgmtFilter)))
+		sortBy := madmin.HealResultSort(r.URL.Query().Get(string(mgmtSortBy)))
+		if !filter.IsValid() || !sortBy.IsValid() {
+			writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+			return
+		}
+		path := bucket + "/" + objPrefix
+		respBytes, errCode := globalAllHealState.PopHealStatusJSON(
+			path, clientToken, filter, sortBy)
+		if errCode != ErrNone {
+			writeErrorResponseJSON(w, errCode, r.URL)
+		} else {
+			// Status replies may list thousands of result items,
+			// they are compressed if the client accepts it. The
+			// whitespace keepConnLive sends during launch requests
+			// is never compressed.
+			writeSuccessResponseJSONGzip(w, r, respBytes)
+		}
+	}
+}
+
+// HealActionHandler - PUT /minio/admin/v1/heal/{clientToken}?action={pause|resume}
+// -----------
+// Pauses a running heal sequence, or resumes a paused one. A paused
+// heal sequence stops scanning at its next safe point, such as before
+// healing the next object, and keeps its accumulated status and
+// position until it is resumed or force-stopped.
+func (a adminAPIHandlers) HealActionHandler(w http.ResponseWriter, r *http.Request) {
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, adminHealAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+		return
+	}
+
+	clientToken := mux.Vars(r)["clientToken"]
+	var pause bool
+	switch r.URL.Query().Get(string(mgmtAction)) {
+	case "pause":
+		pause = true
+	case "resume":
+	default:
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	if errCode := globalAllHealState.PauseHealSequence(clientToken, pause); errCode != ErrNone {
+		writeErrorResponseJSON(w, errCode, r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// AutoHealStatusHandler - GET /minio/admin/v1/heal/auto
+// -----------
+// Returns whether a heal sequence launched automatically after drives
+// came back online or were replaced is active, and its progress.
+func (a adminAPIHandlers) AutoHealStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AutoHealStatus")
+
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, adminAutoHealStatusAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalAutoHealState.status())
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetAutoHealHandler - POST /minio/admin/v1/autoheal?enable={on|off}
+// -----------
+// Enables or disables the heal sequences launched automatically after
+// drives came back online or were replaced. A single server of the
+// cluster launches them.
+func (a adminAPIHandlers) SetAutoHealHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetAutoHeal")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetAutoHealAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+		return
+	}
+
+	enable, err := ParseBoolFlag(r.URL.Query().Get(string(mgmtEnable)))
+	if err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	updateConfigSection(ctx, w, r, objectAPI, configSectionAutoHeal, func(config *serverConfig) {
+		config.AutoHeal = enable
+	})
+}
+
+// GetConfigHandler - GET /minio/admin/v1/config?section={section}
+// Get config.json of this minio setup, converted to YAML if the
+// client accepts `application/yaml`. The config is encrypted with a
+// key wrapped by the KMS if requested with the X-Minio-Config-Encryption
+// header, and with a key derived from the secret key otherwise. The
+// credential of the server is left empty unless it signed the request,
+// and so are the other secrets of the config, such as the passwords of
+// notification targets, which admin credentials get redacted.
+//
+// With a section, such as `notify`, `logger` or `storageclass`, only
+// that top level key of config.json is returned, as a partial config
+// accepted by PatchConfigHandler.
+func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetConfigHandler")
+
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetConfigAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	config, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// The credential of the server would grant an admin credential
+	// all actions.
+	root := isRootAdminRequest(r)
+	if !root {
+		config.Credential = auth.Credentials{}
+	}
+
+	var configData []byte
+	if section := r.URL.Query().Get(string(mgmtSection)); section != "" {
+		if configData, err = extractConfigSection(config, section); err != nil {
+			writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+			return
+		}
+	} else if configData, err = json.Marshal(config); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	if !root {
+		if configData, err = redactConfigJSON(configData); err != nil {
+			logger.LogIf(ctx, err)
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	mType := mimeJSON
+	if acceptsYAML(r) {
+		mType = mimeYAML
+		if configData, err = configJSONToYAML(configData); err != nil {
+			logger.LogIf(ctx, err)
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
+			return
+		}
+	}
+
+	password := getAdminRequestSecretKey(r)
+	econfigData, err := encryptConfigData(r, password, configData)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, econfigData, mType)
+}
+
+// GetConfigEnvHandler - GET /minio/admin/v1/config/env
+// ----------
+// Lists config fields whose value is taken from the environment,
+// changes to these fields through SetConfigHandler are ignored.
+func (a adminAPIHandlers) GetConfigEnvHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetConfigEnvHandler")
+
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, adminGetConfigAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(getConfigEnvOverrides())
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ConfigConsistencyHandler - GET /minio/admin/v1/config/consistency
+// ----------
+// Compares the checksum of the config loaded by each server with the
+// checksum of the saved config, to detect servers which missed a
+// config change, e.g. as they were offline. Servers running with
+// another config are listed as divergent.
+func (a adminAPIHandlers) ConfigConsistencyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConfigConsistency")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminConfigConsistencyAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	config, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	consistency := getConfigConsistency(globalAdminPeers, configChecksum(config))
+	jsonBytes, err := json.Marshal(consistency)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ConfigWatchHandler - GET /minio/admin/v1/config/watch
+// ----------
+// Streams a change event, one JSON object per line, each time this
+// server persists a new config. Events carry the changed sections
+// with their secrets redacted. Whitespace is sent every
+// configWatchKeepAliveInterval to keep the connection open. The
+// stream ends when the client disconnects, when the server stops, or
+// when the client falls too far behind, in which case it should fetch
+// the config again before watching anew.
+func (a adminAPIHandlers) ConfigWatchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConfigWatch")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminConfigWatchAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	changeCh := globalConfigWatch.subscribe()
+	defer globalConfigWatch.unsubscribe(changeCh)
+
+	setCommonHeaders(w)
+	w.Header().Set("Content-Type", string(mimeNDJSON))
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+
+	ticker := time.NewTicker(configWatchKeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case change, ok := <-changeCh:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(change)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return
+			}
+			if _, err = w.Write(append(data, '\n')); err != nil {
+				return
+			}
+		case <-ticker.C:
+			// Send whitespace and keep connection open
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-globalServiceDoneCh:
+			return
+		}
+		w.(http.Flusher).Flush()
+	}
+}
+
+// ConfigStatusHandler - GET /minio/admin/v1/config/status
+// ----------
+// Compares the checksum of the config this server runs with to the
+// checksum of the saved config, and returns when the config was last
+// saved. They differ after a config change until the server restarted
+// with it.
+func (a adminAPIHandlers) ConfigStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConfigStatus")
 
-	if clientToken == "" {
-		// Not a status request
-		nh := newHealSequence(bucket, objPrefix, handlers.GetSourceIP(r),
-			numDisks, hs, forceStart)
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
 
-		respCh := make(chan healResp)
-		go func() {
-			respBytes, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(nh)
-			hr := healResp{respBytes, errCode, errMsg}
-			respCh <- hr
-		}()
+	adminAPIErr := checkAdminRequestAuthType(r, adminConfigStatusAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
 
-		// Due to the force-starting functionality, the Launch
-		// call above can take a long time - to keep the
-		// connection alive, we start sending whitespace
-		keepConnLive(w, respCh)
-	} else {
-		// Since clientToken is given, fetch heal status from running
-		// heal sequence.
-		path := bucket + "/" + objPrefix
-		respBytes, errCode := globalAllHealState.PopHealStatusJSON(
-			path, clientToken)
-		if errCode != ErrNone {
-			writeErrorResponseJSON(w, errCode, r.URL)
-		} else {
-			writeSuccessResponseJSON(w, respBytes)
+	config, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	lastSaved, err := getServerConfigModTime(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	globalServerConfigMu.RLock()
+	runningChecksum := globalServerConfigChecksum
+	globalServerConfigMu.RUnlock()
+
+	status := madmin.ConfigStatus{
+		RunningChecksum: runningChecksum,
+		SavedChecksum:   configChecksum(config),
+		LastSaved:       lastSaved,
+	}
+	status.Consistent = status.RunningChecksum == status.SavedChecksum
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// toAdminAPIErrCode - converts errXLWriteQuorum error to admin API
+// specific error.
+func toAdminAPIErrCode(err error) APIErrorCode {
+	switch err {
+	case errXLWriteQuorum:
+		return ErrAdminConfigNoQuorum
+	case errConfigKMSNotConfigured:
+		return ErrAdminConfigNoKMS
+	case errChaosTestingDisabled:
+		return ErrAdminChaosTestingDisabled
+	default:
+		return toAPIErrorCode(err)
+	}
+}
+
+// readConfigRequest - reads, decrypts and validates the config sent
+// in the request body, converting it to JSON if sent as YAML. An
+// error response is written if the config is invalid.
+func readConfigRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	// Read configuration bytes from request body.
+	configBuf := make([]byte, maxConfigJSONSize+1)
+	n, err := io.ReadFull(r.Body, configBuf)
+	if err == nil {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
+		return nil, false
+	}
+	if err != io.ErrUnexpectedEOF {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return nil, false
+	}
+
+	password := getAdminRequestSecretKey(r)
+	configBytes, err := decryptConfigData(r, password, bytes.NewReader(configBuf[:n]))
+	if err == errConfigKMSNotConfigured {
+		writeErrorResponseJSON(w, ErrAdminConfigNoKMS, r.URL)
+		return nil, false
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrAdminConfigDecryptFailed, r.URL)
+		return nil, false
+	}
+
+	// Convert a YAML config to JSON, so that it goes through
+	// the same validation.
+	if isYAMLMediaType(r.Header.Get("Content-Type")) {
+		if configBytes, err = configYAMLToJSON(configBytes); err != nil {
+			logger.LogIf(ctx, err)
+			writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+			return nil, false
+		}
+	}
+
+	// Validate JSON provided in the request body: check the
+	// client has not sent JSON objects with duplicate keys.
+	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
+		return nil, false
+	}
+
+	return configBytes, true
+}
+
+// validateNewConfig - validates a config about to replace the current
+// one, writing an error response if it is invalid. Its warnings are
+// set as a JSON array in the configWarningsHeader of the response.
+func validateNewConfig(w http.ResponseWriter, r *http.Request, config *serverConfig) bool {
+	// If credentials for the server are provided via environment,
+	// then credentials in the provided configuration must match.
+	if globalIsEnvCreds {
+		creds := globalServerConfig.GetCredential()
+		if config.Credential.AccessKey != creds.AccessKey ||
+			config.Credential.SecretKey != creds.SecretKey {
+			writeErrorResponseJSON(w, ErrAdminCredentialsMismatch, r.URL)
+			return false
+		}
+	}
+
+	warnings, err := config.Validate()
+	if err != nil {
+		var details []string
+		if cerrs, ok := err.(configErrors); ok {
+			details = cerrs.Details()
+		}
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL, details...)
+		return false
+	}
+
+	// The config is saved in spite of warnings, which are reported
+	// to the client in a header since the body of the reply depends
+	// on how servers restart.
+	if len(warnings) > 0 {
+		if warningsJSON, err := json.Marshal(warnings); err == nil {
+			w.Header().Set(configWarningsHeader, string(warningsJSON))
 		}
 	}
+
+	return true
 }
 
-// GetConfigHandler - GET /minio/admin/v1/config
-// Get config.json of this minio setup.
-func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "GetConfigHandler")
+// checkConfigCredential - checks the credential of a config sent by an
+// admin request. Admin credentials are not shown the credential of the
+// server, so an empty one keeps the current credential, while changing
+// it is denied unless the credential of the server signed the request.
+// A change is an update of the credentials, denied as well while
+// admin:UpdateCredentials is disabled and requiring a confirmation
+// token as set-credentials does.
+func checkConfigCredential(r *http.Request, config *serverConfig) APIErrorCode {
+	root := isRootAdminRequest(r)
+	creds := globalServerConfig.GetCredential()
+	if !root && config.Credential == (auth.Credentials{}) {
+		config.Credential = creds
+	}
+	if config.Credential.Equal(creds) {
+		return ErrNone
+	}
+	if !root {
+		return ErrAdminCredentialChangeDenied
+	}
+	if globalDisabledAdminActions.isDisabled(adminUpdateCredentialsAction) {
+		return ErrAdminActionDisabled
+	}
+	return checkAdminConfirmation(r, madmin.ConfirmSetCredentials)
+}
+
+// checkConfigReadOnlySections - checks that a config sent by an admin
+// request keeps the sections admin APIs cannot change as they are in
+// prevConfig, they are empty without prevConfig.
+func checkConfigReadOnlySections(prevConfig, config *serverConfig) APIErrorCode {
+	if prevConfig == nil {
+		prevConfig = &serverConfig{}
+	}
+	kept := *config
+	copyConfigSections(&kept, prevConfig, readOnlyConfigSections.ToSlice())
+	if configChecksum(&kept) != configChecksum(config) {
+		return ErrAdminConfigSectionReadOnly
+	}
+	return ErrNone
+}
+
+// SetConfigHandler - PUT /minio/admin/v1/config?waitForReady&restartDelay={duration}
+// Set config.json of this minio setup, the config is read as YAML if
+// sent with `Content-Type: application/yaml`. The config is encrypted
+// as in GetConfigHandler. With waitForReady, the reply holds whether
+// the other servers came back up with the new config, see
+// restartForConfig. With restartDelay, such as `30s`, servers restart
+// after the delay unless CancelConfigRestartHandler is called with the
+// token of the reply. The config must keep the read-only sections, such
+// as `adminDisabledActions`, as saved. Secrets sent back by admin
+// credentials as GetConfigHandler redacted them keep their saved value.
+func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetConfigHandler")
 
 	// Get current object layer instance.
 	objectAPI := newObjectLayerFn()
@@ -448,50 +4606,148 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validate request signature.
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetConfigAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	config, err := readServerConfig(ctx, objectAPI)
-	if err != nil {
-		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+	restartDelay, apiErr := extractConfigRestartDelay(r.URL.Query())
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
 		return
 	}
 
-	configData, err := json.Marshal(config)
+	configBytes, ok := readConfigRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var config serverConfig
+	err := json.Unmarshal(configBytes, &config)
 	if err != nil {
 		logger.LogIf(ctx, err)
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+		return
+	}
+
+	// Admin credentials get the secrets of the config redacted, the
+	// saved secrets are kept where they are sent back redacted.
+	if !isRootAdminRequest(r) {
+		savedConfig := globalServerConfig
+		if c, err := readServerConfig(ctx, objectAPI); err == nil {
+			savedConfig = c
+		}
+		restored, err := restoreRedactedConfig(&config, savedConfig)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
+			return
+		}
+		config = *restored
+	}
+
+	if adminAPIErr = checkConfigCredential(r, &config); adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if !validateNewConfig(w, r, &config) {
+		return
+	}
+
+	// The whole config is replaced, so wait for updates of any of
+	// its sections sent to any server.
+	unlockSections, err := lockConfigSections(configSections()...)
+	if err != nil {
 		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
+	defer unlockSections()
 
-	password := config.GetCredential().SecretKey
-	econfigData, err := madmin.EncryptServerConfigData(password, configData)
+	unlock, err := lockServerConfig()
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	// Without the previous config, the read-only sections are checked
+	// against the running config and all sections are reported as
+	// changed.
+	prevConfig, err := readServerConfig(ctx, objectAPI)
+	readOnlyConfig := prevConfig
+	if err != nil {
+		readOnlyConfig = globalServerConfig
+	}
+	if adminAPIErr = checkConfigReadOnlySections(readOnlyConfig, &config); adminAPIErr != ErrNone {
+		unlock()
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+	err = saveServerConfig(objectAPI, &config)
+	unlock()
 	if err != nil {
-		logger.LogIf(ctx, err)
 		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
 
-	writeSuccessResponseJSON(w, econfigData)
+	notifyConfigChange(configHookEventConfig, prevConfig, &config)
+	restartForConfig(ctx, w, r, &config, restartDelay)
 }
 
-// toAdminAPIErrCode - converts errXLWriteQuorum error to admin API
-// specific error.
-func toAdminAPIErrCode(err error) APIErrorCode {
-	switch err {
-	case errXLWriteQuorum:
-		return ErrAdminConfigNoQuorum
-	default:
-		return toAPIErrorCode(err)
+// CancelConfigRestartHandler - POST /minio/admin/v1/config/restart/cancel?token={token}
+// ----------
+// Aborts the delayed restart of all servers scheduled when setting or
+// patching the config with a restart delay, given the token returned
+// then. The config stays saved, servers run with their current config
+// until they are restarted.
+func (a adminAPIHandlers) CancelConfigRestartHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetConfigAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	token := r.URL.Query().Get(string(mgmtToken))
+	if token == "" {
+		writeErrorResponseJSON(w, ErrAdminInvalidArgument, r.URL)
+		return
+	}
+
+	cancelled, errs := cancelPeersRestart(globalAdminPeers, token)
+
+	var details []string
+	for i, err := range errs {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return
 	}
+	if !cancelled {
+		writeErrorResponseJSON(w, ErrAdminNoScheduledRestart, r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
 }
 
-// SetConfigHandler - PUT /minio/admin/v1/config
-func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "SetConfigHandler")
+// PatchConfigHandler - PATCH /minio/admin/v1/config?waitForReady&restartDelay={duration}
+// ----------
+// Update some sections of config.json of this minio setup, leaving
+// the others unchanged. The request holds a partial config made of
+// top level keys of config.json, such as `credential`, `notify` or
+// `storageclass`, read and encrypted as in SetConfigHandler. Read-only
+// sections, such as `adminDisabledActions`, cannot be updated.
+//
+// Only the updated sections are locked on all servers, so that admins
+// updating unrelated sections do not wait on each other, while updates
+// of the same section are applied one after the other. The whole
+// config is only locked while the sections are saved on top of it.
+func (a adminAPIHandlers) PatchConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PatchConfigHandler")
 
 	// Get current object layer instance.
 	objectAPI := newObjectLayerFn()
@@ -501,81 +4757,113 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validate request signature.
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetConfigAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Read configuration bytes from request body.
-	configBuf := make([]byte, maxConfigJSONSize+1)
-	n, err := io.ReadFull(r.Body, configBuf)
-	if err == nil {
-		// More than maxConfigSize bytes were available
-		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
+	restartDelay, apiErr := extractConfigRestartDelay(r.URL.Query())
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
 		return
 	}
-	if err != io.ErrUnexpectedEOF {
-		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+
+	patchBytes, ok := readConfigRequest(ctx, w, r)
+	if !ok {
 		return
 	}
 
-	password := globalServerConfig.GetCredential().SecretKey
-	configBytes, err := madmin.DecryptServerConfigData(password, bytes.NewReader(configBuf[:n]))
+	patch, err := parseConfigPatch(patchBytes)
 	if err != nil {
-		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
 		return
 	}
 
-	// Validate JSON provided in the request body: check the
-	// client has not sent JSON objects with duplicate keys.
-	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
-		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
+	var sections []string
+	for section := range patch {
+		if readOnlyConfigSections.Contains(section) {
+			writeErrorResponseJSON(w, ErrAdminConfigSectionReadOnly, r.URL)
+			return
+		}
+		sections = append(sections, section)
+	}
+	unlockSections, err := lockConfigSections(sections...)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
+	defer unlockSections()
 
-	var config serverConfig
-	err = json.Unmarshal(configBytes, &config)
+	config, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	patched, err := patchConfig(config, patch)
 	if err != nil {
-		logger.LogIf(ctx, err)
 		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
 		return
 	}
 
-	// If credentials for the server are provided via environment,
-	// then credentials in the provided configuration must match.
-	if globalIsEnvCreds {
-		creds := globalServerConfig.GetCredential()
-		if config.Credential.AccessKey != creds.AccessKey ||
-			config.Credential.SecretKey != creds.SecretKey {
-			writeErrorResponseJSON(w, ErrAdminCredentialsMismatch, r.URL)
+	// Admin credentials get the secrets of the config redacted, the
+	// saved secrets are kept where they are sent back redacted.
+	if !isRootAdminRequest(r) {
+		if patched, err = restoreRedactedConfig(patched, config); err != nil {
+			logger.LogIf(ctx, err)
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
 			return
 		}
 	}
 
-	if err = config.Validate(); err != nil {
-		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+	if _, ok := patch[configSectionCredential]; ok {
+		if adminAPIErr = checkConfigCredential(r, patched); adminAPIErr != ErrNone {
+			writeErrorResponseJSON(w, adminAPIErr, r.URL)
+			return
+		}
+	}
+
+	if !validateNewConfig(w, r, patched) {
 		return
 	}
 
-	if err = saveServerConfig(objectAPI, &config); err != nil {
+	// Other sections may have been updated meanwhile, the patched
+	// sections are saved on top of the config saved by now.
+	unlock, err := lockServerConfig()
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	prevConfig, err := readServerConfig(ctx, objectAPI)
+	if err == nil {
+		savedConfig := *prevConfig
+		copyConfigSections(&savedConfig, patched, sections)
+		config = &savedConfig
+		err = saveServerConfig(objectAPI, config)
+	}
+	unlock()
+	if err != nil {
 		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
 
-	// Reply to the client before restarting minio server.
-	writeSuccessResponseHeadersOnly(w)
-
-	sendServiceCmd(globalAdminPeers, serviceRestart)
+	notifyConfigChange(configHookEventConfig, prevConfig, config)
+	restartForConfig(ctx, w, r, config, restartDelay)
 }
 
-// UpdateCredsHandler - POST /minio/admin/v1/config/credential
+// UpdateCredsHandler - POST /minio/admin/v1/config/credential?force&dryRun
 // ----------
 // Update credentials in a minio server. In a distributed setup,
 // update all the servers in the cluster.
+//
+// If any peer fails to load the new credentials, the update is
+// rolled back on all servers and an error is returned, unless the
+// force flag is provided, in which case the update is kept and peer
+// failures are only logged.
+//
+// With the dryRun flag, the new credentials are only validated and the
+// subsystems depending on the current ones are reported.
 func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
 	r *http.Request) {
 
@@ -596,12 +4884,28 @@ func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
 	}
 
 	// Authenticate request
-	adminAPIErr := checkAdminRequestAuthType(r, "")
+	adminAPIErr := checkAdminRequestAuthType(r, adminUpdateCredentialsAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
+	// The credential of the server would grant an admin credential
+	// all actions, only the credential of the server may change it.
+	if !isRootAdminRequest(r) {
+		writeErrorResponseJSON(w, ErrAdminCredentialChangeDenied, r.URL)
+		return
+	}
+
+	// A dry run changes nothing and needs no confirmation.
+	_, dryRun := r.URL.Query()[string(mgmtDryRun)]
+	if !dryRun {
+		if adminAPIErr = checkAdminConfirmation(r, madmin.ConfirmSetCredentials); adminAPIErr != ErrNone {
+			writeErrorResponseJSON(w, adminAPIErr, r.URL)
+			return
+		}
+	}
+
 	// Read configuration bytes from request body.
 	configBuf := make([]byte, maxConfigJSONSize+1)
 	n, err := io.ReadFull(r.Body, configBuf)
@@ -616,11 +4920,11 @@ func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
 		return
 	}
 
-	password := globalServerConfig.GetCredential().SecretKey
+	password := getAdminRequestSecretKey(r)
 	configBytes, err := madmin.DecryptServerConfigData(password, bytes.NewReader(configBuf[:n]))
 	if err != nil {
 		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
+		writeErrorResponseJSON(w, ErrAdminConfigDecryptFailed, r.URL)
 		return
 	}
 
@@ -638,25 +4942,228 @@ func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Only report the impact of the new credentials on a dry run.
+	if dryRun {
+		globalServerConfigMu.RLock()
+		prevCreds := globalServerConfig.GetCredential()
+		globalServerConfigMu.RUnlock()
+
+		jsonBytes, err := json.Marshal(getCredentialsImpact(prevCreds, creds))
+		if err != nil {
+			writeErrorResponseJSON(w, ErrInternalError, r.URL)
+			logger.LogIf(ctx, err)
+			return
+		}
+		writeSuccessResponseJSON(w, jsonBytes)
+		return
+	}
+
+	_, force := r.URL.Query()[string(mgmtForce)]
+
 	// Acquire lock before updating global configuration.
+	unlock, err := lockConfigSections(configSectionCredential)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	defer unlock()
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
 
+	prevCreds := globalServerConfig.GetCredential()
+
 	// Update local credentials in memory.
 	globalServerConfig.SetCredential(creds)
 
-	if err = saveServerConfig(objectAPI, globalServerConfig); err != nil {
+	if err = saveConfigCredential(ctx, objectAPI, creds); err != nil {
+		globalServerConfig.SetCredential(prevCreds)
 		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
 
-	// Notify all other Minio peers to update credentials
-	for host, err := range globalNotificationSys.LoadCredentials() {
+	// Notify all other Minio peers to update credentials, peers
+	// only accept calls signed with the credentials they run with.
+	peerErrs := globalNotificationSys.LoadCredentialsWith(prevCreds, nil)
+	var details []string
+	for host, err := range peerErrs {
 		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host.String())
 		ctx := logger.SetReqInfo(ctx, reqInfo)
 		logger.LogIf(ctx, err)
+		details = append(details, fmt.Sprintf("%s: %v", host, err))
+	}
+
+	if len(peerErrs) > 0 && !force {
+		// Restore previous credentials so that the cluster
+		// keeps using a single set of credentials. Peers which
+		// loaded the new credentials are called with them.
+		errCode := ErrAdminPeerCredentialsFailed
+		if err = saveConfigCredential(ctx, objectAPI, prevCreds); err != nil {
+			logger.LogIf(ctx, err)
+			errCode = ErrAdminPeerCredentialsRollbackFailed
+			details = append(details, fmt.Sprintf("rollback: %v", err))
+		} else {
+			globalServerConfig.SetCredential(prevCreds)
+			for host, err := range globalNotificationSys.LoadCredentialsWith(creds, peerErrs) {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host.String())
+				ctx := logger.SetReqInfo(ctx, reqInfo)
+				logger.LogIf(ctx, err)
+				errCode = ErrAdminPeerCredentialsRollbackFailed
+				details = append(details, fmt.Sprintf("rollback %s: %v", host, err))
+			}
+		}
+
+		sort.Strings(details)
+		apiErr := getAPIError(errCode)
+		writeCustomErrorResponseJSON(w, errCode, apiErr.Description, r.URL, details...)
+		return
 	}
 
+	notifyConfigChange(configHookEventCredentials, &serverConfig{Credential: prevCreds}, &serverConfig{Credential: creds})
+
 	// Reply to the client before restarting minio server.
 	writeSuccessResponseHeadersOnly(w)
 }
+
+// reloadPeersAdminCredentials - makes all peers load the saved admin
+// credentials, writing an error response listing the peers which
+// failed to. Returns false if an error response was written.
+func reloadPeersAdminCredentials(w http.ResponseWriter, r *http.Request) bool {
+	var details []string
+	for i, err := range loadPeersAdminCredentials(globalAdminPeers) {
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", globalAdminPeers[i].addr, err))
+		}
+	}
+	if len(details) > 0 {
+		apiErr := getAPIError(ErrInternalError)
+		writeCustomErrorResponseJSON(w, ErrInternalError, apiErr.Description, r.URL, details...)
+		return false
+	}
+	return true
+}
+
+// SetAdminCredentialHandler - PUT /minio/admin/v1/credentials/admin
+// ----------
+// Adds or replaces a credential restricted by a policy to some admin
+// actions. The credential, sent encrypted like the config, is saved
+// and loaded by all servers. An admin credential may only grant the
+// actions it is allowed itself.
+func (a adminAPIHandlers) SetAdminCredentialHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetAdminCredential")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminSetAdminCredentialAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	credBuf := make([]byte, maxConfigJSONSize+1)
+	n, err := io.ReadFull(r.Body, credBuf)
+	if err == nil {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
+		return
+	}
+	if err != io.ErrUnexpectedEOF {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	credBytes, err := madmin.DecryptServerConfigData(getAdminRequestSecretKey(r), bytes.NewReader(credBuf[:n]))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrAdminConfigDecryptFailed, r.URL)
+		return
+	}
+
+	var cred madmin.AdminCredential
+	if err = json.Unmarshal(credBytes, &cred); err != nil {
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+	if err = validateAdminCredential(cred); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminInvalidArgument, err.Error(), r.URL)
+		return
+	}
+
+	// Otherwise an admin credential could grant itself, or a
+	// credential it sets, all admin actions.
+	_, adminPolicy, _ := getAdminRequestCredential(r, "")
+	if adminPolicy != nil && !isAdminPolicyGrantable(cred.Policy, *adminPolicy) {
+		writeErrorResponseJSON(w, ErrAccessDenied, r.URL)
+		return
+	}
+
+	if err = setAdminCredential(ctx, objectAPI, cred); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	if reloadPeersAdminCredentials(w, r) {
+		writeSuccessResponseHeadersOnly(w)
+	}
+}
+
+// RemoveAdminCredentialHandler - DELETE /minio/admin/v1/credentials/admin?accessKey={accessKey}
+// ----------
+// Removes an admin credential from all servers.
+func (a adminAPIHandlers) RemoveAdminCredentialHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveAdminCredential")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminRemoveAdminCredentialAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	accessKey := r.URL.Query().Get(string(mgmtAccessKey))
+	err := removeAdminCredential(ctx, objectAPI, accessKey)
+	if err == errNoSuchAdminCredential {
+		writeErrorResponseJSON(w, ErrAdminNoSuchCredential, r.URL)
+		return
+	}
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	if reloadPeersAdminCredentials(w, r) {
+		writeSuccessResponseHeadersOnly(w)
+	}
+}
+
+// ListAdminCredentialsHandler - GET /minio/admin/v1/credentials/admin
+// ----------
+// Returns the admin credentials along with their policy, without
+// their secret key.
+func (a adminAPIHandlers) ListAdminCredentialsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListAdminCredentials")
+
+	adminAPIErr := checkAdminRequestAuthType(r, adminListAdminCredentialsAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(globalAdminCredentials.list())
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
@@ -0,0 +1,242 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Config section enabling automatic heals.
+	configSectionAutoHeal = "autoHeal"
+
+	// Resource locked by the server launching automatic heals, so
+	// that a single server of the cluster launches them.
+	autoHealLeaderResource = "heal/auto.lock"
+
+	// Client address recorded for automatically launched heal
+	// sequences.
+	autoHealClientAddr = "auto-heal"
+)
+
+// Interval at which drives are checked for changes which require a
+// heal.
+var autoHealInterval = time.Minute
+
+// autoHealState - tracks drive state between checks and the heal
+// sequence launched automatically when a drive came back online or
+// was replaced.
+type autoHealState struct {
+	sync.Mutex
+
+	// whether heals are launched after drive changes
+	enabled bool
+
+	// whether this server holds the leader lock and launches heals
+	leader bool
+
+	// drives seen at the last check, keyed by endpoint
+	drives map[string]madmin.DriveInfo
+
+	// drives which changed and still need a heal
+	pending map[string]struct{}
+
+	// drives which triggered the current heal sequence
+	triggeredBy []string
+
+	// most recently launched heal sequence
+	seq *healSequence
+}
+
+var globalAutoHealState = &autoHealState{}
+
+// changedDrives - records the drives found in storageInfo and returns
+// the endpoints of drives which came back online or were replaced
+// since the previous call. The first call only records drives.
+func (a *autoHealState) changedDrives(storageInfo StorageInfo) []string {
+	drives := make(map[string]madmin.DriveInfo)
+	for _, set := range storageInfo.Backend.Sets {
+		for _, drive := range set {
+			if drive.Endpoint != "" {
+				drives[drive.Endpoint] = drive
+			}
+		}
+	}
+
+	var changed []string
+	if a.drives != nil {
+		for endpoint, drive := range drives {
+			if drive.State != madmin.DriveStateOk {
+				continue
+			}
+			prev, ok := a.drives[endpoint]
+			switch {
+			case !ok, prev.State != madmin.DriveStateOk:
+				// Drive came back online.
+				changed = append(changed, endpoint)
+			case prev.UUID != drive.UUID:
+				// Drive was replaced.
+				changed = append(changed, endpoint)
+			}
+		}
+	}
+	a.drives = drives
+
+	sort.Strings(changed)
+	return changed
+}
+
+// setEnabled - enables or disables automatic heals.
+func (a *autoHealState) setEnabled(enabled bool) {
+	a.Lock()
+	defer a.Unlock()
+	a.enabled = enabled
+}
+
+// isEnabled - returns whether automatic heals are enabled.
+func (a *autoHealState) isEnabled() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.enabled
+}
+
+// setLeader - records whether this server launches automatic heals.
+func (a *autoHealState) setLeader(leader bool) {
+	a.Lock()
+	defer a.Unlock()
+	a.leader = leader
+}
+
+// check - looks for drive changes and, on the leader with automatic
+// heals enabled, launches a heal sequence of all buckets if any were
+// found. Other servers keep track of the changes, which they heal if
+// they become the leader. A heal which could not be launched, e.g.
+// because one is already running, is retried on the next check.
+func (a *autoHealState) check(ctx context.Context, objAPI ObjectLayer) {
+	storageInfo := objAPI.StorageInfo(ctx)
+
+	a.Lock()
+	defer a.Unlock()
+
+	for _, endpoint := range a.changedDrives(storageInfo) {
+		if a.pending == nil {
+			a.pending = make(map[string]struct{})
+		}
+		a.pending[endpoint] = struct{}{}
+	}
+	if len(a.pending) == 0 || !a.enabled || !a.leader {
+		return
+	}
+	if a.seq != nil && !a.seq.hasEnded() {
+		return
+	}
+
+	var triggeredBy []string
+	for endpoint := range a.pending {
+		triggeredBy = append(triggeredBy, endpoint)
+	}
+	sort.Strings(triggeredBy)
+
+	numDisks := storageInfo.Backend.OfflineDisks + storageInfo.Backend.OnlineDisks
+	seq := newHealSequence("", "", autoHealClientAddr, numDisks,
+		madmin.HealOpts{Recursive: true}, false)
+	seq.discardResults = true
+
+	_, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(seq)
+	if errCode != ErrNone {
+		if errMsg == "" {
+			errMsg = getAPIError(errCode).Description
+		}
+		logger.Debug(ctx, logger.HealSubsystem, "Unable to launch auto heal for %v: %s", triggeredBy, errMsg)
+		return
+	}
+
+	logger.Debug(ctx, logger.HealSubsystem, "Launched auto heal for %v", triggeredBy)
+	a.seq = seq
+	a.triggeredBy = triggeredBy
+	a.pending = nil
+}
+
+// status - returns the state of the most recent automatic heal.
+func (a *autoHealState) status() madmin.AutoHealStatus {
+	a.Lock()
+	defer a.Unlock()
+
+	status := madmin.AutoHealStatus{
+		Enabled:     a.enabled,
+		Leader:      a.leader,
+		TriggeredBy: a.triggeredBy,
+	}
+	if a.seq == nil {
+		return status
+	}
+
+	a.seq.currentStatus.updateLock.RLock()
+	defer a.seq.currentStatus.updateLock.RUnlock()
+
+	summary := a.seq.currentStatus.Summary
+	status.Active = summary != healStoppedStatus && summary != healFinishedStatus
+	status.Summary = string(summary)
+	status.FailureDetail = a.seq.currentStatus.FailureDetail
+	status.StartTime = a.seq.currentStatus.StartTime
+	status.ItemsHealed = a.seq.lastSentResultIndex
+	status.ChecksumFailures = a.seq.currentStatus.ChecksumFailures
+	return status
+}
+
+// lockAutoHealLeader - tries to become the server launching automatic
+// heals, the lock is held until the server exits. Locks of servers
+// which went offline are released by the lock maintenance, letting
+// another server take over.
+func lockAutoHealLeader() bool {
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, autoHealLeaderResource)
+	// A new timeout for each try, as failing to get the lock while
+	// another server holds it would lengthen a shared one.
+	return leaderLock.GetLock(newDynamicTimeout(time.Second, time.Second)) == nil
+}
+
+// startAutoHeal - periodically checks drives and heals after any of
+// them came back online or was replaced, until the server exits.
+func startAutoHeal(objAPI ObjectLayer) {
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "AutoHeal"})
+
+	// Record the drives present at startup.
+	globalAutoHealState.check(ctx, objAPI)
+
+	ticker := time.NewTicker(autoHealInterval)
+	defer ticker.Stop()
+	leader := false
+	for {
+		select {
+		case <-ticker.C:
+			if !leader && globalAutoHealState.isEnabled() {
+				if leader = lockAutoHealLeader(); leader {
+					globalAutoHealState.setLeader(true)
+				}
+			}
+			globalAutoHealState.check(ctx, objAPI)
+		case <-globalServiceDoneCh:
+			return
+		}
+	}
+}
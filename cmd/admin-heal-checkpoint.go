@@ -0,0 +1,168 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Prefix under minioMetaBucket where heal checkpoints are saved.
+const healCheckpointPrefix = "heal/checkpoints"
+
+// healCheckpoint - namespace walk position of a recursive heal
+// sequence, persisted so that an interrupted heal can be resumed.
+type healCheckpoint struct {
+	// path on which the heal sequence was initiated
+	Path string `json:"path"`
+
+	// bucket being walked and the last object healed in it
+	Bucket string `json:"bucket"`
+	Marker string `json:"marker"`
+
+	// buckets whose objects have all been healed
+	HealedBuckets []string `json:"healedBuckets"`
+
+	// number of objects healed in each bucket so far
+	ObjectsHealed map[string]int64 `json:"objectsHealed"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// healCheckpointFile - returns the checkpoint file of a heal path.
+func healCheckpointFile(healPath string) string {
+	return path.Join(healCheckpointPrefix, getSHA256Hash([]byte(healPath))+".json")
+}
+
+// isBucketHealed - returns true if all objects of the bucket were
+// healed before the checkpoint was saved.
+func (c *healCheckpoint) isBucketHealed(bucket string) bool {
+	if c == nil {
+		return false
+	}
+	for _, b := range c.HealedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// markerFor - returns the marker from which the walk of the bucket
+// resumes.
+func (c *healCheckpoint) markerFor(bucket string) string {
+	if c == nil || c.Bucket != bucket {
+		return ""
+	}
+	return c.Marker
+}
+
+// initCheckpoint - loads the checkpoint of a previous heal of the
+// same path when resuming, or clears it otherwise. Only recursive
+// heals which mutate data are checkpointed, resuming from a dry run
+// would skip objects which were never healed.
+func (h *healSequence) initCheckpoint() error {
+	if !h.settings.Recursive || h.settings.DryRun {
+		return nil
+	}
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	h.checkpoint = &healCheckpoint{
+		Path:          h.path,
+		ObjectsHealed: make(map[string]int64),
+	}
+
+	checkpointFile := healCheckpointFile(h.path)
+	if !h.settings.Resume {
+		h.removeCheckpoint()
+		return nil
+	}
+
+	buffer, err := readConfig(h.ctx, objectAPI, checkpointFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			// Nothing to resume from.
+			return nil
+		}
+		return err
+	}
+
+	var checkpoint healCheckpoint
+	if err = json.Unmarshal(buffer.Bytes(), &checkpoint); err != nil {
+		return err
+	}
+	if checkpoint.ObjectsHealed == nil {
+		checkpoint.ObjectsHealed = make(map[string]int64)
+	}
+	h.checkpoint = &checkpoint
+	return nil
+}
+
+// saveCheckpoint - records that the walk of the bucket has healed
+// objects up to marker, and count more objects. An empty marker
+// means all objects of the bucket are healed.
+func (h *healSequence) saveCheckpoint(bucket, marker string, count int64) {
+	if h.checkpoint == nil {
+		return
+	}
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return
+	}
+
+	h.checkpoint.ObjectsHealed[bucket] += count
+	if marker == "" {
+		h.checkpoint.Bucket, h.checkpoint.Marker = "", ""
+		h.checkpoint.HealedBuckets = append(h.checkpoint.HealedBuckets, bucket)
+	} else {
+		h.checkpoint.Bucket, h.checkpoint.Marker = bucket, marker
+	}
+	h.checkpoint.UpdatedAt = UTCNow()
+
+	data, err := json.Marshal(h.checkpoint)
+	if err != nil {
+		logger.LogIf(h.ctx, err)
+		return
+	}
+	logger.LogIf(h.ctx, saveConfig(objectAPI, healCheckpointFile(h.path), data))
+}
+
+// removeCheckpoint - removes the checkpoint of the heal path, once the
+// heal sequence is complete.
+func (h *healSequence) removeCheckpoint() {
+	if h.checkpoint == nil {
+		return
+	}
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return
+	}
+
+	err := objectAPI.DeleteObject(h.ctx, minioMetaBucket, healCheckpointFile(h.path))
+	if err != nil && !isErrObjectNotFound(err) {
+		logger.LogIf(h.ctx, err)
+	}
+}
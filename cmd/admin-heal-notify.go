@@ -0,0 +1,128 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// Time between two heal progress events when not set in the heal
+// settings.
+const defaultHealNotifyInterval = time.Minute
+
+// notifyInterval - returns the time between two progress events.
+func (h *healSequence) notifyInterval() time.Duration {
+	if h.settings.NotifyInterval > 0 {
+		return h.settings.NotifyInterval
+	}
+	return defaultHealNotifyInterval
+}
+
+// notifyProgress - sends a progress event at each notify interval
+// until doneCh is closed.
+func (h *healSequence) notifyProgress(doneCh <-chan struct{}) {
+	ticker := time.NewTicker(h.notifyInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.notify(event.HealProgress)
+		case <-doneCh:
+			return
+		}
+	}
+}
+
+// notify - sends an event with the current status of the heal
+// sequence to the notification target set in the heal settings, if
+// any.
+func (h *healSequence) notify(name event.Name) {
+	if h.settings.NotifyARN == "" || globalNotificationSys == nil {
+		return
+	}
+
+	// The target may have been removed since the heal started.
+	targetID, ok := globalNotificationSys.targetIDForARN(h.settings.NotifyARN)
+	if !ok {
+		logger.LogIf(h.ctx, fmt.Errorf("heal notification target %s not found", h.settings.NotifyARN))
+		return
+	}
+	for _, terr := range globalNotificationSys.send(h.bucket, h.toEvent(name), targetID) {
+		logger.LogIf(h.ctx, terr.Err)
+	}
+}
+
+// toEvent - converts the current status of the heal sequence to a
+// notification event.
+func (h *healSequence) toEvent(name event.Name) event.Event {
+	h.currentStatus.updateLock.RLock()
+	summary := h.currentStatus.Summary
+	failureDetail := h.currentStatus.FailureDetail
+	checksumFailures := h.currentStatus.ChecksumFailures
+	itemsHealed := h.lastSentResultIndex
+	if n := len(h.currentStatus.Items); n > 0 {
+		itemsHealed = h.currentStatus.Items[n-1].ResultIndex
+	}
+	h.currentStatus.updateLock.RUnlock()
+
+	creds := globalServerConfig.GetCredential()
+	eventTime := UTCNow()
+	uniqueID := fmt.Sprintf("%X", eventTime.UnixNano())
+
+	respElements := map[string]string{
+		"x-amz-request-id":               uniqueID,
+		"x-minio-origin-endpoint":        getOriginEndpoint(),
+		"x-minio-heal-client-token":      h.clientToken,
+		"x-minio-heal-summary":           string(summary),
+		"x-minio-heal-items":             strconv.FormatInt(itemsHealed, 10),
+		"x-minio-heal-checksum-failures": strconv.FormatInt(checksumFailures, 10),
+	}
+	if failureDetail != "" {
+		respElements["x-minio-heal-detail"] = failureDetail
+	}
+
+	return event.Event{
+		EventVersion:      "2.0",
+		EventSource:       "minio:admin",
+		AwsRegion:         globalServerConfig.GetRegion(),
+		EventTime:         eventTime.Format(event.AMZTimeFormat),
+		EventName:         name,
+		UserIdentity:      event.Identity{PrincipalID: creds.AccessKey},
+		RequestParameters: map[string]string{"sourceIPAddress": h.clientAddress},
+		ResponseElements:  respElements,
+		S3: event.Metadata{
+			SchemaVersion:   "1.0",
+			ConfigurationID: "Config",
+			Bucket: event.Bucket{
+				Name:          h.bucket,
+				OwnerIdentity: event.Identity{PrincipalID: creds.AccessKey},
+				ARN:           policy.ResourceARNPrefix + h.bucket,
+			},
+			Object: event.Object{
+				Key:       url.QueryEscape(h.objPrefix),
+				Sequencer: uniqueID,
+			},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type healNotifyTestTarget struct {
+	id     event.TargetID
+	events []event.Event
+}
+
+func (target *healNotifyTestTarget) ID() event.TargetID {
+	return target.id
+}
+
+func (target *healNotifyTestTarget) Send(eventData event.Event) error {
+	target.events = append(target.events, eventData)
+	return nil
+}
+
+func (target *healNotifyTestTarget) Close() error {
+	return nil
+}
+
+// Tests that heal events are sent to the target set in the heal
+// settings with the status of the heal sequence.
+func TestHealSequenceNotify(t *testing.T) {
+	tmpServerConfig, tmpNotificationSys := globalServerConfig, globalNotificationSys
+	defer func() {
+		globalServerConfig, globalNotificationSys = tmpServerConfig, tmpNotificationSys
+	}()
+	globalServerConfig = newServerConfig()
+
+	target := &healNotifyTestTarget{id: event.TargetID{ID: "1", Name: "webhook"}}
+	globalNotificationSys = &NotificationSys{targetList: event.NewTargetList()}
+	if err := globalNotificationSys.targetList.Add(target); err != nil {
+		t.Fatal(err)
+	}
+
+	arn := target.id.ToARN(globalServerConfig.GetRegion()).String()
+	if _, ok := globalNotificationSys.targetIDForARN(arn); !ok {
+		t.Fatalf("Expected target %s to be found", arn)
+	}
+	if _, ok := globalNotificationSys.targetIDForARN("arn:minio:sqs::2:webhook"); ok {
+		t.Fatalf("Expected unknown target not to be found")
+	}
+
+	h := newHealSequence("bucket", "prefix", "127.0.0.1", 4, madmin.HealOpts{NotifyARN: arn}, false)
+	h.notify(event.HealStarted)
+
+	h.currentStatus.Summary = healStoppedStatus
+	h.currentStatus.FailureDetail = "disk not found"
+	h.currentStatus.ChecksumFailures = 2
+	h.currentStatus.Items = []madmin.HealResultItem{{ResultIndex: 7}}
+	h.notify(event.HealFailed)
+
+	if len(target.events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(target.events))
+	}
+	if target.events[0].EventName != event.HealStarted || target.events[1].EventName != event.HealFailed {
+		t.Errorf("Unexpected events %v, %v", target.events[0].EventName, target.events[1].EventName)
+	}
+
+	eventData := target.events[1]
+	if eventData.S3.Bucket.Name != "bucket" || eventData.S3.Object.Key != "prefix" {
+		t.Errorf("Unexpected heal path %s/%s", eventData.S3.Bucket.Name, eventData.S3.Object.Key)
+	}
+	expectedElements := map[string]string{
+		"x-minio-heal-client-token":      h.clientToken,
+		"x-minio-heal-summary":           healStoppedStatus,
+		"x-minio-heal-detail":            "disk not found",
+		"x-minio-heal-items":             "7",
+		"x-minio-heal-checksum-failures": "2",
+	}
+	for key, value := range expectedElements {
+		if eventData.ResponseElements[key] != value {
+			t.Errorf("Expected %s to be %s, got %s", key, value, eventData.ResponseElements[key])
+		}
+	}
+
+	// No event is sent without a target.
+	h = newHealSequence("bucket", "", "127.0.0.1", 4, madmin.HealOpts{}, false)
+	h.notify(event.HealStarted)
+	if len(target.events) != 2 {
+		t.Errorf("Expected no event without a target, got %d events", len(target.events))
+	}
+}
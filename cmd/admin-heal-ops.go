@@ -20,11 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -35,6 +38,7 @@ type healStatusSummary string
 const (
 	healNotStartedStatus healStatusSummary = "not started"
 	healRunningStatus                      = "running"
+	healPausedStatus                       = "paused"
 	healStoppedStatus                      = "stopped"
 	healFinishedStatus                     = "finished"
 )
@@ -52,6 +56,26 @@ const (
 	// time-duration to keep heal sequence state after it
 	// completes.
 	keepHealSeqStateDuration = time.Minute * 10
+
+	// maximum number of objects a heal sequence may heal in
+	// parallel.
+	maxHealConcurrency = 64
+
+	// environment variable making heal launch requests send the
+	// response status and headers right away.
+	healEarlyHeadersEnv = "MINIO_HEAL_EARLY_HEADERS"
+)
+
+var (
+	// interval at which heal launch requests send whitespace to
+	// keep the connection alive until the response is ready.
+	healKeepAliveInterval = 10 * time.Second
+
+	// when set, heal launch requests send the 200 status and the
+	// headers right away instead of after the first keepalive
+	// interval, so that clients do not mistake the initial silence
+	// for a connection problem.
+	globalHealEarlyHeaders bool
 )
 
 var (
@@ -84,6 +108,17 @@ type healSequenceStatus struct {
 	// settings for the heal sequence
 	HealSettings madmin.HealOpts `json:"Settings"`
 
+	// number of objects healed in parallel
+	Concurrency int `json:"Concurrency"`
+
+	// number of bitrot checksum failures found so far, only
+	// reported by a deep scan
+	ChecksumFailures int64 `json:"ChecksumFailures"`
+
+	// number of objects of other erasure sets skipped by a heal
+	// restricted to one erasure set
+	ObjectsSkipped int64 `json:"ObjectsSkipped"`
+
 	// slice of available heal result records
 	Items []madmin.HealResultItem `json:"Items"`
 }
@@ -121,6 +156,33 @@ func (ahs *allHealState) getHealSequence(path string) (h *healSequence, exists b
 	return h, exists
 }
 
+// getHealSequenceByToken - Retrieve a heal sequence by client
+// token. The second argument returns if a heal sequence actually
+// exists.
+func (ahs *allHealState) getHealSequenceByToken(clientToken string) (h *healSequence, exists bool) {
+	ahs.Lock()
+	defer ahs.Unlock()
+	for _, h = range ahs.healSeqMap {
+		if h.clientToken == clientToken {
+			return h, true
+		}
+	}
+	return nil, false
+}
+
+// PauseHealSequence - suspends or resumes the heal sequence of the
+// given client token, keeping its accumulated status and position.
+func (ahs *allHealState) PauseHealSequence(clientToken string, pause bool) APIErrorCode {
+	h, exists := ahs.getHealSequenceByToken(clientToken)
+	if !exists {
+		return ErrHealNoSuchProcess
+	}
+	if pause {
+		return h.pause()
+	}
+	return h.resume()
+}
+
 // LaunchNewHealSequence - launches a background routine that performs
 // healing according to the healSequence argument. For each heal
 // sequence, state is stored in the `globalAllHealState`, which is a
@@ -228,12 +290,66 @@ func (ahs *allHealState) LaunchNewHealSequence(h *healSequence) (
 	return b, ErrNone, ""
 }
 
+// healResultMatches - returns whether a heal result item belongs to
+// the given category of results, all items match an empty filter.
+func healResultMatches(item madmin.HealResultItem, filter madmin.HealResultFilter) bool {
+	switch filter {
+	case "":
+		return true
+	case madmin.HealFilterFailed:
+		_, corruptAfter := item.GetCorruptedCounts()
+		_, missingAfter := item.GetMissingCounts()
+		return item.Detail != "" || corruptAfter > 0 || missingAfter > 0
+	case madmin.HealFilterCorrupted:
+		corruptBefore, _ := item.GetCorruptedCounts()
+		return corruptBefore > 0
+	case madmin.HealFilterMissing:
+		missingBefore, _ := item.GetMissingCounts()
+		return missingBefore > 0
+	}
+	return false
+}
+
+// healResultSeverity - returns how critical a heal result item is,
+// the higher the more critical. Items which could not be healed or
+// remain unreadable after heal are the most critical, then items by
+// increasing number of drives they can still lose.
+func healResultSeverity(item madmin.HealResultItem) int {
+	_, onlineAfter := item.GetOnlineCounts()
+	readQuorum := item.DataBlocks
+	if readQuorum == 0 {
+		// Buckets and metadata are read from a majority of drives.
+		readQuorum = len(item.After.Drives) / 2
+	}
+	redundancy := onlineAfter - readQuorum
+	if item.Detail != "" || redundancy < 0 {
+		return math.MaxInt32
+	}
+	return -redundancy
+}
+
+// sortHealResults - sorts heal result items in the given order, items
+// of the same severity keep their scan order.
+func sortHealResults(items []madmin.HealResultItem, sortBy madmin.HealResultSort) {
+	if sortBy != madmin.HealSortSeverity {
+		return
+	}
+	severities := make(map[int64]int, len(items))
+	for _, item := range items {
+		severities[item.ResultIndex] = healResultSeverity(item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return severities[items[i].ResultIndex] > severities[items[j].ResultIndex]
+	})
+}
+
 // PopHealStatusJSON - Called by heal-status API. It fetches the heal
 // status results from global state and returns its JSON
-// representation. The clientToken helps ensure there aren't
-// conflicting clients fetching status.
+// representation, with only the result items matching filter, in the
+// given order. The clientToken helps ensure there aren't conflicting
+// clients fetching status.
 func (ahs *allHealState) PopHealStatusJSON(path string,
-	clientToken string) ([]byte, APIErrorCode) {
+	clientToken string, filter madmin.HealResultFilter, sortBy madmin.HealResultSort) ([]byte, APIErrorCode) {
 
 	// fetch heal state for given path
 	h, exists := ahs.getHealSequence(path)
@@ -268,7 +384,20 @@ func (ahs *allHealState) PopHealStatusJSON(path string,
 		h.currentStatus.Items = nil
 	}(lastResultIndex)
 
-	jbytes, err := json.Marshal(h.currentStatus)
+	// Items not matching the filter are dropped along with the
+	// sent ones.
+	status := h.currentStatus
+	if filter != "" || sortBy != "" {
+		status.Items = nil
+		for _, item := range h.currentStatus.Items {
+			if healResultMatches(item, filter) {
+				status.Items = append(status.Items, item)
+			}
+		}
+		sortHealResults(status.Items, sortBy)
+	}
+
+	jbytes, err := json.Marshal(status)
 	if err != nil {
 		logger.LogIf(context.Background(), err)
 		return nil, ErrInternalError
@@ -309,9 +438,27 @@ type healSequence struct {
 	// heal-stop API)
 	stopSignalCh chan struct{}
 
+	// channel closed when a paused heal sequence resumes, nil if
+	// the heal sequence is not paused - protected by the
+	// updateLock of currentStatus
+	resumeCh chan struct{}
+
 	// the last result index sent to client
 	lastSentResultIndex int64
 
+	// heal results are only counted and not kept in memory, for
+	// heal sequences which have no client consuming the results
+	discardResults bool
+
+	// number of heal results which failed, only counted for heal
+	// sequences discarding results - protected by the updateLock of
+	// currentStatus
+	itemsFailed int64
+
+	// namespace walk position, nil if the heal sequence is not
+	// checkpointed
+	checkpoint *healCheckpoint
+
 	// Holds the request-info for logging
 	ctx context.Context
 }
@@ -338,6 +485,7 @@ func newHealSequence(bucket, objPrefix, clientAddr string,
 			Summary:      healNotStartedStatus,
 			HealSettings: hs,
 			NumDisks:     numDisks,
+			Concurrency:  healConcurrency(hs),
 			updateLock:   &sync.RWMutex{},
 		},
 		traverseAndHealDoneCh: make(chan error),
@@ -374,6 +522,51 @@ func (h *healSequence) stop() {
 	}
 }
 
+// pause - suspends a running heal sequence at its next safe point.
+func (h *healSequence) pause() APIErrorCode {
+	h.currentStatus.updateLock.Lock()
+	defer h.currentStatus.updateLock.Unlock()
+
+	if h.currentStatus.Summary != healRunningStatus {
+		return ErrHealNotRunning
+	}
+	h.currentStatus.Summary = healPausedStatus
+	h.resumeCh = make(chan struct{})
+	return ErrNone
+}
+
+// resume - resumes a paused heal sequence where it was suspended.
+func (h *healSequence) resume() APIErrorCode {
+	h.currentStatus.updateLock.Lock()
+	defer h.currentStatus.updateLock.Unlock()
+
+	if h.currentStatus.Summary != healPausedStatus {
+		return ErrHealNotPaused
+	}
+	h.currentStatus.Summary = healRunningStatus
+	close(h.resumeCh)
+	h.resumeCh = nil
+	return ErrNone
+}
+
+// waitIfPaused - blocks while the heal sequence is paused, returns an
+// error if it is stopped meanwhile.
+func (h *healSequence) waitIfPaused() error {
+	h.currentStatus.updateLock.RLock()
+	resumeCh := h.resumeCh
+	h.currentStatus.updateLock.RUnlock()
+
+	if resumeCh == nil {
+		return nil
+	}
+	select {
+	case <-resumeCh:
+		return nil
+	case <-h.stopSignalCh:
+		return errHealStopSignalled
+	}
+}
+
 // pushHealResultItem - pushes a heal result item for consumption in
 // the heal-status API. It blocks if there are
 // maxUnconsumedHealResultItems. When it blocks, the heal sequence
@@ -383,6 +576,22 @@ func (h *healSequence) stop() {
 // sequence automatically resumes. The return value indicates if the
 // operation succeeded.
 func (h *healSequence) pushHealResultItem(r madmin.HealResultItem) error {
+	if h.discardResults {
+		h.currentStatus.updateLock.Lock()
+		h.lastSentResultIndex++
+		h.currentStatus.ChecksumFailures += int64(r.ChecksumFailures)
+		// The format heal result describes itself in its
+		// detail, which is not an error.
+		if r.Type != madmin.HealItemMetadata && healResultMatches(r, madmin.HealFilterFailed) {
+			h.itemsFailed++
+		}
+		h.currentStatus.updateLock.Unlock()
+
+		if h.isQuitting() {
+			return errHealStopSignalled
+		}
+		return nil
+	}
 
 	// start a timer to keep an upper time limit to find an empty
 	// slot to add the given heal result - if no slot is found it
@@ -436,6 +645,7 @@ func (h *healSequence) pushHealResultItem(r madmin.HealResultItem) error {
 
 	// append to results
 	h.currentStatus.Items = append(h.currentStatus.Items, r)
+	h.currentStatus.ChecksumFailures += int64(r.ChecksumFailures)
 
 	// release lock
 	h.currentStatus.updateLock.Unlock()
@@ -463,12 +673,23 @@ func (h *healSequence) healSequenceStart() {
 	h.currentStatus.StartTime = UTCNow()
 	h.currentStatus.updateLock.Unlock()
 
+	h.notify(event.HealStarted)
+	var progressWg sync.WaitGroup
+	progressDoneCh := make(chan struct{})
+	if h.settings.NotifyARN != "" {
+		progressWg.Add(1)
+		go func() {
+			defer progressWg.Done()
+			h.notifyProgress(progressDoneCh)
+		}()
+	}
+
 	go h.traverseAndHeal()
 
+	endEvent := event.HealFailed
 	select {
 	case err, ok := <-h.traverseAndHealDoneCh:
 		h.currentStatus.updateLock.Lock()
-		defer h.currentStatus.updateLock.Unlock()
 		// Heal traversal is complete.
 		if ok {
 			// heal traversal had an error.
@@ -477,7 +698,9 @@ func (h *healSequence) healSequenceStart() {
 		} else {
 			// heal traversal succeeded.
 			h.currentStatus.Summary = healFinishedStatus
+			endEvent = event.HealCompleted
 		}
+		h.currentStatus.updateLock.Unlock()
 
 	case <-h.stopSignalCh:
 		h.currentStatus.updateLock.Lock()
@@ -494,6 +717,11 @@ func (h *healSequence) healSequenceStart() {
 			<-h.traverseAndHealDoneCh
 		}()
 	}
+
+	// Stop progress events so none is sent after the end event.
+	close(progressDoneCh)
+	progressWg.Wait()
+	h.notify(endEvent)
 }
 
 // traverseAndHeal - traverses on-disk data and performs healing
@@ -516,6 +744,9 @@ func (h *healSequence) traverseAndHeal() {
 		err = f()
 	}
 
+	// Resume from a previous checkpoint if requested
+	checkErr(h.initCheckpoint)
+
 	// Start with format healing
 	checkErr(h.healDiskFormat)
 
@@ -524,6 +755,8 @@ func (h *healSequence) traverseAndHeal() {
 
 	if err != nil {
 		h.traverseAndHealDoneCh <- err
+	} else {
+		h.removeCheckpoint()
 	}
 
 	close(h.traverseAndHealDoneCh)
@@ -559,6 +792,9 @@ func (h *healSequence) healDiskFormat() error {
 func (h *healSequence) healBuckets() error {
 	// 1. If a bucket was specified, heal only the bucket.
 	if h.bucket != "" {
+		if h.checkpoint.isBucketHealed(h.bucket) {
+			return nil
+		}
 		return h.healBucket(h.bucket)
 	}
 
@@ -574,6 +810,10 @@ func (h *healSequence) healBuckets() error {
 	}
 
 	for _, bucket := range buckets {
+		// Skip buckets healed before resuming.
+		if h.checkpoint.isBucketHealed(bucket.Name) {
+			continue
+		}
 		if err = h.healBucket(bucket.Name); err != nil {
 			return err
 		}
@@ -584,6 +824,9 @@ func (h *healSequence) healBuckets() error {
 
 // healBucket - traverses and heals given bucket
 func (h *healSequence) healBucket(bucket string) error {
+	if err := h.waitIfPaused(); err != nil {
+		return err
+	}
 	if h.isQuitting() {
 		return errHealStopSignalled
 	}
@@ -622,7 +865,7 @@ func (h *healSequence) healBucket(bucket string) error {
 		return nil
 	}
 
-	marker := ""
+	marker := h.checkpoint.markerFor(bucket)
 	isTruncated := true
 	for isTruncated {
 		objectInfos, err := objectAPI.ListObjectsHeal(h.ctx, bucket,
@@ -631,20 +874,104 @@ func (h *healSequence) healBucket(bucket string) error {
 			return errFnHealFromAPIErr(err)
 		}
 
-		for _, o := range objectInfos.Objects {
-			if err := h.healObject(o.Bucket, o.Name); err != nil {
-				return err
-			}
+		if err = h.healObjects(objectInfos.Objects); err != nil {
+			return err
 		}
 
 		isTruncated = objectInfos.IsTruncated
 		marker = objectInfos.NextMarker
+		if !isTruncated {
+			marker = ""
+		}
+
+		// Checkpoint after each page of healed objects.
+		h.saveCheckpoint(bucket, marker, int64(len(objectInfos.Objects)))
 	}
 	return nil
 }
 
+// erasureSetCount - returns the number of erasure sets of the object
+// layer, zero if it is not erasure coded.
+func erasureSetCount(objAPI ObjectLayer) int {
+	if s, ok := objAPI.(*xlSets); ok {
+		return len(s.sets)
+	}
+	return 0
+}
+
+// erasureSetIndex - returns the index of the erasure set storing the
+// given object, -1 if the object layer is not erasure coded.
+func erasureSetIndex(objAPI ObjectLayer, object string) int {
+	if s, ok := objAPI.(*xlSets); ok {
+		return s.getHashedSetIndex(object)
+	}
+	return -1
+}
+
+// healConcurrency - returns the number of objects healed in parallel
+// with the given settings.
+func healConcurrency(hs madmin.HealOpts) int {
+	if hs.MaxConcurrency > 1 {
+		return hs.MaxConcurrency
+	}
+	return 1
+}
+
+// healObjects - heals the given objects, at most as many in parallel
+// as the concurrency of the heal sequence, and returns the first
+// error. No more objects are healed once an error occurred.
+func (h *healSequence) healObjects(objects []ObjectInfo) error {
+	concurrency := healConcurrency(h.settings)
+	if concurrency == 1 {
+		for _, o := range objects {
+			if err := h.healObject(o.Bucket, o.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		objectCh = make(chan ObjectInfo)
+		errCh    = make(chan struct{})
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range objectCh {
+				if err := h.healObject(o.Bucket, o.Name); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(errCh)
+					})
+				}
+			}
+		}()
+	}
+
+sendObjects:
+	for _, o := range objects {
+		select {
+		case objectCh <- o:
+		case <-errCh:
+			break sendObjects
+		}
+	}
+	close(objectCh)
+	wg.Wait()
+
+	return firstErr
+}
+
 // healObject - heal the given object and record result
 func (h *healSequence) healObject(bucket, object string) error {
+	if err := h.waitIfPaused(); err != nil {
+		return err
+	}
 	if h.isQuitting() {
 		return errHealStopSignalled
 	}
@@ -655,7 +982,14 @@ func (h *healSequence) healObject(bucket, object string) error {
 		return errServerNotInitialized
 	}
 
-	hri, err := objectAPI.HealObject(h.ctx, bucket, object, h.settings.DryRun)
+	if h.settings.ErasureSet != nil && erasureSetIndex(objectAPI, object) != *h.settings.ErasureSet {
+		h.currentStatus.updateLock.Lock()
+		h.currentStatus.ObjectsSkipped++
+		h.currentStatus.updateLock.Unlock()
+		return nil
+	}
+
+	hri, err := objectAPI.HealObject(h.ctx, bucket, object, h.settings.DryRun, h.settings.ScanMode)
 	if err != nil {
 		hri.Detail = err.Error()
 	}
@@ -0,0 +1,148 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// newHealResultItem - returns a heal result item with one drive in
+// the given states before and after heal.
+func newHealResultItem(index int64, before, after, detail string) madmin.HealResultItem {
+	item := madmin.HealResultItem{ResultIndex: index, Detail: detail}
+	item.Before.Drives = []madmin.HealDriveInfo{{State: before}}
+	item.After.Drives = []madmin.HealDriveInfo{{State: after}}
+	return item
+}
+
+func TestPopHealStatusJSONFilter(t *testing.T) {
+	items := []madmin.HealResultItem{
+		newHealResultItem(1, madmin.DriveStateOk, madmin.DriveStateOk, ""),
+		newHealResultItem(2, madmin.DriveStateCorrupt, madmin.DriveStateOk, ""),
+		newHealResultItem(3, madmin.DriveStateMissing, madmin.DriveStateOk, ""),
+		newHealResultItem(4, madmin.DriveStateMissing, madmin.DriveStateMissing, ""),
+		newHealResultItem(5, madmin.DriveStateOffline, madmin.DriveStateOffline, "disk not found"),
+	}
+
+	testCases := []struct {
+		filter          madmin.HealResultFilter
+		expectedIndexes []int64
+	}{
+		{"", []int64{1, 2, 3, 4, 5}},
+		{madmin.HealFilterFailed, []int64{4, 5}},
+		{madmin.HealFilterCorrupted, []int64{2}},
+		{madmin.HealFilterMissing, []int64{3, 4}},
+	}
+
+	for i, testCase := range testCases {
+		h := newHealSequence("bucket", "", "127.0.0.1", 1, madmin.HealOpts{}, false)
+		h.currentStatus.Items = append([]madmin.HealResultItem{}, items...)
+		ahs := allHealState{healSeqMap: map[string]*healSequence{h.path: h}}
+
+		data, errCode := ahs.PopHealStatusJSON(h.path, h.clientToken, testCase.filter, "")
+		if errCode != ErrNone {
+			t.Fatalf("Test %d: unexpected error %v", i+1, errCode)
+		}
+		var status madmin.HealTaskStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			t.Fatal(err)
+		}
+
+		var indexes []int64
+		for _, item := range status.Items {
+			indexes = append(indexes, item.ResultIndex)
+		}
+		if len(indexes) != len(testCase.expectedIndexes) {
+			t.Fatalf("Test %d: expected items %v, got %v", i+1, testCase.expectedIndexes, indexes)
+		}
+		for j := range indexes {
+			if indexes[j] != testCase.expectedIndexes[j] {
+				t.Fatalf("Test %d: expected items %v, got %v", i+1, testCase.expectedIndexes, indexes)
+			}
+		}
+
+		// Items not matching the filter are popped as well.
+		if len(h.currentStatus.Items) != 0 || h.lastSentResultIndex != 5 {
+			t.Errorf("Test %d: expected all items to be popped", i+1)
+		}
+	}
+}
+
+// newHealResultItemDrives - returns a heal result item of an object
+// with the given data blocks, ok drives and lost drives after heal.
+func newHealResultItemDrives(index int64, dataBlocks, okDrives, lostDrives int, detail string) madmin.HealResultItem {
+	item := madmin.HealResultItem{ResultIndex: index, DataBlocks: dataBlocks, Detail: detail}
+	for i := 0; i < okDrives; i++ {
+		item.After.Drives = append(item.After.Drives, madmin.HealDriveInfo{State: madmin.DriveStateOk})
+	}
+	for i := 0; i < lostDrives; i++ {
+		item.After.Drives = append(item.After.Drives, madmin.HealDriveInfo{State: madmin.DriveStateMissing})
+	}
+	return item
+}
+
+func TestPopHealStatusJSONSort(t *testing.T) {
+	items := []madmin.HealResultItem{
+		newHealResultItemDrives(1, 2, 4, 0, ""),
+		newHealResultItemDrives(2, 2, 3, 1, ""),
+		newHealResultItemDrives(3, 2, 1, 3, ""),
+		newHealResultItemDrives(4, 2, 4, 0, "disk not found"),
+		newHealResultItemDrives(5, 2, 2, 2, ""),
+		newHealResultItemDrives(6, 2, 3, 1, ""),
+		newHealResultItemDrives(7, 0, 3, 1, ""),
+	}
+
+	testCases := []struct {
+		filter          madmin.HealResultFilter
+		sortBy          madmin.HealResultSort
+		expectedIndexes []int64
+	}{
+		{"", "", []int64{1, 2, 3, 4, 5, 6, 7}},
+		{"", madmin.HealSortSeverity, []int64{3, 4, 5, 2, 6, 7, 1}},
+		{madmin.HealFilterFailed, madmin.HealSortSeverity, []int64{3, 4, 5, 2, 6, 7}},
+	}
+
+	for i, testCase := range testCases {
+		h := newHealSequence("bucket", "", "127.0.0.1", 4, madmin.HealOpts{}, false)
+		h.currentStatus.Items = append([]madmin.HealResultItem{}, items...)
+		ahs := allHealState{healSeqMap: map[string]*healSequence{h.path: h}}
+
+		data, errCode := ahs.PopHealStatusJSON(h.path, h.clientToken, testCase.filter, testCase.sortBy)
+		if errCode != ErrNone {
+			t.Fatalf("Test %d: unexpected error %v", i+1, errCode)
+		}
+		var status madmin.HealTaskStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			t.Fatal(err)
+		}
+
+		var indexes []int64
+		for _, item := range status.Items {
+			indexes = append(indexes, item.ResultIndex)
+		}
+		if !reflect.DeepEqual(indexes, testCase.expectedIndexes) {
+			t.Fatalf("Test %d: expected items %v, got %v", i+1, testCase.expectedIndexes, indexes)
+		}
+		if len(h.currentStatus.Items) != 0 || h.lastSentResultIndex != 7 {
+			t.Errorf("Test %d: expected all items to be popped", i+1)
+		}
+	}
+}
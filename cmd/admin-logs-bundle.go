@@ -0,0 +1,277 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Number of most recent log entries kept in memory by
+	// each server.
+	maxRecentLogEntries = 10000
+
+	// Number of log entries of each server included in a logs
+	// bundle by default.
+	defaultBundleLogLines = 1000
+
+	// Value replacing secrets in the config of a logs bundle.
+	redactedValue = "REDACTED"
+)
+
+// Most recent log entries of this server.
+var globalRecentLogs = logger.NewMemory(maxRecentLogEntries)
+
+// Keys of config.json whose values are secrets, lower cased.
+var configSecretKeys = map[string]bool{
+	"secretkey":        true,
+	"password":         true,
+	"token":            true,
+	"secret":           true,
+	"connectionstring": true,
+	"dsnstring":        true,
+}
+
+// redactConfig - returns the given config in JSON with all secrets,
+// such as the secret key and the passwords of notification targets,
+// replaced by redactedValue.
+func redactConfig(config *serverConfig) ([]byte, error) {
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err = json.Unmarshal(configBytes, &v); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(redactConfigValue(v), "", "\t")
+}
+
+// redactConfigJSON - returns the given config, or partial config, in
+// JSON with all its secrets replaced by redactedValue.
+func redactConfigJSON(configBytes []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(configBytes, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactConfigValue(v))
+}
+
+// restoreRedactedConfig - returns the given config with the secrets
+// left redacted replaced by the ones of prevConfig, so that a config
+// sent back as it was returned redacted keeps the saved secrets.
+func restoreRedactedConfig(config, prevConfig *serverConfig) (*serverConfig, error) {
+	var v, prev interface{}
+	for _, c := range []struct {
+		config *serverConfig
+		value  *interface{}
+	}{{config, &v}, {prevConfig, &prev}} {
+		configBytes, err := json.Marshal(c.config)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(configBytes, c.value); err != nil {
+			return nil, err
+		}
+	}
+
+	configBytes, err := json.Marshal(restoreRedactedValue(v, prev))
+	if err != nil {
+		return nil, err
+	}
+	restored := &serverConfig{}
+	if err = json.Unmarshal(configBytes, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// restoreRedactedValue - replaces the secrets of a value decoded from
+// JSON which are still redacted by the ones of prev, the same value
+// before it was redacted.
+func restoreRedactedValue(v, prev interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		prevMap, _ := prev.(map[string]interface{})
+		for key, value := range t {
+			if value == redactedValue && configSecretKeys[strings.ToLower(key)] {
+				if s, ok := prevMap[key].(string); ok {
+					t[key] = s
+				}
+				continue
+			}
+			t[key] = restoreRedactedValue(value, prevMap[key])
+		}
+	case []interface{}:
+		prevSlice, _ := prev.([]interface{})
+		for i, value := range t {
+			if i < len(prevSlice) {
+				t[i] = restoreRedactedValue(value, prevSlice[i])
+			}
+		}
+	case string:
+		prevString, _ := prev.(string)
+		u, err := url.Parse(t)
+		if err != nil || u.User == nil {
+			break
+		}
+		if password, ok := u.User.Password(); !ok || password != redactedValue {
+			break
+		}
+		if prevURL, err := url.Parse(prevString); err == nil && prevURL.User != nil {
+			if password, ok := prevURL.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), password)
+				return u.String()
+			}
+		}
+	}
+	return v
+}
+
+// redactConfigValue - replaces the secrets of a value decoded from
+// JSON, including passwords embedded in URLs.
+func redactConfigValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if s, ok := value.(string); ok && s != "" && configSecretKeys[strings.ToLower(key)] {
+				t[key] = redactedValue
+				continue
+			}
+			t[key] = redactConfigValue(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = redactConfigValue(value)
+		}
+	case string:
+		if u, err := url.Parse(t); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redactedValue)
+				return u.String()
+			}
+		}
+	}
+	return v
+}
+
+// bundleNodeName - returns the name of the directory of a server in a
+// logs bundle, which is valid on all platforms.
+func bundleNodeName(addr string) string {
+	return strings.NewReplacer(":", "_", "[", "", "]", "").Replace(addr)
+}
+
+// serverLogs - recent logs of a server, or the error which prevented
+// getting them.
+type serverLogs struct {
+	addr string
+	logs []string
+	err  error
+}
+
+// getPeersRecentLogs - gathers the most recent logs of all peers.
+func getPeersRecentLogs(peers adminPeers, lines int, since time.Time) []serverLogs {
+	logs := make([]serverLogs, len(peers))
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			logs[idx].addr = peer.addr
+			logs[idx].logs, logs[idx].err = peer.cmdRunner.RecentLogs(lines, since)
+			if logs[idx].err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, logs[idx].err)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return logs
+}
+
+// createLogsBundle - returns a zip archive holding the logs of each
+// server, their server info and the redacted config.
+func createLogsBundle(logs []serverLogs, serverInfo []ServerInfo, config []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	addFile := func(name string, data []byte) error {
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: UTCNow(),
+		}
+		f, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	for _, l := range logs {
+		name := bundleNodeName(l.addr)
+		if l.err != nil {
+			if err := addFile(name+"/error.txt", []byte(l.err.Error()+"\n")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var data []byte
+		for _, line := range l.logs {
+			data = append(data, line...)
+			data = append(data, '\n')
+		}
+		if err := addFile(name+"/logs.json", data); err != nil {
+			return nil, err
+		}
+	}
+
+	serverInfoBytes, err := json.MarshalIndent(serverInfo, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err = addFile("serverinfo.json", serverInfoBytes); err != nil {
+		return nil, err
+	}
+	if err = addFile("config.json", config); err != nil {
+		return nil, err
+	}
+
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// logsBundleName - returns the file name of a logs bundle created at
+// the given time.
+func logsBundleName(t time.Time) string {
+	return "minio-logs-" + t.Format("20060102T150405Z") + ".zip"
+}
@@ -0,0 +1,161 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Defaults and bounds of the size of the payload sent over each
+	// link to measure its bandwidth. Links are measured one after
+	// the other, so that a server reports its links within the
+	// admin RPC timeout.
+	defaultNetPerfSize = humanize.MiByte
+	maxNetPerfSize     = 16 * humanize.MiByte
+
+	// Round trips measuring the latency of a link.
+	netperfPings = 10
+
+	// A link is slow if its latency is netperfSlowFactor times the
+	// median latency of all links, and at least
+	// minNetPerfSlowLatency above it so that sub-millisecond jitter
+	// is ignored, or if its bandwidth is netperfSlowFactor times
+	// below the median bandwidth.
+	netperfSlowFactor     = 3
+	minNetPerfSlowLatency = time.Millisecond
+)
+
+// measureNetPerfLink - measures the round trip latency of the link to
+// the given peer, then its bandwidth by sending the given payload.
+func measureNetPerfLink(peer adminPeer, payload []byte) (link madmin.NetPerfLink) {
+	link.Target = peer.addr
+
+	var total time.Duration
+	for i := 0; i < netperfPings; i++ {
+		start := time.Now()
+		if err := peer.cmdRunner.NetPerfPing(nil); err != nil {
+			link.Error = err.Error()
+			return link
+		}
+		latency := time.Since(start)
+		total += latency
+		if latency > link.MaxLatency {
+			link.MaxLatency = latency
+		}
+	}
+	link.Latency = total / netperfPings
+
+	start := time.Now()
+	if err := peer.cmdRunner.NetPerfPing(payload); err != nil {
+		link.Error = err.Error()
+		return link
+	}
+	if elapsed := time.Since(start); elapsed > 0 {
+		link.Bandwidth = uint64(float64(len(payload)) / elapsed.Seconds())
+	}
+	return link
+}
+
+// measureNetPerf - measures the links from this server to each other
+// peer, one after the other so that they do not compete.
+func measureNetPerf(peers adminPeers, size int64) []madmin.NetPerfLink {
+	payload := make([]byte, size)
+	links := []madmin.NetPerfLink{}
+	for _, peer := range peers {
+		if peer.isLocal {
+			continue
+		}
+		links = append(links, measureNetPerfLink(peer, payload))
+	}
+	return links
+}
+
+// flagSlowLinks - computes the median latency and bandwidth of the
+// measured links and flags the links anomalously slower.
+func flagSlowLinks(perf *madmin.NetPerf) {
+	var latencies []time.Duration
+	var bandwidths []uint64
+	for _, server := range perf.Servers {
+		for _, link := range server.Links {
+			if link.Error == "" {
+				latencies = append(latencies, link.Latency)
+				bandwidths = append(bandwidths, link.Bandwidth)
+			}
+		}
+	}
+	if len(latencies) == 0 {
+		return
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	sort.Slice(bandwidths, func(i, j int) bool { return bandwidths[i] < bandwidths[j] })
+	perf.MedianLatency = latencies[len(latencies)/2]
+	perf.MedianBandwidth = bandwidths[len(bandwidths)/2]
+
+	for i := range perf.Servers {
+		for j := range perf.Servers[i].Links {
+			link := &perf.Servers[i].Links[j]
+			if link.Error != "" {
+				continue
+			}
+			link.Slow = (link.Latency > netperfSlowFactor*perf.MedianLatency &&
+				link.Latency > perf.MedianLatency+minNetPerfSlowLatency) ||
+				link.Bandwidth < perf.MedianBandwidth/netperfSlowFactor
+		}
+	}
+}
+
+// getNetPerf - has each server measure its links to all other
+// servers and returns the resulting matrix, with anomalously slow
+// links flagged.
+func getNetPerf(peers adminPeers, size int64) madmin.NetPerf {
+	perf := madmin.NetPerf{
+		Size:    size,
+		Servers: make([]madmin.ServerNetPerf, len(peers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			perf.Servers[idx].Addr = peer.addr
+			links, err := peer.cmdRunner.NetPerf(size)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				perf.Servers[idx].Error = err.Error()
+				return
+			}
+			perf.Servers[idx].Links = links
+		}(i, p)
+	}
+	wg.Wait()
+
+	flagSlowLinks(&perf)
+	return perf
+}
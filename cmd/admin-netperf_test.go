@@ -0,0 +1,110 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+type netperfTestRunner struct {
+	localAdminClient
+	delay    time.Duration
+	received int
+	links    []madmin.NetPerfLink
+	err      error
+}
+
+func (runner *netperfTestRunner) NetPerfPing(payload []byte) error {
+	time.Sleep(runner.delay)
+	runner.received += len(payload)
+	return runner.err
+}
+
+func (runner *netperfTestRunner) NetPerf(size int64) ([]madmin.NetPerfLink, error) {
+	return runner.links, runner.err
+}
+
+// Tests that each remote peer is measured and that unreachable peers
+// are reported.
+func TestMeasureNetPerf(t *testing.T) {
+	server2 := &netperfTestRunner{delay: time.Millisecond}
+	peers := adminPeers{
+		{addr: "server1", cmdRunner: localAdminClient{}, isLocal: true},
+		{addr: "server2", cmdRunner: server2},
+		{addr: "server3", cmdRunner: &netperfTestRunner{err: errors.New("unreachable")}},
+	}
+
+	links := measureNetPerf(peers, 1024)
+	if len(links) != 2 || links[0].Target != "server2" || links[1].Target != "server3" {
+		t.Fatalf("Unexpected links %v", links)
+	}
+	if links[0].Error != "" || links[0].Latency < time.Millisecond || links[0].MaxLatency < links[0].Latency || links[0].Bandwidth == 0 {
+		t.Errorf("Unexpected link to server2 %v", links[0])
+	}
+	if server2.received != 1024 {
+		t.Errorf("Expected server2 to receive 1024 bytes, got %d", server2.received)
+	}
+	if links[1].Error != "unreachable" {
+		t.Errorf("Expected link to server3 to be unreachable, got %v", links[1])
+	}
+}
+
+// Tests that links anomalously slower than the median are flagged.
+func TestGetNetPerf(t *testing.T) {
+	link := func(target string, latency time.Duration, bandwidth uint64) madmin.NetPerfLink {
+		return madmin.NetPerfLink{Target: target, Latency: latency, Bandwidth: bandwidth}
+	}
+	peers := adminPeers{
+		{addr: "server1", cmdRunner: &netperfTestRunner{links: []madmin.NetPerfLink{
+			link("server2", 2*time.Millisecond, 1000),
+			link("server3", 10*time.Millisecond, 1000),
+		}}},
+		{addr: "server2", cmdRunner: &netperfTestRunner{links: []madmin.NetPerfLink{
+			link("server1", 2*time.Millisecond, 1000),
+			link("server3", 2*time.Millisecond, 100),
+			{Target: "server4", Error: "unreachable"},
+		}}},
+		{addr: "server3", cmdRunner: &netperfTestRunner{links: []madmin.NetPerfLink{
+			link("server1", 3*time.Millisecond, 900),
+			link("server2", 2*time.Millisecond, 1100),
+		}}},
+		{addr: "server4", cmdRunner: &netperfTestRunner{err: errors.New("unreachable")}},
+	}
+
+	perf := getNetPerf(peers, 1024)
+	if perf.Size != 1024 || perf.MedianLatency != 2*time.Millisecond || perf.MedianBandwidth != 1000 {
+		t.Fatalf("Unexpected medians %v", perf)
+	}
+	var slow []string
+	for _, server := range perf.Servers {
+		for _, link := range server.Links {
+			if link.Slow {
+				slow = append(slow, server.Addr+"->"+link.Target)
+			}
+		}
+	}
+	if len(slow) != 2 || slow[0] != "server1->server3" || slow[1] != "server2->server3" {
+		t.Errorf("Expected the links to server3 from server1 and server2 to be slow, got %v", slow)
+	}
+	if perf.Servers[3].Addr != "server4" || perf.Servers[3].Error != "unreachable" {
+		t.Errorf("Unexpected unreachable server %v", perf.Servers[3])
+	}
+}
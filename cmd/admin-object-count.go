@@ -0,0 +1,191 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errObjectCountStopSignalled = errors.New("object count stop signaled")
+
+// objectCountSequence - state of an on-demand count of the objects of
+// the cluster. Every server of a distributed setup lists the whole
+// namespace, so a single walk counts the objects of all servers.
+type objectCountSequence struct {
+	// bucket on which the count was initiated, empty for all
+	// buckets
+	bucket string
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.ObjectCountStatus
+
+	// channel to signal the count to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// Time the status of an object count is kept once it ended.
+const keepObjectCountStatusDuration = time.Hour
+
+// objectCountState - holds the object count running on this server
+// and the ended ones until their status expires. Only one count may
+// run at a time.
+type objectCountState struct {
+	*adminScanRegistry
+}
+
+var globalObjectCountState = objectCountState{newAdminScanRegistry(keepObjectCountStatusDuration)}
+
+// newObjectCountSequence - creates an object count, assumes bucket is
+// already validated.
+func newObjectCountSequence(bucket, clientAddr string) *objectCountSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "ObjectCount", BucketName: bucket}
+
+	return &objectCountSequence{
+		bucket: bucket,
+		status: madmin.ObjectCountStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.ObjectCountRunning,
+			StartTime:   UTCNow(),
+		},
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the count unless another one is running. A running
+// count is stopped first if forceStart is set.
+func (s *objectCountState) Launch(seq *objectCountSequence, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, seq.run)
+}
+
+// Status - returns the status of the count identified by clientToken.
+func (s *objectCountState) Status(clientToken string) (madmin.ObjectCountStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.ObjectCountStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*objectCountSequence)
+
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	status := seq.status
+	status.Buckets = append([]madmin.BucketObjectCount(nil), seq.status.Buckets...)
+	return status, ErrNone
+}
+
+func (seq *objectCountSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *objectCountSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.ObjectCountRunning
+}
+
+// stop - stops the count, safe to call multiple times.
+func (seq *objectCountSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *objectCountSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// run - walks the namespace and counts objects, bucket by bucket.
+func (seq *objectCountSequence) run() {
+	err := seq.count()
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.ObjectCountStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.ObjectCountFinished
+}
+
+func (seq *objectCountSequence) count() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	buckets := []string{seq.bucket}
+	if seq.bucket == "" {
+		bucketsInfo, err := objectAPI.ListBuckets(seq.ctx)
+		if err != nil {
+			return err
+		}
+		buckets = buckets[:0]
+		for _, bucket := range bucketsInfo {
+			buckets = append(buckets, bucket.Name)
+		}
+	}
+
+	for _, bucket := range buckets {
+		var count int64
+		marker := ""
+		for {
+			if seq.isQuitting() {
+				return errObjectCountStopSignalled
+			}
+			result, err := objectAPI.ListObjects(seq.ctx, bucket, "", marker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			count += int64(len(result.Objects))
+
+			seq.mu.Lock()
+			seq.status.Count += int64(len(result.Objects))
+			seq.mu.Unlock()
+
+			if !result.IsTruncated {
+				break
+			}
+			marker = result.NextMarker
+		}
+
+		seq.mu.Lock()
+		seq.status.Buckets = append(seq.status.Buckets, madmin.BucketObjectCount{Bucket: bucket, Count: count})
+		seq.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// xlMetaChecksum - returns the checksum of the parts of an `xl.json`
+// which must be the same on all disks of an erasure set, i.e. all but
+// the index of the disk and the bitrot checksums of its shards.
+func xlMetaChecksum(xlMeta xlMetaV1) (string, error) {
+	xlMeta.Erasure.Index = 0
+	xlMeta.Erasure.Checksums = nil
+	data, err := json.Marshal(xlMeta)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// verifyShards - verifies the bitrot checksum of each part of an
+// object on a disk, reading all of its data.
+func verifyShards(disk StorageAPI, bucket, object string, xlMeta xlMetaV1) []madmin.ObjectShardState {
+	shards := make([]madmin.ObjectShardState, len(xlMeta.Parts))
+	for i, part := range xlMeta.Parts {
+		shards[i].Part = part.Name
+
+		checksumInfo := xlMeta.Erasure.GetChecksumInfo(part.Name)
+		if checksumInfo.Hash == nil {
+			shards[i].Error = errFileCorrupt.Error()
+			continue
+		}
+		shards[i].Algorithm = checksumInfo.Algorithm.String()
+		shards[i].Checksum = hex.EncodeToString(checksumInfo.Hash)
+
+		// Verification happens even if a 0-length buffer is
+		// passed.
+		verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
+		if _, err := disk.ReadFile(bucket, path.Join(object, part.Name), 0, []byte{}, verifier); err != nil {
+			shards[i].Error = err.Error()
+		}
+	}
+	return shards
+}
+
+// verifyObject - reads the metadata and the data of an object from
+// every disk of its erasure set and reports whether they agree. The
+// metadata most disks agree on is considered the reference, disks
+// which are offline, have a different metadata or a corrupt or missing
+// shard are divergent. Nothing is repaired.
+func (xl xlObjects) verifyObject(ctx context.Context, bucket, object string) (result madmin.ObjectVerifyResult, err error) {
+	result, _, _, err = xl.inspectObject(ctx, bucket, object)
+	return result, err
+}
+
+// inspectObject - verifies an object as verifyObject does, returns the
+// metadata read from each disk along with the index of a disk holding
+// the reference metadata, -1 if no disk holds a metadata.
+func (xl xlObjects) inspectObject(ctx context.Context, bucket, object string) (result madmin.ObjectVerifyResult, partsMetadata []xlMetaV1, referenceIdx int, err error) {
+	referenceIdx = -1
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return result, nil, referenceIdx, err
+	}
+
+	// Lock the object before reading.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return result, nil, referenceIdx, err
+	}
+	defer objectLock.RUnlock()
+
+	disks := xl.getDisks()
+	partsMetadata, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	if maxCount, maxErr := reduceErrs(errs, nil); maxErr != nil && maxCount == len(errs) {
+		return result, nil, referenceIdx, toObjectErr(maxErr, bucket, object)
+	}
+
+	result = madmin.ObjectVerifyResult{
+		Bucket: bucket,
+		Object: object,
+		Disks:  make([]madmin.ObjectDiskState, len(disks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, disk := range disks {
+		result.Disks[i].Index = i
+		if disk != nil {
+			result.Disks[i].Disk = disk.String()
+		}
+		if errs[i] != nil {
+			result.Disks[i].Error = errs[i].Error()
+			continue
+		}
+
+		xlMeta := partsMetadata[i]
+		result.Disks[i].ModTime = xlMeta.Stat.ModTime
+		result.Disks[i].Size = xlMeta.Stat.Size
+		result.Disks[i].ETag = xlMeta.Meta["etag"]
+		result.Disks[i].ErasureIndex = xlMeta.Erasure.Index
+
+		wg.Add(1)
+		go func(idx int, disk StorageAPI) {
+			defer wg.Done()
+			result.Disks[idx].Shards = verifyShards(disk, bucket, object, partsMetadata[idx])
+		}(i, disk)
+	}
+	wg.Wait()
+
+	// The reference metadata is the one found on most disks.
+	counts := make(map[string]int)
+	var reference string
+	for i, d := range result.Disks {
+		if d.Error != "" {
+			continue
+		}
+		if d.MetadataChecksum, err = xlMetaChecksum(partsMetadata[i]); err != nil {
+			return result, nil, referenceIdx, err
+		}
+		result.Disks[i].MetadataChecksum = d.MetadataChecksum
+		counts[d.MetadataChecksum]++
+		if counts[d.MetadataChecksum] > counts[reference] {
+			reference = d.MetadataChecksum
+			referenceIdx = i
+		}
+	}
+
+	result.Consistent = true
+	for i := range result.Disks {
+		d := &result.Disks[i]
+		d.Consistent = d.Error == "" && d.MetadataChecksum == reference
+		for _, shard := range d.Shards {
+			if shard.Error != "" {
+				d.Consistent = false
+			}
+		}
+		if !d.Consistent {
+			result.Consistent = false
+			result.DivergentDisks = append(result.DivergentDisks, d.Index)
+		}
+	}
+	return result, partsMetadata, referenceIdx, nil
+}
+
+// healObjectDetail - inspects an object as verifyObject does and
+// reports whether heal can repair its divergent disks. Heal rebuilds
+// their shards from the disks holding the reference metadata and
+// intact shards, which must be at least as many as the data shards.
+// Nothing is repaired.
+func (xl xlObjects) healObjectDetail(ctx context.Context, bucket, object string) (detail madmin.ObjectHealDetail, err error) {
+	result, partsMetadata, referenceIdx, err := xl.inspectObject(ctx, bucket, object)
+	if err != nil {
+		return detail, err
+	}
+	detail.ObjectVerifyResult = result
+	if referenceIdx < 0 {
+		detail.Reason = "no disk holds a readable metadata"
+		return detail, nil
+	}
+
+	reference := partsMetadata[referenceIdx].Erasure
+	detail.DataBlocks = reference.DataBlocks
+	detail.ParityBlocks = reference.ParityBlocks
+	detail.Distribution = reference.Distribution
+	for _, d := range result.Disks {
+		if d.Consistent {
+			detail.HealthyDisks++
+		}
+	}
+
+	detail.Healable = detail.HealthyDisks >= detail.DataBlocks
+	if !detail.Healable {
+		detail.Reason = fmt.Sprintf("only %d disks hold the reference metadata and intact shards, at least %d are needed to rebuild the others",
+			detail.HealthyDisks, detail.DataBlocks)
+	}
+	return detail, nil
+}
+
+// verifyObject - verifies an object on the disks of its erasure set.
+func (s *xlSets) verifyObject(ctx context.Context, bucket, object string) (madmin.ObjectVerifyResult, error) {
+	return s.getHashedSet(object).verifyObject(ctx, bucket, object)
+}
+
+// healObjectDetail - inspects an object on the disks of its erasure
+// set.
+func (s *xlSets) healObjectDetail(ctx context.Context, bucket, object string) (madmin.ObjectHealDetail, error) {
+	return s.getHashedSet(object).healObjectDetail(ctx, bucket, object)
+}
@@ -0,0 +1,181 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Tests that disks with a different metadata or corrupt or missing
+// data are reported as divergent.
+func TestVerifyObject(t *testing.T) {
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(mustGetNewEndpointList(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xl := obj.(*xlObjects)
+	ctx := context.Background()
+
+	bucket := "bucket"
+	object := "object"
+	data := bytes.Repeat([]byte("a"), 1024*1024)
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("Failed to put an object - %v", err)
+	}
+
+	if _, err = xl.verifyObject(ctx, bucket, "missing"); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found but got %v", err)
+	}
+
+	result, err := xl.verifyObject(ctx, bucket, object)
+	if err != nil {
+		t.Fatalf("Failed to verify object - %v", err)
+	}
+	if !result.Consistent || len(result.DivergentDisks) != 0 || len(result.Disks) != len(fsDirs) {
+		t.Fatalf("Expected object to be consistent but got %v", result)
+	}
+	for _, d := range result.Disks {
+		if !d.Consistent || d.MetadataChecksum != result.Disks[0].MetadataChecksum || len(d.Shards) != 1 || d.Shards[0].Checksum == "" {
+			t.Fatalf("Expected disk %d to be consistent but got %v", d.Index, d)
+		}
+	}
+
+	disks := xl.getDisks()
+
+	// Corrupt the data on the first disk.
+	if err = disks[0].AppendFile(bucket, filepath.Join(object, "part.1"), []byte("corruption")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the metadata on the second disk.
+	if err = disks[1].DeleteFile(bucket, filepath.Join(object, xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Change the metadata on the third disk.
+	xlMeta, err := readXLMeta(ctx, disks[2], bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xlMeta.Meta["etag"] = "divergent"
+	if err = disks[2].DeleteFile(bucket, filepath.Join(object, xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+	if err = writeXLMetadata(ctx, disks[2], bucket, object, xlMeta); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err = xl.verifyObject(ctx, bucket, object); err != nil {
+		t.Fatalf("Failed to verify object - %v", err)
+	}
+	if result.Consistent {
+		t.Fatalf("Expected object to be inconsistent")
+	}
+	if !reflect.DeepEqual(result.DivergentDisks, []int{0, 1, 2}) {
+		t.Fatalf("Expected disks 0, 1 and 2 to diverge but got %v", result.DivergentDisks)
+	}
+	if result.Disks[0].Shards[0].Error == "" {
+		t.Errorf("Expected corrupt shard to be reported")
+	}
+	if result.Disks[1].Error == "" {
+		t.Errorf("Expected missing metadata to be reported")
+	}
+	if result.Disks[2].ETag != "divergent" || result.Disks[2].MetadataChecksum == result.Disks[3].MetadataChecksum {
+		t.Errorf("Expected divergent metadata to be reported but got %v", result.Disks[2])
+	}
+}
+
+// Tests that objects are reported as healable only while enough
+// disks hold intact shards to rebuild the others.
+func TestHealObjectDetail(t *testing.T) {
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(mustGetNewEndpointList(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xl := obj.(*xlObjects)
+	ctx := context.Background()
+
+	bucket := "bucket"
+	object := "object"
+	data := bytes.Repeat([]byte("a"), 1024*1024)
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("Failed to put an object - %v", err)
+	}
+
+	detail, err := xl.healObjectDetail(ctx, bucket, object)
+	if err != nil {
+		t.Fatalf("Failed to get heal detail - %v", err)
+	}
+	if !detail.Healable || detail.Reason != "" || detail.HealthyDisks != len(fsDirs) || detail.DataBlocks != 8 || detail.ParityBlocks != 8 {
+		t.Fatalf("Unexpected heal detail %#v", detail)
+	}
+	for i, d := range detail.Disks {
+		if d.ErasureIndex != detail.Distribution[i] {
+			t.Fatalf("Expected disk %d to hold shard %d but got %d", i, detail.Distribution[i], d.ErasureIndex)
+		}
+	}
+
+	// Corrupt the data on 7 disks, the 9 others are enough to
+	// rebuild them.
+	disks := xl.getDisks()
+	for i := 0; i < 7; i++ {
+		if err = disks[i].AppendFile(bucket, filepath.Join(object, "part.1"), []byte("corruption")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if detail, err = xl.healObjectDetail(ctx, bucket, object); err != nil {
+		t.Fatalf("Failed to get heal detail - %v", err)
+	}
+	if detail.Consistent || !detail.Healable || detail.HealthyDisks != 9 {
+		t.Fatalf("Unexpected heal detail %#v", detail)
+	}
+
+	// Corrupt two more disks, too few intact shards are left.
+	for i := 7; i < 9; i++ {
+		if err = disks[i].AppendFile(bucket, filepath.Join(object, "part.1"), []byte("corruption")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if detail, err = xl.healObjectDetail(ctx, bucket, object); err != nil {
+		t.Fatalf("Failed to get heal detail - %v", err)
+	}
+	if detail.Healable || detail.HealthyDisks != 7 || detail.Reason == "" {
+		t.Fatalf("Unexpected heal detail %#v", detail)
+	}
+}
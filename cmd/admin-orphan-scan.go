@@ -0,0 +1,317 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Maximum number of orphaned shards listed in the status of an orphan
+// scan, all of them are counted.
+const maxReportedOrphans = 1000
+
+var errOrphanScanStopSignalled = errors.New("orphan scan stop signaled")
+
+// orphanScanSequence - state of a scan of the disks for data shards
+// which are not referenced by the metadata of any object. Every server
+// of a distributed setup has access to all disks, so a single scan
+// covers the disks of all servers.
+type orphanScanSequence struct {
+	// bucket on which the scan was initiated, empty for all
+	// buckets
+	bucket string
+
+	// whether orphaned shards are deleted
+	reclaim bool
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.OrphanScanStatus
+
+	// channel to signal the scan to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// Time the status of an orphan scan is kept once it ended.
+const keepOrphanScanStatusDuration = time.Hour
+
+// orphanScanState - holds the orphan scan running on this server and
+// the ended ones until their status expires. Only one scan may run at
+// a time.
+type orphanScanState struct {
+	*adminScanRegistry
+}
+
+var globalOrphanScanState = orphanScanState{newAdminScanRegistry(keepOrphanScanStatusDuration)}
+
+// newOrphanScanSequence - creates an orphan scan, assumes bucket is
+// already validated.
+func newOrphanScanSequence(bucket string, reclaim bool, clientAddr string) *orphanScanSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "OrphanScan", BucketName: bucket}
+
+	return &orphanScanSequence{
+		bucket:  bucket,
+		reclaim: reclaim,
+		status: madmin.OrphanScanStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.OrphanScanRunning,
+			StartTime:   UTCNow(),
+			Reclaim:     reclaim,
+		},
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the scan unless another one is running. A running
+// scan is stopped first if forceStart is set.
+func (s *orphanScanState) Launch(seq *orphanScanSequence, sets *xlSets, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, func() { seq.run(sets) })
+}
+
+// Status - returns the status of the scan identified by clientToken.
+func (s *orphanScanState) Status(clientToken string) (madmin.OrphanScanStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.OrphanScanStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*orphanScanSequence)
+
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	status := seq.status
+	status.Orphans = append([]madmin.OrphanShard(nil), seq.status.Orphans...)
+	return status, ErrNone
+}
+
+func (seq *orphanScanSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *orphanScanSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.OrphanScanRunning
+}
+
+// stop - stops the scan, safe to call multiple times.
+func (seq *orphanScanSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *orphanScanSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// run - scans the disks of all erasure sets, bucket by bucket.
+func (seq *orphanScanSequence) run(sets *xlSets) {
+	err := seq.scan(sets)
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.OrphanScanStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.OrphanScanFinished
+}
+
+func (seq *orphanScanSequence) scan(sets *xlSets) error {
+	buckets := []string{seq.bucket}
+	if seq.bucket != "" {
+		if _, err := sets.GetBucketInfo(seq.ctx, seq.bucket); err != nil {
+			return err
+		}
+	} else {
+		bucketsInfo, err := sets.ListBuckets(seq.ctx)
+		if err != nil {
+			return err
+		}
+		buckets = buckets[:0]
+		for _, bucket := range bucketsInfo {
+			buckets = append(buckets, bucket.Name)
+		}
+	}
+
+	for _, bucket := range buckets {
+		for _, set := range sets.sets {
+			for _, disk := range set.getDisks() {
+				if disk == nil {
+					continue
+				}
+				if err := seq.scanDir(set, disk, bucket, ""); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isPartFile - returns true if the given file name is the name of a
+// data shard, e.g. `part.1`.
+func isPartFile(name string) bool {
+	if !strings.HasPrefix(name, "part.") {
+		return false
+	}
+	_, err := strconv.Atoi(strings.TrimPrefix(name, "part."))
+	return err == nil
+}
+
+// scanDir - walks a directory of a disk, looking for orphaned shards
+// in the directories of objects.
+func (seq *orphanScanSequence) scanDir(set *xlObjects, disk StorageAPI, bucket, dir string) error {
+	if seq.isQuitting() {
+		return errOrphanScanStopSignalled
+	}
+
+	entries, err := disk.ListDir(bucket, dir, -1)
+	if err != nil {
+		// The bucket or the directory may not exist on
+		// disks which are being healed or were just deleted.
+		if err == errVolumeNotFound || err == errFileNotFound {
+			return nil
+		}
+		return err
+	}
+
+	var parts []string
+	for _, entry := range entries {
+		if isPartFile(entry) {
+			parts = append(parts, entry)
+		}
+	}
+	if len(parts) > 0 || contains(entries, xlMetaJSONFile) {
+		// Objects may not be nested, this is the directory of
+		// an object.
+		object := strings.TrimSuffix(dir, slashSeparator)
+		seq.mu.Lock()
+		seq.status.ObjectsScanned++
+		seq.mu.Unlock()
+		if len(parts) == 0 {
+			return nil
+		}
+		return seq.checkObject(set, disk, bucket, object, parts)
+	}
+
+	for _, entry := range entries {
+		if hasSuffix(entry, slashSeparator) {
+			if err = seq.scanDir(set, disk, bucket, dir+entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkObject - reports, and reclaims if requested, the shards of an
+// object on a disk which are not referenced by the metadata of the
+// object on any disk of its erasure set. Objects whose metadata cannot
+// be read from enough disks are skipped, their shards may be in use.
+func (seq *orphanScanSequence) checkObject(set *xlObjects, disk StorageAPI, bucket, object string, parts []string) error {
+	objectLock := set.nsMutex.NewNSLock(bucket, object)
+	if seq.reclaim {
+		if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+			return err
+		}
+		defer objectLock.Unlock()
+	} else {
+		if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+			return err
+		}
+		defer objectLock.RUnlock()
+	}
+
+	disks := set.getDisks()
+	partsMetadata, errs := readAllXLMetadata(seq.ctx, disks, bucket, object)
+	if err := reduceReadQuorumErrs(seq.ctx, errs, objectOpIgnoredErrs, len(disks)/2); err != nil && err != errFileNotFound {
+		return nil
+	}
+
+	referenced := make(map[string]bool)
+	for i, xlMeta := range partsMetadata {
+		if errs[i] != nil {
+			continue
+		}
+		for _, part := range xlMeta.Parts {
+			referenced[part.Name] = true
+		}
+	}
+
+	for _, part := range parts {
+		if referenced[part] {
+			continue
+		}
+
+		orphan := madmin.OrphanShard{
+			Disk:   disk.String(),
+			Bucket: bucket,
+			Object: object,
+			Part:   part,
+		}
+		partPath := path.Join(object, part)
+		fi, err := disk.StatFile(bucket, partPath)
+		if err != nil {
+			orphan.Error = err.Error()
+		}
+		orphan.Size = fi.Size
+		if seq.reclaim && err == nil {
+			if err = disk.DeleteFile(bucket, partPath); err != nil {
+				orphan.Error = err.Error()
+			} else {
+				orphan.Reclaimed = true
+			}
+		}
+
+		seq.mu.Lock()
+		seq.status.OrphanCount++
+		seq.status.OrphanSize += orphan.Size
+		if orphan.Reclaimed {
+			seq.status.ReclaimedSize += orphan.Size
+		}
+		if len(seq.status.Orphans) < maxReportedOrphans {
+			seq.status.Orphans = append(seq.status.Orphans, orphan)
+		}
+		seq.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,463 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// File under minioMetaBucket holding the admin credentials along
+// with their policy.
+const adminCredentialsFile = "admin-credentials.json"
+
+// adminAction - permission required by an admin API endpoint.
+type adminAction string
+
+// Admin actions, each admin API handler declares the one it requires.
+const (
+	adminVersionAction               adminAction = "admin:Version"
+	adminServiceStatusAction         adminAction = "admin:ServiceStatus"
+	adminServiceStopRestartAction    adminAction = "admin:ServiceStopRestart"
+	adminServerInfoAction            adminAction = "admin:ServerInfo"
+	adminAPIErrorStatsAction         adminAction = "admin:APIErrorStats"
+	adminResetAPIErrorStatsAction    adminAction = "admin:ResetAPIErrorStats"
+	adminLogsBundleAction            adminAction = "admin:LogsBundle"
+	adminSlowRequestsAction          adminAction = "admin:SlowRequests"
+	adminMetricsHistoryAction        adminAction = "admin:MetricsHistory"
+	adminActiveRequestsAction        adminAction = "admin:ActiveRequests"
+	adminCancelRequestAction         adminAction = "admin:CancelRequest"
+	adminSimulatePolicyAction        adminAction = "admin:SimulatePolicy"
+	adminBucketsUsageAction          adminAction = "admin:BucketsUsage"
+	adminTestDiskAction              adminAction = "admin:TestDisk"
+	adminDiskDrainAction             adminAction = "admin:DiskDrain"
+	adminMultipartPolicyAction       adminAction = "admin:MultipartPolicy"
+	adminSetMinPartSizeAction        adminAction = "admin:SetMinPartSize"
+	adminBucketVerifyAction          adminAction = "admin:BucketVerify"
+	adminCompressionPolicyAction     adminAction = "admin:CompressionPolicy"
+	adminSetCompressionAction        adminAction = "admin:SetCompression"
+	adminClockSkewAction             adminAction = "admin:ClockSkew"
+	adminSetClockSkewAction          adminAction = "admin:SetClockSkew"
+	adminSpeedtestAction             adminAction = "admin:Speedtest"
+	adminNetPerfAction               adminAction = "admin:NetPerf"
+	adminNodeStatusAction            adminAction = "admin:NodeStatus"
+	adminChaosAction                 adminAction = "admin:Chaos"
+	adminConfirmAction               adminAction = "admin:Confirm"
+	adminVerifyObjectAction          adminAction = "admin:VerifyObject"
+	adminHealDetailAction            adminAction = "admin:HealDetail"
+	adminStorageClassInfoAction      adminAction = "admin:StorageClassInfo"
+	adminGetBucketStorageClassAction adminAction = "admin:GetBucketStorageClass"
+	adminSetBucketStorageClassAction adminAction = "admin:SetBucketStorageClass"
+	adminNotifyReplayAction          adminAction = "admin:NotifyReplay"
+	adminNotifyQueuesAction          adminAction = "admin:NotifyQueues"
+	adminGetLogLevelAction           adminAction = "admin:GetLogLevel"
+	adminSetLogLevelAction           adminAction = "admin:SetLogLevel"
+	adminScanDuplicatesAction        adminAction = "admin:ScanDuplicates"
+	adminScanOrphansAction           adminAction = "admin:ScanOrphans"
+	adminChecksumAction              adminAction = "admin:Checksum"
+	adminSnapshotAction              adminAction = "admin:Snapshot"
+	adminFsckAction                  adminAction = "admin:Fsck"
+	adminCountObjectsAction          adminAction = "admin:CountObjects"
+	adminGetRateLimitsAction         adminAction = "admin:GetRateLimits"
+	adminSetRateLimitAction          adminAction = "admin:SetRateLimit"
+	adminGetScannerAction            adminAction = "admin:GetScanner"
+	adminSetScannerAction            adminAction = "admin:SetScanner"
+	adminRefreshUsageAction          adminAction = "admin:RefreshUsage"
+	adminFederationAction            adminAction = "admin:Federation"
+	adminHealAction                  adminAction = "admin:Heal"
+	adminAutoHealStatusAction        adminAction = "admin:AutoHealStatus"
+	adminSetAutoHealAction           adminAction = "admin:SetAutoHeal"
+	adminGetConfigAction             adminAction = "admin:GetConfig"
+	adminSetConfigAction             adminAction = "admin:SetConfig"
+	adminConfigConsistencyAction     adminAction = "admin:ConfigConsistency"
+	adminConfigStatusAction          adminAction = "admin:ConfigStatus"
+	adminReloadTLSCertsAction        adminAction = "admin:ReloadTLSCerts"
+	adminExplainAccessAction         adminAction = "admin:ExplainAccess"
+	adminValidatePostPolicyAction    adminAction = "admin:ValidatePostPolicy"
+	adminUploadsUsageAction          adminAction = "admin:UploadsUsage"
+	adminDisksUsageAction            adminAction = "admin:DisksUsage"
+	adminDisksLayoutAction           adminAction = "admin:DisksLayout"
+	adminConfigWatchAction           adminAction = "admin:ConfigWatch"
+	adminBitrotStatsAction           adminAction = "admin:BitrotStats"
+	adminResetBitrotStatsAction      adminAction = "admin:ResetBitrotStats"
+	adminConsistencyTestAction       adminAction = "admin:ConsistencyTest"
+	adminParityInfoAction            adminAction = "admin:ParityInfo"
+	adminSetParityAction             adminAction = "admin:SetParity"
+	adminConnectionsAction           adminAction = "admin:Connections"
+	adminGetResponseHeadersAction    adminAction = "admin:GetResponseHeaders"
+	adminSetResponseHeadersAction    adminAction = "admin:SetResponseHeaders"
+	adminAdmissionStatusAction       adminAction = "admin:AdmissionStatus"
+	adminSetMaxRequestsAction        adminAction = "admin:SetMaxRequests"
+	adminGetServerInfoExportAction   adminAction = "admin:GetServerInfoExport"
+	adminSetServerInfoExportAction   adminAction = "admin:SetServerInfoExport"
+	adminUpdateCredentialsAction     adminAction = "admin:UpdateCredentials"
+	adminListAdminCredentialsAction  adminAction = "admin:ListAdminCredentials"
+	adminSetAdminCredentialAction    adminAction = "admin:SetAdminCredential"
+	adminRemoveAdminCredentialAction adminAction = "admin:RemoveAdminCredential"
+)
+
+var supportedAdminActions = map[adminAction]struct{}{
+	adminVersionAction:               {},
+	adminServiceStatusAction:         {},
+	adminServiceStopRestartAction:    {},
+	adminServerInfoAction:            {},
+	adminAPIErrorStatsAction:         {},
+	adminResetAPIErrorStatsAction:    {},
+	adminLogsBundleAction:            {},
+	adminSlowRequestsAction:          {},
+	adminMetricsHistoryAction:        {},
+	adminActiveRequestsAction:        {},
+	adminCancelRequestAction:         {},
+	adminSimulatePolicyAction:        {},
+	adminBucketsUsageAction:          {},
+	adminTestDiskAction:              {},
+	adminDiskDrainAction:             {},
+	adminMultipartPolicyAction:       {},
+	adminSetMinPartSizeAction:        {},
+	adminBucketVerifyAction:          {},
+	adminCompressionPolicyAction:     {},
+	adminSetCompressionAction:        {},
+	adminClockSkewAction:             {},
+	adminSetClockSkewAction:          {},
+	adminSpeedtestAction:             {},
+	adminNetPerfAction:               {},
+	adminNodeStatusAction:            {},
+	adminChaosAction:                 {},
+	adminConfirmAction:               {},
+	adminVerifyObjectAction:          {},
+	adminHealDetailAction:            {},
+	adminStorageClassInfoAction:      {},
+	adminGetBucketStorageClassAction: {},
+	adminSetBucketStorageClassAction: {},
+	adminNotifyReplayAction:          {},
+	adminNotifyQueuesAction:          {},
+	adminGetLogLevelAction:           {},
+	adminSetLogLevelAction:           {},
+	adminScanDuplicatesAction:        {},
+	adminScanOrphansAction:           {},
+	adminChecksumAction:              {},
+	adminSnapshotAction:              {},
+	adminFsckAction:                  {},
+	adminCountObjectsAction:          {},
+	adminGetRateLimitsAction:         {},
+	adminSetRateLimitAction:          {},
+	adminGetScannerAction:            {},
+	adminSetScannerAction:            {},
+	adminRefreshUsageAction:          {},
+	adminFederationAction:            {},
+	adminHealAction:                  {},
+	adminAutoHealStatusAction:        {},
+	adminSetAutoHealAction:           {},
+	adminGetConfigAction:             {},
+	adminSetConfigAction:             {},
+	adminConfigConsistencyAction:     {},
+	adminConfigStatusAction:          {},
+	adminReloadTLSCertsAction:        {},
+	adminExplainAccessAction:         {},
+	adminValidatePostPolicyAction:    {},
+	adminUploadsUsageAction:          {},
+	adminDisksUsageAction:            {},
+	adminDisksLayoutAction:           {},
+	adminConfigWatchAction:           {},
+	adminBitrotStatsAction:           {},
+	adminResetBitrotStatsAction:      {},
+	adminConsistencyTestAction:       {},
+	adminParityInfoAction:            {},
+	adminSetParityAction:             {},
+	adminConnectionsAction:           {},
+	adminGetResponseHeadersAction:    {},
+	adminSetResponseHeadersAction:    {},
+	adminAdmissionStatusAction:       {},
+	adminSetMaxRequestsAction:        {},
+	adminGetServerInfoExportAction:   {},
+	adminSetServerInfoExportAction:   {},
+	adminUpdateCredentialsAction:     {},
+	adminListAdminCredentialsAction:  {},
+	adminSetAdminCredentialAction:    {},
+	adminRemoveAdminCredentialAction: {},
+}
+
+var (
+	errInvalidAdminPolicy    = errors.New("admin policy statements must have an Allow or Deny effect and known actions")
+	errAdminCredentialIsRoot = errors.New("admin credential cannot use the access key of the server")
+	errNoSuchAdminCredential = errors.New("no such admin credential")
+)
+
+// validateAdminPolicy - returns an error if a statement of the policy
+// has an unknown effect or action. Actions ending with "*" are
+// patterns matching all actions with the same prefix.
+func validateAdminPolicy(policy madmin.AdminPolicy) error {
+	for _, statement := range policy.Statement {
+		if statement.Effect != madmin.AdminPolicyAllow && statement.Effect != madmin.AdminPolicyDeny {
+			return errInvalidAdminPolicy
+		}
+		if len(statement.Action) == 0 {
+			return errInvalidAdminPolicy
+		}
+		for _, action := range statement.Action {
+			if strings.HasSuffix(action, "*") {
+				continue
+			}
+			if _, ok := supportedAdminActions[adminAction(action)]; !ok {
+				return errInvalidAdminPolicy
+			}
+		}
+	}
+	return nil
+}
+
+// isAdminActionAllowed - returns whether the policy permits an admin
+// action, that is if a statement allows it and none denies it.
+func isAdminActionAllowed(policy madmin.AdminPolicy, action adminAction) bool {
+	allowed := false
+	for _, statement := range policy.Statement {
+		for _, pattern := range statement.Action {
+			if !wildcard.MatchSimple(pattern, string(action)) {
+				continue
+			}
+			if statement.Effect == madmin.AdminPolicyDeny {
+				return false
+			}
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// isAdminPolicyGrantable - returns whether a credential with the
+// grantor policy may grant the given policy, that is if all admin
+// actions permitted by the policy are permitted by the grantor policy.
+func isAdminPolicyGrantable(policy, grantor madmin.AdminPolicy) bool {
+	for action := range supportedAdminActions {
+		if isAdminActionAllowed(policy, action) && !isAdminActionAllowed(grantor, action) {
+			return false
+		}
+	}
+	return true
+}
+
+// adminCredentialStore - admin credentials of this server, keyed by
+// access key.
+type adminCredentialStore struct {
+	sync.RWMutex
+	creds map[string]madmin.AdminCredential
+}
+
+// get - returns the admin credential of an access key.
+func (s *adminCredentialStore) get(accessKey string) (madmin.AdminCredential, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	cred, ok := s.creds[accessKey]
+	return cred, ok
+}
+
+// set - replaces all admin credentials.
+func (s *adminCredentialStore) set(creds []madmin.AdminCredential) {
+	newCreds := make(map[string]madmin.AdminCredential, len(creds))
+	for _, cred := range creds {
+		newCreds[cred.AccessKey] = cred
+	}
+
+	s.Lock()
+	s.creds = newCreds
+	s.Unlock()
+}
+
+// list - returns the admin credentials without their secret key,
+// sorted by access key.
+func (s *adminCredentialStore) list() []madmin.AdminCredential {
+	s.RLock()
+	defer s.RUnlock()
+
+	creds := make([]madmin.AdminCredential, 0, len(s.creds))
+	for _, cred := range s.creds {
+		cred.SecretKey = ""
+		creds = append(creds, cred)
+	}
+	sort.Slice(creds, func(i, j int) bool {
+		return creds[i].AccessKey < creds[j].AccessKey
+	})
+	return creds
+}
+
+// Prepare new adminCredentialStore structure
+func newAdminCredentialStore() *adminCredentialStore {
+	return &adminCredentialStore{creds: make(map[string]madmin.AdminCredential)}
+}
+
+// getAdminRequestCredential - returns the credential which signed an
+// admin request, either the credential of the server or an admin
+// credential. The policy of the credential is also returned, nil for
+// the credential of the server which is permitted all actions.
+func getAdminRequestCredential(r *http.Request, region string) (auth.Credentials, *madmin.AdminPolicy, APIErrorCode) {
+	signV4Values, errCode := parseSignV4(r.Header.Get("Authorization"), region)
+	if errCode != ErrNone {
+		return auth.Credentials{}, nil, errCode
+	}
+
+	// Unless set by the environment, the credentials are unknown
+	// until the config is loaded while the server starts.
+	if globalServerConfig == nil && !globalActiveCred.IsValid() {
+		return auth.Credentials{}, nil, ErrServerNotInitialized
+	}
+
+	cred := globalServerConfig.GetCredential()
+	if signV4Values.Credential.accessKey == cred.AccessKey {
+		return cred, nil, ErrNone
+	}
+
+	adminCred, ok := globalAdminCredentials.get(signV4Values.Credential.accessKey)
+	if !ok {
+		return auth.Credentials{}, nil, ErrInvalidAccessKeyID
+	}
+	return auth.Credentials{AccessKey: adminCred.AccessKey, SecretKey: adminCred.SecretKey}, &adminCred.Policy, ErrNone
+}
+
+// getAdminRequestSecretKey - returns the secret key of the credential
+// which signed an authenticated admin request, used to encrypt and
+// decrypt the config in request and response bodies.
+func getAdminRequestSecretKey(r *http.Request) string {
+	cred, _, errCode := getAdminRequestCredential(r, "")
+	if errCode != ErrNone {
+		return globalServerConfig.GetCredential().SecretKey
+	}
+	return cred.SecretKey
+}
+
+// isRootAdminRequest - returns whether an admin request is signed with
+// the credential of the server, rather than with an admin credential.
+func isRootAdminRequest(r *http.Request) bool {
+	_, adminPolicy, errCode := getAdminRequestCredential(r, "")
+	return errCode == ErrNone && adminPolicy == nil
+}
+
+// validateAdminCredential - returns an error if an admin credential
+// has an invalid access or secret key, uses the access key of the
+// server or has an invalid policy.
+func validateAdminCredential(cred madmin.AdminCredential) error {
+	if _, err := auth.CreateCredentials(cred.AccessKey, cred.SecretKey); err != nil {
+		return err
+	}
+	if cred.AccessKey == globalServerConfig.GetCredential().AccessKey {
+		return errAdminCredentialIsRoot
+	}
+	return validateAdminPolicy(cred.Policy)
+}
+
+// readAdminCredentials - reads the saved admin credentials.
+func readAdminCredentials(ctx context.Context, objAPI ObjectLayer) ([]madmin.AdminCredential, error) {
+	reader, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, adminCredentialsFile))
+	if err == errConfigNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []madmin.AdminCredential
+	if err = json.Unmarshal(data, &creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// saveAdminCredentials - saves the admin credentials.
+func saveAdminCredentials(objAPI ObjectLayer, creds []madmin.AdminCredential) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	return saveConfig(objAPI, path.Join(minioConfigPrefix, adminCredentialsFile), data)
+}
+
+// updateAdminCredentials - saves the admin credentials updated by the
+// given function while they are locked on all servers.
+func updateAdminCredentials(ctx context.Context, objAPI ObjectLayer, update func([]madmin.AdminCredential) ([]madmin.AdminCredential, error)) error {
+	unlock, err := lockConfigFile(path.Join(minioConfigPrefix, adminCredentialsFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	creds, err := readAdminCredentials(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if creds, err = update(creds); err != nil {
+		return err
+	}
+	return saveAdminCredentials(objAPI, creds)
+}
+
+// setAdminCredential - saves an admin credential, replacing the one
+// with the same access key if any.
+func setAdminCredential(ctx context.Context, objAPI ObjectLayer, cred madmin.AdminCredential) error {
+	return updateAdminCredentials(ctx, objAPI, func(creds []madmin.AdminCredential) ([]madmin.AdminCredential, error) {
+		updated := []madmin.AdminCredential{cred}
+		for _, c := range creds {
+			if c.AccessKey != cred.AccessKey {
+				updated = append(updated, c)
+			}
+		}
+		return updated, nil
+	})
+}
+
+// removeAdminCredential - removes the saved admin credential of an
+// access key.
+func removeAdminCredential(ctx context.Context, objAPI ObjectLayer, accessKey string) error {
+	return updateAdminCredentials(ctx, objAPI, func(creds []madmin.AdminCredential) ([]madmin.AdminCredential, error) {
+		var updated []madmin.AdminCredential
+		for _, c := range creds {
+			if c.AccessKey != accessKey {
+				updated = append(updated, c)
+			}
+		}
+		if len(updated) == len(creds) {
+			return nil, errNoSuchAdminCredential
+		}
+		return updated, nil
+	})
+}
+
+// loadAdminCredentials - loads the saved admin credentials into the
+// credential store of this server.
+func loadAdminCredentials(objAPI ObjectLayer) error {
+	creds, err := readAdminCredentials(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	globalAdminCredentials.set(creds)
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests that denied actions take precedence over allowed actions and
+// that actions are denied by default.
+func TestIsAdminActionAllowed(t *testing.T) {
+	policy := madmin.AdminPolicy{Statement: []madmin.AdminPolicyStatement{
+		{Effect: madmin.AdminPolicyAllow, Action: []string{"admin:Get*", "admin:ServerInfo"}},
+		{Effect: madmin.AdminPolicyDeny, Action: []string{"admin:GetConfig"}},
+	}}
+
+	testCases := []struct {
+		policy        madmin.AdminPolicy
+		action        adminAction
+		expectedAllow bool
+	}{
+		{policy, adminServerInfoAction, true},
+		{policy, adminGetLogLevelAction, true},
+		{policy, adminGetConfigAction, false},
+		{policy, adminSetConfigAction, false},
+		{madmin.AdminPolicy{}, adminServerInfoAction, false},
+		{madmin.AdminPolicy{Statement: []madmin.AdminPolicyStatement{
+			{Effect: madmin.AdminPolicyAllow, Action: []string{"*"}},
+		}}, adminUpdateCredentialsAction, true},
+	}
+	for i, testCase := range testCases {
+		if allow := isAdminActionAllowed(testCase.policy, testCase.action); allow != testCase.expectedAllow {
+			t.Errorf("Test %d: Expected allow %v but got %v", i+1, testCase.expectedAllow, allow)
+		}
+	}
+}
+
+func TestValidateAdminPolicy(t *testing.T) {
+	testCases := []struct {
+		statement  madmin.AdminPolicyStatement
+		shouldPass bool
+	}{
+		{madmin.AdminPolicyStatement{Effect: madmin.AdminPolicyAllow, Action: []string{"admin:ServerInfo"}}, true},
+		{madmin.AdminPolicyStatement{Effect: madmin.AdminPolicyDeny, Action: []string{"admin:Scan*"}}, true},
+		{madmin.AdminPolicyStatement{Effect: "Maybe", Action: []string{"admin:ServerInfo"}}, false},
+		{madmin.AdminPolicyStatement{Effect: madmin.AdminPolicyAllow}, false},
+		{madmin.AdminPolicyStatement{Effect: madmin.AdminPolicyAllow, Action: []string{"admin:Unknown"}}, false},
+	}
+	for i, testCase := range testCases {
+		policy := madmin.AdminPolicy{Statement: []madmin.AdminPolicyStatement{testCase.statement}}
+		if err := validateAdminPolicy(policy); (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+	}
+}
+
+// Tests that listed admin credentials do not hold their secret key.
+func TestAdminCredentialStoreList(t *testing.T) {
+	s := newAdminCredentialStore()
+	s.set([]madmin.AdminCredential{
+		{AccessKey: "zeta", SecretKey: "zeta-secret"},
+		{AccessKey: "alpha", SecretKey: "alpha-secret"},
+	})
+
+	creds := s.list()
+	if len(creds) != 2 || creds[0].AccessKey != "alpha" || creds[1].AccessKey != "zeta" {
+		t.Fatalf("Unexpected admin credentials %v", creds)
+	}
+	for _, cred := range creds {
+		if cred.SecretKey != "" {
+			t.Errorf("Expected secret key of %s to be hidden", cred.AccessKey)
+		}
+	}
+	if cred, ok := s.get("alpha"); !ok || cred.SecretKey != "alpha-secret" {
+		t.Errorf("Expected admin credential alpha, got %v", cred)
+	}
+}
+
+// Tests that updates of the saved admin credentials wait for the lock
+// of the file held by any server.
+func TestUpdateAdminCredentialsLock(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	initNSLock(false)
+
+	unlock, err := lockConfigFile(path.Join(minioConfigPrefix, adminCredentialsFile))
+	if err != nil {
+		t.Fatalf("Unable to lock the admin credentials: %v", err)
+	}
+
+	cred := madmin.AdminCredential{AccessKey: "operator", SecretKey: "operator-secret"}
+	saved := make(chan error)
+	go func() {
+		saved <- setAdminCredential(context.Background(), objLayer, cred)
+	}()
+
+	select {
+	case <-saved:
+		t.Fatal("Expected the update of the admin credentials to wait for the lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	if err = <-saved; err != nil {
+		t.Fatalf("Unable to save the admin credential once unlocked: %v", err)
+	}
+	creds, err := readAdminCredentials(context.Background(), objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(creds) != 1 || creds[0].AccessKey != cred.AccessKey {
+		t.Fatalf("Expected admin credential %s to be saved, got %v", cred.AccessKey, creds)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Environment variable enabling the signature of admin
+	// responses.
+	adminSignResponsesEnv = "MINIO_ADMIN_SIGN_RESPONSES"
+
+	// Headers carrying the signature of admin responses, the
+	// signature header has the same format as the Authorization
+	// header of signature V4 requests.
+	adminResponseDateHeader      = "X-Minio-Response-Date"
+	adminResponseSignatureHeader = "X-Minio-Response-Signature"
+)
+
+// getAdminResponseStringToSign - returns the string to sign of the
+// response body to a request, binding the body to the method and
+// path of the request.
+func getAdminResponseStringToSign(method, urlPath string, body []byte, t time.Time, scope string) string {
+	bodySum := sha256.Sum256(body)
+	canonicalResponse := strings.Join([]string{
+		method,
+		urlPath,
+		hex.EncodeToString(bodySum[:]),
+	}, "\n")
+	return getStringToSign(canonicalResponse, t, scope)
+}
+
+// signAdminResponse - signs the body of the response to r with the
+// secret key of the server, the way signature V4 requests are signed,
+// and sets the signature headers so that clients can verify the
+// response was not tampered with.
+func signAdminResponse(w http.ResponseWriter, r *http.Request, body []byte) {
+	cred := globalServerConfig.GetCredential()
+	region := globalServerConfig.GetRegion()
+	t := UTCNow()
+	scope := getScope(t, region)
+
+	stringToSign := getAdminResponseStringToSign(r.Method, r.URL.Path, body, t, scope)
+	signature := getSignature(getSigningKey(cred.SecretKey, t, region), stringToSign)
+
+	w.Header().Set(adminResponseDateHeader, t.Format(iso8601Format))
+	w.Header().Set(adminResponseSignatureHeader, fmt.Sprintf("%s Credential=%s/%s, Signature=%s",
+		signV4Algorithm, cred.AccessKey, scope, signature))
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests that server info responses are signed when enabled, and that
+// the admin client detects unsigned and tampered responses.
+func TestSignAdminResponse(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	initGlobalAdminPeers(globalEndpoints)
+	defer func() { globalAdminSignResponses = false }()
+
+	// Flips a byte of the response body on the way to the client.
+	var tamper bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, r)
+		body := rec.Body.Bytes()
+		if tamper && len(body) > 0 {
+			body = append([]byte{}, body...)
+			body[len(body)-1] = ' '
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := globalServerConfig.GetCredential()
+	client, err := madmin.New(u.Host, cred.AccessKey, cred.SecretKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unsigned responses are accepted unless signatures are required.
+	if _, err = client.ServerInfo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	client.RequireSignedResponses(true)
+	if _, err = client.ServerInfo(); err != madmin.ErrResponseNotSigned {
+		t.Fatalf("Expected %v, got %v", madmin.ErrResponseNotSigned, err)
+	}
+
+	globalAdminSignResponses = true
+	if _, err = client.ServerInfo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err = client.ServerInfoRollup(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tamper = true
+	if _, err = client.ServerInfo(); err != madmin.ErrResponseSignatureMismatch {
+		t.Fatalf("Expected %v, got %v", madmin.ErrResponseSignatureMismatch, err)
+	}
+}
+
+// Tests that the signature binds the body to the request path.
+func TestAdminResponseStringToSign(t *testing.T) {
+	now := UTCNow()
+	scope := getScope(now, globalMinioDefaultRegion)
+	s := getAdminResponseStringToSign(http.MethodGet, "/minio/admin/v1/info", []byte("{}"), now, scope)
+	if s == getAdminResponseStringToSign(http.MethodGet, "/minio/admin/v1/storageinfo", []byte("{}"), now, scope) {
+		t.Error("Expected different strings to sign for different paths")
+	}
+	if s == getAdminResponseStringToSign(http.MethodGet, "/minio/admin/v1/info", []byte("[]"), now, scope) {
+		t.Error("Expected different strings to sign for different bodies")
+	}
+	if !bytes.HasPrefix([]byte(s), []byte(signV4Algorithm+"\n")) {
+		t.Errorf("Unexpected string to sign %q", s)
+	}
+}
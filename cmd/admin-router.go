@@ -17,15 +17,49 @@
 package cmd
 
 import (
+	"fmt"
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/gorilla/mux"
 )
 
 const (
 	adminAPIPathPrefix = "/minio/admin"
+
+	// Environment variable mounting the admin API under another path
+	// of the reserved `/minio` namespace, such as "/minio/ops".
+	adminAPIPathEnv = "MINIO_ADMIN_API_PATH"
 )
 
+// Paths of the reserved `/minio` namespace served by other routers,
+// the admin API cannot be mounted under them.
+var reservedAdminAPIPaths = []string{
+	"lock", "storage", "s3", "health", "prometheus", "webrpc", "upload", "download", "zip",
+}
+
+// Path the admin API is mounted under, adminAPIPathPrefix unless set
+// by MINIO_ADMIN_API_PATH.
+var globalAdminAPIPathPrefix = adminAPIPathPrefix
+
+// parseAdminAPIPathPrefix - parses the path the admin API is mounted
+// under. It must stay within the reserved `/minio` namespace so that
+// it never shadows a bucket, and apart from the paths of the other
+// routers.
+func parseAdminAPIPathPrefix(s string) (string, error) {
+	if s != path.Clean(s) || !strings.HasPrefix(s, minioReservedBucketPath+"/") {
+		return "", fmt.Errorf("path `%s` must be a clean path under %s/", s, minioReservedBucketPath)
+	}
+	first := strings.SplitN(strings.TrimPrefix(s, minioReservedBucketPath+"/"), "/", 2)[0]
+	for _, reserved := range reservedAdminAPIPaths {
+		if first == reserved {
+			return "", fmt.Errorf("path `%s` conflicts with %s/%s", s, minioReservedBucketPath, reserved)
+		}
+	}
+	return s, nil
+}
+
 // adminAPIHandlers provides HTTP handlers for Minio admin API.
 type adminAPIHandlers struct {
 }
@@ -35,7 +69,7 @@ func registerAdminRouter(router *mux.Router) {
 
 	adminAPI := adminAPIHandlers{}
 	// Admin router
-	adminRouter := router.PathPrefix(adminAPIPathPrefix).Subrouter()
+	adminRouter := router.PathPrefix(globalAdminAPIPathPrefix).Subrouter()
 
 	// Version handler
 	adminRouter.Methods(http.MethodGet).Path("/version").HandlerFunc(httpTraceAll(adminAPI.VersionHandler))
@@ -50,9 +84,166 @@ func registerAdminRouter(router *mux.Router) {
 	// Service restart and stop - TODO
 	adminV1Router.Methods(http.MethodPost).Path("/service").HandlerFunc(httpTraceAll(adminAPI.ServiceStopNRestartHandler))
 
+	// Confirmation token of destructive actions
+	adminV1Router.Methods(http.MethodPost).Path("/confirm").HandlerFunc(httpTraceAll(adminAPI.ConfirmActionHandler))
+
 	// Info operations
 	adminV1Router.Methods(http.MethodGet).Path("/info").HandlerFunc(httpTraceAll(adminAPI.ServerInfoHandler))
 
+	// Periodic export of the server information
+	adminV1Router.Methods(http.MethodGet).Path("/info/export").HandlerFunc(httpTraceAll(adminAPI.ServerInfoExportStatusHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/info/export").HandlerFunc(httpTraceAll(adminAPI.SetServerInfoExportHandler))
+
+	// Error responses statistics
+	adminV1Router.Methods(http.MethodGet).Path("/stats/errors").HandlerFunc(httpTraceAll(adminAPI.APIErrorStatsHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/stats/errors").HandlerFunc(httpTraceAll(adminAPI.ResetAPIErrorStatsHandler))
+
+	// Slow requests
+	adminV1Router.Methods(http.MethodGet).Path("/stats/slow").HandlerFunc(httpTraceAll(adminAPI.SlowRequestsHandler))
+
+	// Metrics history
+	adminV1Router.Methods(http.MethodGet).Path("/metrics/history").HandlerFunc(httpTraceAll(adminAPI.MetricsHistoryHandler))
+
+	// Logs bundle for support
+	adminV1Router.Methods(http.MethodGet).Path("/logs/bundle").HandlerFunc(httpTraceHdrs(adminAPI.LogsBundleHandler))
+
+	// Active requests
+	adminV1Router.Methods(http.MethodGet).Path("/requests/active").HandlerFunc(httpTraceAll(adminAPI.ActiveRequestsHandler))
+	adminV1Router.Methods(http.MethodDelete).Path("/requests/active").HandlerFunc(httpTraceAll(adminAPI.CancelRequestHandler))
+
+	// Admission control of requests
+	adminV1Router.Methods(http.MethodGet).Path("/admission").HandlerFunc(httpTraceAll(adminAPI.AdmissionStatusHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/admission").HandlerFunc(httpTraceAll(adminAPI.SetMaxRequestsHandler))
+
+	// Bucket policy simulation
+	adminV1Router.Methods(http.MethodPost).Path("/policy/simulate").HandlerFunc(httpTraceAll(adminAPI.SimulatePolicyHandler))
+
+	// Access explanation
+	adminV1Router.Methods(http.MethodGet).Path("/access/explain").HandlerFunc(httpTraceAll(adminAPI.ExplainAccessHandler))
+
+	// POST policy validation
+	adminV1Router.Methods(http.MethodPost).Path("/presign/validate").HandlerFunc(httpTraceAll(adminAPI.ValidatePostPolicyHandler))
+
+	// Buckets usage
+	adminV1Router.Methods(http.MethodGet).Path("/buckets").HandlerFunc(httpTraceAll(adminAPI.BucketsUsageHandler))
+
+	// In-progress multipart uploads usage
+	adminV1Router.Methods(http.MethodGet).Path("/uploads/usage").HandlerFunc(httpTraceAll(adminAPI.UploadsUsageHandler))
+
+	// Disks capacity and usage
+	adminV1Router.Methods(http.MethodGet).Path("/disks/usage").HandlerFunc(httpTraceAll(adminAPI.DisksUsageHandler))
+
+	// Expected and actual position of disks in the erasure sets
+	adminV1Router.Methods(http.MethodGet).Path("/disks/layout").HandlerFunc(httpTraceAll(adminAPI.DisksLayoutHandler))
+
+	// Reason a node or its disks are offline
+	adminV1Router.Methods(http.MethodGet).Path("/node/{node}/status").HandlerFunc(httpTraceAll(adminAPI.NodeStatusHandler))
+
+	// Bitrot detected on disks
+	adminV1Router.Methods(http.MethodGet).Path("/bitrot").HandlerFunc(httpTraceAll(adminAPI.BitrotStatsHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/bitrot").HandlerFunc(httpTraceAll(adminAPI.ResetBitrotStatsHandler))
+
+	// Read-after-write consistency test
+	adminV1Router.Methods(http.MethodPost).Path("/consistency/test").HandlerFunc(httpTraceAll(adminAPI.ConsistencyTestHandler))
+
+	// Open connections per client IP
+	adminV1Router.Methods(http.MethodGet).Path("/connections").HandlerFunc(httpTraceAll(adminAPI.ConnectionsHandler))
+
+	// Minimum part size of multipart uploads and part size stats
+	adminV1Router.Methods(http.MethodGet).Path("/multipart/policy").HandlerFunc(httpTraceAll(adminAPI.MultipartPolicyHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/multipart/policy").HandlerFunc(httpTraceAll(adminAPI.SetMinPartSizeHandler))
+
+	// Compression of GET responses and compression stats
+	adminV1Router.Methods(http.MethodGet).Path("/compression").HandlerFunc(httpTraceAll(adminAPI.CompressionPolicyHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/compression").HandlerFunc(httpTraceAll(adminAPI.SetCompressionHandler))
+
+	// Maximum clock skew of signed requests
+	adminV1Router.Methods(http.MethodGet).Path("/clockskew").HandlerFunc(httpTraceAll(adminAPI.ClockSkewHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/clockskew").HandlerFunc(httpTraceAll(adminAPI.SetClockSkewHandler))
+
+	// Storage class info
+	adminV1Router.Methods(http.MethodGet).Path("/storageclass").HandlerFunc(httpTraceAll(adminAPI.StorageClassInfoHandler))
+
+	// Parity of new writes
+	adminV1Router.Methods(http.MethodGet).Path("/parity").HandlerFunc(httpTraceAll(adminAPI.ParityInfoHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/parity").HandlerFunc(httpTraceAll(adminAPI.SetParityHandler))
+
+	// Get and set default storage classes of buckets
+	adminV1Router.Methods(http.MethodGet).Path("/bucket/storageclass").HandlerFunc(httpTraceAll(adminAPI.BucketStorageClassesHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/bucket/storageclass").HandlerFunc(httpTraceAll(adminAPI.SetBucketStorageClassHandler))
+
+	// Disk read/write test
+	adminV1Router.Methods(http.MethodPost).Path("/disk/test").HandlerFunc(httpTraceAll(adminAPI.TestDiskHandler))
+
+	// Heal of the erasure set of a disk before its removal
+	adminV1Router.Methods(http.MethodPost).Path("/disk/drain").HandlerFunc(httpTraceAll(adminAPI.DrainDiskHandler))
+
+	// Cluster throughput benchmark
+	adminV1Router.Methods(http.MethodPost).Path("/speedtest").HandlerFunc(httpTraceAll(adminAPI.SpeedtestHandler))
+
+	// Latency and bandwidth matrix of the links between servers
+	adminV1Router.Methods(http.MethodGet).Path("/netperf").HandlerFunc(httpTraceAll(adminAPI.NetPerfHandler))
+
+	// Simulated node failure for chaos testing
+	adminV1Router.Methods(http.MethodPost).Path("/chaos").HandlerFunc(httpTraceAll(adminAPI.SimulateNodeOfflineHandler))
+
+	/// Notification operations
+
+	// Replay undelivered events
+	adminV1Router.Methods(http.MethodPost).Path("/notify/replay").HandlerFunc(httpTraceAll(adminAPI.NotifyReplayHandler))
+
+	// Backlog of events of notification targets
+	adminV1Router.Methods(http.MethodGet).Path("/notify/queues").HandlerFunc(httpTraceAll(adminAPI.NotifyQueuesHandler))
+
+	/// Logging operations
+
+	// Get and set log levels
+	adminV1Router.Methods(http.MethodGet).Path("/loglevel").HandlerFunc(httpTraceAll(adminAPI.GetLogLevelHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/loglevel").HandlerFunc(httpTraceAll(adminAPI.SetLogLevelHandler))
+
+	/// Scan operations
+
+	// Find objects with identical content
+	adminV1Router.Methods(http.MethodPost).Path("/scan/duplicates").HandlerFunc(httpTraceAll(adminAPI.ScanDuplicatesHandler))
+
+	// Find, and optionally reclaim, data shards of no object
+	adminV1Router.Methods(http.MethodPost).Path("/scan/orphans").HandlerFunc(httpTraceAll(adminAPI.ScanOrphansHandler))
+
+	// Checksums of the objects under a prefix
+	adminV1Router.Methods(http.MethodPost).Path("/checksum").HandlerFunc(httpTraceAll(adminAPI.ChecksumHandler))
+
+	// Point-in-time manifest of the objects of a bucket
+	adminV1Router.Methods(http.MethodPost).Path("/snapshot").HandlerFunc(httpTraceAll(adminAPI.SnapshotHandler))
+
+	// Read-only consistency check of the backend
+	adminV1Router.Methods(http.MethodPost).Path("/fsck").HandlerFunc(httpTraceAll(adminAPI.FsckHandler))
+
+	// Object count
+	adminV1Router.Methods(http.MethodGet).Path("/count").HandlerFunc(httpTraceAll(adminAPI.CountObjectsHandler))
+
+	/// Rate limit operations
+
+	// Get and set request rate limits of tenants
+	adminV1Router.Methods(http.MethodGet).Path("/ratelimit").HandlerFunc(httpTraceAll(adminAPI.RateLimitsHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/ratelimit").HandlerFunc(httpTraceAll(adminAPI.SetRateLimitHandler))
+
+	// Schedule and progress of the background usage scans
+	adminV1Router.Methods(http.MethodGet).Path("/scanner").HandlerFunc(httpTraceAll(adminAPI.ScannerStatusHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/scanner").HandlerFunc(httpTraceAll(adminAPI.SetScannerScheduleHandler))
+
+	// Refresh the disk usage right away
+	adminV1Router.Methods(http.MethodPost).Path("/usage/refresh").HandlerFunc(httpTraceAll(adminAPI.RefreshUsageHandler))
+
+	// Buckets of the federation and reachability of its store
+	adminV1Router.Methods(http.MethodGet).Path("/federation").HandlerFunc(httpTraceAll(adminAPI.FederationStatusHandler))
+
+	// Custom headers added to all responses
+	adminV1Router.Methods(http.MethodGet).Path("/headers").HandlerFunc(httpTraceAll(adminAPI.ResponseHeadersHandler))
+	adminV1Router.Methods(http.MethodPost).Path("/headers").HandlerFunc(httpTraceAll(adminAPI.SetResponseHeadersHandler))
+
+	// Reload the TLS certificates
+	adminV1Router.Methods(http.MethodPost).Path("/tls/reload").HandlerFunc(httpTraceAll(adminAPI.ReloadTLSCertsHandler))
+
 	/// Heal operations
 
 	// Heal processing endpoint.
@@ -60,12 +251,62 @@ func registerAdminRouter(router *mux.Router) {
 	adminV1Router.Methods(http.MethodPost).Path("/heal/{bucket}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 	adminV1Router.Methods(http.MethodPost).Path("/heal/{bucket}/{prefix:.*}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 
+	// Pause or resume a heal sequence.
+	adminV1Router.Methods(http.MethodPut).Path("/heal/{clientToken}").HandlerFunc(httpTraceAll(adminAPI.HealActionHandler))
+
+	// Auto heal status endpoint.
+	adminV1Router.Methods(http.MethodGet).Path("/heal/auto").HandlerFunc(httpTraceAll(adminAPI.AutoHealStatusHandler))
+	// POST /heal/auto would heal the bucket named auto.
+	adminV1Router.Methods(http.MethodPost).Path("/autoheal").HandlerFunc(httpTraceAll(adminAPI.SetAutoHealHandler))
+
+	// Heal detail of an object.
+	adminV1Router.Methods(http.MethodGet).Path("/heal/detail").HandlerFunc(httpTraceAll(adminAPI.HealObjectDetailHandler))
+
+	// Verify the consistency of an object across disks.
+	adminV1Router.Methods(http.MethodGet).Path("/object/verify").HandlerFunc(httpTraceAll(adminAPI.VerifyObjectHandler))
+
+	// Verify and repair the buckets and their config files across disks.
+	adminV1Router.Methods(http.MethodPost).Path("/bucket/verify").HandlerFunc(httpTraceAll(adminAPI.VerifyBucketsHandler))
+
 	/// Config operations
 
 	// Update credentials
 	adminV1Router.Methods(http.MethodPut).Path("/config/credential").HandlerFunc(httpTraceHdrs(adminAPI.UpdateCredentialsHandler))
+	// Credentials restricted to some admin actions
+	adminV1Router.Methods(http.MethodGet).Path("/credentials/admin").HandlerFunc(httpTraceAll(adminAPI.ListAdminCredentialsHandler))
+	adminV1Router.Methods(http.MethodPut).Path("/credentials/admin").HandlerFunc(httpTraceHdrs(adminAPI.SetAdminCredentialHandler))
+	adminV1Router.Methods(http.MethodDelete).Path("/credentials/admin").HandlerFunc(httpTraceAll(adminAPI.RemoveAdminCredentialHandler))
 	// Get config
 	adminV1Router.Methods(http.MethodGet).Path("/config").HandlerFunc(httpTraceHdrs(adminAPI.GetConfigHandler))
 	// Set config
 	adminV1Router.Methods(http.MethodPut).Path("/config").HandlerFunc(httpTraceHdrs(adminAPI.SetConfigHandler))
+	// Update some sections of config
+	adminV1Router.Methods(http.MethodPatch).Path("/config").HandlerFunc(httpTraceHdrs(adminAPI.PatchConfigHandler))
+	// Cancel the delayed restart applying a new config
+	adminV1Router.Methods(http.MethodPost).Path("/config/restart/cancel").HandlerFunc(httpTraceAll(adminAPI.CancelConfigRestartHandler))
+	// Get config fields overridden by the environment
+	adminV1Router.Methods(http.MethodGet).Path("/config/env").HandlerFunc(httpTraceHdrs(adminAPI.GetConfigEnvHandler))
+	// Check that all servers run with the saved config
+	adminV1Router.Methods(http.MethodGet).Path("/config/consistency").HandlerFunc(httpTraceAll(adminAPI.ConfigConsistencyHandler))
+	// Compare the config of this server with the saved config
+	adminV1Router.Methods(http.MethodGet).Path("/config/status").HandlerFunc(httpTraceAll(adminAPI.ConfigStatusHandler))
+	// Stream the config changes persisted by this server
+	adminV1Router.Methods(http.MethodGet).Path("/config/watch").HandlerFunc(httpTraceHdrs(adminAPI.ConfigWatchHandler))
+}
+
+// registerGatewayAdminRouter - Add handler functions of the service REST
+// API routes available in gateway mode, other admin operations apply to
+// the storage of a minio server only.
+func registerGatewayAdminRouter(router *mux.Router) {
+	adminAPI := adminAPIHandlers{}
+	// Admin router
+	adminRouter := router.PathPrefix(globalAdminAPIPathPrefix).Subrouter()
+
+	// Version handler
+	adminRouter.Methods(http.MethodGet).Path("/version").HandlerFunc(httpTraceAll(adminAPI.VersionHandler))
+
+	adminV1Router := adminRouter.PathPrefix("/v1").Subrouter()
+
+	// Service status
+	adminV1Router.Methods(http.MethodGet).Path("/service").HandlerFunc(httpTraceAll(adminAPI.ServiceStatusHandler))
 }
@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests the validation of the path the admin API is mounted under.
+func TestParseAdminAPIPathPrefix(t *testing.T) {
+	testCases := []struct {
+		path      string
+		expectErr bool
+	}{
+		{"/minio/admin", false},
+		{"/minio/ops", false},
+		{"/minio/ops/admin", false},
+		{"/minio", true},
+		{"/minio/", true},
+		{"/minio/ops/", true},
+		{"/minio//ops", true},
+		{"/minio/ops/../admin", true},
+		{"/admin", true},
+		{"minio/ops", true},
+		{"/miniox/ops", true},
+		{"/minio/health", true},
+		{"/minio/storage/admin", true},
+		{"/minio/webrpc", true},
+	}
+	for i, testCase := range testCases {
+		prefix, err := parseAdminAPIPathPrefix(testCase.path)
+		if testCase.expectErr != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if err == nil && prefix != testCase.path {
+			t.Errorf("Test %d: expected path %s, got %s", i+1, testCase.path, prefix)
+		}
+	}
+}
+
+// Tests that the admin API is served under the configured path.
+func TestAdminAPIPathPrefix(t *testing.T) {
+	defer func(prefix string) { globalAdminAPIPathPrefix = prefix }(globalAdminAPIPathPrefix)
+	globalAdminAPIPathPrefix = "/minio/ops"
+
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	server := httptest.NewServer(adminTestBed.router)
+	defer server.Close()
+
+	if !isAdminReq(httptest.NewRequest(http.MethodGet, "/minio/ops/version", nil)) {
+		t.Fatal("Expected request under the configured path to be an admin request")
+	}
+	if isAdminReq(httptest.NewRequest(http.MethodGet, "/minio/admin/version", nil)) {
+		t.Fatal("Expected request under the default path not to be an admin request")
+	}
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := globalServerConfig.GetCredential()
+	client, err := madmin.New(u.Host, cred.AccessKey, cred.SecretKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.VersionInfo(); err == nil {
+		t.Fatal("Expected admin API not to be served under the default path")
+	}
+	client.SetAPIPathPrefix("/minio/ops/")
+	if _, err = client.VersionInfo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
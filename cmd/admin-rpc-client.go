@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -45,6 +46,21 @@ func (rpcClient *AdminRPCClient) SignalService(signal serviceSignal) (err error)
 	return rpcClient.Call(adminServiceName+".SignalService", &args, &reply)
 }
 
+// ScheduleRestart - calls ScheduleRestart RPC.
+func (rpcClient *AdminRPCClient) ScheduleRestart(delay time.Duration, token string) error {
+	args := ScheduleRestartArgs{Delay: delay, Token: token}
+	reply := VoidReply{}
+
+	return rpcClient.Call(adminServiceName+".ScheduleRestart", &args, &reply)
+}
+
+// CancelRestart - calls CancelRestart RPC.
+func (rpcClient *AdminRPCClient) CancelRestart(token string) (cancelled bool, err error) {
+	args := CancelRestartArgs{Token: token}
+	err = rpcClient.Call(adminServiceName+".CancelRestart", &args, &cancelled)
+	return cancelled, err
+}
+
 // ReInitFormat - re-initialize disk format, remotely.
 func (rpcClient *AdminRPCClient) ReInitFormat(dryRun bool) error {
 	args := ReInitFormatArgs{DryRun: dryRun}
@@ -68,6 +84,239 @@ func (rpcClient *AdminRPCClient) GetConfig() ([]byte, error) {
 	return reply, err
 }
 
+// SetLogLevel - sets the log level of a subsystem of the remote server.
+func (rpcClient *AdminRPCClient) SetLogLevel(subsystem string, level logger.Level) error {
+	args := SetLogLevelArgs{Subsystem: subsystem, Level: level}
+	reply := VoidReply{}
+
+	return rpcClient.Call(adminServiceName+".SetLogLevel", &args, &reply)
+}
+
+// APIErrorStats - returns the count of error responses per API error
+// code of the remote server, resetting them if reset is set.
+func (rpcClient *AdminRPCClient) APIErrorStats(reset bool) (counts map[string]uint64, err error) {
+	args := APIErrorStatsArgs{Reset: reset}
+	err = rpcClient.Call(adminServiceName+".APIErrorStats", &args, &counts)
+	return counts, err
+}
+
+// SlowRequests - returns the most recent slow requests of the remote
+// server.
+func (rpcClient *AdminRPCClient) SlowRequests() (requests []madmin.SlowRequest, err error) {
+	err = rpcClient.Call(adminServiceName+".SlowRequests", &AuthArgs{}, &requests)
+	return requests, err
+}
+
+// MetricsHistory - returns the metrics samples of the remote server
+// taken between from and to.
+func (rpcClient *AdminRPCClient) MetricsHistory(from, to time.Time) (samples []metricsSample, err error) {
+	args := MetricsHistoryArgs{From: from, To: to}
+	err = rpcClient.Call(adminServiceName+".MetricsHistory", &args, &samples)
+	return samples, err
+}
+
+// Speedtest - runs a speedtest on the remote server.
+func (rpcClient *AdminRPCClient) Speedtest(size int64, concurrency int, duration time.Duration) (result madmin.SpeedtestNodeResult, err error) {
+	args := SpeedtestArgs{Size: size, Concurrency: concurrency, Duration: duration}
+	err = rpcClient.Call(adminServiceName+".Speedtest", &args, &result)
+	return result, err
+}
+
+// NetPerf - measures the links from the remote server to the other
+// servers.
+func (rpcClient *AdminRPCClient) NetPerf(size int64) (links []madmin.NetPerfLink, err error) {
+	args := NetPerfArgs{Size: size}
+	err = rpcClient.Call(adminServiceName+".NetPerf", &args, &links)
+	return links, err
+}
+
+// NetPerfPing - sends a payload to the remote server, which discards
+// it, to measure the link to it.
+func (rpcClient *AdminRPCClient) NetPerfPing(payload []byte) error {
+	args := NetPerfPingArgs{Payload: payload}
+	return rpcClient.Call(adminServiceName+".NetPerfPing", &args, &VoidReply{})
+}
+
+// TestDisk - tests read and write on a disk of the remote server.
+func (rpcClient *AdminRPCClient) TestDisk(disk string) (result madmin.DiskTestResult, err error) {
+	args := TestDiskArgs{Disk: disk}
+	err = rpcClient.Call(adminServiceName+".TestDisk", &args, &result)
+	return result, err
+}
+
+// ActiveRequests - returns the requests being served by the remote
+// server.
+func (rpcClient *AdminRPCClient) ActiveRequests() (requests []madmin.ActiveRequest, err error) {
+	err = rpcClient.Call(adminServiceName+".ActiveRequests", &AuthArgs{}, &requests)
+	return requests, err
+}
+
+// CancelRequest - cancels a request being served by the remote server.
+func (rpcClient *AdminRPCClient) CancelRequest(requestID string) (cancelled bool, err error) {
+	args := CancelRequestArgs{RequestID: requestID}
+	err = rpcClient.Call(adminServiceName+".CancelRequest", &args, &cancelled)
+	return cancelled, err
+}
+
+// RecentLogs - returns the most recent log entries of the remote
+// server.
+func (rpcClient *AdminRPCClient) RecentLogs(lines int, since time.Time) (logs []string, err error) {
+	args := RecentLogsArgs{Lines: lines, Since: since}
+	err = rpcClient.Call(adminServiceName+".RecentLogs", &args, &logs)
+	return logs, err
+}
+
+// LoadRateLimits - makes the remote server load the saved rate limits
+// of tenants.
+func (rpcClient *AdminRPCClient) LoadRateLimits() error {
+	return rpcClient.Call(adminServiceName+".LoadRateLimits", &AuthArgs{}, &VoidReply{})
+}
+
+// RateLimits - returns the rate limits of tenants of the remote server
+// along with their observed request rate.
+func (rpcClient *AdminRPCClient) RateLimits() (limits []madmin.RateLimitStatus, err error) {
+	err = rpcClient.Call(adminServiceName+".RateLimits", &AuthArgs{}, &limits)
+	return limits, err
+}
+
+// LoadScannerSchedule - makes the remote server load the saved
+// schedule of the background usage scans.
+func (rpcClient *AdminRPCClient) LoadScannerSchedule() error {
+	return rpcClient.Call(adminServiceName+".LoadScannerSchedule", &AuthArgs{}, &VoidReply{})
+}
+
+// ScannerProgress - returns the progress of the background usage
+// scans of the remote server.
+func (rpcClient *AdminRPCClient) ScannerProgress() (scans []madmin.ScannerProgress, err error) {
+	err = rpcClient.Call(adminServiceName+".ScannerProgress", &AuthArgs{}, &scans)
+	return scans, err
+}
+
+// RefreshUsage - makes the background usage scans of the remote server
+// run again right away, returns the time of the request on the remote
+// server.
+func (rpcClient *AdminRPCClient) RefreshUsage() (requested time.Time, err error) {
+	err = rpcClient.Call(adminServiceName+".RefreshUsage", &AuthArgs{}, &requested)
+	return requested, err
+}
+
+// LoadAdminCredentials - makes the remote server load the saved admin
+// credentials.
+func (rpcClient *AdminRPCClient) LoadAdminCredentials() error {
+	return rpcClient.Call(adminServiceName+".LoadAdminCredentials", &AuthArgs{}, &VoidReply{})
+}
+
+// LoadConfigSection - makes the remote server load a section of the
+// config.json saved.
+func (rpcClient *AdminRPCClient) LoadConfigSection(section string) error {
+	args := LoadConfigSectionArgs{Section: section}
+	return rpcClient.Call(adminServiceName+".LoadConfigSection", &args, &VoidReply{})
+}
+
+// SimulateOffline - makes the remote server simulate being offline for
+// the given duration, returns the time it recovers at.
+func (rpcClient *AdminRPCClient) SimulateOffline(duration time.Duration) (until time.Time, err error) {
+	args := SimulateOfflineArgs{Duration: duration}
+	err = rpcClient.Call(adminServiceName+".SimulateOffline", &args, &until)
+	return until, err
+}
+
+// AdmissionStatus - returns the state of the admission control of the
+// remote server.
+func (rpcClient *AdminRPCClient) AdmissionStatus() (status madmin.AdmissionStatus, err error) {
+	err = rpcClient.Call(adminServiceName+".AdmissionStatus", &AuthArgs{}, &status)
+	return status, err
+}
+
+// SetMaxRequests - sets the maximum number of requests served at once
+// by the remote server.
+func (rpcClient *AdminRPCClient) SetMaxRequests(limit int) error {
+	args := SetMaxRequestsArgs{MaxRequests: limit}
+	return rpcClient.Call(adminServiceName+".SetMaxRequests", &args, &VoidReply{})
+}
+
+// LoadServerInfoExport - makes the remote server load the saved
+// configuration of the export of the server information.
+func (rpcClient *AdminRPCClient) LoadServerInfoExport() error {
+	return rpcClient.Call(adminServiceName+".LoadServerInfoExport", &AuthArgs{}, &VoidReply{})
+}
+
+// ServerInfoExporterState - returns the state of the export of the
+// server information on the remote server.
+func (rpcClient *AdminRPCClient) ServerInfoExporterState() (state madmin.ServerInfoExporterState, err error) {
+	err = rpcClient.Call(adminServiceName+".ServerInfoExporterState", &AuthArgs{}, &state)
+	return state, err
+}
+
+// PartSizeStats - returns the sizes of the parts uploaded to the
+// remote server.
+func (rpcClient *AdminRPCClient) PartSizeStats() (stats madmin.PartSizeStats, err error) {
+	err = rpcClient.Call(adminServiceName+".PartSizeStats", &AuthArgs{}, &stats)
+	return stats, err
+}
+
+// CompressionStats - returns the GET responses compressed by the
+// remote server.
+func (rpcClient *AdminRPCClient) CompressionStats() (stats madmin.CompressionStats, err error) {
+	err = rpcClient.Call(adminServiceName+".CompressionStats", &AuthArgs{}, &stats)
+	return stats, err
+}
+
+// Connections - returns the open connections of each client IP of the
+// remote server.
+func (rpcClient *AdminRPCClient) Connections() (connections madmin.ServerConnections, err error) {
+	err = rpcClient.Call(adminServiceName+".Connections", &AuthArgs{}, &connections)
+	return connections, err
+}
+
+// NotifyQueues - returns the backlog of events being sent to each
+// notification target of the remote server.
+func (rpcClient *AdminRPCClient) NotifyQueues() (queues []madmin.NotifyQueue, err error) {
+	err = rpcClient.Call(adminServiceName+".NotifyQueues", &AuthArgs{}, &queues)
+	return queues, err
+}
+
+// ReloadTLSCerts - makes the remote server reload its TLS certificate
+// and private key files, returns the certificate chain it serves.
+func (rpcClient *AdminRPCClient) ReloadTLSCerts() (chain []madmin.TLSCertificate, err error) {
+	err = rpcClient.Call(adminServiceName+".ReloadTLSCerts", &AuthArgs{}, &chain)
+	return chain, err
+}
+
+// DisksUsage - returns the capacity, used and free space of the local
+// disks of the remote server.
+func (rpcClient *AdminRPCClient) DisksUsage() (disks []madmin.DiskUsage, err error) {
+	err = rpcClient.Call(adminServiceName+".DisksUsage", &AuthArgs{}, &disks)
+	return disks, err
+}
+
+// DisksLayout - returns the expected and actual position in the
+// erasure sets of the local disks of the remote server.
+func (rpcClient *AdminRPCClient) DisksLayout() (disks []madmin.DiskLayout, err error) {
+	err = rpcClient.Call(adminServiceName+".DisksLayout", &AuthArgs{}, &disks)
+	return disks, err
+}
+
+// BitrotStats - returns the bitrot detected on the disks of the remote
+// server since its counters were reset.
+func (rpcClient *AdminRPCClient) BitrotStats() (stats madmin.ServerBitrotStats, err error) {
+	err = rpcClient.Call(adminServiceName+".BitrotStats", &AuthArgs{}, &stats)
+	return stats, err
+}
+
+// ResetBitrotStats - resets the bitrot counters of the remote server.
+func (rpcClient *AdminRPCClient) ResetBitrotStats() error {
+	return rpcClient.Call(adminServiceName+".ResetBitrotStats", &AuthArgs{}, &VoidReply{})
+}
+
+// ReadConsistencyTestObject - reads a consistency test object on the
+// remote server and returns the SHA256 of its data.
+func (rpcClient *AdminRPCClient) ReadConsistencyTestObject(object string) (sum string, err error) {
+	args := ReadConsistencyTestObjectArgs{Object: object}
+	err = rpcClient.Call(adminServiceName+".ReadConsistencyTestObject", &args, &sum)
+	return sum, err
+}
+
 // NewAdminRPCClient - returns new admin RPC client.
 func NewAdminRPCClient(host *xnet.Host) (*AdminRPCClient, error) {
 	scheme := "http"
@@ -112,6 +361,41 @@ type adminCmdRunner interface {
 	ReInitFormat(dryRun bool) error
 	ServerInfo() (ServerInfoData, error)
 	GetConfig() ([]byte, error)
+	SetLogLevel(subsystem string, level logger.Level) error
+	APIErrorStats(reset bool) (map[string]uint64, error)
+	SlowRequests() ([]madmin.SlowRequest, error)
+	MetricsHistory(from, to time.Time) ([]metricsSample, error)
+	TestDisk(disk string) (madmin.DiskTestResult, error)
+	Speedtest(size int64, concurrency int, duration time.Duration) (madmin.SpeedtestNodeResult, error)
+	NetPerf(size int64) ([]madmin.NetPerfLink, error)
+	NetPerfPing(payload []byte) error
+	ScheduleRestart(delay time.Duration, token string) error
+	CancelRestart(token string) (bool, error)
+	ActiveRequests() ([]madmin.ActiveRequest, error)
+	CancelRequest(requestID string) (bool, error)
+	RecentLogs(lines int, since time.Time) ([]string, error)
+	LoadRateLimits() error
+	RateLimits() ([]madmin.RateLimitStatus, error)
+	LoadScannerSchedule() error
+	ScannerProgress() ([]madmin.ScannerProgress, error)
+	RefreshUsage() (time.Time, error)
+	LoadAdminCredentials() error
+	LoadConfigSection(section string) error
+	NotifyQueues() ([]madmin.NotifyQueue, error)
+	ReloadTLSCerts() ([]madmin.TLSCertificate, error)
+	DisksUsage() ([]madmin.DiskUsage, error)
+	DisksLayout() ([]madmin.DiskLayout, error)
+	BitrotStats() (madmin.ServerBitrotStats, error)
+	ResetBitrotStats() error
+	ReadConsistencyTestObject(object string) (string, error)
+	Connections() (madmin.ServerConnections, error)
+	PartSizeStats() (madmin.PartSizeStats, error)
+	CompressionStats() (madmin.CompressionStats, error)
+	AdmissionStatus() (madmin.AdmissionStatus, error)
+	SetMaxRequests(limit int) error
+	SimulateOffline(duration time.Duration) (time.Time, error)
+	LoadServerInfoExport() error
+	ServerInfoExporterState() (madmin.ServerInfoExporterState, error)
 }
 
 // adminPeer - represents an entity that implements admin API RPCs.
@@ -185,23 +469,206 @@ func invokeServiceCmd(cp adminPeer, cmd serviceSignal) (err error) {
 	return err
 }
 
+// signalPeers - sends the service command to all given peers at the
+// same time, returns the error of each peer in the same order.
+func signalPeers(peers adminPeers, cmd serviceSignal) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = invokeServiceCmd(peer, cmd)
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
 // sendServiceCmd - Invoke Restart command on remote peers
-// adminPeer followed by on the local peer.
-func sendServiceCmd(cps adminPeers, cmd serviceSignal) {
+// adminPeer followed by on the local peer. Returns the error of each
+// peer in the same order.
+func sendServiceCmd(cps adminPeers, cmd serviceSignal) []error {
 	// Send service command like stop or restart to all remote nodes and finally run on local node.
-	errs := make([]error, len(cps))
+	errs := append([]error{nil}, signalPeers(cps[1:], cmd)...)
+	errs[0] = invokeServiceCmd(cps[0], cmd)
+	return errs
+}
+
+// loadPeersRateLimits - makes all peers load the saved rate limits of
+// tenants, returns the error of each peer in the same order.
+func loadPeersRateLimits(peers adminPeers) []error {
+	errs := make([]error, len(peers))
 	var wg sync.WaitGroup
-	remotePeers := cps[1:]
-	for i := range remotePeers {
+	for i, peer := range peers {
 		wg.Add(1)
-		go func(idx int) {
+		go func(idx int, peer adminPeer) {
 			defer wg.Done()
-			// we use idx+1 because remotePeers slice is 1 position shifted w.r.t cps
-			errs[idx+1] = invokeServiceCmd(remotePeers[idx], cmd)
-		}(i)
+			errs[idx] = peer.cmdRunner.LoadRateLimits()
+		}(i, peer)
 	}
 	wg.Wait()
-	errs[0] = invokeServiceCmd(cps[0], cmd)
+	return errs
+}
+
+// loadPeersScannerSchedule - makes all peers load the saved schedule
+// of the background usage scans, returns the error of each peer in the
+// same order.
+func loadPeersScannerSchedule(peers adminPeers) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.LoadScannerSchedule()
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// loadPeersAdminCredentials - makes all peers load the saved admin
+// credentials, returns the error of each peer in the same order.
+func loadPeersAdminCredentials(peers adminPeers) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.LoadAdminCredentials()
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// loadPeersConfigSection - makes all peers load a section of the
+// config.json saved, returns the error of each peer in the same order.
+func loadPeersConfigSection(peers adminPeers, section string) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.LoadConfigSection(section)
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// loadPeersServerInfoExport - makes all peers load the saved
+// configuration of the export of the server information, returns the
+// error of each peer in the same order.
+func loadPeersServerInfoExport(peers adminPeers) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.LoadServerInfoExport()
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// setPeersMaxRequests - sets the maximum number of requests served at
+// once on all peers, returns the error of each peer in the same order.
+func setPeersMaxRequests(peers adminPeers, limit int) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.SetMaxRequests(limit)
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// setPeersLogLevel - sets the log level of a subsystem on all peers,
+// returns the error of each peer in the same order.
+func setPeersLogLevel(peers adminPeers, subsystem string, level logger.Level) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.SetLogLevel(subsystem, level)
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// schedulePeersRestart - schedules the restart of all peers after the
+// delay, cancelable with the token if not empty. Returns the error of
+// each peer in the same order.
+func schedulePeersRestart(peers adminPeers, delay time.Duration, token string) []error {
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			errs[idx] = peer.cmdRunner.ScheduleRestart(delay, token)
+		}(i, peer)
+	}
+	wg.Wait()
+	return errs
+}
+
+// cancelPeersRestart - aborts the restart of all peers scheduled with
+// the token, or any scheduled restart if the token is empty. Returns
+// whether a restart was pending on any peer and the error of each
+// peer in the same order.
+func cancelPeersRestart(peers adminPeers, token string) (cancelled bool, errs []error) {
+	errs = make([]error, len(peers))
+	cancels := make([]bool, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			cancels[idx], errs[idx] = peer.cmdRunner.CancelRestart(token)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	for _, c := range cancels {
+		cancelled = cancelled || c
+	}
+	return cancelled, errs
+}
+
+// cancelPeersRequest - cancels the request with the given ID on all
+// peers, returns whether the request was served by any peer and the
+// error of each peer in the same order.
+func cancelPeersRequest(peers adminPeers, requestID string) (cancelled bool, errs []error) {
+	errs = make([]error, len(peers))
+	cancels := make([]bool, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			cancels[idx], errs[idx] = peer.cmdRunner.CancelRequest(requestID)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	for _, c := range cancels {
+		cancelled = cancelled || c
+	}
+	return cancelled, errs
 }
 
 // uptimeSlice - used to sort uptimes in chronological order.
@@ -19,10 +19,12 @@ package cmd
 import (
 	"context"
 	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
 	xrpc "github.com/minio/minio/cmd/rpc"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const adminServiceName = "Admin"
@@ -46,6 +48,31 @@ func (receiver *adminRPCReceiver) SignalService(args *SignalServiceArgs, reply *
 	return receiver.local.SignalService(args.Sig)
 }
 
+// ScheduleRestartArgs - provides the delay and the cancel token to
+// ScheduleRestart RPC
+type ScheduleRestartArgs struct {
+	AuthArgs
+	Delay time.Duration
+	Token string
+}
+
+// ScheduleRestart - restarts the server after a delay
+func (receiver *adminRPCReceiver) ScheduleRestart(args *ScheduleRestartArgs, reply *VoidReply) error {
+	return receiver.local.ScheduleRestart(args.Delay, args.Token)
+}
+
+// CancelRestartArgs - provides the cancel token to CancelRestart RPC
+type CancelRestartArgs struct {
+	AuthArgs
+	Token string
+}
+
+// CancelRestart - aborts the scheduled restart of the server
+func (receiver *adminRPCReceiver) CancelRestart(args *CancelRestartArgs, reply *bool) (err error) {
+	*reply, err = receiver.local.CancelRestart(args.Token)
+	return err
+}
+
 // ServerInfo - returns the server info when object layer was initialized on this server.
 func (receiver *adminRPCReceiver) ServerInfo(args *AuthArgs, reply *ServerInfoData) (err error) {
 	*reply, err = receiver.local.ServerInfo()
@@ -69,6 +96,285 @@ func (receiver *adminRPCReceiver) ReInitFormat(args *ReInitFormatArgs, reply *Vo
 	return receiver.local.ReInitFormat(args.DryRun)
 }
 
+// SetLogLevelArgs - provides the subsystem and level to SetLogLevel RPC
+type SetLogLevelArgs struct {
+	AuthArgs
+	Subsystem string
+	Level     logger.Level
+}
+
+// SetLogLevel - sets the log level of a subsystem
+func (receiver *adminRPCReceiver) SetLogLevel(args *SetLogLevelArgs, reply *VoidReply) error {
+	return receiver.local.SetLogLevel(args.Subsystem, args.Level)
+}
+
+// APIErrorStatsArgs - provides the reset flag to APIErrorStats RPC
+type APIErrorStatsArgs struct {
+	AuthArgs
+	Reset bool
+}
+
+// APIErrorStats - returns the count of error responses per API error code
+func (receiver *adminRPCReceiver) APIErrorStats(args *APIErrorStatsArgs, reply *map[string]uint64) (err error) {
+	*reply, err = receiver.local.APIErrorStats(args.Reset)
+	return err
+}
+
+// SlowRequests - returns the most recent slow requests
+func (receiver *adminRPCReceiver) SlowRequests(args *AuthArgs, reply *[]madmin.SlowRequest) (err error) {
+	*reply, err = receiver.local.SlowRequests()
+	return err
+}
+
+// MetricsHistoryArgs - provides the time range to MetricsHistory RPC
+type MetricsHistoryArgs struct {
+	AuthArgs
+	From time.Time
+	To   time.Time
+}
+
+// MetricsHistory - returns the metrics samples taken in a time range
+func (receiver *adminRPCReceiver) MetricsHistory(args *MetricsHistoryArgs, reply *[]metricsSample) (err error) {
+	*reply, err = receiver.local.MetricsHistory(args.From, args.To)
+	return err
+}
+
+// TestDiskArgs - provides the disk to TestDisk RPC
+type TestDiskArgs struct {
+	AuthArgs
+	Disk string
+}
+
+// TestDisk - tests read and write on a disk
+func (receiver *adminRPCReceiver) TestDisk(args *TestDiskArgs, reply *madmin.DiskTestResult) (err error) {
+	*reply, err = receiver.local.TestDisk(args.Disk)
+	return err
+}
+
+// SpeedtestArgs - provides the object size, concurrency and duration
+// to Speedtest RPC
+type SpeedtestArgs struct {
+	AuthArgs
+	Size        int64
+	Concurrency int
+	Duration    time.Duration
+}
+
+// Speedtest - runs a speedtest
+func (receiver *adminRPCReceiver) Speedtest(args *SpeedtestArgs, reply *madmin.SpeedtestNodeResult) (err error) {
+	*reply, err = receiver.local.Speedtest(args.Size, args.Concurrency, args.Duration)
+	return err
+}
+
+// NetPerfArgs - provides the size of the payload sent over each link
+// to NetPerf RPC
+type NetPerfArgs struct {
+	AuthArgs
+	Size int64
+}
+
+// NetPerf - measures the links to the other servers
+func (receiver *adminRPCReceiver) NetPerf(args *NetPerfArgs, reply *[]madmin.NetPerfLink) (err error) {
+	*reply, err = receiver.local.NetPerf(args.Size)
+	return err
+}
+
+// NetPerfPingArgs - provides the payload of NetPerfPing RPC
+type NetPerfPingArgs struct {
+	AuthArgs
+	Payload []byte
+}
+
+// NetPerfPing - discards the payload sent to measure a link
+func (receiver *adminRPCReceiver) NetPerfPing(args *NetPerfPingArgs, reply *VoidReply) error {
+	return receiver.local.NetPerfPing(args.Payload)
+}
+
+// ActiveRequests - returns the requests being served
+func (receiver *adminRPCReceiver) ActiveRequests(args *AuthArgs, reply *[]madmin.ActiveRequest) (err error) {
+	*reply, err = receiver.local.ActiveRequests()
+	return err
+}
+
+// CancelRequestArgs - provides the request ID to CancelRequest RPC
+type CancelRequestArgs struct {
+	AuthArgs
+	RequestID string
+}
+
+// CancelRequest - cancels a request being served
+func (receiver *adminRPCReceiver) CancelRequest(args *CancelRequestArgs, reply *bool) (err error) {
+	*reply, err = receiver.local.CancelRequest(args.RequestID)
+	return err
+}
+
+// RecentLogsArgs - provides the number of lines and the start time
+// to RecentLogs RPC
+type RecentLogsArgs struct {
+	AuthArgs
+	Lines int
+	Since time.Time
+}
+
+// RecentLogs - returns the most recent log entries
+func (receiver *adminRPCReceiver) RecentLogs(args *RecentLogsArgs, reply *[]string) (err error) {
+	*reply, err = receiver.local.RecentLogs(args.Lines, args.Since)
+	return err
+}
+
+// LoadRateLimits - loads the saved rate limits of tenants
+func (receiver *adminRPCReceiver) LoadRateLimits(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.LoadRateLimits()
+}
+
+// RateLimits - returns the rate limits of tenants
+func (receiver *adminRPCReceiver) RateLimits(args *AuthArgs, reply *[]madmin.RateLimitStatus) (err error) {
+	*reply, err = receiver.local.RateLimits()
+	return err
+}
+
+// LoadScannerSchedule - loads the saved schedule of the background
+// usage scans
+func (receiver *adminRPCReceiver) LoadScannerSchedule(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.LoadScannerSchedule()
+}
+
+// ScannerProgress - returns the progress of the background usage scans
+func (receiver *adminRPCReceiver) ScannerProgress(args *AuthArgs, reply *[]madmin.ScannerProgress) (err error) {
+	*reply, err = receiver.local.ScannerProgress()
+	return err
+}
+
+// RefreshUsage - makes the background usage scans run again right away
+func (receiver *adminRPCReceiver) RefreshUsage(args *AuthArgs, reply *time.Time) (err error) {
+	*reply, err = receiver.local.RefreshUsage()
+	return err
+}
+
+// LoadAdminCredentials - loads the saved admin credentials
+func (receiver *adminRPCReceiver) LoadAdminCredentials(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.LoadAdminCredentials()
+}
+
+// LoadConfigSectionArgs - provides the config section to LoadConfigSection RPC
+type LoadConfigSectionArgs struct {
+	AuthArgs
+	Section string
+}
+
+// LoadConfigSection - loads a section of the saved config
+func (receiver *adminRPCReceiver) LoadConfigSection(args *LoadConfigSectionArgs, reply *VoidReply) error {
+	return receiver.local.LoadConfigSection(args.Section)
+}
+
+// Connections - returns the open connections of each client IP
+func (receiver *adminRPCReceiver) Connections(args *AuthArgs, reply *madmin.ServerConnections) (err error) {
+	*reply, err = receiver.local.Connections()
+	return err
+}
+
+// PartSizeStats - returns the sizes of the uploaded parts
+func (receiver *adminRPCReceiver) PartSizeStats(args *AuthArgs, reply *madmin.PartSizeStats) (err error) {
+	*reply, err = receiver.local.PartSizeStats()
+	return err
+}
+
+// CompressionStats - returns the compressed GET responses
+func (receiver *adminRPCReceiver) CompressionStats(args *AuthArgs, reply *madmin.CompressionStats) (err error) {
+	*reply, err = receiver.local.CompressionStats()
+	return err
+}
+
+// AdmissionStatus - returns the state of the admission control
+func (receiver *adminRPCReceiver) AdmissionStatus(args *AuthArgs, reply *madmin.AdmissionStatus) (err error) {
+	*reply, err = receiver.local.AdmissionStatus()
+	return err
+}
+
+// SetMaxRequestsArgs - provides the limit to SetMaxRequests RPC
+type SetMaxRequestsArgs struct {
+	AuthArgs
+	MaxRequests int
+}
+
+// SetMaxRequests - sets the maximum number of requests served at once
+func (receiver *adminRPCReceiver) SetMaxRequests(args *SetMaxRequestsArgs, reply *VoidReply) error {
+	return receiver.local.SetMaxRequests(args.MaxRequests)
+}
+
+// LoadServerInfoExport - loads the saved configuration of the export
+// of the server information
+func (receiver *adminRPCReceiver) LoadServerInfoExport(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.LoadServerInfoExport()
+}
+
+// ServerInfoExporterState - returns the state of the export of the
+// server information
+func (receiver *adminRPCReceiver) ServerInfoExporterState(args *AuthArgs, reply *madmin.ServerInfoExporterState) (err error) {
+	*reply, err = receiver.local.ServerInfoExporterState()
+	return err
+}
+
+// SimulateOfflineArgs - provides the duration to SimulateOffline RPC
+type SimulateOfflineArgs struct {
+	AuthArgs
+	Duration time.Duration
+}
+
+// SimulateOffline - makes the server simulate being offline
+func (receiver *adminRPCReceiver) SimulateOffline(args *SimulateOfflineArgs, reply *time.Time) (err error) {
+	*reply, err = receiver.local.SimulateOffline(args.Duration)
+	return err
+}
+
+// NotifyQueues - returns the backlog of events of notification targets
+func (receiver *adminRPCReceiver) NotifyQueues(args *AuthArgs, reply *[]madmin.NotifyQueue) (err error) {
+	*reply, err = receiver.local.NotifyQueues()
+	return err
+}
+
+// ReloadTLSCerts - reloads the TLS certificate and private key files
+func (receiver *adminRPCReceiver) ReloadTLSCerts(args *AuthArgs, reply *[]madmin.TLSCertificate) (err error) {
+	*reply, err = receiver.local.ReloadTLSCerts()
+	return err
+}
+
+// DisksUsage - returns the capacity, used and free space of the disks
+func (receiver *adminRPCReceiver) DisksUsage(args *AuthArgs, reply *[]madmin.DiskUsage) (err error) {
+	*reply, err = receiver.local.DisksUsage()
+	return err
+}
+
+// DisksLayout - returns the expected and actual position of the disks
+func (receiver *adminRPCReceiver) DisksLayout(args *AuthArgs, reply *[]madmin.DiskLayout) (err error) {
+	*reply, err = receiver.local.DisksLayout()
+	return err
+}
+
+// BitrotStats - returns the bitrot detected on the disks
+func (receiver *adminRPCReceiver) BitrotStats(args *AuthArgs, reply *madmin.ServerBitrotStats) (err error) {
+	*reply, err = receiver.local.BitrotStats()
+	return err
+}
+
+// ResetBitrotStats - resets the bitrot counters
+func (receiver *adminRPCReceiver) ResetBitrotStats(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.ResetBitrotStats()
+}
+
+// ReadConsistencyTestObjectArgs - provides the object to
+// ReadConsistencyTestObject RPC
+type ReadConsistencyTestObjectArgs struct {
+	AuthArgs
+	Object string
+}
+
+// ReadConsistencyTestObject - reads a consistency test object
+func (receiver *adminRPCReceiver) ReadConsistencyTestObject(args *ReadConsistencyTestObjectArgs, reply *string) (err error) {
+	*reply, err = receiver.local.ReadConsistencyTestObject(args.Object)
+	return err
+}
+
 // NewAdminRPCServer - returns new admin RPC server.
 func NewAdminRPCServer() (*xrpc.Server, error) {
 	rpcServer := xrpc.NewServer()
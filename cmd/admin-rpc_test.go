@@ -17,11 +17,25 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/certs"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -167,6 +181,883 @@ func testAdminCmdRunnerGetConfig(t *testing.T, client adminCmdRunner) {
 	}
 }
 
+func testAdminCmdRunnerSetLogLevel(t *testing.T, client adminCmdRunner) {
+	prevLevels := logger.GetLevels()
+	defer func() {
+		// Subsystems without a previous level get a zero level,
+		// which removes the level they were given.
+		for _, subsystem := range logger.Subsystems {
+			logger.SetLevel(subsystem, prevLevels[subsystem])
+		}
+		logger.SetLevel("", prevLevels[""])
+	}()
+
+	testCases := []struct {
+		subsystem string
+		level     logger.Level
+		expectErr bool
+	}{
+		{logger.LockSubsystem, logger.DebugLvl, false},
+		{"", logger.FatalLvl, false},
+		{"unknown", logger.DebugLvl, true},
+	}
+
+	for i, testCase := range testCases {
+		err := client.SetLogLevel(testCase.subsystem, testCase.level)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if !expectErr && logger.GetLevels()[testCase.subsystem] != testCase.level {
+			t.Fatalf("case %v: expected level %v to be set", i+1, testCase.level)
+		}
+	}
+
+	// A zero level removes the level of the subsystem and restores
+	// the default level.
+	if err := client.SetLogLevel(logger.LockSubsystem, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetLogLevel("", 0); err != nil {
+		t.Fatal(err)
+	}
+	levels := logger.GetLevels()
+	if _, ok := levels[logger.LockSubsystem]; ok || levels[""] != logger.DefaultLevel {
+		t.Fatalf("expected levels to be reset, got %v", levels)
+	}
+	if !logger.IsEnabled(logger.LockSubsystem, logger.ErrorLvl) {
+		t.Fatal("expected errors to be logged at the default level")
+	}
+}
+
+func testAdminCmdRunnerAPIErrorStats(t *testing.T, client adminCmdRunner) {
+	tmpGlobalAPIErrorStats := globalAPIErrorStats
+	defer func() {
+		globalAPIErrorStats = tmpGlobalAPIErrorStats
+	}()
+	globalAPIErrorStats = newAPIErrorStats()
+	globalAPIErrorStats.inc("AccessDenied")
+
+	testCases := []struct {
+		reset         bool
+		expectedCount uint64
+	}{
+		{false, 1},
+		{true, 1},
+		{false, 0},
+	}
+
+	for i, testCase := range testCases {
+		counts, err := client.APIErrorStats(testCase.reset)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if counts["AccessDenied"] != testCase.expectedCount {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCount, counts["AccessDenied"])
+		}
+	}
+}
+
+func testAdminCmdRunnerSlowRequests(t *testing.T, client adminCmdRunner) {
+	tmpGlobalSlowRequests := globalSlowRequests
+	defer func() {
+		globalSlowRequests = tmpGlobalSlowRequests
+	}()
+	globalSlowRequests = newSlowRequestLog()
+	globalSlowRequests.add(madmin.SlowRequest{Method: "GET", Path: "/bucket/object"})
+
+	requests, err := client.SlowRequests()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(requests) != 1 || requests[0].Path != "/bucket/object" {
+		t.Fatalf("unexpected slow requests %#v", requests)
+	}
+}
+
+func testAdminCmdRunnerMetricsHistory(t *testing.T, client adminCmdRunner) {
+	tmpGlobalMetricsHistory := globalMetricsHistory
+	defer func() {
+		globalMetricsHistory = tmpGlobalMetricsHistory
+	}()
+	globalMetricsHistory = newMetricsHistory(time.Minute)
+	now := UTCNow().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		globalMetricsHistory.add(metricsSample{
+			Time:      now.Add(time.Duration(i) * time.Minute),
+			ConnStats: ServerConnStats{TotalInputBytes: uint64(i)},
+		})
+	}
+
+	samples, err := client.MetricsHistory(now.Add(time.Minute), time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(samples) != 2 || samples[0].ConnStats.TotalInputBytes != 1 || !samples[1].Time.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("unexpected metrics samples %#v", samples)
+	}
+}
+
+func testAdminCmdRunnerScheduleRestart(t *testing.T, client adminCmdRunner) {
+	tmpGlobalRestartScheduler := globalRestartScheduler
+	defer func() {
+		globalRestartScheduler = tmpGlobalRestartScheduler
+	}()
+	globalRestartScheduler = &restartScheduler{}
+
+	if err := client.ScheduleRestart(time.Hour, "token"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	cancelled, err := client.CancelRestart("other-token")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if cancelled {
+		t.Fatal("expected scheduled restart not to be cancelled with another token")
+	}
+
+	cancelled, err = client.CancelRestart("token")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !cancelled {
+		t.Fatal("expected scheduled restart to be cancelled")
+	}
+
+	cancelled, err = client.CancelRestart("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if cancelled {
+		t.Fatal("expected no restart to be pending")
+	}
+}
+
+func testAdminCmdRunnerActiveRequests(t *testing.T, client adminCmdRunner) {
+	tmpGlobalActiveRequests := globalActiveRequests
+	defer func() {
+		globalActiveRequests = tmpGlobalActiveRequests
+	}()
+	globalActiveRequests = newActiveRequests()
+	req, _ := globalActiveRequests.register(httptest.NewRequest(http.MethodGet, "/bucket/object", nil), "1")
+
+	requests, err := client.ActiveRequests()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(requests) != 1 || requests[0].ID != "1" || requests[0].Path != "/bucket/object" {
+		t.Fatalf("unexpected active requests %#v", requests)
+	}
+
+	testCases := []struct {
+		requestID         string
+		expectedCancelled bool
+	}{
+		{"2", false},
+		{"1", true},
+	}
+	for i, testCase := range testCases {
+		cancelled, err := client.CancelRequest(testCase.requestID)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if cancelled != testCase.expectedCancelled {
+			t.Fatalf("case %v: expected cancelled: %v, got: %v", i+1, testCase.expectedCancelled, cancelled)
+		}
+	}
+	if !req.isCancelled() {
+		t.Fatal("expected request to be cancelled")
+	}
+}
+
+func testAdminCmdRunnerTestDisk(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	testCases := []struct {
+		objectAPI ObjectLayer
+		expectErr bool
+	}{
+		{&DummyObjectLayer{}, true},
+		{nil, true},
+	}
+
+	for i, testCase := range testCases {
+		globalObjectAPI = testCase.objectAPI
+		_, err := client.TestDisk("/data1")
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+func testAdminCmdRunnerSpeedtest(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	globalObjectAPI = nil
+	if _, err := client.Speedtest(1024, 2, 100*time.Millisecond); err == nil {
+		t.Fatal("Expected speedtest to fail without object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	globalObjectAPI = objLayer
+
+	result, err := client.Speedtest(1024, 2, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected speedtest error: %v", err)
+	}
+	if result.Write.Objects == 0 || result.Write.Bytes != result.Write.Objects*1024 {
+		t.Errorf("Unexpected write stats %#v", result.Write)
+	}
+	if result.Read.Objects == 0 || result.Read.Bytes != result.Read.Objects*1024 {
+		t.Errorf("Unexpected read stats %#v", result.Read)
+	}
+
+	// Objects written by the speedtest are deleted.
+	objects, err := objLayer.ListObjects(context.Background(), minioMetaBucket, speedtestPrefix+"/", "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects.Objects) != 0 {
+		t.Errorf("Expected speedtest objects to be deleted, found %d", len(objects.Objects))
+	}
+}
+
+func testAdminCmdRunnerRecentLogs(t *testing.T, client adminCmdRunner) {
+	tmpGlobalRecentLogs := globalRecentLogs
+	defer func(targets []logger.LoggingTarget, disableLog bool) {
+		globalRecentLogs = tmpGlobalRecentLogs
+		logger.Targets = targets
+		logger.Disable = disableLog
+	}(logger.Targets, logger.Disable)
+	globalRecentLogs = logger.NewMemory(2)
+	logger.Targets = []logger.LoggingTarget{globalRecentLogs}
+	logger.Disable = false
+
+	for _, msg := range []string{"first", "second", "third"} {
+		logger.LogEvent(context.Background(), "%s event", msg)
+	}
+
+	logs, err := client.RecentLogs(0, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(logs) != 2 || !strings.Contains(logs[0], "second") || !strings.Contains(logs[1], "third") {
+		t.Fatalf("unexpected recent logs %v", logs)
+	}
+
+	if logs, err = client.RecentLogs(1, time.Time{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "third") {
+		t.Fatalf("unexpected recent logs %v", logs)
+	}
+
+	if logs, err = client.RecentLogs(0, UTCNow().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(logs) != 0 {
+		t.Fatalf("unexpected recent logs %v", logs)
+	}
+}
+
+func testAdminCmdRunnerRateLimits(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpGlobalTenantRateLimiter := globalTenantRateLimiter
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalTenantRateLimiter = tmpGlobalTenantRateLimiter
+	}()
+	globalTenantRateLimiter = newTenantRateLimiter()
+
+	globalObjectAPI = nil
+	if err := client.LoadRateLimits(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	limits := []madmin.RateLimit{
+		{Bucket: "bucket", RequestsPerSecond: 10},
+		{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 1},
+	}
+	if err = saveTenantRateLimits(objLayer, limits); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.LoadRateLimits(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	status, err := client.RateLimits()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(status) != len(limits) {
+		t.Fatalf("expected %d rate limits, got %v", len(limits), status)
+	}
+	for i := range limits {
+		if status[i].RateLimit != limits[i] {
+			t.Fatalf("expected rate limit %v, got %v", limits[i], status[i].RateLimit)
+		}
+	}
+}
+
+func testAdminCmdRunnerScannerSchedule(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpSchedule, _ := globalUsageScanner.getSchedule()
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalUsageScanner.setSchedule(tmpSchedule)
+	}()
+
+	globalObjectAPI = nil
+	if err := client.LoadScannerSchedule(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	schedule := madmin.ScannerSchedule{
+		Interval:         time.Hour,
+		Delay:            time.Millisecond,
+		ActiveHoursStart: 22,
+		ActiveHoursEnd:   6,
+	}
+	if err = saveScannerSchedule(objLayer, schedule); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.LoadScannerSchedule(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if loaded, _ := globalUsageScanner.getSchedule(); loaded != schedule {
+		t.Fatalf("expected schedule %v, got %v", schedule, loaded)
+	}
+
+	if _, err = client.ScannerProgress(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	requested, err := client.RefreshUsage()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if requested.IsZero() || !globalUsageScanner.isRefreshPending("/unscanned") {
+		t.Fatalf("expected a refresh to be requested, got %v", requested)
+	}
+}
+
+func testAdminCmdRunnerLoadAdminCredentials(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpGlobalAdminCredentials := globalAdminCredentials
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalAdminCredentials = tmpGlobalAdminCredentials
+	}()
+	globalAdminCredentials = newAdminCredentialStore()
+
+	globalObjectAPI = nil
+	if err := client.LoadAdminCredentials(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	cred := madmin.AdminCredential{
+		AccessKey: "operator",
+		SecretKey: "operator-secret",
+		Policy: madmin.AdminPolicy{Statement: []madmin.AdminPolicyStatement{
+			{Effect: madmin.AdminPolicyAllow, Action: []string{"admin:ServerInfo"}},
+		}},
+	}
+	if err = saveAdminCredentials(objLayer, []madmin.AdminCredential{cred}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.LoadAdminCredentials(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if loaded, ok := globalAdminCredentials.get(cred.AccessKey); !ok || !reflect.DeepEqual(loaded, cred) {
+		t.Fatalf("expected admin credential %v, got %v", cred, loaded)
+	}
+}
+
+func testAdminCmdRunnerLoadConfigSection(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpGlobalServerConfig := globalServerConfig
+	tmpGlobalClockSkewTolerance := globalClockSkewTolerance
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalServerConfig = tmpGlobalServerConfig
+		globalClockSkewTolerance = tmpGlobalClockSkewTolerance
+	}()
+	globalServerConfig = newServerConfig()
+	globalClockSkewTolerance = &clockSkewTolerance{}
+
+	globalObjectAPI = nil
+	if err := client.LoadConfigSection(configSectionMaxClockSkew); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	config := newServerConfig()
+	config.MaxClockSkew = "5m0s"
+	if err = saveServerConfig(objLayer, config); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.LoadConfigSection("region"); err == nil {
+		t.Fatal("expected error loading a section requiring a restart")
+	}
+	if err = client.LoadConfigSection(configSectionMaxClockSkew); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if d := globalClockSkewTolerance.get(); d != 5*time.Minute {
+		t.Fatalf("expected maximum clock skew %s, got %s", 5*time.Minute, d)
+	}
+	if globalServerConfig.MaxClockSkew != config.MaxClockSkew {
+		t.Fatalf("expected running config to hold %s, got %s", config.MaxClockSkew, globalServerConfig.MaxClockSkew)
+	}
+}
+
+func testAdminCmdRunnerConnections(t *testing.T, client adminCmdRunner) {
+	tmpGlobalHTTPServer, tmpMaxConnsPerIP := globalHTTPServer, globalMaxConnsPerIP
+	defer func() {
+		globalHTTPServer, globalMaxConnsPerIP = tmpGlobalHTTPServer, tmpMaxConnsPerIP
+	}()
+	globalMaxConnsPerIP = 5
+
+	globalHTTPServer = nil
+	connections, err := client.Connections()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if connections.Limit != 5 || len(connections.Clients) != 0 {
+		t.Fatalf("unexpected connections %v", connections)
+	}
+
+	globalHTTPServer = xhttp.NewServer([]string{"127.0.0.1:0"}, nil, nil)
+	globalHTTPServer.ConnLimiter = newConnLimiter()
+	if connections, err = client.Connections(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if connections.Limit != 5 || connections.Rejected != 0 || len(connections.Clients) != 0 {
+		t.Fatalf("unexpected connections %v", connections)
+	}
+}
+
+func testAdminCmdRunnerServerInfoExport(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpGlobalServerInfoExporter := globalServerInfoExporter
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalServerInfoExporter = tmpGlobalServerInfoExporter
+	}()
+	globalServerInfoExporter = newServerInfoExporter()
+
+	globalObjectAPI = nil
+	if err := client.LoadServerInfoExport(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	config := madmin.ServerInfoExportConfig{
+		Enabled:  true,
+		Interval: time.Hour,
+		Bucket:   "monitoring",
+		Prefix:   "serverinfo/",
+	}
+	if err = saveServerInfoExport(objLayer, config); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.LoadServerInfoExport(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if loaded, _ := globalServerInfoExporter.getConfig(); loaded != config {
+		t.Fatalf("expected config %v, got %v", config, loaded)
+	}
+
+	now := UTCNow().Round(0)
+	globalServerInfoExporter.recordSnapshot(now, "serverinfo/serverinfo-1.json", nil)
+	state, err := client.ServerInfoExporterState()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !state.LastSnapshot.Equal(now) || state.LastObject != "serverinfo/serverinfo-1.json" {
+		t.Fatalf("unexpected export state %#v", state)
+	}
+}
+
+func testAdminCmdRunnerSimulateOffline(t *testing.T, client adminCmdRunner) {
+	tmpGlobalChaosTestingEnabled := globalChaosTestingEnabled
+	defer func() {
+		globalChaosTestingEnabled = tmpGlobalChaosTestingEnabled
+		globalSimulatedOffline.set(0)
+	}()
+
+	globalChaosTestingEnabled = false
+	if _, err := client.SimulateOffline(time.Minute); err == nil {
+		t.Fatal("expected error with chaos testing disabled")
+	}
+
+	globalChaosTestingEnabled = true
+	if _, err := client.SimulateOffline(2 * maxSimulatedOfflineDuration); err == nil {
+		t.Fatal("expected error with a duration over the maximum")
+	}
+	until, err := client.SimulateOffline(time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !until.After(UTCNow()) || !globalSimulatedOffline.isOffline() {
+		t.Fatalf("expected the server to be offline until %s", until)
+	}
+
+	if until, err = client.SimulateOffline(0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !until.IsZero() || globalSimulatedOffline.isOffline() {
+		t.Fatal("expected the server to recover")
+	}
+}
+
+func testAdminCmdRunnerAdmission(t *testing.T, client adminCmdRunner) {
+	tmpGlobalAdmissionController := globalAdmissionController
+	defer func() {
+		globalAdmissionController = tmpGlobalAdmissionController
+	}()
+	globalAdmissionController = newAdmissionController(0)
+
+	if err := client.SetMaxRequests(-1); err == nil {
+		t.Fatal("expected error with a negative limit")
+	}
+	if err := client.SetMaxRequests(100); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	status, err := client.AdmissionStatus()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if status.MaxRequests != 100 || status.MaxQueued != 100 || status.QueueTimeout != admissionQueueTimeout {
+		t.Fatalf("unexpected admission status %#v", status)
+	}
+}
+
+func testAdminCmdRunnerPartSizeStats(t *testing.T, client adminCmdRunner) {
+	tmpGlobalMultipartPolicy := globalMultipartPolicy
+	defer func() {
+		globalMultipartPolicy = tmpGlobalMultipartPolicy
+	}()
+	globalMultipartPolicy = newMultipartPolicy()
+	globalMultipartPolicy.recordPart(humanize.KiByte)
+	globalMultipartPolicy.recordPart(8 * humanize.MiByte)
+	globalMultipartPolicy.recordRejected()
+
+	stats, err := client.PartSizeStats()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if stats.Parts != 2 || stats.Bytes != humanize.KiByte+8*humanize.MiByte || stats.RejectedUploads != 1 {
+		t.Fatalf("unexpected part size stats %#v", stats)
+	}
+	if len(stats.PartsBySize) != len(partSizeBounds)+1 || stats.PartsBySize[0].Parts != 1 || stats.PartsBySize[2].Parts != 1 {
+		t.Fatalf("unexpected part size ranges %#v", stats.PartsBySize)
+	}
+}
+
+func testAdminCmdRunnerCompressionStats(t *testing.T, client adminCmdRunner) {
+	tmpGlobalResponseCompression := globalResponseCompression
+	defer func() {
+		globalResponseCompression = tmpGlobalResponseCompression
+	}()
+	globalResponseCompression = &responseCompression{requests: 4}
+	globalResponseCompression.record(4096, 1024)
+
+	stats, err := client.CompressionStats()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := madmin.CompressionStats{Requests: 4, Compressed: 1, HitRate: 0.25, BytesIn: 4096, BytesOut: 1024}
+	if stats != expected {
+		t.Fatalf("expected compression stats %#v, got %#v", expected, stats)
+	}
+}
+
+func testAdminCmdRunnerNotifyQueues(t *testing.T, client adminCmdRunner) {
+	tmpGlobalNotificationSys := globalNotificationSys
+	defer func() {
+		globalNotificationSys = tmpGlobalNotificationSys
+	}()
+
+	globalNotificationSys = nil
+	if _, err := client.NotifyQueues(); err == nil {
+		t.Fatal("expected error without a notification system")
+	}
+
+	target := &replayTestTarget{id: event.TargetID{ID: "1", Name: "webhook"}, sendErr: true}
+	globalNotificationSys = &NotificationSys{targetList: event.NewTargetList()}
+	if err := globalNotificationSys.targetList.Add(target); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for range globalNotificationSys.targetList.Send(event.Event{EventName: event.ObjectCreatedPut}, target.id) {
+	}
+
+	queues, err := client.NotifyQueues()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := []madmin.NotifyQueue{{
+		ARN:       target.id.ToARN(globalServerConfig.GetRegion()).String(),
+		ErrorRate: 1,
+	}}
+	if !reflect.DeepEqual(queues, expected) {
+		t.Fatalf("expected queues %v, got %v", expected, queues)
+	}
+}
+
+func testAdminCmdRunnerDisksUsage(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	globalObjectAPI = nil
+	if _, err := client.DisksUsage(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+	globalObjectAPI = objLayer
+
+	disks, err := client.DisksUsage()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(disks) != 1 || disks[0].Path != fsDir || disks[0].Error != "" || disks[0].Total == 0 {
+		t.Fatalf("unexpected disks usage %v", disks)
+	}
+	if disks[0].Used+disks[0].Free != disks[0].Total {
+		t.Fatalf("expected used and free space to add up to %d, got %v", disks[0].Total, disks[0])
+	}
+}
+
+func testAdminCmdRunnerDisksLayout(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	globalObjectAPI = nil
+	if _, err := client.DisksLayout(); err == nil {
+		t.Fatal("expected error without an object layer")
+	}
+
+	objLayer, fsDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatalf("unable to initialize XL backend: %v", err)
+	}
+	defer removeRoots(fsDirs)
+	globalObjectAPI = objLayer
+
+	disks, err := client.DisksLayout()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(disks) != len(fsDirs) {
+		t.Fatalf("expected %d disks, got %v", len(fsDirs), disks)
+	}
+	for i, disk := range disks {
+		if disk.Path != fsDirs[i] || disk.Misplaced || disk.Error != "" || disk.UUID != disk.ExpectedUUID {
+			t.Fatalf("unexpected disk layout %v", disk)
+		}
+	}
+}
+
+func testAdminCmdRunnerNetPerf(t *testing.T, client adminCmdRunner) {
+	tmpGlobalAdminPeers := globalAdminPeers
+	defer func() {
+		globalAdminPeers = tmpGlobalAdminPeers
+	}()
+
+	if err := client.NetPerfPing(make([]byte, 1024)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// A single server has no link to measure.
+	globalAdminPeers = adminPeers{{addr: "server1", cmdRunner: localAdminClient{}, isLocal: true}}
+	links, err := client.NetPerf(1024)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(links) != 0 {
+		t.Fatalf("expected no links, got %v", links)
+	}
+}
+
+func testAdminCmdRunnerBitrotStats(t *testing.T, client adminCmdRunner) {
+	tmpGlobalBitrotStats := globalBitrotStats
+	defer func() {
+		globalBitrotStats = tmpGlobalBitrotStats
+	}()
+
+	globalBitrotStats = newBitrotStats()
+	globalBitrotStats.record("/data1")
+
+	stats, err := client.BitrotStats()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(stats.Disks) != 1 || stats.Disks[0].Path != "/data1" || stats.Disks[0].Count != 1 {
+		t.Fatalf("unexpected bitrot stats %v", stats)
+	}
+
+	if err = client.ResetBitrotStats(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if stats, err = client.BitrotStats(); err != nil || len(stats.Disks) != 0 {
+		t.Fatalf("expected reset bitrot stats, got %v, %v", stats, err)
+	}
+}
+
+func testAdminCmdRunnerReadConsistencyTestObject(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+	}()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	globalObjectAPI = objLayer
+
+	object := pathJoin(consistencyTestPrefix, "object")
+	if _, err = client.ReadConsistencyTestObject("config/config.json"); err == nil {
+		t.Fatal("Expected reading other objects than consistency test objects to fail")
+	}
+	sum, err := client.ReadConsistencyTestObject(object)
+	if err != nil || sum != "" {
+		t.Fatalf("Expected missing object, got %q, %v", sum, err)
+	}
+
+	data := []byte("hello")
+	if _, err = objLayer.PutObject(context.Background(), minioMetaBucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatal(err)
+	}
+	sum, err = client.ReadConsistencyTestObject(object)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := getSHA256Hash(data); sum != expected {
+		t.Errorf("Expected SHA256 %s, got %s", expected, sum)
+	}
+}
+
+func testAdminCmdRunnerReloadTLSCerts(t *testing.T, client adminCmdRunner) {
+	tmpGlobalTLSCerts := globalTLSCerts
+	defer func() {
+		globalTLSCerts = tmpGlobalTLSCerts
+	}()
+
+	globalTLSCerts = nil
+	if _, err := client.ReloadTLSCerts(); err == nil {
+		t.Fatal("expected error without TLS certificates")
+	}
+
+	certsDir, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(certsDir)
+
+	certFile, keyFile := filepath.Join(certsDir, publicCertFile), filepath.Join(certsDir, privateKeyFile)
+	writeCertKey := func() {
+		cert, key, err := generateTLSCertKey("127.0.0.1")
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err = ioutil.WriteFile(certFile, cert, 0600); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err = ioutil.WriteFile(keyFile, key, 0600); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	writeCertKey()
+
+	if globalTLSCerts, err = certs.New(certFile, keyFile, tls.LoadX509KeyPair); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer globalTLSCerts.Stop()
+
+	// Replace the certificate, the new one is served once reloaded.
+	writeCertKey()
+	expected, err := parsePublicCertFile(certFile)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	chain, err := client.ReloadTLSCerts()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(chain) != 1 || !chain[0].NotAfter.Equal(expected[0].NotAfter) {
+		t.Fatalf("expected certificate expiring at %v, got %v", expected[0].NotAfter, chain)
+	}
+
+	// A certificate which fails to load is not served.
+	if err = ioutil.WriteFile(certFile, []byte("invalid"), 0600); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err = client.ReloadTLSCerts(); err == nil {
+		t.Fatal("expected error reloading an invalid certificate")
+	}
+}
+
 func newAdminRPCHTTPServerClient(t *testing.T) (*httptest.Server, *AdminRPCClient, *serverConfig) {
 	rpcServer, err := NewAdminRPCServer()
 	if err != nil {
@@ -237,3 +1128,263 @@ func TestAdminRPCClientGetConfig(t *testing.T) {
 
 	testAdminCmdRunnerGetConfig(t, rpcClient)
 }
+
+func TestAdminRPCClientSetLogLevel(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSetLogLevel(t, rpcClient)
+}
+
+func TestAdminRPCClientAPIErrorStats(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerAPIErrorStats(t, rpcClient)
+}
+
+func TestAdminRPCClientSlowRequests(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSlowRequests(t, rpcClient)
+}
+
+func TestAdminRPCClientMetricsHistory(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerMetricsHistory(t, rpcClient)
+}
+
+func TestAdminRPCClientTestDisk(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerTestDisk(t, rpcClient)
+}
+
+func TestAdminRPCClientSpeedtest(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSpeedtest(t, rpcClient)
+}
+
+func TestAdminRPCClientScannerSchedule(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerScannerSchedule(t, rpcClient)
+}
+
+func TestAdminRPCClientScheduleRestart(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerScheduleRestart(t, rpcClient)
+}
+
+func TestAdminRPCClientActiveRequests(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerActiveRequests(t, rpcClient)
+}
+
+func TestAdminRPCClientRecentLogs(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerRecentLogs(t, rpcClient)
+}
+
+func TestAdminRPCClientRateLimits(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerRateLimits(t, rpcClient)
+}
+
+func TestAdminRPCClientLoadAdminCredentials(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerLoadAdminCredentials(t, rpcClient)
+}
+
+func TestAdminRPCClientLoadConfigSection(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerLoadConfigSection(t, rpcClient)
+}
+
+func TestAdminRPCClientNotifyQueues(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerNotifyQueues(t, rpcClient)
+}
+
+func TestAdminRPCClientReloadTLSCerts(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerReloadTLSCerts(t, rpcClient)
+}
+
+func TestAdminRPCClientDisksUsage(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerDisksUsage(t, rpcClient)
+}
+
+func TestAdminRPCClientNetPerf(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerNetPerf(t, rpcClient)
+}
+
+func TestAdminRPCClientDisksLayout(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerDisksLayout(t, rpcClient)
+}
+
+func TestAdminRPCClientBitrotStats(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerBitrotStats(t, rpcClient)
+}
+
+func TestAdminRPCClientReadConsistencyTestObject(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerReadConsistencyTestObject(t, rpcClient)
+}
+
+func TestAdminRPCClientConnections(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerConnections(t, rpcClient)
+}
+
+func TestAdminRPCClientPartSizeStats(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerPartSizeStats(t, rpcClient)
+}
+
+func TestAdminRPCClientCompressionStats(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerCompressionStats(t, rpcClient)
+}
+
+func TestAdminRPCClientAdmission(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerAdmission(t, rpcClient)
+}
+
+func TestAdminRPCClientServerInfoExport(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerServerInfoExport(t, rpcClient)
+}
+
+func TestAdminRPCClientSimulateOffline(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSimulateOffline(t, rpcClient)
+}
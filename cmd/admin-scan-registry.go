@@ -0,0 +1,92 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// adminScan - a background scan started through the admin API, whose
+// progress is polled with a client token.
+type adminScan interface {
+	clientToken() string
+	hasEnded() bool
+	stop()
+}
+
+// adminScanRegistry - holds the scan running on this server, and the
+// scans which ended until their status expires. Only one scan may run
+// at a time.
+type adminScanRegistry struct {
+	mu      sync.Mutex
+	running adminScan
+	ended   *expiringMap
+}
+
+// newAdminScanRegistry - returns a registry keeping the status of an
+// ended scan for the given duration.
+func newAdminScanRegistry(keepEndedDuration time.Duration) *adminScanRegistry {
+	return &adminScanRegistry{ended: newExpiringMap(keepEndedDuration)}
+}
+
+// launch - calls run in the background unless another scan is
+// running. A running scan is stopped first if forceStart is set.
+func (r *adminScanRegistry) launch(scan adminScan, forceStart bool, run func()) APIErrorCode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running != nil && !r.running.hasEnded() {
+		if !forceStart {
+			return ErrAdminScanAlreadyRunning
+		}
+		r.running.stop()
+	}
+
+	r.running = scan
+	go func() {
+		run()
+		r.end(scan)
+	}()
+	return ErrNone
+}
+
+// end - moves a scan which ended to the expiring ended scans.
+func (r *adminScanRegistry) end(scan adminScan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ended.Set(scan.clientToken(), scan)
+	if r.running == scan {
+		r.running = nil
+	}
+}
+
+// get - returns the running or ended scan identified by clientToken.
+func (r *adminScanRegistry) get(clientToken string) (adminScan, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running != nil && r.running.clientToken() == clientToken {
+		return r.running, true
+	}
+	scan, ok := r.ended.Get(clientToken)
+	if !ok {
+		return nil, false
+	}
+	return scan.(adminScan), true
+}
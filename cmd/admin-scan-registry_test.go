@@ -0,0 +1,84 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// registryTestScan - scan running until it is stopped.
+type registryTestScan struct {
+	token  string
+	stopCh chan struct{}
+	ended  chan struct{}
+}
+
+func newRegistryTestScan(token string) *registryTestScan {
+	return &registryTestScan{token: token, stopCh: make(chan struct{}), ended: make(chan struct{})}
+}
+
+func (s *registryTestScan) clientToken() string { return s.token }
+
+func (s *registryTestScan) hasEnded() bool {
+	select {
+	case <-s.ended:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *registryTestScan) stop() { close(s.stopCh) }
+
+func (s *registryTestScan) run() {
+	<-s.stopCh
+	close(s.ended)
+}
+
+func TestAdminScanRegistry(t *testing.T) {
+	r := newAdminScanRegistry(200 * time.Millisecond)
+
+	first := newRegistryTestScan("first")
+	if err := r.launch(first, false, first.run); err != ErrNone {
+		t.Fatalf("Failed to launch scan - %v", err)
+	}
+	second := newRegistryTestScan("second")
+	if err := r.launch(second, false, second.run); err != ErrAdminScanAlreadyRunning {
+		t.Fatalf("Expected %v, got %v", ErrAdminScanAlreadyRunning, err)
+	}
+	if err := r.launch(second, true, second.run); err != ErrNone {
+		t.Fatalf("Failed to force launch scan - %v", err)
+	}
+	<-first.ended
+
+	// The ended scan is kept until its status expires.
+	time.Sleep(50 * time.Millisecond)
+	for _, token := range []string{"first", "second"} {
+		if scan, ok := r.get(token); !ok || scan.clientToken() != token {
+			t.Fatalf("Expected scan %s to be found", token)
+		}
+	}
+	time.Sleep(250 * time.Millisecond)
+	if _, ok := r.get("first"); ok {
+		t.Fatal("Expected the status of the ended scan to expire")
+	}
+	if _, ok := r.get("second"); !ok {
+		t.Fatal("Expected the running scan to be found")
+	}
+	second.stop()
+}
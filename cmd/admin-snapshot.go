@@ -0,0 +1,295 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Maximum number of manifest entries returned by a single
+	// status request of a snapshot.
+	maxSnapshotEntriesPerReply = 1000
+
+	// Maximum number of manifest entries listed but not yet
+	// received by the client, the snapshot waits for the client
+	// beyond it.
+	maxPendingSnapshotEntries = 10000
+
+	// Time after which a snapshot waiting for its client to receive
+	// entries is stopped.
+	snapshotIdleTimeout = 30 * time.Minute
+
+	// Time the status of a snapshot, along with the entries not yet
+	// received, is kept once the snapshot ended.
+	keepSnapshotStatusDuration = 10 * time.Minute
+)
+
+var (
+	errSnapshotStopSignalled = errors.New("snapshot stop signaled")
+	errSnapshotIdle          = errors.New("snapshot stopped as manifest entries were not received")
+	errSnapshotOffsetTooOld  = errors.New("manifest entries before the given offset were already received")
+)
+
+// snapshotSequence - state of a snapshot listing the objects of a
+// bucket as they were at the snapshot time. Objects modified after it
+// are left out of the manifest and counted as changed, as their
+// content at the snapshot time is no longer available. Entries are
+// kept until the client received them, see Status.
+type snapshotSequence struct {
+	bucket string
+
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.SnapshotStatus
+
+	// entries not yet received by the client, the first one being
+	// at offset in all the entries listed
+	entries []madmin.SnapshotEntry
+	offset  int64
+
+	// channel to signal that the client received entries
+	receivedCh chan struct{}
+
+	// channel to signal the snapshot to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// snapshotState - holds the snapshot running on this server and the
+// ended ones until their status expires. Only one snapshot may run at
+// a time.
+type snapshotState struct {
+	*adminScanRegistry
+}
+
+var globalSnapshotState = snapshotState{newAdminScanRegistry(keepSnapshotStatusDuration)}
+
+// newSnapshotSequence - creates a snapshot of the objects of bucket
+// at the current time, assumes bucket is already validated.
+func newSnapshotSequence(bucket, clientAddr string) *snapshotSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "Snapshot", BucketName: bucket}
+
+	now := UTCNow()
+	return &snapshotSequence{
+		bucket: bucket,
+		status: madmin.SnapshotStatus{
+			ClientToken:  mustGetUUID(),
+			Summary:      madmin.SnapshotRunning,
+			StartTime:    now,
+			SnapshotTime: now,
+			Bucket:       bucket,
+		},
+		receivedCh:   make(chan struct{}, 1),
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the snapshot unless another one is running. A
+// running snapshot is stopped first if forceStart is set.
+func (s *snapshotState) Launch(seq *snapshotSequence, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, seq.run)
+}
+
+// Status - returns the status of the snapshot identified by
+// clientToken along with at most limit entries starting at the given
+// offset. Entries before the offset are considered received by the
+// client and are dropped, they cannot be requested again.
+func (s *snapshotState) Status(clientToken string, offset int64, limit int) (madmin.SnapshotStatus, []madmin.SnapshotEntry, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.SnapshotStatus{}, nil, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*snapshotSequence)
+
+	status, entries, err := seq.receive(offset, limit)
+	if err != nil {
+		return status, nil, ErrAdminInvalidArgument
+	}
+	return status, entries, ErrNone
+}
+
+// receive - drops the entries before offset and returns the status
+// of the snapshot with at most limit entries from offset.
+func (seq *snapshotSequence) receive(offset int64, limit int) (madmin.SnapshotStatus, []madmin.SnapshotEntry, error) {
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	if offset < seq.offset {
+		return madmin.SnapshotStatus{}, nil, errSnapshotOffsetTooOld
+	}
+	if offset > seq.status.ObjectsListed {
+		offset = seq.status.ObjectsListed
+	}
+	if received := offset - seq.offset; received > 0 {
+		seq.entries = append([]madmin.SnapshotEntry(nil), seq.entries[received:]...)
+		seq.offset = offset
+
+		// Wake up the snapshot if it waits for the client.
+		select {
+		case seq.receivedCh <- struct{}{}:
+		default:
+		}
+	}
+
+	if limit <= 0 || limit > maxSnapshotEntriesPerReply {
+		limit = maxSnapshotEntriesPerReply
+	}
+	start, end := pageBounds(len(seq.entries), 0, limit)
+
+	status := seq.status
+	status.Offset = offset
+	return status, append([]madmin.SnapshotEntry{}, seq.entries[start:end]...), nil
+}
+
+func (seq *snapshotSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *snapshotSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.SnapshotRunning
+}
+
+// stop - stops the snapshot, safe to call multiple times.
+func (seq *snapshotSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+func (seq *snapshotSequence) isQuitting() bool {
+	select {
+	case <-seq.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// pending - returns the number of entries not yet received.
+func (seq *snapshotSequence) pending() int {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return len(seq.entries)
+}
+
+// waitForClient - waits until the client received enough entries for
+// the snapshot to go on.
+func (seq *snapshotSequence) waitForClient() error {
+	for seq.pending() >= maxPendingSnapshotEntries {
+		select {
+		case <-seq.receivedCh:
+		case <-seq.stopSignalCh:
+			return errSnapshotStopSignalled
+		case <-time.After(snapshotIdleTimeout):
+			return errSnapshotIdle
+		}
+	}
+	return nil
+}
+
+// run - walks the namespace of the bucket and lists the objects as
+// they were at the snapshot time.
+func (seq *snapshotSequence) run() {
+	err := seq.list()
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if err != nil {
+		seq.status.Summary = madmin.SnapshotStopped
+		seq.status.FailureDetail = err.Error()
+		return
+	}
+	seq.status.Summary = madmin.SnapshotFinished
+}
+
+func (seq *snapshotSequence) list() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	marker := ""
+	for {
+		if seq.isQuitting() {
+			return errSnapshotStopSignalled
+		}
+		result, err := objectAPI.ListObjects(seq.ctx, seq.bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range result.Objects {
+			if err = seq.waitForClient(); err != nil {
+				return err
+			}
+
+			seq.mu.Lock()
+			if obj.ModTime.After(seq.status.SnapshotTime) {
+				seq.status.ObjectsChanged++
+			} else {
+				seq.entries = append(seq.entries, madmin.SnapshotEntry{
+					Object:  obj.Name,
+					Size:    obj.Size,
+					ETag:    obj.ETag,
+					ModTime: obj.ModTime,
+				})
+				seq.status.ObjectsListed++
+			}
+			seq.mu.Unlock()
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// encodeSnapshotNDJSON - returns the status of a snapshot followed by
+// its manifest entries, one JSON document per line.
+func encodeSnapshotNDJSON(status madmin.SnapshotStatus, entries []madmin.SnapshotEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if err := encoder.Encode(status); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,363 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Prefix under minioMetaBucket of the objects written by
+	// speedtests.
+	speedtestPrefix = "speedtest"
+
+	// Defaults and bounds of the speedtest parameters. Each phase
+	// lasts at most maxSpeedtestDuration, so that a server reports
+	// its result within the admin RPC timeout.
+	defaultSpeedtestSize        = 4 * humanize.MiByte
+	maxSpeedtestSize            = 256 * humanize.MiByte
+	defaultSpeedtestConcurrency = 8
+	maxSpeedtestConcurrency     = 64
+	defaultSpeedtestDuration    = 10 * time.Second
+	maxSpeedtestDuration        = 20 * time.Second
+
+	// Time the status of a speedtest is kept once it ended.
+	keepSpeedtestStatusDuration = 10 * time.Minute
+)
+
+// speedtestRecorder - records the operations of a speedtest phase.
+type speedtestRecorder struct {
+	sync.Mutex
+	objects      int64
+	bytes        int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+func (r *speedtestRecorder) record(size int64, latency time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.objects++
+	r.bytes += size
+	r.totalLatency += latency
+	if latency > r.maxLatency {
+		r.maxLatency = latency
+	}
+}
+
+// stats - returns the stats of the operations recorded during
+// elapsed.
+func (r *speedtestRecorder) stats(elapsed time.Duration) (stats madmin.SpeedtestStats) {
+	r.Lock()
+	defer r.Unlock()
+	stats.Objects = r.objects
+	stats.Bytes = r.bytes
+	stats.MaxLatency = r.maxLatency
+	if r.objects > 0 {
+		stats.AvgLatency = r.totalLatency / time.Duration(r.objects)
+	}
+	if elapsed > 0 {
+		stats.Throughput = uint64(float64(r.bytes) / elapsed.Seconds())
+	}
+	return stats
+}
+
+// runSpeedtestPhase - calls op in concurrency workers until duration
+// elapsed, the first error stops all workers.
+func runSpeedtestPhase(concurrency int, duration time.Duration, op func(worker, n int) (int64, error)) (madmin.SpeedtestStats, error) {
+	var recorder speedtestRecorder
+	var wg sync.WaitGroup
+	errs := make([]error, concurrency)
+	doneCh := make(chan struct{})
+	var closeOnce sync.Once
+
+	start := UTCNow()
+	deadline := start.Add(duration)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for n := 0; UTCNow().Before(deadline); n++ {
+				select {
+				case <-doneCh:
+					return
+				default:
+				}
+				opStart := UTCNow()
+				size, err := op(worker, n)
+				if err != nil {
+					errs[worker] = err
+					closeOnce.Do(func() { close(doneCh) })
+					return
+				}
+				recorder.record(size, UTCNow().Sub(opStart))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return madmin.SpeedtestStats{}, err
+		}
+	}
+	return recorder.stats(UTCNow().Sub(start)), nil
+}
+
+// runSpeedtest - writes objects of the given size with concurrency
+// workers during duration, reads them back during duration and
+// deletes them.
+func runSpeedtest(ctx context.Context, objAPI ObjectLayer, size int64, concurrency int, duration time.Duration) (result madmin.SpeedtestNodeResult, err error) {
+	data := make([]byte, size)
+	if _, err = io.ReadFull(rand.Reader, data); err != nil {
+		return result, err
+	}
+
+	prefix := pathJoin(speedtestPrefix, mustGetUUID())
+	objectName := func(worker, n int) string {
+		return pathJoin(prefix, fmt.Sprintf("%d/%d", worker, n))
+	}
+
+	// Objects written by each worker.
+	written := make([]int, concurrency)
+	defer func() {
+		for worker, count := range written {
+			for n := 0; n < count; n++ {
+				if err := objAPI.DeleteObject(ctx, minioMetaBucket, objectName(worker, n)); err != nil {
+					logger.LogIf(ctx, err)
+				}
+			}
+		}
+	}()
+
+	result.Write, err = runSpeedtestPhase(concurrency, duration, func(worker, n int) (int64, error) {
+		reader, err := hash.NewReader(bytes.NewReader(data), size, "", "")
+		if err != nil {
+			return 0, err
+		}
+		// Count the object before writing it so that a partially
+		// written one is deleted as well.
+		written[worker]++
+		if _, err = objAPI.PutObject(ctx, minioMetaBucket, objectName(worker, n), reader, nil); err != nil {
+			return 0, err
+		}
+		return size, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	var objects []string
+	for worker, count := range written {
+		for n := 0; n < count; n++ {
+			objects = append(objects, objectName(worker, n))
+		}
+	}
+	if len(objects) == 0 {
+		return result, nil
+	}
+
+	// Workers read the written objects in turn.
+	result.Read, err = runSpeedtestPhase(concurrency, duration, func(worker, n int) (int64, error) {
+		object := objects[(worker+n*concurrency)%len(objects)]
+		if err := objAPI.GetObject(ctx, minioMetaBucket, object, 0, size, ioutil.Discard, ""); err != nil {
+			return 0, err
+		}
+		return size, nil
+	})
+	return result, err
+}
+
+// speedtestSequence - state of a speedtest run by all servers at the
+// same time.
+type speedtestSequence struct {
+	// lock to update status as it is concurrently accessed
+	mu     sync.RWMutex
+	status madmin.SpeedtestStatus
+
+	// channel to signal the speedtest to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// speedtestState - holds the speedtest running on this server and
+// the ended ones until their status expires. Only one speedtest may
+// run at a time.
+type speedtestState struct {
+	*adminScanRegistry
+}
+
+var globalSpeedtestState = speedtestState{newAdminScanRegistry(keepSpeedtestStatusDuration)}
+
+// newSpeedtestSequence - creates a speedtest, assumes its parameters
+// are already validated.
+func newSpeedtestSequence(size int64, concurrency int, duration time.Duration, clientAddr string) *speedtestSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "Speedtest"}
+	return &speedtestSequence{
+		status: madmin.SpeedtestStatus{
+			ClientToken: mustGetUUID(),
+			Summary:     madmin.SpeedtestRunning,
+			StartTime:   UTCNow(),
+			Size:        size,
+			Concurrency: concurrency,
+			Duration:    duration,
+		},
+		stopSignalCh: make(chan struct{}),
+		ctx:          logger.SetReqInfo(context.Background(), reqInfo),
+	}
+}
+
+// Launch - starts the speedtest on the given servers unless another
+// one is running. A running speedtest is stopped first if forceStart
+// is set.
+func (s *speedtestState) Launch(seq *speedtestSequence, peers adminPeers, forceStart bool) APIErrorCode {
+	return s.launch(seq, forceStart, func() { seq.run(peers) })
+}
+
+// Status - returns the status of the speedtest identified by
+// clientToken.
+func (s *speedtestState) Status(clientToken string) (madmin.SpeedtestStatus, APIErrorCode) {
+	scan, ok := s.get(clientToken)
+	if !ok {
+		return madmin.SpeedtestStatus{}, ErrAdminScanNoSuchProcess
+	}
+	seq := scan.(*speedtestSequence)
+
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	status := seq.status
+	status.Nodes = append([]madmin.SpeedtestNodeResult{}, seq.status.Nodes...)
+	return status, ErrNone
+}
+
+func (seq *speedtestSequence) clientToken() string {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.ClientToken
+}
+
+func (seq *speedtestSequence) hasEnded() bool {
+	seq.mu.RLock()
+	defer seq.mu.RUnlock()
+	return seq.status.Summary != madmin.SpeedtestRunning
+}
+
+// stop - stops waiting for the results of the servers, safe to call
+// multiple times. Servers finish their run, which is bounded.
+func (seq *speedtestSequence) stop() {
+	select {
+	case <-seq.stopSignalCh:
+	default:
+		close(seq.stopSignalCh)
+	}
+}
+
+// run - runs the speedtest on all servers at the same time and
+// aggregates their results.
+func (seq *speedtestSequence) run(peers adminPeers) {
+	seq.mu.Lock()
+	seq.status.Nodes = make([]madmin.SpeedtestNodeResult, len(peers))
+	for i, peer := range peers {
+		seq.status.Nodes[i].Addr = peer.addr
+	}
+	size, concurrency, duration := seq.status.Size, seq.status.Concurrency, seq.status.Duration
+	seq.mu.Unlock()
+
+	doneCh := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			result, err := peer.cmdRunner.Speedtest(size, concurrency, duration)
+			result.Addr = peer.addr
+			result.Done = true
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(seq.ctx, reqInfo)
+				logger.LogIf(ctx, err)
+				result.Error = err.Error()
+			}
+
+			seq.mu.Lock()
+			seq.status.Nodes[idx] = result
+			seq.mu.Unlock()
+		}(i, p)
+	}
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+
+	var stopped bool
+	select {
+	case <-doneCh:
+	case <-seq.stopSignalCh:
+		stopped = true
+	}
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+	seq.status.EndTime = UTCNow()
+	if stopped {
+		seq.status.Summary = madmin.SpeedtestStopped
+		seq.status.FailureDetail = "speedtest stop signaled"
+		return
+	}
+	seq.status.Write, seq.status.Read = aggregateSpeedtestResults(seq.status.Nodes)
+	seq.status.Summary = madmin.SpeedtestFinished
+}
+
+// aggregateSpeedtestResults - sums the throughput of the servers
+// which succeeded, and averages their latency.
+func aggregateSpeedtestResults(nodes []madmin.SpeedtestNodeResult) (write, read madmin.SpeedtestStats) {
+	aggregate := func(total *madmin.SpeedtestStats, stats madmin.SpeedtestStats) {
+		if total.Objects+stats.Objects > 0 {
+			total.AvgLatency = time.Duration((int64(total.AvgLatency)*total.Objects + int64(stats.AvgLatency)*stats.Objects) /
+				(total.Objects + stats.Objects))
+		}
+		total.Objects += stats.Objects
+		total.Bytes += stats.Bytes
+		total.Throughput += stats.Throughput
+		if stats.MaxLatency > total.MaxLatency {
+			total.MaxLatency = stats.MaxLatency
+		}
+	}
+	for _, node := range nodes {
+		if node.Error != "" {
+			continue
+		}
+		aggregate(&write, node.Write)
+		aggregate(&read, node.Read)
+	}
+	return write, read
+}
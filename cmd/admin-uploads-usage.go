@@ -0,0 +1,157 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Metadata key recording the bucket of a multipart upload, as the
+// directory of an upload is only named after the hash of its bucket
+// and object. It is removed once the upload is completed.
+const multipartUploadBucketKey = ReservedMetadataPrefix + "Upload-Bucket"
+
+// uploadsUsage - in-progress multipart uploads aggregated by bucket,
+// uploads created before their bucket was recorded are aggregated
+// under an empty bucket name.
+type uploadsUsage map[string]*madmin.BucketUploadsUsage
+
+// add - records an in-progress upload of the given bucket.
+func (u uploadsUsage) add(bucket string, size int64, modTime time.Time) {
+	usage, ok := u[bucket]
+	if !ok {
+		usage = &madmin.BucketUploadsUsage{Bucket: bucket, OldestModTime: modTime}
+		u[bucket] = usage
+	}
+	usage.Count++
+	usage.Size += size
+	if modTime.Before(usage.OldestModTime) {
+		usage.OldestModTime = modTime
+	}
+}
+
+// toUploadsUsage - returns the usage of all buckets, sorted by name,
+// along with the totals.
+func (u uploadsUsage) toUploadsUsage() madmin.UploadsUsage {
+	result := madmin.UploadsUsage{Buckets: []madmin.BucketUploadsUsage{}}
+	for _, usage := range u {
+		result.Count += usage.Count
+		result.Size += usage.Size
+		result.Buckets = append(result.Buckets, *usage)
+	}
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Bucket < result.Buckets[j].Bucket
+	})
+	return result
+}
+
+// getUploadsUsage - returns the count and size of the parts of the
+// in-progress multipart uploads of an FS or erasure coded backend.
+func getUploadsUsage(ctx context.Context, objectAPI ObjectLayer) (madmin.UploadsUsage, error) {
+	usage := make(uploadsUsage)
+	switch objLayer := objectAPI.(type) {
+	case *FSObjects:
+		objLayer.addUploadsUsage(ctx, usage)
+	case *xlObjects:
+		objLayer.addUploadsUsage(ctx, usage)
+	case *xlSets:
+		for _, set := range objLayer.sets {
+			set.addUploadsUsage(ctx, usage)
+		}
+	default:
+		return madmin.UploadsUsage{}, NotImplemented{}
+	}
+	return usage.toUploadsUsage(), nil
+}
+
+// addUploadsUsage - records the in-progress multipart uploads, the
+// parts of an upload are the files of its directory.
+func (fs *FSObjects) addUploadsUsage(ctx context.Context, usage uploadsUsage) {
+	multipartDir := pathJoin(fs.fsPath, minioMetaMultipartBucket)
+	shaDirs, err := readDir(multipartDir)
+	if err != nil {
+		return
+	}
+	for _, shaDir := range shaDirs {
+		uploadIDs, err := readDir(pathJoin(multipartDir, shaDir))
+		if err != nil {
+			continue
+		}
+		for _, uploadID := range uploadIDs {
+			uploadIDDir := pathJoin(multipartDir, shaDir, uploadID)
+			fi, err := fsStatDir(ctx, uploadIDDir)
+			if err != nil {
+				continue
+			}
+			fsMetaBytes, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
+			if err != nil {
+				continue
+			}
+			entries, err := readDir(uploadIDDir)
+			if err != nil {
+				continue
+			}
+			var size int64
+			for _, entry := range entries {
+				if entry == fs.metaJSONFile {
+					continue
+				}
+				if partFi, err := fsStatFile(ctx, pathJoin(uploadIDDir, entry)); err == nil {
+					size += partFi.Size()
+				}
+			}
+			usage.add(parseFSMetaMap(fsMetaBytes)[multipartUploadBucketKey], size, fi.ModTime())
+		}
+	}
+}
+
+// addUploadsUsage - records the in-progress multipart uploads, read
+// from the first disk of the set which is online.
+func (xl xlObjects) addUploadsUsage(ctx context.Context, usage uploadsUsage) {
+	for _, disk := range xl.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		shaDirs, err := disk.ListDir(minioMetaMultipartBucket, "", -1)
+		if err != nil {
+			continue
+		}
+		for _, shaDir := range shaDirs {
+			uploadIDDirs, err := disk.ListDir(minioMetaMultipartBucket, shaDir, -1)
+			if err != nil {
+				continue
+			}
+			for _, uploadIDDir := range uploadIDDirs {
+				xlMeta, err := readXLMeta(ctx, disk, minioMetaMultipartBucket, pathJoin(shaDir, uploadIDDir))
+				if err != nil {
+					continue
+				}
+				var size int64
+				for _, part := range xlMeta.Parts {
+					size += part.Size
+				}
+				usage.add(xlMeta.Meta[multipartUploadBucketKey], size, xlMeta.Stat.ModTime)
+			}
+		}
+		return
+	}
+}
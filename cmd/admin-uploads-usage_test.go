@@ -0,0 +1,85 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Tests the usage of the in-progress multipart uploads.
+func TestUploadsUsage(t *testing.T) {
+	ExecObjectLayerTest(t, testUploadsUsage)
+}
+
+func testUploadsUsage(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	for _, bucket := range []string{"bucket1", "bucket2"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	upload := func(bucket, object string, parts int) string {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		for i := 1; i <= parts; i++ {
+			reader := mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", "")
+			if _, err = obj.PutObjectPart(ctx, bucket, object, uploadID, i, reader); err != nil {
+				t.Fatalf("%s: %v", instanceType, err)
+			}
+		}
+		return uploadID
+	}
+	upload("bucket1", "object1", 2)
+	upload("bucket1", "dir/object2", 1)
+	upload("bucket2", "object1", 0)
+
+	// Completed uploads are no longer in progress and do not keep
+	// the bucket of the upload in their metadata.
+	uploadID := upload("bucket2", "object2", 1)
+	parts := []CompletePart{{PartNumber: 1, ETag: getMD5Hash(data)}}
+	if _, err := obj.CompleteMultipartUpload(ctx, "bucket2", "object2", uploadID, parts); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, "bucket2", "object2")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, ok := objInfo.UserDefined[multipartUploadBucketKey]; ok {
+		t.Errorf("%s: Expected the upload bucket to be removed from the metadata, got %v", instanceType, objInfo.UserDefined)
+	}
+
+	usage, err := getUploadsUsage(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if usage.Count != 3 || usage.Size != 3*int64(len(data)) || len(usage.Buckets) != 2 {
+		t.Fatalf("%s: Unexpected uploads usage %#v", instanceType, usage)
+	}
+	bucket1, bucket2 := usage.Buckets[0], usage.Buckets[1]
+	if bucket1.Bucket != "bucket1" || bucket1.Count != 2 || bucket1.Size != 3*int64(len(data)) || bucket1.OldestModTime.IsZero() {
+		t.Errorf("%s: Unexpected usage of bucket1 %#v", instanceType, bucket1)
+	}
+	if bucket2.Bucket != "bucket2" || bucket2.Count != 1 || bucket2.Size != 0 {
+		t.Errorf("%s: Unexpected usage of bucket2 %#v", instanceType, bucket2)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Environment variable limiting the requests served at once.
+	maxRequestsEnv = "MINIO_MAX_REQUESTS"
+
+	// Time a request over the limit waits for another one to
+	// complete before it is rejected.
+	admissionQueueTimeout = time.Second
+)
+
+// admissionController - limits the number of requests served at once.
+// Requests over the limit wait in a queue, as long as the limit, for
+// a request to complete and are rejected when the queue is full or
+// no request completed in time, so that an overloaded server sheds
+// load instead of collapsing.
+type admissionController struct {
+	sync.Mutex
+
+	// Maximum number of requests served at once, 0 if unlimited.
+	limit int
+
+	inflight int
+	queue    []chan struct{}
+	rejected uint64
+}
+
+// acquire - admits a request, waiting for a slot if the limit is
+// reached. Returns false if the request is rejected.
+func (a *admissionController) acquire(ctx context.Context, timeout time.Duration) bool {
+	a.Lock()
+	if a.limit == 0 || (a.inflight < a.limit && len(a.queue) == 0) {
+		a.inflight++
+		a.Unlock()
+		return true
+	}
+	if len(a.queue) >= a.limit {
+		a.rejected++
+		a.Unlock()
+		return false
+	}
+	admitted := make(chan struct{})
+	a.queue = append(a.queue, admitted)
+	a.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-admitted:
+		return true
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	for i, ch := range a.queue {
+		if ch == admitted {
+			a.queue = append(a.queue[:i], a.queue[i+1:]...)
+			a.rejected++
+			return false
+		}
+	}
+	// The request was admitted while giving up, serve it.
+	return true
+}
+
+// release - records that an admitted request completed, handing its
+// slot over to the oldest waiting request.
+func (a *admissionController) release() {
+	a.Lock()
+	defer a.Unlock()
+	if len(a.queue) > 0 && (a.limit == 0 || a.inflight <= a.limit) {
+		close(a.queue[0])
+		a.queue = a.queue[1:]
+		return
+	}
+	a.inflight--
+}
+
+// setLimit - changes the maximum number of requests served at once,
+// waiting requests are admitted right away if the limit was raised.
+func (a *admissionController) setLimit(limit int) {
+	a.Lock()
+	defer a.Unlock()
+	a.limit = limit
+	for len(a.queue) > 0 && (limit == 0 || a.inflight < limit) {
+		close(a.queue[0])
+		a.queue = a.queue[1:]
+		a.inflight++
+	}
+}
+
+// status - returns the current state of the admission control.
+func (a *admissionController) status() madmin.AdmissionStatus {
+	a.Lock()
+	defer a.Unlock()
+	return madmin.AdmissionStatus{
+		MaxRequests:      a.limit,
+		MaxQueued:        a.limit,
+		QueueTimeout:     admissionQueueTimeout,
+		InflightRequests: a.inflight,
+		QueuedRequests:   len(a.queue),
+		RejectedRequests: a.rejected,
+	}
+}
+
+// Prepare new admissionController structure
+func newAdmissionController(limit int) *admissionController {
+	return &admissionController{limit: limit}
+}
+
+// parseMaxRequests - parses the maximum number of requests served at
+// once.
+func parseMaxRequests(s string) (int, error) {
+	limit, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("limit `%s` must not be negative", s)
+	}
+	return limit, nil
+}
+
+// setAdmissionControlHandler middleware rejects requests over the
+// maximum number of requests served at once with a 503 response.
+// Admin and inter-node requests are always admitted, so that the
+// limit can be tuned and servers keep talking to each other under
+// overload.
+func setAdmissionControlHandler(h http.Handler) http.Handler {
+	return admissionControlHandler{h}
+}
+
+type admissionControlHandler struct {
+	handler http.Handler
+}
+
+func (a admissionControlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resource := getRequestResource(r)
+	if resource == minioReservedBucketPath || strings.HasPrefix(resource, minioReservedBucketPath+"/") {
+		a.handler.ServeHTTP(w, r)
+		return
+	}
+	if !globalAdmissionController.acquire(r.Context(), admissionQueueTimeout) {
+		writeErrorResponse(w, ErrTooManyRequestsInFlight, r.URL)
+		return
+	}
+	defer globalAdmissionController.release()
+	a.handler.ServeHTTP(w, r)
+}
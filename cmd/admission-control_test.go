@@ -0,0 +1,154 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseMaxRequests(t *testing.T) {
+	testCases := []struct {
+		value      string
+		expected   int
+		shouldPass bool
+	}{
+		{"0", 0, true},
+		{"1000", 1000, true},
+		{"-1", 0, false},
+		{"many", 0, false},
+		{"", 0, false},
+	}
+	for i, testCase := range testCases {
+		limit, err := parseMaxRequests(testCase.value)
+		if (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+		if err == nil && limit != testCase.expected {
+			t.Errorf("Test %d: Expected limit %d, got %d", i+1, testCase.expected, limit)
+		}
+	}
+}
+
+// Tests that requests over the limit wait for a slot and are rejected
+// once the queue is full or the timeout expires.
+func TestAdmissionController(t *testing.T) {
+	a := newAdmissionController(1)
+	ctx := context.Background()
+
+	if !a.acquire(ctx, time.Millisecond) {
+		t.Fatal("Expected first request to be admitted")
+	}
+	// The queue is as long as the limit, the waiting request times out.
+	if a.acquire(ctx, time.Millisecond) {
+		t.Fatal("Expected request over the limit to time out")
+	}
+
+	admitted := make(chan bool)
+	go func() {
+		admitted <- a.acquire(ctx, time.Minute)
+	}()
+	for a.status().QueuedRequests != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	// The queue is full.
+	if a.acquire(ctx, time.Minute) {
+		t.Fatal("Expected request over the queue length to be rejected")
+	}
+
+	// Completing the first request admits the waiting one.
+	a.release()
+	if !<-admitted {
+		t.Fatal("Expected waiting request to be admitted")
+	}
+
+	status := a.status()
+	if status.InflightRequests != 1 || status.QueuedRequests != 0 || status.RejectedRequests != 2 {
+		t.Fatalf("Unexpected status %#v", status)
+	}
+
+	// Raising the limit admits waiting requests right away.
+	go func() {
+		admitted <- a.acquire(ctx, time.Minute)
+	}()
+	for a.status().QueuedRequests != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	a.setLimit(0)
+	if !<-admitted {
+		t.Fatal("Expected waiting request to be admitted")
+	}
+	if !a.acquire(ctx, time.Millisecond) {
+		t.Fatal("Expected request to be admitted without a limit")
+	}
+
+	a.release()
+	a.release()
+	a.release()
+	if status = a.status(); status.InflightRequests != 0 || status.MaxRequests != 0 {
+		t.Fatalf("Unexpected status %#v", status)
+	}
+}
+
+// Tests that requests over the limit are rejected with a 503 while
+// admin requests are always served.
+func TestAdmissionControlHandler(t *testing.T) {
+	tmpGlobalAdmissionController := globalAdmissionController
+	defer func() {
+		globalAdmissionController = tmpGlobalAdmissionController
+	}()
+	globalAdmissionController = newAdmissionController(1)
+
+	// Fill the only slot and the queue.
+	if !globalAdmissionController.acquire(context.Background(), time.Millisecond) {
+		t.Fatal("Expected request to be admitted")
+	}
+	go globalAdmissionController.acquire(context.Background(), time.Minute)
+	for globalAdmissionController.status().QueuedRequests != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	handler := setAdmissionControlHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		path         string
+		expectedCode int
+	}{
+		{"/bucket/object", http.StatusServiceUnavailable},
+		{"/bucket", http.StatusServiceUnavailable},
+		{minioReservedBucketPath + "/admin/v1/admission", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: Expected status %d but got %d", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code == http.StatusServiceUnavailable && rec.Header().Get("Retry-After") != "1" {
+			t.Errorf("Test %d: Expected Retry-After header", i+1)
+		}
+	}
+
+	// Release the waiting request.
+	globalAdmissionController.setLimit(0)
+}
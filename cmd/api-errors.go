@@ -47,6 +47,9 @@ type APIErrorResponse struct {
 	Resource   string
 	RequestID  string `xml:"RequestId" json:"RequestId"`
 	HostID     string `xml:"HostId" json:"HostId"`
+	// Additional details about the error, only set in JSON
+	// responses of admin APIs.
+	Details []string `xml:"-" json:"Details,omitempty"`
 }
 
 // APIErrorCode type of error status.
@@ -192,7 +195,25 @@ const (
 	ErrAdminConfigNoQuorum
 	ErrAdminConfigTooLarge
 	ErrAdminConfigBadJSON
+	ErrAdminConfigDecryptFailed
+	ErrAdminConfigNoKMS
 	ErrAdminCredentialsMismatch
+	ErrAdminInvalidArgument
+	ErrAdminPeerCredentialsFailed
+	ErrAdminPeerCredentialsRollbackFailed
+	ErrAdminScanNoSuchProcess
+	ErrAdminScanAlreadyRunning
+	ErrAdminNoScheduledRestart
+	ErrAdminNoSuchRequest
+	ErrAdminNoSuchCredential
+	ErrAdminTLSNotConfigured
+	ErrAdminChaosTestingDisabled
+	ErrAdminConfirmationRequired
+	ErrAdminActionDisabled
+	ErrAdminCredentialChangeDenied
+	ErrAdminConfigSectionReadOnly
+	ErrTenantRateLimitExceeded
+	ErrTooManyRequestsInFlight
 	ErrInsecureClientRequest
 	ErrObjectTampered
 
@@ -202,6 +223,11 @@ const (
 	ErrHealMissingBucket
 	ErrHealAlreadyRunning
 	ErrHealOverlappingPaths
+	ErrHealInvalidConcurrency
+	ErrHealInvalidErasureSet
+	ErrHealNotRunning
+	ErrHealNotPaused
+	ErrHealInvalidNotifyTarget
 	ErrIncorrectContinuationToken
 
 	//S3 Select Errors
@@ -886,11 +912,101 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "JSON configuration provided has objects with duplicate keys",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminConfigDecryptFailed: {
+		Code:           "XMinioAdminConfigDecryptFailed",
+		Description:    "Data provided could not be decrypted, it must be encrypted with the secret key of the credentials signing the request",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminConfigNoKMS: {
+		Code:           "XMinioAdminConfigNoKMS",
+		Description:    "Configuration encryption with a KMS was requested but no KMS is configured on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAdminCredentialsMismatch: {
 		Code:           "XMinioAdminCredentialsMismatch",
 		Description:    "Credentials in config mismatch with server environment variables",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrAdminInvalidArgument: {
+		Code:           "XMinioAdminInvalidArgument",
+		Description:    "Invalid arguments specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminPeerCredentialsFailed: {
+		Code:           "XMinioAdminPeerCredentialsFailed",
+		Description:    "Credentials update was rolled back because some peers failed to load the new credentials",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrAdminPeerCredentialsRollbackFailed: {
+		Code:           "XMinioAdminPeerCredentialsRollbackFailed",
+		Description:    "Some peers failed to load the new credentials and the credentials update could not be rolled back on all servers",
+		HTTPStatusCode: http.StatusInternalServerError,
+	},
+	ErrAdminScanNoSuchProcess: {
+		Code:           "XMinioAdminScanNoSuchProcess",
+		Description:    "No such scan process is running on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminScanAlreadyRunning: {
+		Code:           "XMinioAdminScanAlreadyRunning",
+		Description:    "A scan process is already running on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoScheduledRestart: {
+		Code:           "XMinioAdminNoScheduledRestart",
+		Description:    "No restart is scheduled on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchRequest: {
+		Code:           "XMinioAdminNoSuchRequest",
+		Description:    "No request with the given ID is being served",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminNoSuchCredential: {
+		Code:           "XMinioAdminNoSuchCredential",
+		Description:    "No admin credential with the given access key exists",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminTLSNotConfigured: {
+		Code:           "XMinioAdminTLSNotConfigured",
+		Description:    "Server is not configured with TLS certificates",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminChaosTestingDisabled: {
+		Code:           "XMinioAdminChaosTestingDisabled",
+		Description:    "Chaos testing is disabled on this server",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminConfirmationRequired: {
+		Code:           "XMinioAdminConfirmationRequired",
+		Description:    "This admin action requires a valid confirmation token",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminActionDisabled: {
+		Code:           "XMinioAdminActionDisabled",
+		Description:    "This admin API is disabled by policy",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminCredentialChangeDenied: {
+		Code:           "XMinioAdminCredentialChangeDenied",
+		Description:    "Only the credential of the server may change the credential of the server",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminConfigSectionReadOnly: {
+		Code:           "XMinioAdminConfigSectionReadOnly",
+		Description:    "This config section can only be changed by editing config.json",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrTenantRateLimitExceeded: {
+		Code:           "XMinioTenantRateLimitExceeded",
+		Description:    "Request rate of the bucket or prefix exceeds its limit, please reduce your request rate",
+		HTTPStatusCode: http.StatusTooManyRequests,
+	},
+	ErrTooManyRequestsInFlight: {
+		Code:           "SlowDown",
+		Description:    "Server is serving too many requests at once, please retry later",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -955,6 +1071,31 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrHealInvalidConcurrency: {
+		Code:           "XMinioHealInvalidConcurrency",
+		Description:    fmt.Sprintf("Heal concurrency must be between 0 and %d", maxHealConcurrency),
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrHealInvalidErasureSet: {
+		Code:           "XMinioHealInvalidErasureSet",
+		Description:    "Heal erasure set is not an erasure set of the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrHealNotRunning: {
+		Code:           "XMinioHealNotRunning",
+		Description:    "The heal sequence is not running and cannot be paused",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrHealNotPaused: {
+		Code:           "XMinioHealNotPaused",
+		Description:    "The heal sequence is not paused and cannot be resumed",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrHealInvalidNotifyTarget: {
+		Code:           "XMinioHealInvalidNotifyTarget",
+		Description:    "The heal notification target does not exist or its interval is negative",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrBackendDown: {
 		Code:           "XMinioBackendDown",
 		Description:    "Object storage backend is unreachable",
@@ -34,6 +34,9 @@ func mustGetRequestID(t time.Time) string {
 
 // Write http common headers
 func setCommonHeaders(w http.ResponseWriter) {
+	// Custom headers come first, they cannot override the ones
+	// set by the server anyway.
+	globalResponseHeaders.apply(w)
 	w.Header().Set("Server", globalServerUserAgent)
 	// Set `x-amz-bucket-region` only if region is set on the server
 	// by default minio uses an empty region.
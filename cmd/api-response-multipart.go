@@ -23,7 +23,7 @@ type completeMultipartAPIError struct {
 	// Proposed size represents uploaded size of the part.
 	ProposedSize int64
 	// Minimum size allowed epresents the minimum size allowed per
-	// part. Defaults to 5MB.
+	// part. Defaults to 5MB, unless configured otherwise.
 	MinSizeAllowed int64
 	// Part number of the part which is incorrect.
 	PartNumber int
@@ -45,7 +45,7 @@ func writePartSmallErrorResponse(w http.ResponseWriter, r *http.Request, err Par
 	apiError := getAPIError(toAPIErrorCode(err))
 	// Generate complete multipart error response.
 	errorResponse := getAPIErrorResponse(apiError, r.URL.Path, w.Header().Get(responseRequestIDKey))
-	cmpErrResp := completeMultipartAPIError{err.PartSize, int64(5242880), err.PartNumber, err.PartETag, errorResponse}
+	cmpErrResp := completeMultipartAPIError{err.PartSize, globalMultipartPolicy.getMinPartSize(), err.PartNumber, err.PartETag, errorResponse}
 	encodedErrorResponse := encodeResponse(cmpErrResp)
 
 	// respond with 400 bad request.
@@ -17,10 +17,13 @@
 package cmd
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +35,10 @@ const (
 	maxObjectList     = 1000                       // Limit number of objects in a listObjectsResponse.
 	maxUploadsList    = 1000                       // Limit number of uploads in a listUploadsResponse.
 	maxPartsList      = 1000                       // Limit number of parts in a listPartsResponse.
+
+	// Minimum size of the responses compressed for the clients
+	// accepting gzip, smaller ones do not benefit from it.
+	minGzipResponseSize = 1024
 )
 
 // LocationResponse - format for location response.
@@ -538,6 +545,12 @@ const (
 	mimeJSON mimeType = "application/json"
 	// Means response type is XML.
 	mimeXML mimeType = "application/xml"
+	// Means response type is YAML.
+	mimeYAML mimeType = "application/yaml"
+	// Means response type is a zip archive.
+	mimeZip mimeType = "application/zip"
+	// Means response type is newline delimited JSON.
+	mimeNDJSON mimeType = "application/x-ndjson"
 )
 
 // writeSuccessResponseJSON writes success headers and response if any,
@@ -546,6 +559,55 @@ func writeSuccessResponseJSON(w http.ResponseWriter, response []byte) {
 	writeResponse(w, http.StatusOK, response, mimeJSON)
 }
 
+// acceptsGzip - returns whether the Accept-Encoding header of the
+// request accepts gzip encoded responses.
+func acceptsGzip(r *http.Request) bool {
+	for _, header := range r.Header["Accept-Encoding"] {
+		for _, coding := range strings.Split(header, ",") {
+			params := strings.Split(coding, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), "gzip") {
+				continue
+			}
+			// A zero quality value means not acceptable.
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if !strings.HasPrefix(param, "q=") {
+					continue
+				}
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil && q == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// writeSuccessResponseJSONGzip writes success headers and response,
+// gzip compressed if the client accepts it and the response is large
+// enough.
+func writeSuccessResponseJSONGzip(w http.ResponseWriter, r *http.Request, response []byte) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if len(response) < minGzipResponseSize || !acceptsGzip(r) {
+		writeSuccessResponseJSON(w, response)
+		return
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	if _, err := gzipWriter.Write(response); err != nil {
+		writeSuccessResponseJSON(w, response)
+		return
+	}
+	if err := gzipWriter.Close(); err != nil {
+		writeSuccessResponseJSON(w, response)
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	writeSuccessResponseJSON(w, buf.Bytes())
+}
+
 // writeSuccessResponseXML writes success headers and response if any,
 // with content-type set to `application/xml`.
 func writeSuccessResponseXML(w http.ResponseWriter, response []byte) {
@@ -574,8 +636,17 @@ func writeErrorResponse(w http.ResponseWriter, errorCode APIErrorCode, reqURL *u
 		// Set retry-after header to indicate user-agents to retry request after 120secs.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
 		w.Header().Set("Retry-After", "120")
+	case ErrTenantRateLimitExceeded:
+		// Requests are allowed again as soon as the rate of
+		// the tenant falls under its limit.
+		w.Header().Set("Retry-After", "1")
+	case ErrTooManyRequestsInFlight:
+		// Requests are admitted again as soon as others
+		// complete.
+		w.Header().Set("Retry-After", "1")
 	}
 	apiError := getAPIError(errorCode)
+	globalAPIErrorStats.inc(apiError.Code)
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(apiError, reqURL.Path, w.Header().Get(responseRequestIDKey))
 	encodedErrorResponse := encodeResponse(errorResponse)
@@ -584,6 +655,7 @@ func writeErrorResponse(w http.ResponseWriter, errorCode APIErrorCode, reqURL *u
 
 func writeErrorResponseHeadersOnly(w http.ResponseWriter, errorCode APIErrorCode) {
 	apiError := getAPIError(errorCode)
+	globalAPIErrorStats.inc(apiError.Code)
 	writeResponse(w, apiError.HTTPStatusCode, nil, mimeNone)
 }
 
@@ -591,6 +663,7 @@ func writeErrorResponseHeadersOnly(w http.ResponseWriter, errorCode APIErrorCode
 // useful for admin APIs.
 func writeErrorResponseJSON(w http.ResponseWriter, errorCode APIErrorCode, reqURL *url.URL) {
 	apiError := getAPIError(errorCode)
+	globalAPIErrorStats.inc(apiError.Code)
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(apiError, reqURL.Path, w.Header().Get(responseRequestIDKey))
 	encodedErrorResponse := encodeResponseJSON(errorResponse)
@@ -599,17 +672,20 @@ func writeErrorResponseJSON(w http.ResponseWriter, errorCode APIErrorCode, reqUR
 
 // writeCustomErrorResponseJSON - similar to writeErrorResponseJSON,
 // but accepts the error message directly (this allows messages to be
-// dynamically generated.)
+// dynamically generated.) Optional details are sent as a list along
+// with the message.
 func writeCustomErrorResponseJSON(w http.ResponseWriter, errorCode APIErrorCode,
-	errBody string, reqURL *url.URL) {
+	errBody string, reqURL *url.URL, details ...string) {
 
 	apiError := getAPIError(errorCode)
+	globalAPIErrorStats.inc(apiError.Code)
 	errorResponse := APIErrorResponse{
 		Code:      apiError.Code,
 		Message:   errBody,
 		Resource:  reqURL.Path,
 		RequestID: "3L137",
 		HostID:    "3L137",
+		Details:   details,
 	}
 	encodedErrorResponse := encodeResponseJSON(errorResponse)
 	writeResponse(w, apiError.HTTPStatusCode, encodedErrorResponse, mimeJSON)
@@ -17,7 +17,11 @@
 package cmd
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -119,3 +123,52 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests that JSON responses are gzip compressed only when accepted.
+func TestWriteSuccessResponseJSONGzip(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"status":"ok"}`), minGzipResponseSize)
+	small := []byte(`{"status":"ok"}`)
+
+	testCases := []struct {
+		acceptEncoding string
+		response       []byte
+		expectGzip     bool
+	}{
+		{"", large, false},
+		{"gzip", large, true},
+		{"deflate, GZIP;q=0.5", large, true},
+		{"gzip;q=0", large, false},
+		{"identity", large, false},
+		{"gzip", small, false},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/minio/admin/v1/heal/", nil)
+		if testCase.acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", testCase.acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		writeSuccessResponseJSONGzip(rec, req, testCase.response)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: expected status %d, got %d", i+1, http.StatusOK, rec.Code)
+		}
+		isGzip := rec.Header().Get("Content-Encoding") == "gzip"
+		if isGzip != testCase.expectGzip {
+			t.Fatalf("Test %d: expected gzip %v, got %v", i+1, testCase.expectGzip, isGzip)
+		}
+
+		body := rec.Body.Bytes()
+		if isGzip {
+			gzipReader, err := gzip.NewReader(rec.Body)
+			if err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+			if body, err = ioutil.ReadAll(gzipReader); err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+		}
+		if !bytes.Equal(body, testCase.response) {
+			t.Errorf("Test %d: unexpected response body", i+1)
+		}
+	}
+}
@@ -28,7 +28,9 @@ import (
 	"strings"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/policy"
 )
 
@@ -111,11 +113,29 @@ func getRequestAuthType(r *http.Request) authType {
 }
 
 // checkAdminRequestAuthType checks whether the request is a valid signature V2 or V4 request.
-// It does not accept presigned or JWT or anonymous requests.
-func checkAdminRequestAuthType(r *http.Request, region string) APIErrorCode {
+// It does not accept presigned or JWT or anonymous requests. Requests signed with an admin
+// credential are only accepted if its policy permits the given action.
+// Requests for an action disabled by MINIO_ADMIN_DISABLED_ACTIONS are
+// rejected whatever their signature.
+func checkAdminRequestAuthType(r *http.Request, action adminAction, region string) APIErrorCode {
+	if globalDisabledAdminActions.isDisabled(action) {
+		return ErrAdminActionDisabled
+	}
+
 	s3Err := ErrAccessDenied
 	if _, ok := r.Header["X-Amz-Content-Sha256"]; ok && getRequestAuthType(r) == authTypeSigned && !skipContentSha256Cksum(r) { // we only support V4 (no presign) with auth. body
-		s3Err = isReqAuthenticated(r, region)
+		var cred auth.Credentials
+		var adminPolicy *madmin.AdminPolicy
+		cred, adminPolicy, s3Err = getAdminRequestCredential(r, region)
+		if s3Err == ErrNone {
+			s3Err = doesSignatureMatchCred(cred, getContentSha256Cksum(r), r, region)
+		}
+		if s3Err == ErrNone {
+			s3Err = verifyRequestContent(r)
+		}
+		if s3Err == ErrNone && adminPolicy != nil && !isAdminActionAllowed(*adminPolicy, action) {
+			s3Err = ErrAccessDenied
+		}
 	}
 	if s3Err != ErrNone {
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
@@ -182,7 +202,9 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		IsOwner:         isOwner,
 		ObjectName:      objectName,
 	}) {
-		return ErrNone
+		// Authorized requests count against the rate limit of
+		// their tenant.
+		return checkTenantRateLimit(r, bucketName, objectName)
 	}
 
 	return ErrAccessDenied
@@ -213,7 +235,12 @@ func isReqAuthenticated(r *http.Request, region string) (s3Error APIErrorCode) {
 	if errCode := reqSignatureV4Verify(r, region); errCode != ErrNone {
 		return errCode
 	}
+	return verifyRequestContent(r)
+}
 
+// verifyRequestContent - verifies the 'Content-Md5' and
+// 'X-Amz-Content-Sha256' of the body of a request while it is read.
+func verifyRequestContent(r *http.Request) (s3Error APIErrorCode) {
 	var (
 		err                       error
 		contentMD5, contentSHA256 []byte
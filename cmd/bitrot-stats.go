@@ -0,0 +1,80 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// bitrotStats - counts the reads of the local disks which detected
+// bitrot since the counters were last reset.
+type bitrotStats struct {
+	mu    sync.Mutex
+	since time.Time
+	disks map[string]*madmin.DiskBitrot
+}
+
+// Global bitrot counters of the local disks.
+var globalBitrotStats = newBitrotStats()
+
+// Prepare new bitrotStats structure.
+func newBitrotStats() *bitrotStats {
+	return &bitrotStats{
+		since: UTCNow(),
+		disks: make(map[string]*madmin.DiskBitrot),
+	}
+}
+
+// record - records bitrot detected while reading the given disk.
+func (b *bitrotStats) record(diskPath string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	disk, ok := b.disks[diskPath]
+	if !ok {
+		disk = &madmin.DiskBitrot{Path: diskPath}
+		b.disks[diskPath] = disk
+	}
+	disk.Count++
+	disk.LastDetected = UTCNow()
+}
+
+// get - returns the counters of the disks, sorted by path, along with
+// the time since which they count.
+func (b *bitrotStats) get() madmin.ServerBitrotStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := madmin.ServerBitrotStats{Since: b.since}
+	for _, disk := range b.disks {
+		stats.Disks = append(stats.Disks, *disk)
+	}
+	sort.Slice(stats.Disks, func(i, j int) bool {
+		return stats.Disks[i].Path < stats.Disks[j].Path
+	})
+	return stats
+}
+
+// reset - resets the counters of all disks.
+func (b *bitrotStats) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.since = UTCNow()
+	b.disks = make(map[string]*madmin.DiskBitrot)
+}
@@ -0,0 +1,49 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+// Tests counting, listing and resetting the bitrot detected on disks.
+func TestBitrotStats(t *testing.T) {
+	stats := newBitrotStats()
+	if s := stats.get(); len(s.Disks) != 0 || s.Since.IsZero() {
+		t.Fatalf("Unexpected initial stats %v", s)
+	}
+
+	stats.record("/data2")
+	stats.record("/data1")
+	stats.record("/data2")
+
+	s := stats.get()
+	if len(s.Disks) != 2 {
+		t.Fatalf("Expected 2 disks, got %v", s.Disks)
+	}
+	if s.Disks[0].Path != "/data1" || s.Disks[0].Count != 1 {
+		t.Errorf("Unexpected stats of /data1 %v", s.Disks[0])
+	}
+	if s.Disks[1].Path != "/data2" || s.Disks[1].Count != 2 || s.Disks[1].LastDetected.IsZero() {
+		t.Errorf("Unexpected stats of /data2 %v", s.Disks[1])
+	}
+
+	stats.reset()
+	if r := stats.get(); len(r.Disks) != 0 || r.Since.Before(s.Since) {
+		t.Errorf("Unexpected stats after reset %v", r)
+	}
+}
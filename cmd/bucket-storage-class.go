@@ -0,0 +1,112 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Config section holding the storage classes.
+	configSectionStorageClass = "storageclass"
+
+	// Config section holding the default storage classes of buckets.
+	configSectionBucketStorageClasses = "bucketStorageClasses"
+)
+
+var errInvalidBucketStorageClass = errors.New("bucket storage class must have a valid bucket name and storage class")
+
+// bucketStorageClasses - default storage class of buckets, applied to
+// objects written without a storage class.
+type bucketStorageClasses struct {
+	sync.RWMutex
+	classes map[string]string
+}
+
+// Default storage class of buckets loaded from config.json.
+var globalBucketStorageClasses = &bucketStorageClasses{}
+
+// set - replaces the default storage classes of all buckets.
+func (s *bucketStorageClasses) set(classes map[string]string) {
+	s.Lock()
+	defer s.Unlock()
+	s.classes = classes
+}
+
+// get - returns the default storage class of a bucket, empty if none
+// is set.
+func (s *bucketStorageClasses) get(bucket string) string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.classes[bucket]
+}
+
+// list - returns the default storage classes sorted by bucket.
+func (s *bucketStorageClasses) list() []madmin.BucketStorageClass {
+	s.RLock()
+	defer s.RUnlock()
+	classes := []madmin.BucketStorageClass{}
+	for bucket, sc := range s.classes {
+		classes = append(classes, madmin.BucketStorageClass{Bucket: bucket, StorageClass: sc})
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Bucket < classes[j].Bucket })
+	return classes
+}
+
+// applyBucketStorageClass - sets the storage class of an object being
+// written to the default one of its bucket, unless the client set one.
+func applyBucketStorageClass(bucket string, metadata map[string]string) {
+	if metadata[amzStorageClass] != "" {
+		return
+	}
+	if sc := globalBucketStorageClasses.get(bucket); sc != "" {
+		metadata[amzStorageClass] = sc
+	}
+}
+
+// validateBucketStorageClasses - validates the bucket names and
+// storage classes of the bucketStorageClasses config section.
+func validateBucketStorageClasses(classes map[string]string) error {
+	for bucket, sc := range classes {
+		if !IsValidBucketName(bucket) || !isValidStorageClassMeta(sc) {
+			return errInvalidBucketStorageClass
+		}
+	}
+	return nil
+}
+
+// setBucketStorageClass - sets the default storage class of a bucket
+// in the given config, an empty storage class removes it.
+func setBucketStorageClass(config *serverConfig, bucket, sc string) {
+	classes := make(map[string]string)
+	for b, c := range config.BucketStorageClasses {
+		classes[b] = c
+	}
+	if sc == "" {
+		delete(classes, bucket)
+	} else {
+		classes[bucket] = sc
+	}
+	if len(classes) == 0 {
+		classes = nil
+	}
+	config.BucketStorageClasses = classes
+}
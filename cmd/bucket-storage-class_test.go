@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestApplyBucketStorageClass(t *testing.T) {
+	tmpGlobalBucketStorageClasses := globalBucketStorageClasses
+	defer func() {
+		globalBucketStorageClasses = tmpGlobalBucketStorageClasses
+	}()
+	globalBucketStorageClasses = &bucketStorageClasses{}
+	globalBucketStorageClasses.set(map[string]string{"logs": reducedRedundancyStorageClass})
+
+	testCases := []struct {
+		bucket, storageClass, expectedStorageClass string
+	}{
+		{"logs", "", reducedRedundancyStorageClass},
+		{"logs", standardStorageClass, standardStorageClass},
+		{"data", "", ""},
+	}
+	for i, testCase := range testCases {
+		metadata := make(map[string]string)
+		if testCase.storageClass != "" {
+			metadata[amzStorageClass] = testCase.storageClass
+		}
+		applyBucketStorageClass(testCase.bucket, metadata)
+		if metadata[amzStorageClass] != testCase.expectedStorageClass {
+			t.Errorf("Test %d: Expected storage class %q, got %q", i+1, testCase.expectedStorageClass, metadata[amzStorageClass])
+		}
+	}
+
+	expected := []madmin.BucketStorageClass{{Bucket: "logs", StorageClass: reducedRedundancyStorageClass}}
+	if classes := globalBucketStorageClasses.list(); !reflect.DeepEqual(classes, expected) {
+		t.Errorf("Expected %v, got %v", expected, classes)
+	}
+}
+
+func TestValidateBucketStorageClasses(t *testing.T) {
+	testCases := []struct {
+		classes    map[string]string
+		shouldPass bool
+	}{
+		{map[string]string{"logs": reducedRedundancyStorageClass}, true},
+		{nil, true},
+		{map[string]string{"logs": "GLACIER"}, false},
+		{map[string]string{"l": standardStorageClass}, false},
+	}
+	for i, testCase := range testCases {
+		err := validateBucketStorageClasses(testCase.classes)
+		if (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+	}
+}
+
+// Tests that the default storage class of a bucket is set in the
+// config and removed by an empty storage class.
+func TestSetBucketStorageClass(t *testing.T) {
+	config := newServerConfig()
+	for _, bucket := range []string{"logs", "backups"} {
+		setBucketStorageClass(config, bucket, reducedRedundancyStorageClass)
+	}
+	setBucketStorageClass(config, "backups", "")
+
+	expected := map[string]string{"logs": reducedRedundancyStorageClass}
+	if !reflect.DeepEqual(config.BucketStorageClasses, expected) {
+		t.Errorf("Expected storage classes %v, got %v", expected, config.BucketStorageClasses)
+	}
+
+	setBucketStorageClass(config, "logs", "")
+	if config.BucketStorageClasses != nil {
+		t.Errorf("Expected no storage classes, got %v", config.BucketStorageClasses)
+	}
+}
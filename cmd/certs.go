@@ -23,10 +23,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"io/ioutil"
 	"os"
 
 	"github.com/minio/minio/pkg/certs"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // TLSPrivateKeyPassword is the environment variable which contains the password used
@@ -34,6 +36,8 @@ import (
 // password protected.
 const TLSPrivateKeyPassword = "MINIO_CERT_PASSWD"
 
+var errTLSNotConfigured = errors.New("server is not configured with TLS certificates")
+
 func parsePublicCertFile(certFile string) (x509Certs []*x509.Certificate, err error) {
 	// Read certificate file.
 	var data []byte
@@ -171,3 +175,34 @@ func getSSLConfig() (x509Certs []*x509.Certificate, rootCAs *x509.CertPool, c *c
 	secureConn = true
 	return x509Certs, rootCAs, c, secureConn, nil
 }
+
+// reloadTLSCerts - reloads the certificate and private key files of
+// the server, and returns the certificate chain served from now on.
+// The current certificate is kept if the files cannot be loaded.
+func reloadTLSCerts() ([]madmin.TLSCertificate, error) {
+	if globalTLSCerts == nil {
+		return nil, errTLSNotConfigured
+	}
+	if err := globalTLSCerts.Reload(); err != nil {
+		return nil, err
+	}
+
+	cert, err := globalTLSCerts.GetCertificate(nil)
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]madmin.TLSCertificate, 0, len(cert.Certificate))
+	for _, der := range cert.Certificate {
+		x509Cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, madmin.TLSCertificate{
+			Subject:   x509Cert.Subject.CommonName,
+			Issuer:    x509Cert.Issuer.CommonName,
+			NotBefore: x509Cert.NotBefore,
+			NotAfter:  x509Cert.NotAfter,
+		})
+	}
+	return chain, nil
+}
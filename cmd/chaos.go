@@ -0,0 +1,104 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	xrpc "github.com/minio/minio/cmd/rpc"
+)
+
+const (
+	// Environment variable enabling the chaos testing admin API, off
+	// unless set so that failures are never simulated in production.
+	chaosTestingEnv = "MINIO_CHAOS_TESTING"
+
+	// Maximum time a server simulates being offline before it
+	// recovers by itself.
+	maxSimulatedOfflineDuration = time.Hour
+)
+
+var errChaosTestingDisabled = errors.New("chaos testing is disabled, set " + chaosTestingEnv + " to `on` to enable it")
+
+// Set when chaos testing is enabled, so that admins may make servers
+// simulate failures.
+var globalChaosTestingEnabled bool
+
+// simulatedOffline - time until which this server simulates being
+// offline.
+type simulatedOffline struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// Simulated offline state of this server.
+var globalSimulatedOffline = &simulatedOffline{}
+
+// set - makes the server simulate being offline for the given
+// duration from now, 0 makes it recover right away. Returns the time
+// it recovers at.
+func (s *simulatedOffline) set(d time.Duration) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.until = time.Time{}
+	if d > 0 {
+		s.until = UTCNow().Add(d)
+	}
+	return s.until
+}
+
+// isOffline - returns true while the server simulates being offline.
+func (s *simulatedOffline) isOffline() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return UTCNow().Before(s.until)
+}
+
+// parseSimulatedOfflineDuration - parses the time a server simulates
+// being offline, such as "30s", which must be at most
+// maxSimulatedOfflineDuration.
+func parseSimulatedOfflineDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d > maxSimulatedOfflineDuration {
+		return 0, fmt.Errorf("duration `%s` must be between 0s and %s", s, maxSimulatedOfflineDuration)
+	}
+	return d, nil
+}
+
+// rejectRPCWhileOffline - wraps the handler of an RPC service so that
+// its calls fail with errDiskNotFound while this server simulates
+// being offline, as if it were unreachable. Calls fail instead of
+// connections being dropped since storage RPC clients never reconnect
+// to a disconnected server, whereas the server is online again for
+// its peers once it recovers.
+func rejectRPCWhileOffline(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !globalSimulatedOffline.isOffline() {
+			h(w, r)
+			return
+		}
+		gob.NewEncoder(w).Encode(xrpc.CallResponse{Error: errDiskNotFound.Error()})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	xrpc "github.com/minio/minio/cmd/rpc"
+)
+
+// Tests parsing the time a server simulates being offline.
+func TestParseSimulatedOfflineDuration(t *testing.T) {
+	testCases := []struct {
+		duration         string
+		expectedDuration time.Duration
+		shouldPass       bool
+	}{
+		{"30s", 30 * time.Second, true},
+		{"0s", 0, true},
+		{"1h", time.Hour, true},
+		{"2h", 0, false},
+		{"-1s", 0, false},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+	for i, testCase := range testCases {
+		d, err := parseSimulatedOfflineDuration(testCase.duration)
+		if err != nil && testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if err == nil && !testCase.shouldPass {
+			t.Errorf("Test %d: Expected to fail", i+1)
+		}
+		if d != testCase.expectedDuration {
+			t.Errorf("Test %d: Expected %s but got %s", i+1, testCase.expectedDuration, d)
+		}
+	}
+}
+
+// Tests that a server simulating being offline recovers.
+func TestSimulatedOffline(t *testing.T) {
+	s := &simulatedOffline{}
+	if s.isOffline() {
+		t.Fatal("Expected to be online")
+	}
+
+	if until := s.set(time.Hour); until.Before(UTCNow()) {
+		t.Fatalf("Unexpected recovery time %s", until)
+	}
+	if !s.isOffline() {
+		t.Fatal("Expected to be offline")
+	}
+
+	if until := s.set(0); !until.IsZero() {
+		t.Fatalf("Unexpected recovery time %s", until)
+	}
+	if s.isOffline() {
+		t.Fatal("Expected to recover right away")
+	}
+
+	s.set(10 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if s.isOffline() {
+		t.Fatal("Expected to recover by itself")
+	}
+}
+
+// Tests that RPCs fail and local disks are offline while the server
+// simulates being offline.
+func TestSimulatedOfflineRPCAndDisks(t *testing.T) {
+	defer globalSimulatedOffline.set(0)
+
+	served := false
+	handler := rejectRPCWhileOffline(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	if !served {
+		t.Fatal("Expected the RPC to be served")
+	}
+
+	dir, err := getRandomDisks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir[0])
+	disk, err := newPosix(dir[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, online, offline := getDisksInfo([]StorageAPI{disk}); online != 1 || offline != 0 {
+		t.Fatalf("Expected the disk to be online, got %d online and %d offline", online, offline)
+	}
+
+	globalSimulatedOffline.set(time.Hour)
+
+	served = false
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if served {
+		t.Fatal("Expected the RPC to be refused")
+	}
+	var callResponse xrpc.CallResponse
+	if err = gob.NewDecoder(rec.Body).Decode(&callResponse); err != nil {
+		t.Fatal(err)
+	}
+	if toStorageErr(errors.New(callResponse.Error)) != errDiskNotFound {
+		t.Fatalf("Unexpected RPC error %s", callResponse.Error)
+	}
+
+	if _, online, offline := getDisksInfo([]StorageAPI{disk}); online != 0 || offline != 1 {
+		t.Fatalf("Expected the disk to be offline, got %d online and %d offline", online, offline)
+	}
+}
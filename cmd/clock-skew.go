@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// Config section holding the maximum clock skew of signed
+	// requests.
+	configSectionMaxClockSkew = "maxClockSkew"
+
+	// Bounds of the maximum clock skew, a shorter one rejects the
+	// requests of clients whose clock drifts slightly while a longer
+	// one widens the window signed requests can be replayed in.
+	minMaxClockSkew = time.Minute
+	maxMaxClockSkew = time.Hour
+)
+
+// parseMaxClockSkew - parses the maximum clock skew of signed requests,
+// such as "5m", which must be between minMaxClockSkew and
+// maxMaxClockSkew. An empty string or 0 selects the default.
+func parseMaxClockSkew(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d != 0 && (d < minMaxClockSkew || d > maxMaxClockSkew) {
+		return 0, fmt.Errorf("maximum clock skew `%s` must be between %s and %s", s, minMaxClockSkew, maxMaxClockSkew)
+	}
+	return d, nil
+}
+
+// clockSkewTolerance - maximum difference allowed between the time a
+// request was signed at and the time of this server.
+type clockSkewTolerance struct {
+	sync.Mutex
+
+	// configured maximum clock skew, 0 for the default
+	maxSkew time.Duration
+}
+
+// Maximum clock skew of signed requests loaded from config.json.
+var globalClockSkewTolerance = &clockSkewTolerance{}
+
+// set - replaces the maximum clock skew, 0 restores the default.
+func (c *clockSkewTolerance) set(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.maxSkew = d
+}
+
+// get - returns the maximum clock skew of signed requests.
+func (c *clockSkewTolerance) get() time.Duration {
+	c.Lock()
+	defer c.Unlock()
+	if c.maxSkew == 0 {
+		return globalMaxSkewTime
+	}
+	return c.maxSkew
+}
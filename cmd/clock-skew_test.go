@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMaxClockSkew(t *testing.T) {
+	testCases := []struct {
+		s          string
+		expected   time.Duration
+		shouldPass bool
+	}{
+		{"", 0, true},
+		{"0", 0, true},
+		{"5m", 5 * time.Minute, true},
+		{"1m", time.Minute, true},
+		{"1h", time.Hour, true},
+		{"30s", 0, false},
+		{"2h", 0, false},
+		{"-5m", 0, false},
+		{"five minutes", 0, false},
+	}
+	for i, testCase := range testCases {
+		d, err := parseMaxClockSkew(testCase.s)
+		if (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: expected to pass %v, got %v", i+1, testCase.shouldPass, err)
+			continue
+		}
+		if d != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, d)
+		}
+	}
+}
+
+func TestClockSkewTolerance(t *testing.T) {
+	tolerance := &clockSkewTolerance{}
+	if d := tolerance.get(); d != globalMaxSkewTime {
+		t.Fatalf("expected default maximum clock skew %s, got %s", globalMaxSkewTime, d)
+	}
+	tolerance.set(5 * time.Minute)
+	if d := tolerance.get(); d != 5*time.Minute {
+		t.Fatalf("expected maximum clock skew %s, got %s", 5*time.Minute, d)
+	}
+	tolerance.set(0)
+	if d := tolerance.get(); d != globalMaxSkewTime {
+		t.Fatalf("expected default maximum clock skew %s, got %s", globalMaxSkewTime, d)
+	}
+}
@@ -49,6 +49,9 @@ func checkUpdate(mode string) {
 
 // Load logger targets based on user's configuration
 func loadLoggers() {
+	// Keep the most recent logs for support bundles.
+	logger.AddTarget(globalRecentLogs)
+
 	if globalServerConfig.Logger.Console.Enabled {
 		// Enable console logging
 		logger.AddTarget(logger.NewConsole())
@@ -252,6 +255,147 @@ func handleCommonEnvVars() {
 		globalWORMEnabled = bool(wormFlag)
 	}
 
+	// Get notification replay environment variable.
+	if replay := os.Getenv(notifyReplayEnv); replay != "" {
+		replayFlag, err := ParseBoolFlag(replay)
+		if err != nil {
+			logger.Fatal(uiErrInvalidNotifyReplayValue(nil).Msg("Unknown value `%s`", replay), "Unable to validate %s environment variable", notifyReplayEnv)
+		}
+		globalNotifyReplayEnabled = bool(replayFlag)
+	}
+
+	// Get admin response signature environment variable.
+	if sign := os.Getenv(adminSignResponsesEnv); sign != "" {
+		signFlag, err := ParseBoolFlag(sign)
+		if err != nil {
+			logger.Fatal(uiErrInvalidAdminSignResponsesValue(nil).Msg("Unknown value `%s`", sign), "Unable to validate %s environment variable", adminSignResponsesEnv)
+		}
+		globalAdminSignResponses = bool(signFlag)
+	}
+
+	// Get config change hook environment variables.
+	if hookURL := os.Getenv(configHookURLEnv); hookURL != "" {
+		hook, err := parseConfigHook(hookURL, os.Getenv(configHookEventsEnv))
+		if err != nil {
+			logger.Fatal(uiErrInvalidConfigHook(err), "Unable to validate %s and %s environment variables", configHookURLEnv, configHookEventsEnv)
+		}
+		globalConfigHook = hook
+	}
+
+	// Get heal early headers environment variable.
+	if earlyHeaders := os.Getenv(healEarlyHeadersEnv); earlyHeaders != "" {
+		earlyHeadersFlag, err := ParseBoolFlag(earlyHeaders)
+		if err != nil {
+			logger.Fatal(uiErrInvalidHealEarlyHeadersValue(nil).Msg("Unknown value `%s`", earlyHeaders), "Unable to validate %s environment variable", healEarlyHeadersEnv)
+		}
+		globalHealEarlyHeaders = bool(earlyHeadersFlag)
+	}
+
+	// Get slow request environment variables.
+	if budget := os.Getenv(slowRequestBudgetEnv); budget != "" {
+		slowRequestBudget, err := parseSlowRequestBudget(budget)
+		if err != nil {
+			logger.Fatal(uiErrInvalidSlowRequestBudget(err), "Unable to validate %s environment variable", slowRequestBudgetEnv)
+		}
+		globalSlowRequestBudget = slowRequestBudget
+	}
+	if header := os.Getenv(slowRequestHeaderEnv); header != "" {
+		headerFlag, err := ParseBoolFlag(header)
+		if err != nil {
+			logger.Fatal(uiErrInvalidSlowRequestHeaderValue(nil).Msg("Unknown value `%s`", header), "Unable to validate %s environment variable", slowRequestHeaderEnv)
+		}
+		globalSlowRequestHeader = bool(headerFlag)
+	}
+
+	// Get metrics history environment variable.
+	if retention := os.Getenv(metricsHistoryEnv); retention != "" {
+		d, err := parseMetricsHistoryRetention(retention)
+		if err != nil {
+			logger.Fatal(uiErrInvalidMetricsHistory(err), "Unable to validate %s environment variable", metricsHistoryEnv)
+		}
+		globalMetricsHistory = newMetricsHistory(d)
+	}
+
+	// Get restart backoff environment variables.
+	if backoff := os.Getenv(restartBackoffEnv); backoff != "" {
+		d, err := parseRestartBackoff(backoff)
+		if err != nil {
+			logger.Fatal(uiErrInvalidRestartBackoff(err), "Unable to validate %s environment variable", restartBackoffEnv)
+		}
+		globalRestartBackoff = d
+	}
+	if limit := os.Getenv(crashLoopLimitEnv); limit != "" {
+		n, err := parseCrashLoopLimit(limit)
+		if err != nil {
+			logger.Fatal(uiErrInvalidCrashLoopLimit(err), "Unable to validate %s environment variable", crashLoopLimitEnv)
+		}
+		globalCrashLoopLimit = n
+	}
+
+	// Get connection limit environment variable.
+	if limit := os.Getenv(maxConnsPerIPEnv); limit != "" {
+		n, err := parseMaxConnsPerIP(limit)
+		if err != nil {
+			logger.Fatal(uiErrInvalidMaxConnsPerIP(err), "Unable to validate %s environment variable", maxConnsPerIPEnv)
+		}
+		globalMaxConnsPerIP = n
+	}
+
+	// Get request limit environment variable.
+	if limit := os.Getenv(maxRequestsEnv); limit != "" {
+		n, err := parseMaxRequests(limit)
+		if err != nil {
+			logger.Fatal(uiErrInvalidMaxRequests(err), "Unable to validate %s environment variable", maxRequestsEnv)
+		}
+		globalAdmissionController.setLimit(n)
+	}
+
+	// Get chaos testing environment variable.
+	if chaos := os.Getenv(chaosTestingEnv); chaos != "" {
+		chaosFlag, err := ParseBoolFlag(chaos)
+		if err != nil {
+			logger.Fatal(uiErrInvalidChaosTestingValue(nil).Msg("Unknown value `%s`", chaos), "Unable to validate %s environment variable", chaosTestingEnv)
+		}
+		globalChaosTestingEnabled = bool(chaosFlag)
+	}
+
+	// Get admin actions requiring confirmation environment variable.
+	if actions := os.Getenv(adminConfirmActionsEnv); actions != "" {
+		confirmedActions, err := parseConfirmedAdminActions(actions)
+		if err != nil {
+			logger.Fatal(uiErrInvalidAdminConfirmActions(err), "Unable to validate %s environment variable", adminConfirmActionsEnv)
+		}
+		globalConfirmedAdminActions = confirmedActions
+	}
+
+	// Get disabled admin actions environment variable.
+	if actions := os.Getenv(adminDisabledActionsEnv); actions != "" {
+		disabledActions, err := parseDisabledAdminActions(actions)
+		if err != nil {
+			logger.Fatal(uiErrInvalidAdminDisabledActions(err), "Unable to validate %s environment variable", adminDisabledActionsEnv)
+		}
+		globalDisabledAdminActions.set(disabledActions)
+		globalIsEnvAdminDisabledActions = true
+	}
+
+	// Get Prometheus deployment label environment variable.
+	if deployment := os.Getenv(prometheusDeploymentEnv); deployment != "" {
+		label, err := parsePrometheusDeployment(deployment)
+		if err != nil {
+			logger.Fatal(uiErrInvalidPrometheusDeployment(err), "Unable to validate %s environment variable", prometheusDeploymentEnv)
+		}
+		globalPrometheusDeployment = label
+	}
+
+	// Get admin API path environment variable.
+	if adminPath := os.Getenv(adminAPIPathEnv); adminPath != "" {
+		prefix, err := parseAdminAPIPathPrefix(adminPath)
+		if err != nil {
+			logger.Fatal(uiErrInvalidAdminAPIPath(err), "Unable to validate %s environment variable", adminAPIPathEnv)
+		}
+		globalAdminAPIPathPrefix = prefix
+	}
+
 	kmsConf, err := crypto.NewVaultConfig()
 	if err != nil {
 		logger.Fatal(err, "Unable to initialize hashicorp vault")
@@ -18,9 +18,14 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miekg/dns"
@@ -30,6 +35,7 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/event/target"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // Steps to move from version N to version N+1
@@ -41,16 +47,30 @@ import (
 // 6. Make changes in config-current_test.go for any test change
 
 // Config version
-const serverConfigVersion = "28"
+const serverConfigVersion = "29"
 
-type serverConfig = serverConfigV28
+type serverConfig = serverConfigV29
 
 var (
 	// globalServerConfig server config.
 	globalServerConfig   *serverConfig
 	globalServerConfigMu sync.RWMutex
+
+	// Checksum of the saved config loaded by this server.
+	globalServerConfigChecksum string
 )
 
+// configChecksum - returns the checksum of a config, which tells
+// whether a server loaded the config saved by an admin.
+func configChecksum(config *serverConfig) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // GetVersion get current config version.
 func (s *serverConfig) GetVersion() string {
 	return s.Version
@@ -126,6 +146,30 @@ func (s *serverConfig) GetStorageClass() (storageClass, storageClass) {
 	return s.StorageClass.Standard, s.StorageClass.RRS
 }
 
+// getConfigEnvOverrides - returns the config fields whose value is
+// taken from the environment instead of config.json.
+func getConfigEnvOverrides() []madmin.ConfigEnvOverride {
+	overrides := []madmin.ConfigEnvOverride{}
+	add := func(overridden bool, field string, envVars ...string) {
+		if overridden {
+			overrides = append(overrides, madmin.ConfigEnvOverride{Field: field, EnvVars: envVars})
+		}
+	}
+
+	add(globalIsEnvCreds, "credential", "MINIO_ACCESS_KEY", "MINIO_SECRET_KEY")
+	add(globalIsEnvRegion, "region", "MINIO_REGION")
+	add(globalIsEnvBrowser, "browser", "MINIO_BROWSER")
+	add(globalIsEnvWORM, "worm", "MINIO_WORM")
+	add(globalIsEnvDomainName, "domain", "MINIO_DOMAIN")
+	add(globalIsStorageClass, "storageclass.standard", standardStorageClassEnv)
+	add(globalIsStorageClass, "storageclass.rrs", reducedRedundancyStorageClassEnv)
+	add(globalIsDiskCacheEnabled, "cache", "MINIO_CACHE_DRIVES", "MINIO_CACHE_EXCLUDE",
+		"MINIO_CACHE_EXPIRY", "MINIO_CACHE_MAXUSE")
+	add(globalIsEnvAdminDisabledActions, configSectionAdminDisabledActions, adminDisabledActionsEnv)
+
+	return overrides
+}
+
 // GetBrowser get current credentials.
 func (s *serverConfig) GetBrowser() bool {
 	if globalIsEnvBrowser {
@@ -172,19 +216,43 @@ func (s *serverConfig) GetCacheConfig() CacheConfig {
 	return s.Cache
 }
 
-func (s *serverConfig) Validate() error {
+// configErrors - collection of all errors found while validating a
+// server configuration.
+type configErrors []error
+
+// Error - returns all validation errors joined as a single message.
+func (errs configErrors) Error() string {
+	return strings.Join(errs.Details(), "; ")
+}
+
+// Details - returns the message of each validation error.
+func (errs configErrors) Details() []string {
+	details := make([]string, len(errs))
+	for i, err := range errs {
+		details[i] = err.Error()
+	}
+	return details
+}
+
+// Validate - validates the whole configuration, the returned error
+// is of type configErrors and holds every problem found. Settings
+// which are legal but risky are returned as warnings, which do not
+// make the configuration invalid.
+func (s *serverConfig) Validate() (warnings []string, err error) {
 	if s == nil {
-		return nil
+		return nil, nil
 	}
+
+	var errs configErrors
 	if s.Version != serverConfigVersion {
-		return fmt.Errorf("configuration version mismatch. Expected: ‘%s’, Got: ‘%s’", serverConfigVersion, s.Version)
+		errs = append(errs, fmt.Errorf("configuration version mismatch. Expected: ‘%s’, Got: ‘%s’", serverConfigVersion, s.Version))
 	}
 
 	// Validate credential fields only when
 	// they are not set via the environment
 	// Error out if global is env credential is not set and config has invalid credential
 	if !globalIsEnvCreds && !s.Credential.IsValid() {
-		return errors.New("invalid credential in config file")
+		errs = append(errs, errors.New("invalid credential in config file"))
 	}
 
 	// Region: nothing to validate
@@ -192,65 +260,137 @@ func (s *serverConfig) Validate() error {
 
 	if s.Domain != "" {
 		if _, ok := dns.IsDomainName(s.Domain); !ok {
-			return errors.New("invalid domain name")
+			errs = append(errs, errors.New("invalid domain name"))
 		}
 	}
 
-	for _, v := range s.Notify.AMQP {
+	if err := validateResponseHeaders(s.Headers); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateMinPartSize(s.MinPartSize); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateCompressionConfig(s.Compression); err != nil {
+		errs = append(errs, err)
+	}
+
+	if _, err := parseMaxClockSkew(s.MaxClockSkew); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateDisabledAdminActions(s.AdminDisabledActions); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateBucketStorageClasses(s.BucketStorageClasses); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Notification targets are kept in maps, sort their errors
+	// so that they are always reported in the same order.
+	var notifyErrs configErrors
+	for k, v := range s.Notify.AMQP {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("amqp: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("amqp.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.Elasticsearch {
+	for k, v := range s.Notify.Elasticsearch {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("elasticsearch: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("elasticsearch.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.Kafka {
+	for k, v := range s.Notify.Kafka {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("kafka: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("kafka.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.MQTT {
+	for k, v := range s.Notify.MQTT {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("mqtt: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("mqtt.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.MySQL {
+	for k, v := range s.Notify.MySQL {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("mysql: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("mysql.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.NATS {
+	for k, v := range s.Notify.NATS {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("nats: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("nats.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.PostgreSQL {
+	for k, v := range s.Notify.PostgreSQL {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("postgreSQL: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("postgreSQL.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.Redis {
+	for k, v := range s.Notify.Redis {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("redis: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("redis.%s: %s", k, err.Error()))
 		}
 	}
 
-	for _, v := range s.Notify.Webhook {
+	for k, v := range s.Notify.Webhook {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("webhook: %s", err.Error())
+			notifyErrs = append(notifyErrs, fmt.Errorf("webhook.%s: %s", k, err.Error()))
 		}
 	}
 
-	return nil
+	sort.Slice(notifyErrs, func(i, j int) bool {
+		return notifyErrs[i].Error() < notifyErrs[j].Error()
+	})
+	errs = append(errs, notifyErrs...)
+
+	warnings = s.warnings()
+	if len(errs) == 0 {
+		return warnings, nil
+	}
+	return warnings, errs
+}
+
+// warnings - returns the settings of the configuration which are
+// legal but risky.
+func (s *serverConfig) warnings() (warnings []string) {
+	ssParity, rrsParity := s.StorageClass.Standard.Parity, s.StorageClass.RRS.Parity
+	if ssParity > 0 && ssParity == globalXLSetDriveCount/2 {
+		warnings = append(warnings, fmt.Sprintf("storageclass: standard storage class parity %d stores as much parity as data, halving the usable capacity", ssParity))
+	}
+	if rrsParity > 0 && rrsParity == ssParity {
+		warnings = append(warnings, fmt.Sprintf("storageclass: reduced redundancy storage class parity %d is the same as the standard one, saving no space", rrsParity))
+	}
+
+	// Notification targets are kept in maps, sort their warnings
+	// so that they are always reported in the same order.
+	var notifyWarnings []string
+	for k, v := range s.Notify.AMQP {
+		if v.Enable && v.DeliveryMode != 2 {
+			notifyWarnings = append(notifyWarnings, fmt.Sprintf("amqp.%s: events are not persistent and are lost if the broker restarts, set deliveryMode to 2", k))
+		}
+	}
+
+	for k, v := range s.Notify.MQTT {
+		if v.Enable && v.QoS == 0 {
+			notifyWarnings = append(notifyWarnings, fmt.Sprintf("mqtt.%s: events are delivered at most once with qos 0", k))
+		}
+	}
+
+	for k, v := range s.Notify.Webhook {
+		if v.Enable && v.Endpoint.Scheme == "http" {
+			notifyWarnings = append(notifyWarnings, fmt.Sprintf("webhook.%s: events are sent unencrypted over http", k))
+		}
+	}
+
+	sort.Strings(notifyWarnings)
+	return append(warnings, notifyWarnings...)
 }
 
 func (s *serverConfig) loadFromEnvs() {
@@ -329,6 +469,20 @@ func (s *serverConfig) ConfigDiff(t *serverConfig) string {
 		return "Logger configuration differs"
 	case !reflect.DeepEqual(s.KMS, t.KMS):
 		return "KMS configuration differs"
+	case !reflect.DeepEqual(s.Headers, t.Headers):
+		return "Headers configuration differs"
+	case s.MinPartSize != t.MinPartSize:
+		return "MinPartSize configuration differs"
+	case !reflect.DeepEqual(s.Compression, t.Compression):
+		return "Compression configuration differs"
+	case s.MaxClockSkew != t.MaxClockSkew:
+		return "MaxClockSkew configuration differs"
+	case s.AutoHeal != t.AutoHeal:
+		return "AutoHeal configuration differs"
+	case !reflect.DeepEqual(s.AdminDisabledActions, t.AdminDisabledActions):
+		return "AdminDisabledActions configuration differs"
+	case !reflect.DeepEqual(s.BucketStorageClasses, t.BucketStorageClasses):
+		return "BucketStorageClasses configuration differs"
 	case reflect.DeepEqual(s, t):
 		return ""
 	default:
@@ -411,8 +565,12 @@ func (s *serverConfig) loadToCachedConfigs() {
 	if !globalIsEnvDomainName {
 		globalDomainName = s.Domain
 	}
-	if !globalIsStorageClass {
-		globalStandardStorageClass, globalRRStorageClass = s.GetStorageClass()
+	if !globalIsEnvAdminDisabledActions {
+		globalDisabledAdminActions.set(s.AdminDisabledActions)
+	}
+	for _, loader := range configSectionLoaders {
+		// Invalid sections are rejected by Validate().
+		loader.apply(s)
 	}
 	if !globalIsDiskCacheEnabled {
 		cacheConf := s.GetCacheConfig()
@@ -445,6 +603,7 @@ func newConfig(objAPI ObjectLayer) error {
 	// hold the mutex lock before a new config is assigned.
 	globalServerConfigMu.Lock()
 	globalServerConfig = srvCfg
+	globalServerConfigChecksum = configChecksum(srvCfg)
 	globalServerConfigMu.Unlock()
 
 	// Save config into file.
@@ -458,7 +617,11 @@ func getValidConfig(objAPI ObjectLayer) (*serverConfig, error) {
 		return nil, err
 	}
 
-	return srvCfg, srvCfg.Validate()
+	warnings, err := srvCfg.Validate()
+	for _, warning := range warnings {
+		logger.Info("Config warning: %s", warning)
+	}
+	return srvCfg, err
 }
 
 // loadConfig - loads a new config from disk, overrides params from env
@@ -468,6 +631,12 @@ func loadConfig(objAPI ObjectLayer) error {
 	if err != nil {
 		return uiErrInvalidConfig(nil).Msg(err.Error())
 	}
+	checksum := configChecksum(srvCfg)
+
+	// Changes are streamed to watchers against the saved config.
+	if err = globalConfigWatch.setLast(srvCfg); err != nil {
+		return err
+	}
 
 	// Override any values from ENVs.
 	srvCfg.loadFromEnvs()
@@ -478,6 +647,7 @@ func loadConfig(objAPI ObjectLayer) error {
 	// hold the mutex lock before a new config is assigned.
 	globalServerConfigMu.Lock()
 	globalServerConfig = srvCfg
+	globalServerConfigChecksum = checksum
 	globalServerConfigMu.Unlock()
 
 	return nil
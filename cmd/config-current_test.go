@@ -19,10 +19,13 @@ package cmd
 import (
 	"os"
 	"path"
+	"strings"
 	"testing"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
 )
 
 func TestServerConfig(t *testing.T) {
@@ -54,11 +57,17 @@ func TestServerConfig(t *testing.T) {
 	if err := saveServerConfig(objLayer, globalServerConfig); err != nil {
 		t.Fatalf("Unable to save updated config file %s", err)
 	}
+	checksum := configChecksum(globalServerConfig)
 
 	// Initialize server config.
 	if err := loadConfig(objLayer); err != nil {
 		t.Fatalf("Unable to initialize from updated config file %s", err)
 	}
+
+	// The loaded config is identified by the checksum of the saved one.
+	if globalServerConfigChecksum != checksum {
+		t.Errorf("Expecting config checksum %s found %s", checksum, globalServerConfigChecksum)
+	}
 }
 
 func TestServerConfigWithEnvs(t *testing.T) {
@@ -233,6 +242,22 @@ func TestValidateConfig(t *testing.T) {
 
 		// Test 27 - Test MQTT
 		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "notify": { "mqtt": { "1": { "enable": true, "broker": "",  "topic": "", "qos": 0, "clientId": "", "username": "", "password": ""}}}}`, false},
+
+		// Test 28 - Test minimum part size
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "minPartSize": 16777216}`, true},
+
+		// Test 29 - Test minimum part size below the S3 minimum
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "minPartSize": 1024}`, false},
+
+		// Test 30 - Test compression of GET responses
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "compression": {"enabled": true, "contentTypes": ["text/*"], "minSize": 1024}}`, true},
+
+		// Test 31 - Test compression of an invalid content type
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "compression": {"enabled": true, "contentTypes": ["*/*"]}}`, false},
+		// Test 32 - Test a maximum clock skew within bounds
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "maxClockSkew": "5m"}`, true},
+		// Test 33 - Test a maximum clock skew out of bounds
+		{`{"version": "` + v + `", "credential": { "accessKey": "minio", "secretKey": "minio123" }, "region": "us-east-1", "browser": "on", "maxClockSkew": "24h"}`, false},
 	}
 
 	for i, testCase := range testCases {
@@ -250,6 +275,32 @@ func TestValidateConfig(t *testing.T) {
 
 }
 
+// Tests that all validation errors are reported at once.
+func TestValidateConfigAllErrors(t *testing.T) {
+	config := &serverConfig{
+		Version: "10",
+		Domain:  "invalid..domain",
+	}
+	config.Notify.Redis = map[string]target.RedisArgs{
+		"2": {Enable: true, Format: "invalid"},
+		"1": {Enable: true, Format: "invalid"},
+	}
+
+	_, err := config.Validate()
+	cerrs, ok := err.(configErrors)
+	if !ok {
+		t.Fatalf("Expected configErrors but got %T", err)
+	}
+
+	details := cerrs.Details()
+	if len(details) != 5 {
+		t.Fatalf("Expected 5 errors but got %d: %v", len(details), details)
+	}
+	if !strings.HasPrefix(details[3], "redis.1: ") || !strings.HasPrefix(details[4], "redis.2: ") {
+		t.Errorf("Unexpected order of notification errors: %v", details[3:])
+	}
+}
+
 func TestConfigDiff(t *testing.T) {
 	testCases := []struct {
 		s, t *serverConfig
@@ -341,6 +392,20 @@ func TestConfigDiff(t *testing.T) {
 			}},
 			"Logger configuration differs",
 		},
+		// 17
+		{
+			&serverConfig{MinPartSize: 16 * humanize.MiByte},
+			&serverConfig{},
+			"MinPartSize configuration differs",
+		},
+		// 18
+		{
+			&serverConfig{Compression: &compressionConfig{Enabled: true}},
+			&serverConfig{},
+			"Compression configuration differs",
+		},
+		// 19
+		{&serverConfig{MaxClockSkew: "5m0s"}, &serverConfig{}, "MaxClockSkew configuration differs"},
 	}
 
 	for i, testCase := range testCases {
@@ -350,3 +415,40 @@ func TestConfigDiff(t *testing.T) {
 		}
 	}
 }
+
+// Tests that legal but risky settings are reported as warnings,
+// without making the config invalid.
+func TestValidateConfigWarnings(t *testing.T) {
+	tmpGlobalXLSetDriveCount := globalXLSetDriveCount
+	defer func() {
+		globalXLSetDriveCount = tmpGlobalXLSetDriveCount
+	}()
+	globalXLSetDriveCount = 4
+
+	config := newServerConfig()
+	warnings, err := config.Validate()
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("Expected no warnings for the default config but got %v - %v", warnings, err)
+	}
+
+	endpoint, err := xnet.ParseURL("http://localhost:8080/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.StorageClass.Standard.Parity = 2
+	config.StorageClass.RRS.Parity = 2
+	config.Notify.Webhook["2"] = target.WebhookArgs{Enable: true, Endpoint: *endpoint}
+	config.Notify.Webhook["1"] = target.WebhookArgs{Enable: true, Endpoint: *endpoint}
+	config.Notify.MQTT["1"] = target.MQTTArgs{Enable: false}
+
+	warnings, err = config.Validate()
+	if err != nil {
+		t.Fatalf("Expected warnings not to make the config invalid - %v", err)
+	}
+	if len(warnings) != 4 {
+		t.Fatalf("Expected 4 warnings but got %d: %v", len(warnings), warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "storageclass: ") || !strings.HasPrefix(warnings[2], "webhook.1: ") || !strings.HasPrefix(warnings[3], "webhook.2: ") {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Config change hook URL environment variable.
+	configHookURLEnv = "MINIO_CONFIG_HOOK_URL"
+
+	// Config change hook events environment variable.
+	configHookEventsEnv = "MINIO_CONFIG_HOOK_EVENTS"
+
+	// Time allowed to deliver a config change to the hook.
+	configHookTimeout = 10 * time.Second
+)
+
+// Config changes posted to the config change hook.
+const (
+	// The config was set or patched.
+	configHookEventConfig = "config"
+
+	// The credentials were updated.
+	configHookEventCredentials = "credentials"
+)
+
+// configHook - URL to which config changes are posted, along with the
+// events to post. Config changes are not posted without a URL.
+type configHook struct {
+	url    *url.URL
+	events map[string]bool
+}
+
+// parseConfigHook - parses the URL of the config change hook and a
+// comma separated list of events, all events being posted if the
+// list is empty.
+func parseConfigHook(hookURL, events string) (configHook, error) {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return configHook{}, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return configHook{}, fmt.Errorf("`%s` is not an http or https URL", hookURL)
+	}
+
+	hook := configHook{url: u, events: make(map[string]bool)}
+	if strings.TrimSpace(events) == "" {
+		hook.events[configHookEventConfig] = true
+		hook.events[configHookEventCredentials] = true
+		return hook, nil
+	}
+	for _, event := range strings.Split(events, ",") {
+		event = strings.TrimSpace(event)
+		if event != configHookEventConfig && event != configHookEventCredentials {
+			return configHook{}, fmt.Errorf("unknown config hook event `%s`", event)
+		}
+		hook.events[event] = true
+	}
+	return hook, nil
+}
+
+// enabled - returns whether the given event is posted to the hook.
+func (h configHook) enabled(event string) bool {
+	return h.url != nil && h.events[event]
+}
+
+// configSectionChange - redacted values of a changed config section.
+type configSectionChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// configChange - summary of a config change posted to the hook.
+type configChange struct {
+	Event    string                         `json:"event"`
+	Time     time.Time                      `json:"time"`
+	Server   string                         `json:"server"`
+	Summary  string                         `json:"summary"`
+	Sections []string                       `json:"sections"`
+	Changes  map[string]configSectionChange `json:"changes"`
+}
+
+// redactedConfigSections - returns the top level sections of a config
+// with their secrets redacted.
+func redactedConfigSections(config *serverConfig) (map[string]interface{}, error) {
+	sections := map[string]interface{}{}
+	if config == nil {
+		return sections, nil
+	}
+
+	configBytes, err := redactConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(configBytes, &sections); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// newConfigChange - returns the summary of the change from the old to
+// the new config, secrets of both being redacted.
+func newConfigChange(event string, oldConfig, newConfig *serverConfig) (configChange, error) {
+	change := configChange{
+		Event:    event,
+		Time:     UTCNow(),
+		Server:   globalMinioAddr,
+		Summary:  newConfig.ConfigDiff(oldConfig),
+		Sections: []string{},
+		Changes:  map[string]configSectionChange{},
+	}
+
+	oldSections, err := redactedConfigSections(oldConfig)
+	if err != nil {
+		return change, err
+	}
+	newSections, err := redactedConfigSections(newConfig)
+	if err != nil {
+		return change, err
+	}
+
+	for _, sections := range []map[string]interface{}{oldSections, newSections} {
+		for section := range sections {
+			if _, ok := change.Changes[section]; ok {
+				continue
+			}
+			if !reflect.DeepEqual(oldSections[section], newSections[section]) {
+				change.Changes[section] = configSectionChange{
+					Old: oldSections[section],
+					New: newSections[section],
+				}
+			}
+		}
+	}
+
+	// A changed secret key leaves the redacted section unchanged.
+	if _, ok := change.Changes["credential"]; !ok && oldConfig != nil && oldConfig.Credential != newConfig.Credential {
+		change.Changes["credential"] = configSectionChange{
+			Old: oldSections["credential"],
+			New: newSections["credential"],
+		}
+	}
+
+	for section := range change.Changes {
+		change.Sections = append(change.Sections, section)
+	}
+	sort.Strings(change.Sections)
+	return change, nil
+}
+
+// postConfigChange - posts a config change to the hook, returns an
+// error if the hook does not reply with a 2xx status.
+func postConfigChange(ctx context.Context, hookURL *url.URL, change configChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, configHookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, hookURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Transport: NewCustomHTTPTransport()}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("config hook %s replied with status %s", hookURL, resp.Status)
+	}
+	return nil
+}
+
+// notifyConfigChange - posts, in the background, a redacted summary
+// of a config change to the config change hook if the event is
+// enabled. Delivery failures are only logged.
+func notifyConfigChange(event string, oldConfig, newConfig *serverConfig) {
+	hook := globalConfigHook
+	if !hook.enabled(event) {
+		return
+	}
+
+	reqInfo := (&logger.ReqInfo{}).AppendTags("configHookEvent", event)
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	change, err := newConfigChange(event, oldConfig, newConfig)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	go func() {
+		logger.LogIf(ctx, postConfigChange(ctx, hook.url, change))
+	}()
+}
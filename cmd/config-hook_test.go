@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestParseConfigHook(t *testing.T) {
+	testCases := []struct {
+		url, events    string
+		expectedEvents []string
+		shouldPass     bool
+	}{
+		{"http://cmdb.example.com/hook", "", []string{configHookEventConfig, configHookEventCredentials}, true},
+		{"https://cmdb.example.com/hook", "credentials", []string{configHookEventCredentials}, true},
+		{"https://cmdb.example.com", " config , credentials", []string{configHookEventConfig, configHookEventCredentials}, true},
+		{"https://cmdb.example.com", "config,bucket", nil, false},
+		{"ftp://cmdb.example.com", "", nil, false},
+		{"/hook", "", nil, false},
+	}
+	for i, testCase := range testCases {
+		hook, err := parseConfigHook(testCase.url, testCase.events)
+		if (err == nil) != testCase.shouldPass {
+			t.Fatalf("Test %d: Unexpected result %v", i+1, err)
+		}
+		if !testCase.shouldPass {
+			continue
+		}
+		for _, event := range []string{configHookEventConfig, configHookEventCredentials} {
+			expected := false
+			for _, e := range testCase.expectedEvents {
+				expected = expected || e == event
+			}
+			if hook.enabled(event) != expected {
+				t.Errorf("Test %d: Expected event %s enabled to be %v", i+1, event, expected)
+			}
+		}
+	}
+
+	if (configHook{}).enabled(configHookEventConfig) {
+		t.Errorf("Expected events to be disabled without a hook URL")
+	}
+}
+
+// Tests that config changes list changed sections with their secrets
+// redacted.
+func TestNewConfigChange(t *testing.T) {
+	oldConfig := newServerConfig()
+	oldConfig.Credential = auth.Credentials{AccessKey: "minio", SecretKey: "minio123"}
+
+	newConfig := newServerConfig()
+	newConfig.Credential = auth.Credentials{AccessKey: "minio", SecretKey: "minio456"}
+	newConfig.Region = "eu-west-1"
+
+	change, err := newConfigChange(configHookEventConfig, oldConfig, newConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(change.Sections, []string{"credential", "region"}) {
+		t.Fatalf("Unexpected changed sections %v", change.Sections)
+	}
+	if change.Changes["region"].Old != globalMinioDefaultRegion || change.Changes["region"].New != "eu-west-1" {
+		t.Errorf("Unexpected region change %v", change.Changes["region"])
+	}
+	if change.Summary != "Credential configuration differs" {
+		t.Errorf("Unexpected summary %s", change.Summary)
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "minio123") || strings.Contains(string(data), "minio456") {
+		t.Errorf("Expected secret keys to be redacted in %s", data)
+	}
+
+	if change, err = newConfigChange(configHookEventConfig, newConfig, newConfig); err != nil {
+		t.Fatal(err)
+	}
+	if len(change.Sections) != 0 || change.Summary != "" {
+		t.Errorf("Expected no change, got %v", change)
+	}
+}
+
+func TestPostConfigChange(t *testing.T) {
+	var received configChange
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hookURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := configChange{Event: configHookEventCredentials, Sections: []string{"credential"}}
+
+	if err = postConfigChange(context.Background(), hookURL, change); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if received.Event != change.Event || !reflect.DeepEqual(received.Sections, change.Sections) {
+		t.Errorf("Expected %v to be posted, got %v", change, received)
+	}
+
+	status = http.StatusInternalServerError
+	if err = postConfigChange(context.Background(), hookURL, change); err == nil {
+		t.Errorf("Expected error on a failed delivery")
+	}
+}
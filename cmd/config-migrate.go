@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
@@ -51,7 +52,7 @@ func GetVersion(configFile string) (string, error) {
 	return quick.GetVersion(configFile, globalEtcdClient)
 }
 
-// Migrates all config versions from "1" to "28".
+// Migrates all config versions from "1" to "29".
 func migrateConfig() error {
 	// Purge all configs with version '1',
 	// this is a special case since version '1' used
@@ -219,6 +220,11 @@ func migrateConfig() error {
 			return err
 		}
 		fallthrough
+	case "28":
+		if err = migrateV28ToV29(); err != nil {
+			return err
+		}
+		fallthrough
 	case serverConfigVersion:
 		// No migration needed. this always points to current version.
 		err = nil
@@ -2409,9 +2415,56 @@ func migrateV27ToV28() error {
 	return nil
 }
 
-// Migrates '.minio.sys/config.json' v27 to v28.
+// bucketStorageClassesV28 - default storage classes of buckets, which
+// development builds saved in the storageclass section of config V28.
+// Config V29 saves them in the bucketStorageClasses section.
+type bucketStorageClassesV28 struct {
+	Version      string `json:"version"`
+	StorageClass struct {
+		Buckets map[string]string `json:"buckets"`
+	} `json:"storageclass"`
+}
+
+func migrateV28ToV29() error {
+	configFile := getConfigFile()
+
+	// config V29 is backward compatible with V28, load the old
+	// config file in serverConfigV29 struct, its new fields are
+	// unset and keep their defaults, apart from the default storage
+	// classes of buckets moved out of the storageclass section.
+	srvConfig := &serverConfigV29{}
+	_, err := quick.LoadConfig(configFile, globalEtcdClient, srvConfig)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+
+	if srvConfig.Version != "28" {
+		return nil
+	}
+
+	bucketClasses := &bucketStorageClassesV28{}
+	if _, err = quick.LoadConfig(configFile, globalEtcdClient, bucketClasses); err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+
+	srvConfig.Version = "29"
+	srvConfig.BucketStorageClasses = bucketClasses.StorageClass.Buckets
+	if err = quick.SaveConfig(srvConfig, configFile, globalEtcdClient); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘28’ to ‘29’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "28", "29")
+	return nil
+}
+
+// Migrates '.minio.sys/config.json' from v27 to v29.
 func migrateMinioSysConfig(objAPI ObjectLayer) error {
-	return migrateV27ToV28MinioSys(objAPI)
+	if err := migrateV27ToV28MinioSys(objAPI); err != nil {
+		return err
+	}
+	return migrateV28ToV29MinioSys(objAPI)
 }
 
 func migrateV27ToV28MinioSys(objAPI ObjectLayer) error {
@@ -2435,3 +2488,34 @@ func migrateV27ToV28MinioSys(objAPI ObjectLayer) error {
 	logger.Info(configMigrateMSGTemplate, configFile, "27", "28")
 	return nil
 }
+
+func migrateV28ToV29MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+	srvConfig, err := readServerConfig(context.Background(), objAPI)
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if srvConfig.Version != "28" {
+		return nil
+	}
+
+	configData, err := readServerConfigData(context.Background(), objAPI)
+	if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	bucketClasses := &bucketStorageClassesV28{}
+	if err = json.Unmarshal(configData, bucketClasses); err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+
+	srvConfig.Version = "29"
+	srvConfig.BucketStorageClasses = bucketClasses.StorageClass.Buckets
+	if err = saveServerConfig(objAPI, srvConfig); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘28’ to ‘29’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "28", "29")
+	return nil
+}
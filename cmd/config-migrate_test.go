@@ -17,9 +17,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -157,10 +161,13 @@ func TestServerConfigMigrateInexistentConfig(t *testing.T) {
 	if err := migrateV27ToV28(); err != nil {
 		t.Fatal("migrate v27 to v28 should succeed when no config file is found")
 	}
+	if err := migrateV28ToV29(); err != nil {
+		t.Fatal("migrate v28 to v29 should succeed when no config file is found")
+	}
 }
 
-// Test if a config migration from v2 to v28 is successfully done
-func TestServerConfigMigrateV2toV28(t *testing.T) {
+// Test if a config migration from v2 to v29 is successfully done
+func TestServerConfigMigrateV2toV29(t *testing.T) {
 	rootPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
 	if err != nil {
 		t.Fatal(err)
@@ -223,6 +230,71 @@ func TestServerConfigMigrateV2toV28(t *testing.T) {
 	}
 }
 
+// Test if a config migration from v28 to v29 keeps the config, moves
+// the default storage classes of buckets to their own section and
+// leaves the new fields unset, in the config directory and in the
+// backend.
+func TestServerConfigMigrateV28toV29(t *testing.T) {
+	rootPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootPath)
+	setConfigDir(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	configV28 := "{ \"version\":\"28\", \"credential\": {\"accessKey\":\"accessfoo\", \"secretKey\":\"secretfoo\"}, \"region\":\"eu-west-1\", \"browser\":\"on\", \"storageclass\": {\"standard\":\"\", \"rrs\":\"\", \"buckets\": {\"logs\":\"REDUCED_REDUNDANCY\"}}}"
+	configPath := rootPath + "/" + minioConfigFile
+	if err = ioutil.WriteFile(configPath, []byte(configV28), 0644); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err = migrateV28ToV29(); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	srvConfig := &serverConfigV29{}
+	if _, err = Load(configPath, srvConfig); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if srvConfig.Version != "29" {
+		t.Fatalf("Expect version 29, found: %v", srvConfig.Version)
+	}
+	if srvConfig.Credential.AccessKey != "accessfoo" || srvConfig.Region != "eu-west-1" {
+		t.Fatalf("Config altered during migration: %v", srvConfig)
+	}
+	if srvConfig.Headers != nil || srvConfig.MinPartSize != 0 || srvConfig.Compression != nil ||
+		srvConfig.MaxClockSkew != "" || srvConfig.AutoHeal {
+		t.Fatalf("Expected the fields added in v29 to be unset: %v", srvConfig)
+	}
+	expectedClasses := map[string]string{"logs": reducedRedundancyStorageClass}
+	if !reflect.DeepEqual(srvConfig.BucketStorageClasses, expectedClasses) {
+		t.Fatalf("Expected bucket storage classes %v, found: %v", expectedClasses, srvConfig.BucketStorageClasses)
+	}
+
+	// A v28 config saved in the backend is migrated as well.
+	configV28 = strings.Replace(configV28, "eu-west-1", "us-west-1", 1)
+	if err = saveConfig(objLayer, path.Join(minioConfigPrefix, minioConfigFile), []byte(configV28)); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err = migrateMinioSysConfig(objLayer); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if srvConfig, err = readServerConfig(context.Background(), objLayer); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if srvConfig.Version != "29" || srvConfig.Region != "us-west-1" {
+		t.Fatalf("Expect version 29 and region us-west-1, found: %v and %v", srvConfig.Version, srvConfig.Region)
+	}
+	if !reflect.DeepEqual(srvConfig.BucketStorageClasses, expectedClasses) {
+		t.Fatalf("Expected bucket storage classes %v, found: %v", expectedClasses, srvConfig.BucketStorageClasses)
+	}
+}
+
 // Test if all migrate code returns error with corrupted config files
 func TestServerConfigMigrateFaultyConfig(t *testing.T) {
 	rootPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
@@ -318,6 +390,9 @@ func TestServerConfigMigrateFaultyConfig(t *testing.T) {
 	if err := migrateV27ToV28(); err == nil {
 		t.Fatal("migrateConfigV27ToV28() should fail with a corrupted json")
 	}
+	if err := migrateV28ToV29(); err == nil {
+		t.Fatal("migrateConfigV28ToV29() should fail with a corrupted json")
+	}
 }
 
 // Test if all migrate code returns error with corrupted config files
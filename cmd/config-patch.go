@@ -0,0 +1,149 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Config section holding the credentials.
+const configSectionCredential = "credential"
+
+// configSections - returns the top level keys of config.json which
+// may be updated independently, i.e. all keys but the version.
+func configSections() []string {
+	var sections []string
+	t := reflect.TypeOf(serverConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := configSectionName(t.Field(i)); name != "" {
+			sections = append(sections, name)
+		}
+	}
+	return sections
+}
+
+// configSectionName - returns the top level key of config.json of a
+// field of serverConfig, empty if the field is not a section.
+func configSectionName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" || name == "version" {
+		return ""
+	}
+	return name
+}
+
+// copyConfigSections - replaces the given sections of dst by the ones
+// of src, leaving the other sections of dst unchanged.
+func copyConfigSections(dst, src *serverConfig, sections []string) {
+	copied := make(map[string]bool)
+	for _, section := range sections {
+		copied[section] = true
+	}
+	dstValue, srcValue := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	t := dstValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if copied[configSectionName(t.Field(i))] {
+			dstValue.Field(i).Set(srcValue.Field(i))
+		}
+	}
+}
+
+// parseConfigPatch - returns the sections of config.json updated by
+// the given partial config, which holds a subset of its top level
+// keys.
+func parseConfigPatch(patchBytes []byte) (map[string]json.RawMessage, error) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return nil, fmt.Errorf("no config section to update")
+	}
+
+	known := make(map[string]bool)
+	for _, section := range configSections() {
+		known[section] = true
+	}
+	for section := range patch {
+		if !known[section] {
+			return nil, fmt.Errorf("unknown config section `%s`", section)
+		}
+	}
+	return patch, nil
+}
+
+// extractConfigSection - returns the partial config holding only the
+// given top level key of config.json, as accepted by PatchConfigHandler.
+// Empty sections, omitted from config.json, give an empty partial
+// config.
+func extractConfigSection(config *serverConfig, section string) ([]byte, error) {
+	known := false
+	for _, name := range configSections() {
+		if name == section {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown config section `%s`", section)
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections map[string]json.RawMessage
+	if err = json.Unmarshal(configBytes, &sections); err != nil {
+		return nil, err
+	}
+	partial := make(map[string]json.RawMessage)
+	if value, ok := sections[section]; ok {
+		partial[section] = value
+	}
+	return json.Marshal(partial)
+}
+
+// patchConfig - returns a copy of the given config whose sections are
+// replaced by the ones of the patch.
+func patchConfig(config *serverConfig, patch map[string]json.RawMessage) (*serverConfig, error) {
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections map[string]json.RawMessage
+	if err = json.Unmarshal(configBytes, &sections); err != nil {
+		return nil, err
+	}
+	for section, value := range patch {
+		sections[section] = value
+	}
+
+	if configBytes, err = json.Marshal(sections); err != nil {
+		return nil, err
+	}
+
+	patched := &serverConfig{}
+	if err = json.Unmarshal(configBytes, patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Tests that updates of the saved config wait on each other.
+func TestLockServerConfig(t *testing.T) {
+	initNSLock(false)
+
+	unlock, err := lockServerConfig()
+	if err != nil {
+		t.Fatalf("Unable to lock the config: %v", err)
+	}
+
+	acquired := make(chan error)
+	go func() {
+		unlock, err := lockServerConfig()
+		if err == nil {
+			unlock()
+		}
+		acquired <- err
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected the second lock of the config to wait for the first")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	if err = <-acquired; err != nil {
+		t.Fatalf("Unable to lock the config once unlocked: %v", err)
+	}
+}
+
+// Tests parsing and applying partial configs.
+func TestPatchConfig(t *testing.T) {
+	config := newServerConfig()
+	config.SetRegion("us-east-1")
+
+	testCases := []struct {
+		patch          string
+		expectedRegion string
+		shouldPass     bool
+	}{
+		{`{"region": "eu-west-1"}`, "eu-west-1", true},
+		{`{"browser": "off"}`, "us-east-1", true},
+		{`{"version": "15"}`, "", false},
+		{`{"unknown": {}}`, "", false},
+		{`{}`, "", false},
+		{`[]`, "", false},
+	}
+	for i, testCase := range testCases {
+		patch, err := parseConfigPatch([]byte(testCase.patch))
+		if err != nil {
+			if testCase.shouldPass {
+				t.Errorf("Test %d: Unexpected error %v", i+1, err)
+			}
+			continue
+		}
+		if !testCase.shouldPass {
+			t.Errorf("Test %d: Expected to fail", i+1)
+			continue
+		}
+
+		patched, err := patchConfig(config, patch)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if patched.Region != testCase.expectedRegion {
+			t.Errorf("Test %d: Expected region %s but got %s", i+1, testCase.expectedRegion, patched.Region)
+		}
+		if !patched.Credential.Equal(config.Credential) || patched.Version != config.Version {
+			t.Errorf("Test %d: Expected sections not in the patch to be unchanged", i+1)
+		}
+	}
+
+	// The original config is never modified.
+	if config.Region != "us-east-1" {
+		t.Errorf("Expected the original config to be unchanged")
+	}
+}
+
+// Tests extracting a section of the config.
+func TestExtractConfigSection(t *testing.T) {
+	config := newServerConfig()
+	config.SetRegion("us-east-1")
+
+	testCases := []struct {
+		section          string
+		expectedSections []string
+		shouldPass       bool
+	}{
+		{"region", []string{"region"}, true},
+		{"notify", []string{"notify"}, true},
+		// Omitted from config.json while empty.
+		{"headers", nil, true},
+		{"version", nil, false},
+		{"unknown", nil, false},
+	}
+	for i, testCase := range testCases {
+		partial, err := extractConfigSection(config, testCase.section)
+		if err != nil {
+			if testCase.shouldPass {
+				t.Errorf("Test %d: Unexpected error %v", i+1, err)
+			}
+			continue
+		}
+		if !testCase.shouldPass {
+			t.Errorf("Test %d: Expected to fail", i+1)
+			continue
+		}
+
+		var sections map[string]json.RawMessage
+		if err = json.Unmarshal(partial, &sections); err != nil {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if len(sections) != len(testCase.expectedSections) {
+			t.Fatalf("Test %d: Expected sections %v but got %s", i+1, testCase.expectedSections, partial)
+		}
+		for _, section := range testCase.expectedSections {
+			if _, ok := sections[section]; !ok {
+				t.Errorf("Test %d: Expected section %s in %s", i+1, section, partial)
+			}
+		}
+	}
+
+	// The partial config of a section is accepted as a patch.
+	partial, err := extractConfigSection(config, "region")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = parseConfigPatch(partial); err != nil {
+		t.Errorf("Expected the partial config to be a valid patch, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Prefix of the objects holding the config values stored
+	// outside of config.json, named after the SHA-256 of their
+	// content.
+	configValuesPrefix = minioConfigPrefix + "/values"
+
+	// Prefix of the config.json string values referencing a value
+	// stored outside of config.json.
+	configValueRefPrefix = "minio-config-ref:"
+
+	// Minimum size of the config values stored outside of
+	// config.json, such as certificate bundles.
+	minConfigRefValueSize = 4 * 1024
+
+	// Age after which a value no longer referenced by config.json
+	// is removed. Values are rewritten on each save, younger ones
+	// may belong to a config being saved by another server.
+	staleConfigValueAge = time.Hour
+)
+
+var errInvalidConfigValueRef = errors.New("invalid config value reference")
+
+// walkConfigStrings - replaces each string value of a decoded JSON
+// document with the one returned by fn.
+func walkConfigStrings(v interface{}, fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	switch value := v.(type) {
+	case string:
+		return fn(value)
+	case map[string]interface{}:
+		for key, elem := range value {
+			if value[key], err = walkConfigStrings(elem, fn); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, elem := range value {
+			if value[i], err = walkConfigStrings(elem, fn); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// decodeConfigJSON - decodes a JSON document keeping numbers as they
+// are written.
+func decodeConfigJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// extractConfigValues - replaces the large string values of the
+// given config by references, returning the values by name. Strings
+// which look like a reference are extracted as well so that they are
+// read back unchanged. The config is returned as is when no value is
+// extracted.
+func extractConfigValues(data []byte) ([]byte, map[string][]byte, error) {
+	v, err := decodeConfigJSON(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string][]byte)
+	v, err = walkConfigStrings(v, func(s string) (string, error) {
+		if len(s) < minConfigRefValueSize && !strings.HasPrefix(s, configValueRefPrefix) {
+			return s, nil
+		}
+		name := getSHA256Hash([]byte(s))
+		values[name] = []byte(s)
+		return configValueRefPrefix + name, nil
+	})
+	if err != nil || len(values) == 0 {
+		return data, values, err
+	}
+
+	data, err = json.Marshal(v)
+	return data, values, err
+}
+
+// resolveConfigValues - replaces the references of the given config
+// by the values read with readValue.
+func resolveConfigValues(data []byte, readValue func(name string) ([]byte, error)) ([]byte, error) {
+	if !bytes.Contains(data, []byte(configValueRefPrefix)) {
+		return data, nil
+	}
+
+	v, err := decodeConfigJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	v, err = walkConfigStrings(v, func(s string) (string, error) {
+		if !strings.HasPrefix(s, configValueRefPrefix) {
+			return s, nil
+		}
+		name := strings.TrimPrefix(s, configValueRefPrefix)
+		if sum, err := hex.DecodeString(name); err != nil || len(sum) != 32 {
+			return "", errInvalidConfigValueRef
+		}
+		value, err := readValue(name)
+		if err != nil {
+			return "", err
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// readConfigValue - reads a config value stored outside of
+// config.json.
+func readConfigValue(ctx context.Context, objAPI ObjectLayer, name string) ([]byte, error) {
+	buffer, err := readConfig(ctx, objAPI, path.Join(configValuesPrefix, name))
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// removeStaleConfigValues - removes the config values stored outside
+// of config.json which are no longer referenced by it.
+func removeStaleConfigValues(ctx context.Context, objAPI ObjectLayer, values map[string][]byte) {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, configValuesPrefix+"/", marker, "", maxObjectList)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		for _, obj := range result.Objects {
+			if _, ok := values[path.Base(obj.Name)]; ok || UTCNow().Sub(obj.ModTime) < staleConfigValueAge {
+				continue
+			}
+			if err = objAPI.DeleteObject(ctx, minioMetaBucket, obj.Name); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+		if !result.IsTruncated {
+			return
+		}
+		marker = result.NextMarker
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestExtractConfigValues(t *testing.T) {
+	large := strings.Repeat("a", minConfigRefValueSize)
+	data := []byte(`{"version":"28","small":"b","large":"` + large + `","list":["` + large + `"],"ref":"` + configValueRefPrefix + `x","size":12345678901234567890}`)
+
+	extracted, values, err := extractConfigValues(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("Expected 2 values, got %d", len(values))
+	}
+	if bytes.Contains(extracted, []byte(large)) {
+		t.Fatalf("Expected the large value to be extracted")
+	}
+
+	resolved, err := resolveConfigValues(extracted, func(name string) ([]byte, error) {
+		return values[name], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`"small":"b"`, `"large":"` + large + `"`, `"ref":"` + configValueRefPrefix + `x"`, `"size":12345678901234567890`} {
+		if !bytes.Contains(resolved, []byte(expected)) {
+			t.Errorf("Expected %.40s in the resolved config", expected)
+		}
+	}
+
+	// A config without large values is kept as is.
+	small := []byte(`{"version": "28"}`)
+	if extracted, values, err = extractConfigValues(small); err != nil || !bytes.Equal(extracted, small) || len(values) != 0 {
+		t.Errorf("Expected the config to be unchanged, got %s - %v", extracted, err)
+	}
+
+	// References must be SHA-256 sums.
+	invalid := []byte(`{"large":"` + configValueRefPrefix + `../config.json"}`)
+	if _, err = resolveConfigValues(invalid, nil); err != errInvalidConfigValueRef {
+		t.Errorf("Expected error %v, got %v", errInvalidConfigValueRef, err)
+	}
+}
+
+// Tests that large config values are saved outside of config.json
+// and read back.
+func TestServerConfigValueRefs(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	config := newServerConfig()
+	config.Domain = strings.Repeat("a", minConfigRefValueSize)
+	if err = saveServerConfig(objLayer, config); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	buffer, err := readConfig(ctx, objLayer, path.Join(minioConfigPrefix, minioConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buffer.String(), config.Domain) || !strings.Contains(buffer.String(), configValueRefPrefix) {
+		t.Fatalf("Expected config.json to reference the large value")
+	}
+
+	readConfig, err := readServerConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readConfig.Domain != config.Domain {
+		t.Errorf("Expected the large value to be read back")
+	}
+}
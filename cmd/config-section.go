@@ -0,0 +1,185 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// configSectionLoader - makes a running server use a section of
+// config.json without restarting.
+type configSectionLoader struct {
+	// copy - replaces the section of dst by the one of src.
+	copy func(dst, src *serverConfig)
+
+	// apply - makes the subsystems depending on the section use the
+	// one of the given config, nothing is applied if it is invalid.
+	apply func(config *serverConfig) error
+}
+
+// Sections of config.json which servers load without restarting when
+// updated through the admin API.
+var configSectionLoaders = map[string]configSectionLoader{
+	configSectionStorageClass: {
+		copy: func(dst, src *serverConfig) {
+			dst.StorageClass = src.StorageClass
+		},
+		apply: func(config *serverConfig) error {
+			if !globalIsStorageClass {
+				globalStandardStorageClass, globalRRStorageClass = config.GetStorageClass()
+			}
+			return nil
+		},
+	},
+	configSectionBucketStorageClasses: {
+		copy: func(dst, src *serverConfig) {
+			dst.BucketStorageClasses = src.BucketStorageClasses
+		},
+		apply: func(config *serverConfig) error {
+			globalBucketStorageClasses.set(config.BucketStorageClasses)
+			return nil
+		},
+	},
+	configSectionMaxClockSkew: {
+		copy: func(dst, src *serverConfig) {
+			dst.MaxClockSkew = src.MaxClockSkew
+		},
+		apply: func(config *serverConfig) error {
+			maxSkew, err := parseMaxClockSkew(config.MaxClockSkew)
+			if err != nil {
+				return err
+			}
+			globalClockSkewTolerance.set(maxSkew)
+			return nil
+		},
+	},
+	configSectionMinPartSize: {
+		copy: func(dst, src *serverConfig) {
+			dst.MinPartSize = src.MinPartSize
+		},
+		apply: func(config *serverConfig) error {
+			globalMultipartPolicy.setMinPartSize(config.MinPartSize)
+			return nil
+		},
+	},
+	configSectionHeaders: {
+		copy: func(dst, src *serverConfig) {
+			dst.Headers = src.Headers
+		},
+		apply: func(config *serverConfig) error {
+			globalResponseHeaders.set(config.Headers)
+			return nil
+		},
+	},
+	configSectionCompression: {
+		copy: func(dst, src *serverConfig) {
+			dst.Compression = src.Compression
+		},
+		apply: func(config *serverConfig) error {
+			globalResponseCompression.set(config.Compression)
+			return nil
+		},
+	},
+	configSectionAutoHeal: {
+		copy: func(dst, src *serverConfig) {
+			dst.AutoHeal = src.AutoHeal
+		},
+		apply: func(config *serverConfig) error {
+			globalAutoHealState.setEnabled(bool(config.AutoHeal))
+			return nil
+		},
+	},
+}
+
+// Sections of config.json which admin APIs cannot change, they are only
+// changed by editing config.json.
+var readOnlyConfigSections = set.CreateStringSet(configSectionAdminDisabledActions)
+
+// getConfigSectionLoader - returns the loader of a section of
+// config.json, an error if the section cannot be loaded without
+// restarting.
+func getConfigSectionLoader(section string) (configSectionLoader, error) {
+	loader, ok := configSectionLoaders[section]
+	if !ok {
+		return configSectionLoader{}, fmt.Errorf("config section `%s` cannot be loaded without a restart", section)
+	}
+	return loader, nil
+}
+
+// saveConfigSection - updates the saved config.json with the given
+// function while it is locked on all servers. Callers lock the updated
+// section beforehand. Servers keep running with their config until
+// they load the section.
+func saveConfigSection(ctx context.Context, objAPI ObjectLayer, update func(config *serverConfig)) error {
+	unlock, err := lockServerConfig()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	config, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	update(config)
+	return saveServerConfig(objAPI, config)
+}
+
+// saveConfigCredential - updates the credential of the saved
+// config.json, callers lock the credential section beforehand.
+func saveConfigCredential(ctx context.Context, objAPI ObjectLayer, creds auth.Credentials) error {
+	return saveConfigSection(ctx, objAPI, func(config *serverConfig) {
+		config.Credential = creds
+	})
+}
+
+// loadConfigSection - loads a section of the saved config.json into
+// the running config, the subsystems depending on it use it right
+// away.
+func loadConfigSection(ctx context.Context, objAPI ObjectLayer, section string) error {
+	loader, err := getConfigSectionLoader(section)
+	if err != nil {
+		return err
+	}
+
+	config, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if err = loader.apply(config); err != nil {
+		return err
+	}
+
+	globalServerConfigMu.Lock()
+	defer globalServerConfigMu.Unlock()
+	if globalServerConfig != nil {
+		// A server running with the saved config apart from the
+		// section now runs with the saved config.
+		prevConfig := *config
+		loader.copy(&prevConfig, globalServerConfig)
+		if configChecksum(&prevConfig) == globalServerConfigChecksum {
+			globalServerConfigChecksum = configChecksum(config)
+		}
+		loader.copy(globalServerConfig, config)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Tests that a section saved in config.json is loaded into the
+// running config, which still matches the saved config.
+func TestSaveLoadConfigSection(t *testing.T) {
+	tmpGlobalServerConfig, tmpChecksum := globalServerConfig, globalServerConfigChecksum
+	tmpGlobalBucketStorageClasses := globalBucketStorageClasses
+	defer func() {
+		globalServerConfig, globalServerConfigChecksum = tmpGlobalServerConfig, tmpChecksum
+		globalBucketStorageClasses = tmpGlobalBucketStorageClasses
+	}()
+	globalBucketStorageClasses = &bucketStorageClasses{}
+	initNSLock(false)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	globalServerConfig = newServerConfig()
+	globalServerConfigChecksum = configChecksum(globalServerConfig)
+	if err = saveServerConfig(objLayer, globalServerConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = saveConfigSection(ctx, objLayer, func(config *serverConfig) {
+		setBucketStorageClass(config, "logs", reducedRedundancyStorageClass)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if globalServerConfig.BucketStorageClasses != nil {
+		t.Fatalf("Expected the running config to be unchanged before loading the section")
+	}
+
+	if err = loadConfigSection(ctx, objLayer, "region"); err == nil {
+		t.Fatalf("Expected loading a section requiring a restart to fail")
+	}
+	if err = loadConfigSection(ctx, objLayer, configSectionBucketStorageClasses); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"logs": reducedRedundancyStorageClass}
+	if !reflect.DeepEqual(globalServerConfig.BucketStorageClasses, expected) {
+		t.Errorf("Expected running config to hold %v, got %v", expected, globalServerConfig.BucketStorageClasses)
+	}
+	if sc := globalBucketStorageClasses.get("logs"); sc != reducedRedundancyStorageClass {
+		t.Errorf("Expected storage class %s, got %s", reducedRedundancyStorageClass, sc)
+	}
+
+	// The server still runs with the saved config.
+	config, err := readServerConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if globalServerConfigChecksum != configChecksum(config) {
+		t.Errorf("Expected the config checksum to match the saved config")
+	}
+}
+
+// Tests that an invalid section is neither applied nor copied to the
+// running config.
+func TestLoadConfigSectionInvalid(t *testing.T) {
+	tmpGlobalServerConfig, tmpChecksum := globalServerConfig, globalServerConfigChecksum
+	tmpGlobalClockSkewTolerance := globalClockSkewTolerance
+	defer func() {
+		globalServerConfig, globalServerConfigChecksum = tmpGlobalServerConfig, tmpChecksum
+		globalClockSkewTolerance = tmpGlobalClockSkewTolerance
+	}()
+	globalClockSkewTolerance = &clockSkewTolerance{}
+	initNSLock(false)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	globalServerConfig = newServerConfig()
+	config := newServerConfig()
+	config.MaxClockSkew = "2h"
+	if err = saveServerConfig(objLayer, config); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = loadConfigSection(context.Background(), objLayer, configSectionMaxClockSkew); err == nil {
+		t.Fatalf("Expected loading an invalid maximum clock skew to fail")
+	}
+	if globalServerConfig.MaxClockSkew != "" {
+		t.Errorf("Expected the running config to be unchanged, got %s", globalServerConfig.MaxClockSkew)
+	}
+	if d := globalClockSkewTolerance.get(); d != globalMaxSkewTime {
+		t.Errorf("Expected the default maximum clock skew, got %s", d)
+	}
+}
@@ -756,3 +756,61 @@ type serverConfigV28 struct {
 	// Logger configuration
 	Logger loggerConfig `json:"logger"`
 }
+
+// serverConfigV29 is just like version '28', additionally storing
+// custom response headers, the minimum part size, the compression of
+// GET responses, the maximum clock skew, auto heal and the disabled
+// admin actions.
+//
+// IMPORTANT NOTE: When updating this struct make sure that
+// serverConfig.ConfigDiff() is updated as necessary.
+type serverConfigV29 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Browser    BoolFlag         `json:"browser"`
+	Worm       BoolFlag         `json:"worm"`
+	Domain     string           `json:"domain"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Custom headers added to all responses
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Minimum size of the parts of multipart uploads but the last
+	MinPartSize int64 `json:"minPartSize,omitempty"`
+
+	// Compression of GET responses
+	Compression *compressionConfig `json:"compression,omitempty"`
+
+	// Maximum clock skew of signed requests, such as "5m"
+	MaxClockSkew string `json:"maxClockSkew,omitempty"`
+
+	// Heal after drives come back online or are replaced
+	AutoHeal BoolFlag `json:"autoHeal,omitempty"`
+
+	// Admin actions whose endpoints are disabled, such as
+	// "admin:UpdateCredentials" or "admin:Heal*"
+	AdminDisabledActions []string `json:"adminDisabledActions,omitempty"`
+
+	// Default storage class of objects written to a bucket
+	// without a storage class, indexed by bucket name
+	BucketStorageClasses map[string]string `json:"bucketStorageClasses,omitempty"`
+}
@@ -0,0 +1,141 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Time between two keepalives of a config watch stream.
+	configWatchKeepAliveInterval = 10 * time.Second
+
+	// Config changes buffered for a watcher, a watcher falling
+	// further behind is disconnected.
+	configWatchBufferSize = 16
+)
+
+// configWatch - last config persisted by this server along with the
+// watchers notified of each change.
+type configWatch struct {
+	mu       sync.Mutex
+	last     *serverConfig
+	watchers map[chan configChange]struct{}
+}
+
+// Prepare new configWatch structure
+func newConfigWatch() *configWatch {
+	return &configWatch{watchers: make(map[chan configChange]struct{})}
+}
+
+// copyServerConfig - returns a copy of the given config, which later
+// changes of the config do not alter.
+func copyServerConfig(config *serverConfig) (*serverConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	configCopy := &serverConfig{}
+	if err = json.Unmarshal(data, configCopy); err != nil {
+		return nil, err
+	}
+	return configCopy, nil
+}
+
+// subscribe - returns a channel receiving the changes of the config
+// until unsubscribed. The channel is closed if the watcher falls
+// behind.
+func (c *configWatch) subscribe() chan configChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan configChange, configWatchBufferSize)
+	c.watchers[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe - stops sending the changes of the config to a channel
+// returned by subscribe.
+func (c *configWatch) unsubscribe(ch chan configChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.watchers[ch]; ok {
+		delete(c.watchers, ch)
+		close(ch)
+	}
+}
+
+// setLast - records the given config as the last persisted one,
+// without notifying the watchers.
+func (c *configWatch) setLast(config *serverConfig) error {
+	configCopy, err := copyServerConfig(config)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last = configCopy
+	return nil
+}
+
+// publish - records the given config as the last persisted one and
+// sends its changes, secrets redacted, to the watchers. Nothing is
+// sent if the config did not change.
+func (c *configWatch) publish(config *serverConfig) error {
+	configCopy, err := copyServerConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := c.last
+	c.last = configCopy
+	if len(c.watchers) == 0 {
+		return nil
+	}
+
+	change, err := newConfigChange(configHookEventConfig, last, configCopy)
+	if err != nil {
+		return err
+	}
+	if len(change.Sections) == 0 {
+		return nil
+	}
+	for ch := range c.watchers {
+		select {
+		case ch <- change:
+		default:
+			delete(c.watchers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// publishConfigChange - notifies the watchers of this server of a
+// persisted config, errors are only logged.
+func publishConfigChange(config *serverConfig) {
+	if err := globalConfigWatch.publish(config); err != nil {
+		ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "ConfigWatch"})
+		logger.LogIf(ctx, err)
+	}
+}
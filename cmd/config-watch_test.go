@@ -0,0 +1,88 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests that watchers receive the redacted changes of each persisted
+// config and that unchanged configs are not sent.
+func TestConfigWatchPublish(t *testing.T) {
+	watch := newConfigWatch()
+	config := newServerConfig()
+	if err := watch.setLast(config); err != nil {
+		t.Fatal(err)
+	}
+
+	ch := watch.subscribe()
+	defer watch.unsubscribe(ch)
+
+	// The watch keeps a copy, later changes of the config are changes.
+	cred, err := auth.CreateCredentials("minio-watch", "minio-watch-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.SetRegion("eu-west-1")
+	config.SetCredential(cred)
+	if err = watch.publish(config); err != nil {
+		t.Fatal(err)
+	}
+	change := <-ch
+	if !reflect.DeepEqual(change.Sections, []string{"credential", "region"}) {
+		t.Errorf("Expected credential and region changes, got %v", change.Sections)
+	}
+	if cred, ok := change.Changes["credential"].New.(map[string]interface{}); !ok || cred["secretKey"] != redactedValue {
+		t.Errorf("Expected a redacted secret key, got %v", change.Changes["credential"])
+	}
+
+	if err = watch.publish(config); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case change = <-ch:
+		t.Errorf("Expected no change for an unchanged config, got %v", change)
+	default:
+	}
+}
+
+// Tests that a watcher falling behind is disconnected.
+func TestConfigWatchSlowWatcher(t *testing.T) {
+	watch := newConfigWatch()
+	ch := watch.subscribe()
+
+	config := newServerConfig()
+	for i := 0; i <= configWatchBufferSize; i++ {
+		config.SetRegion("region-" + string('a'+rune(i)))
+		if err := watch.publish(config); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	if received != configWatchBufferSize {
+		t.Errorf("Expected %d changes before the disconnection, got %d", configWatchBufferSize, received)
+	}
+	// Unsubscribing a disconnected watcher is harmless.
+	watch.unsubscribe(ch)
+}
@@ -0,0 +1,118 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// isYAMLMediaType - returns true if the media type, such as the value
+// of a Content-Type header, denotes YAML.
+func isYAMLMediaType(s string) bool {
+	mediaType, _, err := mime.ParseMediaType(s)
+	if err != nil {
+		return false
+	}
+	return mediaType == string(mimeYAML) || mediaType == "application/x-yaml"
+}
+
+// acceptsYAML - returns true if the client asked for a YAML response
+// through the Accept header.
+func acceptsYAML(r *http.Request) bool {
+	for _, mediaType := range strings.Split(r.Header.Get("Accept"), ",") {
+		if isYAMLMediaType(strings.TrimSpace(mediaType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// configJSONToYAML - converts a JSON config into YAML, keeping the
+// order of keys.
+func configJSONToYAML(data []byte) ([]byte, error) {
+	// JSON is a subset of YAML, decoding into a MapSlice keeps
+	// keys in their original order.
+	var config yaml.MapSlice
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(config)
+}
+
+// configYAMLToJSON - converts a YAML config into JSON. Keys are kept
+// in their original order, including duplicate keys, so that the
+// resulting JSON is subject to the same checks as a JSON config.
+func configYAMLToJSON(data []byte) ([]byte, error) {
+	var config yaml.MapSlice
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAMLAsJSON(&buf, config); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAMLAsJSON - writes a value decoded from YAML as JSON.
+func writeYAMLAsJSON(buf *bytes.Buffer, v interface{}) error {
+	switch t := v.(type) {
+	case yaml.MapSlice:
+		buf.WriteByte('{')
+		for i, item := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(fmt.Sprint(item.Key))
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err = writeYAMLAsJSON(buf, item.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeYAMLAsJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		value, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/quick"
+)
+
+func TestConfigYAMLRoundTrip(t *testing.T) {
+	configYAML, err := configJSONToYAML(configJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := configYAMLToJSON(configYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, config serverConfig
+	if err = json.Unmarshal(configJSON, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, config) {
+		t.Fatalf("Expected %#v, got %#v", expected, config)
+	}
+}
+
+func TestConfigYAMLToJSON(t *testing.T) {
+	testCases := []struct {
+		configYAML   string
+		expectedJSON string
+		dupKeys      bool
+	}{
+		{"version: \"28\"\nregion: us-east-1\n", `{"version":"28","region":"us-east-1"}`, false},
+		{"logger:\n  console:\n    enabled: true\n", `{"logger":{"console":{"enabled":true}}}`, false},
+		{"cache:\n  drives: [/a, /b]\n  expiry: 90\n", `{"cache":{"drives":["/a","/b"],"expiry":90}}`, false},
+		{"region: \"\"\nbrowser: \"on\"\n", `{"region":"","browser":"on"}`, false},
+		{"version: \"28\"\nversion: \"28\"\n", `{"version":"28","version":"28"}`, true},
+	}
+
+	for i, testCase := range testCases {
+		data, err := configYAMLToJSON([]byte(testCase.configYAML))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(data) != testCase.expectedJSON {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expectedJSON, data)
+		}
+		if err = quick.CheckDuplicateKeys(string(data)); (err != nil) != testCase.dupKeys {
+			t.Errorf("Test %d: expected duplicate keys: %v, got: %v", i+1, testCase.dupKeys, err)
+		}
+	}
+
+	if _, err := configYAMLToJSON([]byte("version: [")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestIsYAMLMediaType(t *testing.T) {
+	testCases := []struct {
+		mediaType string
+		isYAML    bool
+	}{
+		{"application/yaml", true},
+		{"application/x-yaml; charset=utf-8", true},
+		{"application/json", false},
+		{"", false},
+	}
+
+	for i, testCase := range testCases {
+		if isYAML := isYAMLMediaType(testCase.mediaType); isYAML != testCase.isYAML {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.isYAML, isYAML)
+		}
+	}
+}
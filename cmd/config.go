@@ -27,6 +27,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/minio/minio-go/pkg/set"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/quick"
@@ -54,10 +55,90 @@ func saveServerConfig(objAPI ObjectLayer, config *serverConfig) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		_, err := globalEtcdClient.Put(ctx, configFile, string(data))
 		defer cancel()
+		if err != nil {
+			return err
+		}
+		publishConfigChange(config)
+		return nil
+	}
+
+	// Large values are stored outside of config.json, which
+	// references them.
+	data, values, err := extractConfigValues(data)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		if err = saveConfig(objAPI, path.Join(configValuesPrefix, name), value); err != nil {
+			return err
+		}
+	}
+	if err = saveConfig(objAPI, configFile, data); err != nil {
 		return err
 	}
 
-	return saveConfig(objAPI, configFile, data)
+	removeStaleConfigValues(context.Background(), objAPI, values)
+	publishConfigChange(config)
+	return nil
+}
+
+// lockConfigFile - locks a file under minioMetaBucket across all
+// servers while it is re-read, updated and saved, so that updates sent
+// to different servers do not overwrite each other. As object layer's
+// GetObject() and PutObject() take respective lock on minioMetaBucket
+// and the file, a transaction lock is taken instead.
+func lockConfigFile(configFile string) (unlock func(), err error) {
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, configFile+".transaction")
+	if err = objLock.GetLock(globalOperationTimeout); err != nil {
+		return nil, err
+	}
+	return objLock.Unlock, nil
+}
+
+// lockServerConfig - locks config.json across all servers while it is
+// re-read, merged with the updated sections and saved.
+func lockServerConfig() (unlock func(), err error) {
+	return lockConfigFile(path.Join(minioConfigPrefix, minioConfigFile))
+}
+
+// lockConfigSections - locks the given top level keys of config.json
+// across all servers while they are updated, so that updates of
+// unrelated sections do not wait on each other while updates of the
+// same section are applied one after the other. Sections are locked
+// in the same order by all updates so that overlapping updates cannot
+// deadlock.
+func lockConfigSections(sections ...string) (unlock func(), err error) {
+	sorted := set.CreateStringSet(sections...).ToSlice()
+
+	var objLocks []RWLocker
+	unlock = func() {
+		for i := len(objLocks) - 1; i >= 0; i-- {
+			objLocks[i].Unlock()
+		}
+	}
+	for _, section := range sorted {
+		transactionSectionFile := path.Join(minioConfigPrefix, minioConfigFile+"."+section) + ".transaction"
+		objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionSectionFile)
+		if err = objLock.GetLock(globalOperationTimeout); err != nil {
+			unlock()
+			return nil, err
+		}
+		objLocks = append(objLocks, objLock)
+	}
+	return unlock, nil
+}
+
+// getServerConfigModTime - returns the time config.json was last saved,
+// zero if the config is stored in etcd which does not record it.
+func getServerConfigModTime(ctx context.Context, objAPI ObjectLayer) (time.Time, error) {
+	if globalEtcdClient != nil {
+		return time.Time{}, nil
+	}
+	objInfo, err := objAPI.GetObjectInfo(ctx, minioMetaBucket, path.Join(minioConfigPrefix, minioConfigFile))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return objInfo.ModTime, nil
 }
 
 func readConfigEtcd(configFile string) ([]byte, error) {
@@ -79,6 +160,26 @@ func readConfigEtcd(configFile string) ([]byte, error) {
 }
 
 func readServerConfig(ctx context.Context, objAPI ObjectLayer) (*serverConfig, error) {
+	configData, err := readServerConfigData(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var config = &serverConfig{}
+	if err := json.Unmarshal(configData, config); err != nil {
+		return nil, err
+	}
+
+	if err := quick.CheckData(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// readServerConfigData - returns the saved config.json with its
+// values resolved.
+func readServerConfigData(ctx context.Context, objAPI ObjectLayer) ([]byte, error) {
 	var configData []byte
 	var err error
 	configFile := path.Join(minioConfigPrefix, minioConfigFile)
@@ -104,16 +205,16 @@ func readServerConfig(ctx context.Context, objAPI ObjectLayer) (*serverConfig, e
 		return nil, err
 	}
 
-	var config = &serverConfig{}
-	if err := json.Unmarshal(configData, config); err != nil {
-		return nil, err
-	}
-
-	if err := quick.CheckData(config); err != nil {
-		return nil, err
+	if globalEtcdClient == nil {
+		configData, err = resolveConfigValues(configData, func(name string) ([]byte, error) {
+			return readConfigValue(ctx, objAPI, name)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return config, nil
+	return configData, nil
 }
 
 func checkServerConfigEtcd(configFile string) error {
@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/minio/minio-go/pkg/set"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Environment variable limiting the open connections per client IP.
+const maxConnsPerIPEnv = "MINIO_MAX_CONNS_PER_IP"
+
+// Maximum number of open connections per client IP, 0 if unlimited.
+var globalMaxConnsPerIP int
+
+// parseMaxConnsPerIP - parses the maximum number of open connections
+// per client IP.
+func parseMaxConnsPerIP(s string) (int, error) {
+	limit, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("limit `%s` must not be negative", s)
+	}
+	return limit, nil
+}
+
+// getPeerIPs - returns the IPs of the servers of the given endpoints,
+// resolving their host names.
+func getPeerIPs(endpoints EndpointList) []string {
+	peerIPs := set.NewStringSet()
+	for _, endpoint := range endpoints {
+		if endpoint.Type() != URLEndpointType {
+			continue
+		}
+		host, _ := mustSplitHostPort(endpoint.Host)
+		if ip := net.ParseIP(host); ip != nil {
+			peerIPs.Add(ip.String())
+			continue
+		}
+		ips, err := net.LookupIP(host)
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("host", host)
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, ip := range ips {
+			peerIPs.Add(ip.String())
+		}
+	}
+	return peerIPs.ToSlice()
+}
+
+// newConnLimiter - returns the limiter of the open connections per
+// client IP of the HTTP server. The servers of the cluster talk to
+// each other over many connections, they are never limited.
+func newConnLimiter() *xhttp.ConnLimiter {
+	return xhttp.NewConnLimiter(globalMaxConnsPerIP, getPeerIPs(globalEndpoints))
+}
+
+// getLocalConnections - returns the open connections of each client
+// IP of the local server, the clients with the most connections
+// first.
+func getLocalConnections() madmin.ServerConnections {
+	connections := madmin.ServerConnections{Limit: globalMaxConnsPerIP}
+	if globalHTTPServer == nil || globalHTTPServer.ConnLimiter == nil {
+		return connections
+	}
+
+	conns, rejected := globalHTTPServer.ConnLimiter.Conns()
+	connections.Rejected = rejected
+	for ip, n := range conns {
+		connections.Clients = append(connections.Clients, madmin.ClientConnections{IP: ip, Connections: n})
+	}
+	sort.Slice(connections.Clients, func(i, j int) bool {
+		if connections.Clients[i].Connections != connections.Clients[j].Connections {
+			return connections.Clients[i].Connections > connections.Clients[j].Connections
+		}
+		return connections.Clients[i].IP < connections.Clients[j].IP
+	})
+	return connections
+}
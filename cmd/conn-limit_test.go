@@ -0,0 +1,64 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Tests the parsing of the connection limit environment variable.
+func TestParseMaxConnsPerIP(t *testing.T) {
+	testCases := []struct {
+		limit     string
+		expected  int
+		expectErr bool
+	}{
+		{"100", 100, false},
+		{"0", 0, false},
+		{"-1", 0, true},
+		{"many", 0, true},
+	}
+	for i, testCase := range testCases {
+		limit, err := parseMaxConnsPerIP(testCase.limit)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if limit != testCase.expected {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expected, limit)
+		}
+	}
+}
+
+// Tests that the IPs of the servers of the endpoints are exempt from
+// the connection limit.
+func TestGetPeerIPs(t *testing.T) {
+	endpoints, err := NewEndpointList("http://10.0.0.1:9000/d1", "http://10.0.0.2:9000/d2", "http://10.0.0.1:9000/d3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerIPs := getPeerIPs(endpoints)
+	sort.Strings(peerIPs)
+	if !reflect.DeepEqual(peerIPs, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Fatalf("unexpected peer IPs %v", peerIPs)
+	}
+
+	if peerIPs = getPeerIPs(mustGetNewEndpointList("/d1", "/d2")); len(peerIPs) != 0 {
+		t.Fatalf("expected no peer IPs for path endpoints, got %v", peerIPs)
+	}
+}
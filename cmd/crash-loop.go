@@ -0,0 +1,236 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+)
+
+const (
+	// Restart backoff environment variables.
+	restartBackoffEnv = "MINIO_RESTART_BACKOFF"
+	crashLoopLimitEnv = "MINIO_CRASH_LOOP_LIMIT"
+
+	// File under the config directory recording the startups which
+	// did not complete yet.
+	startupAttemptsFile = "startup.json"
+
+	// Defaults and bounds of the restart backoff. Startups which
+	// failed longer than crashLoopWindow ago are forgotten.
+	defaultRestartBackoff = time.Second
+	maxRestartBackoff     = 5 * time.Minute
+	defaultCrashLoopLimit = 5
+	crashLoopWindow       = 30 * time.Minute
+)
+
+var (
+	// Delay before the first retry of a failed startup, doubled by
+	// each further failure.
+	globalRestartBackoff = defaultRestartBackoff
+
+	// Number of consecutive failed startups after which the server
+	// starts in safe mode, 0 disables safe mode.
+	globalCrashLoopLimit = defaultCrashLoopLimit
+
+	// Set when the server started in safe mode: config errors are
+	// logged instead of stopping the server, and the disk cache is
+	// disabled.
+	globalSafeMode bool
+)
+
+// Returned when the server is asked to stop before its startup
+// completed, which is not a failed startup.
+var errStartupStopped = errors.New("Initializing the server gracefully stopped")
+
+// startupAttempts - startups which did not complete, a startup
+// which completes clears them all.
+type startupAttempts struct {
+	Attempts []time.Time `json:"attempts"`
+}
+
+// parseRestartBackoff - parses the delay before the first retry of a
+// failed startup, such as "1s", which must be at most
+// maxRestartBackoff.
+func parseRestartBackoff(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d > maxRestartBackoff {
+		return 0, fmt.Errorf("backoff `%s` must be between 0s and %s", s, maxRestartBackoff)
+	}
+	return d, nil
+}
+
+// parseCrashLoopLimit - parses the number of consecutive failed
+// startups after which the server starts in safe mode.
+func parseCrashLoopLimit(s string) (int, error) {
+	limit, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("limit `%s` must not be negative", s)
+	}
+	return limit, nil
+}
+
+// restartBackoff - returns the delay before retrying a startup after
+// the given number of consecutive failures, doubling with each one up
+// to maxRestartBackoff.
+func restartBackoff(base time.Duration, failures int) time.Duration {
+	if failures <= 0 || base <= 0 {
+		return 0
+	}
+	backoff := base
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= maxRestartBackoff {
+			return maxRestartBackoff
+		}
+	}
+	return backoff
+}
+
+// recordStartupAttempt - records a startup in the given directory and
+// returns the number of previous startups which failed within
+// crashLoopWindow.
+func recordStartupAttempt(dir string, now time.Time) (failures int, err error) {
+	file := filepath.Join(dir, startupAttemptsFile)
+
+	var previous startupAttempts
+	data, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err == nil {
+		// A corrupted file is overwritten, losing only the history
+		// of the failed startups.
+		if jerr := json.Unmarshal(data, &previous); jerr != nil {
+			previous.Attempts = nil
+		}
+	}
+
+	var attempts startupAttempts
+	for _, attempt := range previous.Attempts {
+		if now.Sub(attempt) < crashLoopWindow {
+			attempts.Attempts = append(attempts.Attempts, attempt)
+		}
+	}
+	failures = len(attempts.Attempts)
+	attempts.Attempts = append(attempts.Attempts, now)
+
+	if data, err = json.Marshal(attempts); err != nil {
+		return failures, err
+	}
+	return failures, ioutil.WriteFile(file, data, 0600)
+}
+
+// clearStartupAttempts - records that the startup completed.
+func clearStartupAttempts(dir string) error {
+	err := os.Remove(filepath.Join(dir, startupAttemptsFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// checkCrashLoop - records this startup and, when the previous ones
+// failed, either waits for the restart backoff or enters safe mode
+// once the crash loop limit is reached, so that a bad config does not
+// make the server hammer the backend while restarting in a loop. A
+// stop signal received while waiting returns errStartupStopped.
+func checkCrashLoop() error {
+	failures, err := recordStartupAttempt(getConfigDir(), UTCNow())
+	if err != nil {
+		return err
+	}
+	if globalCrashLoopLimit > 0 && failures >= globalCrashLoopLimit {
+		globalSafeMode = true
+		return nil
+	}
+	select {
+	case <-time.After(restartBackoff(globalRestartBackoff, failures)):
+	case <-globalOSSignalCh:
+		return errStartupStopped
+	}
+	return nil
+}
+
+// exitStartupStopped - exits on a stop signal received before the
+// startup completed. Stopping or restarting the server is no failed
+// startup, so the failed startups are cleared as a completed startup
+// does.
+func exitStartupStopped() {
+	logger.LogIf(context.Background(), clearStartupAttempts(getConfigDir()))
+	logger.Info("Exiting on signal before the startup completed")
+	os.Exit(0)
+}
+
+// readConfigCredential - returns the credential of the saved config,
+// which is read even if the other sections of the config are invalid.
+func readConfigCredential(ctx context.Context, objAPI ObjectLayer) (auth.Credentials, error) {
+	configData, err := readServerConfigData(ctx, objAPI)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	var config struct {
+		Credential auth.Credentials `json:"credential"`
+	}
+	if err = json.Unmarshal(configData, &config); err != nil {
+		return auth.Credentials{}, err
+	}
+	if !config.Credential.IsValid() {
+		return auth.Credentials{}, errors.New("invalid credential in config file")
+	}
+	return config.Credential, nil
+}
+
+// loadSafeModeConfig - loads the default config overridden by the
+// environment variables, used in safe mode when the saved config
+// cannot be loaded. The credential of the saved config is kept, so
+// that the operator can fix the config through the admin API, an
+// error is returned if neither it nor the environment provides one.
+func loadSafeModeConfig(objAPI ObjectLayer) error {
+	srvCfg := newServerConfig()
+	cred, err := readConfigCredential(context.Background(), objAPI)
+	if err != nil && !globalIsEnvCreds {
+		return err
+	}
+	if err == nil {
+		srvCfg.SetCredential(cred)
+	}
+	srvCfg.loadFromEnvs()
+	srvCfg.loadToCachedConfigs()
+
+	globalServerConfigMu.Lock()
+	globalServerConfig = srvCfg
+	globalServerConfigChecksum = ""
+	globalServerConfigMu.Unlock()
+	return nil
+}
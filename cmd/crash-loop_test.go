@@ -0,0 +1,205 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests the parsing of the restart backoff environment variables.
+func TestParseRestartBackoff(t *testing.T) {
+	testCases := []struct {
+		backoff   string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{"1s", time.Second, false},
+		{"0s", 0, false},
+		{"5m", 5 * time.Minute, false},
+		{"6m", 0, true},
+		{"-1s", 0, true},
+		{"1", 0, true},
+	}
+	for i, testCase := range testCases {
+		backoff, err := parseRestartBackoff(testCase.backoff)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if backoff != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, backoff)
+		}
+	}
+
+	for i, testCase := range []struct {
+		limit     string
+		expectErr bool
+	}{{"5", false}, {"0", false}, {"-1", true}, {"five", true}} {
+		if _, err := parseCrashLoopLimit(testCase.limit); (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+// Tests the exponential restart backoff.
+func TestRestartBackoff(t *testing.T) {
+	testCases := []struct {
+		base     time.Duration
+		failures int
+		expected time.Duration
+	}{
+		{time.Second, 0, 0},
+		{time.Second, 1, time.Second},
+		{time.Second, 2, 2 * time.Second},
+		{time.Second, 4, 8 * time.Second},
+		{time.Second, 20, maxRestartBackoff},
+		{time.Minute, 4, maxRestartBackoff},
+		{0, 3, 0},
+	}
+	for i, testCase := range testCases {
+		if backoff := restartBackoff(testCase.base, testCase.failures); backoff != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, backoff)
+		}
+	}
+}
+
+// Tests the count of the failed startups.
+func TestRecordStartupAttempt(t *testing.T) {
+	dir, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := UTCNow()
+	for i := 0; i < 3; i++ {
+		failures, err := recordStartupAttempt(dir, now.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failures != i {
+			t.Errorf("Startup %d: expected %d failures, got %d", i+1, i, failures)
+		}
+	}
+
+	// Old failures are forgotten.
+	failures, err := recordStartupAttempt(dir, now.Add(crashLoopWindow+time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures != 1 {
+		t.Errorf("Expected 1 recent failure, got %d", failures)
+	}
+
+	// A completed startup clears the failures.
+	if err = clearStartupAttempts(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err = clearStartupAttempts(dir); err != nil {
+		t.Fatal(err)
+	}
+	if failures, err = recordStartupAttempt(dir, now); err != nil || failures != 0 {
+		t.Errorf("Expected no failure, got %d, %v", failures, err)
+	}
+
+	// A corrupted record is overwritten.
+	if err = ioutil.WriteFile(filepath.Join(dir, startupAttemptsFile), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if failures, err = recordStartupAttempt(dir, now); err != nil || failures != 0 {
+		t.Errorf("Expected no failure, got %d, %v", failures, err)
+	}
+}
+
+// Tests that a stop signal received while backing off stops the
+// startup.
+func TestCheckCrashLoopStopped(t *testing.T) {
+	rootPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootPath)
+	setConfigDir(rootPath)
+
+	prevBackoff, prevLimit := globalRestartBackoff, globalCrashLoopLimit
+	defer func() {
+		globalRestartBackoff, globalCrashLoopLimit = prevBackoff, prevLimit
+	}()
+	globalRestartBackoff, globalCrashLoopLimit = time.Minute, defaultCrashLoopLimit
+
+	if _, err = recordStartupAttempt(rootPath, UTCNow()); err != nil {
+		t.Fatal(err)
+	}
+	globalOSSignalCh <- os.Interrupt
+	if err = checkCrashLoop(); err != errStartupStopped {
+		t.Fatalf("Expected the startup to be stopped, got %v", err)
+	}
+}
+
+// Tests that safe mode keeps the credential of a saved config which
+// cannot be loaded.
+func TestLoadSafeModeConfig(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	tmpGlobalServerConfig, tmpChecksum := globalServerConfig, globalServerConfigChecksum
+	tmpActiveCred, tmpIsEnvCreds := globalActiveCred, globalIsEnvCreds
+	defer func() {
+		globalServerConfig, globalServerConfigChecksum = tmpGlobalServerConfig, tmpChecksum
+		globalActiveCred, globalIsEnvCreds = tmpActiveCred, tmpIsEnvCreds
+	}()
+	globalIsEnvCreds = false
+
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+	saved := auth.Credentials{AccessKey: "operator", SecretKey: "operator-secret"}
+	invalidConfig := `{"version": "29", "credential": {"accessKey": "operator", "secretKey": "operator-secret"}, "minPartSize": 1}`
+	if err = saveConfig(objLayer, configFile, []byte(invalidConfig)); err != nil {
+		t.Fatal(err)
+	}
+	if err = loadSafeModeConfig(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if cred := globalServerConfig.GetCredential(); !cred.Equal(saved) {
+		t.Errorf("Expected the saved credential %s to be kept, got %s", saved.AccessKey, cred.AccessKey)
+	}
+
+	// Without a saved credential, only the credential set through
+	// the environment can be used.
+	if err = saveConfig(objLayer, configFile, []byte("{")); err != nil {
+		t.Fatal(err)
+	}
+	if err = loadSafeModeConfig(objLayer); err == nil {
+		t.Fatal("Expected safe mode to fail without credentials")
+	}
+	globalIsEnvCreds = true
+	globalActiveCred = auth.Credentials{AccessKey: "envaccess", SecretKey: "envsecret"}
+	if err = loadSafeModeConfig(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if cred := globalServerConfig.GetCredential(); !cred.Equal(globalActiveCred) {
+		t.Errorf("Expected the credential of the environment, got %s", cred.AccessKey)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// getCredentialsImpact - returns the subsystems depending on the
+// current credentials which would be affected by replacing them with
+// the given ones, along with the conflicts the new ones would cause.
+func getCredentialsImpact(prevCreds, creds auth.Credentials) madmin.CredentialsImpact {
+	impact := madmin.CredentialsImpact{
+		AccessKeyChanged: creds.AccessKey != prevCreds.AccessKey,
+		SecretKeyChanged: creds.SecretKey != prevCreds.SecretKey,
+		References:       []madmin.CredentialReference{},
+	}
+	if !impact.AccessKeyChanged && !impact.SecretKeyChanged {
+		return impact
+	}
+
+	add := func(subsystem, format string, args ...interface{}) {
+		impact.References = append(impact.References, madmin.CredentialReference{
+			Subsystem: subsystem,
+			Detail:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	if peers := len(globalAdminPeers) - 1; peers > 0 {
+		add(madmin.CredentialSubsystemPeers,
+			"%d other servers authenticate with the credentials, they are given the new ones along with this server", peers)
+	}
+	if impact.AccessKeyChanged && globalNotificationSys != nil {
+		for _, arn := range globalNotificationSys.GetARNList() {
+			add(madmin.CredentialSubsystemNotification, "events sent to %s carry the access key as principal", arn)
+		}
+	}
+	if globalDNSConfig != nil {
+		add(madmin.CredentialSubsystemFederation,
+			"other clusters of the federation keep their credentials, clients use them for buckets served by these clusters")
+	}
+	add(madmin.CredentialSubsystemBrowser, "browser sessions are signed with the credentials and are logged out")
+	add(madmin.CredentialSubsystemPresigned, "presigned URLs signed with the credentials are rejected")
+	if globalConfigHook.enabled(configHookEventCredentials) {
+		add(madmin.CredentialSubsystemConfigHook, "the config webhook on %s is notified of the change", globalConfigHook.url.Host)
+	}
+
+	if impact.AccessKeyChanged {
+		if _, ok := globalAdminCredentials.get(creds.AccessKey); ok {
+			impact.Conflicts = append(impact.Conflicts,
+				fmt.Sprintf("access key %s is used by an admin credential", creds.AccessKey))
+		}
+	}
+	return impact
+}
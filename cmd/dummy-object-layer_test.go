@@ -123,7 +123,7 @@ func (api *DummyObjectLayer) HealBucket(ctx context.Context, bucket string, dryR
 	return
 }
 
-func (api *DummyObjectLayer) HealObject(ctx context.Context, bucket, object string, dryRun bool) (item madmin.HealResultItem, err error) {
+func (api *DummyObjectLayer) HealObject(ctx context.Context, bucket, object string, dryRun bool, scanMode madmin.HealScanMode) (item madmin.HealResultItem, err error) {
 	return
 }
 
@@ -0,0 +1,104 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// expiringMapEntry - value of an expiring map with its expiry.
+type expiringMapEntry struct {
+	value  interface{}
+	expiry time.Time
+}
+
+// expiringMap - map whose entries expire once they were not set for a
+// time to live. Expired entries are never returned, and are reclaimed
+// on Set at most once per time to live, so no background routine is
+// needed to bound the size of the map.
+type expiringMap struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]expiringMapEntry
+	lastPurge time.Time
+}
+
+// newExpiringMap - returns an empty map whose entries expire after the
+// given time to live.
+func newExpiringMap(ttl time.Duration) *expiringMap {
+	return &expiringMap{
+		ttl:       ttl,
+		entries:   make(map[string]expiringMapEntry),
+		lastPurge: UTCNow(),
+	}
+}
+
+// Set - adds or replaces the value of a key, which expires after the
+// time to live of the map.
+func (m *expiringMap) Set(key string, value interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := UTCNow()
+	if now.Sub(m.lastPurge) >= m.ttl {
+		m.purge(now)
+	}
+	m.entries[key] = expiringMapEntry{value: value, expiry: now.Add(m.ttl)}
+}
+
+// Get - returns the value of a key unless it expired.
+func (m *expiringMap) Get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !UTCNow().Before(entry.expiry) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Delete - removes a key.
+func (m *expiringMap) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// Len - returns the number of entries which did not expire.
+func (m *expiringMap) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purge(UTCNow())
+	return len(m.entries)
+}
+
+// purge - removes the expired entries, must be called with the lock
+// held.
+func (m *expiringMap) purge(now time.Time) {
+	for key, entry := range m.entries {
+		if !now.Before(entry.expiry) {
+			delete(m.entries, key)
+		}
+	}
+	m.lastPurge = now
+}
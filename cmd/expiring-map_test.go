@@ -0,0 +1,63 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiringMap(t *testing.T) {
+	m := newExpiringMap(200 * time.Millisecond)
+
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if v, ok := m.Get("a"); !ok || v.(int) != 1 {
+		t.Fatalf("Expected a to be 1, got %v", v)
+	}
+	m.Delete("b")
+	if _, ok := m.Get("b"); ok {
+		t.Fatal("Expected b to be deleted")
+	}
+
+	time.Sleep(120 * time.Millisecond)
+	// Setting a key again postpones its expiry.
+	m.Set("c", 3)
+	m.Set("a", 4)
+	time.Sleep(120 * time.Millisecond)
+	if v, ok := m.Get("a"); !ok || v.(int) != 4 {
+		t.Fatalf("Expected a to be 4, got %v", v)
+	}
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+
+	time.Sleep(120 * time.Millisecond)
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("Expected a to be expired")
+	}
+	// Expired entries are reclaimed on set, at most once per time
+	// to live.
+	time.Sleep(120 * time.Millisecond)
+	m.Set("d", 5)
+	m.mu.Lock()
+	n := len(m.entries)
+	m.mu.Unlock()
+	if n != 1 {
+		t.Fatalf("Expected expired entries to be reclaimed, %d entries left", n)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/pkg/dns"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Time to wait for the federation store to list the buckets.
+const federationListTimeout = 10 * time.Second
+
+var errFederationListTimeout = errors.New("federation store did not reply in time")
+
+// listFederatedBuckets - lists the records of the federation store,
+// none if it holds no bucket, or fails if it does not reply within
+// the timeout.
+func listFederatedBuckets(dnsConfig dns.Config, timeout time.Duration) ([]dns.SrvRecord, error) {
+	type listResult struct {
+		records []dns.SrvRecord
+		err     error
+	}
+	// Buffered so that a late reply does not block the listing.
+	resultCh := make(chan listResult, 1)
+	go func() {
+		records, err := dnsConfig.List()
+		if err == dns.ErrNoEntriesFound {
+			err = nil
+		}
+		resultCh <- listResult{records, err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.records, result.err
+	case <-time.After(timeout):
+		return nil, errFederationListTimeout
+	}
+}
+
+// getFederationStatus - returns the state of the federation seen by
+// this server, comparing the buckets of the federation store with the
+// buckets of this cluster.
+func getFederationStatus(ctx context.Context, objAPI ObjectLayer, dnsConfig dns.Config, domainIPs set.StringSet) madmin.FederationStatus {
+	if dnsConfig == nil {
+		return madmin.FederationStatus{}
+	}
+	status := madmin.FederationStatus{
+		Enabled:  true,
+		Domain:   globalDomainName,
+		LocalIPs: domainIPs.ToSlice(),
+	}
+
+	records, err := listFederatedBuckets(dnsConfig, federationListTimeout)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Reachable = true
+
+	buckets := make(map[string]*madmin.FederatedBucket)
+	for _, record := range records {
+		name := strings.Trim(record.Key, slashSeparator)
+		bucket, ok := buckets[name]
+		if !ok {
+			bucket = &madmin.FederatedBucket{Name: name, Created: record.CreationDate}
+			buckets[name] = bucket
+		}
+		bucket.Hosts = append(bucket.Hosts, net.JoinHostPort(record.Host, strconv.Itoa(record.Port)))
+		// Requests on a bucket are served by this cluster if any of
+		// its hosts is one of this cluster, see bucketForwardingHandler.
+		if domainIPs.Contains(record.Host) {
+			bucket.Local = true
+		}
+	}
+	for _, bucket := range buckets {
+		sort.Strings(bucket.Hosts)
+		status.Buckets = append(status.Buckets, *bucket)
+	}
+	sort.Slice(status.Buckets, func(i, j int) bool {
+		return status.Buckets[i].Name < status.Buckets[j].Name
+	})
+
+	localBuckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	local := set.NewStringSet()
+	for _, bucket := range localBuckets {
+		local.Add(bucket.Name)
+		if _, ok := buckets[bucket.Name]; !ok {
+			status.Unregistered = append(status.Unregistered, bucket.Name)
+		}
+	}
+	for _, bucket := range status.Buckets {
+		if bucket.Local && !local.Contains(bucket.Name) {
+			status.Orphaned = append(status.Orphaned, bucket.Name)
+		}
+	}
+	return status
+}
@@ -0,0 +1,115 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/pkg/dns"
+)
+
+// testFederationStore - federation store listing fixed records.
+type testFederationStore struct {
+	records []dns.SrvRecord
+	err     error
+	delay   time.Duration
+}
+
+func (s testFederationStore) Put(key string) error { return nil }
+
+func (s testFederationStore) List() ([]dns.SrvRecord, error) {
+	time.Sleep(s.delay)
+	return s.records, s.err
+}
+
+func (s testFederationStore) Get(key string) ([]dns.SrvRecord, error) {
+	return nil, dns.ErrNoEntriesFound
+}
+
+func (s testFederationStore) Delete(key string) error { return nil }
+
+// Tests the state of the federation seen by a server.
+func TestGetFederationStatus(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	ctx := context.Background()
+	for _, bucket := range []string{"local", "unregistered"} {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if status := getFederationStatus(ctx, objLayer, nil, nil); status.Enabled {
+		t.Fatalf("expected federation to be disabled, got %v", status)
+	}
+
+	domainIPs := set.CreateStringSet("10.0.0.1", "10.0.0.2")
+	status := getFederationStatus(ctx, objLayer, testFederationStore{err: errors.New("etcd is down")}, domainIPs)
+	if !status.Enabled || status.Reachable || status.Error != "etcd is down" || len(status.Buckets) != 0 {
+		t.Fatalf("expected unreachable federation store, got %v", status)
+	}
+
+	status = getFederationStatus(ctx, objLayer, testFederationStore{err: dns.ErrNoEntriesFound}, domainIPs)
+	if !status.Reachable || len(status.Buckets) != 0 ||
+		!reflect.DeepEqual(status.Unregistered, []string{"local", "unregistered"}) {
+		t.Fatalf("expected empty federation store, got %v", status)
+	}
+
+	store := testFederationStore{
+		records: []dns.SrvRecord{
+			{Key: "/local/", Host: "10.0.0.2", Port: 9000},
+			{Key: "/local/", Host: "10.0.0.1", Port: 9000},
+			{Key: "/orphaned/", Host: "10.0.0.1", Port: 9000},
+			{Key: "/remote/", Host: "10.0.1.1", Port: 9001},
+		},
+	}
+	status = getFederationStatus(ctx, objLayer, store, domainIPs)
+	if !status.Reachable || status.Error != "" || !reflect.DeepEqual(status.LocalIPs, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Fatalf("expected reachable federation store, got %v", status)
+	}
+	if len(status.Buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %v", status.Buckets)
+	}
+	if bucket := status.Buckets[0]; bucket.Name != "local" || !bucket.Local ||
+		!reflect.DeepEqual(bucket.Hosts, []string{"10.0.0.1:9000", "10.0.0.2:9000"}) {
+		t.Errorf("unexpected bucket %v", bucket)
+	}
+	if bucket := status.Buckets[2]; bucket.Name != "remote" || bucket.Local ||
+		!reflect.DeepEqual(bucket.Hosts, []string{"10.0.1.1:9001"}) {
+		t.Errorf("unexpected bucket %v", bucket)
+	}
+	if !reflect.DeepEqual(status.Unregistered, []string{"unregistered"}) {
+		t.Errorf("expected unregistered buckets %v, got %v", []string{"unregistered"}, status.Unregistered)
+	}
+	if !reflect.DeepEqual(status.Orphaned, []string{"orphaned"}) {
+		t.Errorf("expected orphaned buckets %v, got %v", []string{"orphaned"}, status.Orphaned)
+	}
+
+	// Federation stores which do not reply in time are unreachable.
+	if _, err = listFederatedBuckets(testFederationStore{delay: time.Second}, 10*time.Millisecond); err != errFederationListTimeout {
+		t.Fatalf("expected %v, got %v", errFederationListTimeout, err)
+	}
+}
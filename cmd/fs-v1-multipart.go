@@ -230,6 +230,10 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = meta
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	fsMeta.Meta[multipartUploadBucketKey] = bucket
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -628,6 +632,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+	delete(fsMeta.Meta, multipartUploadBucketKey)
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
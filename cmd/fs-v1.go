@@ -171,6 +171,7 @@ func (fs *FSObjects) Shutdown(ctx context.Context) error {
 }
 
 // diskUsage returns du information for the posix path, in a continuous routine.
+// Scans follow the schedule of globalUsageScanner.
 func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 	usageFn := func(ctx context.Context, entry string) error {
 		if globalHTTPServer != nil {
@@ -184,68 +185,76 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 			}
 		}
 
-		select {
-		case <-doneCh:
+		if !globalUsageScanner.pause(doneCh, nil) {
 			return errWalkAbort
-		default:
-			var fi os.FileInfo
-			var err error
-			if hasSuffix(entry, slashSeparator) {
-				fi, err = fsStatDir(ctx, entry)
-			} else {
-				fi, err = fsStatFile(ctx, entry)
-			}
-			if err != nil {
-				return err
-			}
-			atomic.AddUint64(&fs.totalUsed, uint64(fi.Size()))
 		}
+
+		var fi os.FileInfo
+		var err error
+		if hasSuffix(entry, slashSeparator) {
+			fi, err = fsStatDir(ctx, entry)
+		} else {
+			fi, err = fsStatFile(ctx, entry)
+		}
+		if err != nil {
+			return err
+		}
+		atomic.AddUint64(&fs.totalUsed, uint64(fi.Size()))
+		globalUsageScanner.entryScanned(fs.fsPath)
 		return nil
 	}
 
 	// Return this routine upon errWalkAbort, continue for any other error on purpose
-	// so that we can start the routine freshly in another 12 hours.
-	if err := getDiskUsage(context.Background(), fs.fsPath, usageFn); err == errWalkAbort {
+	// so that we can start the routine freshly after the scan interval.
+	globalUsageScanner.startScan(fs.fsPath)
+	err := getDiskUsage(context.Background(), fs.fsPath, usageFn)
+	globalUsageScanner.endScan(fs.fsPath, err == nil)
+	if err == errWalkAbort {
 		return
 	}
 
-	for {
-		select {
-		case <-doneCh:
-			return
-		case <-time.After(globalUsageCheckInterval):
-			var usage uint64
-			usageFn = func(ctx context.Context, entry string) error {
-				if globalHTTPServer != nil {
-					// Wait at max 1 minute for an inprogress request
-					// before proceeding to count the usage.
-					waitCount := 60
-					// Any requests in progress, delay the usage.
-					for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-						waitCount--
-						time.Sleep(1 * time.Second)
-					}
+	lastEnd := UTCNow()
+	for globalUsageScanner.waitNextScan(fs.fsPath, lastEnd, doneCh, nil) {
+		var usage uint64
+		usageFn = func(ctx context.Context, entry string) error {
+			if globalHTTPServer != nil {
+				// Wait at max 1 minute for an inprogress request
+				// before proceeding to count the usage.
+				waitCount := 60
+				// Any requests in progress, delay the usage.
+				for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
+					waitCount--
+					time.Sleep(1 * time.Second)
 				}
+			}
 
-				var fi os.FileInfo
-				var err error
-				if hasSuffix(entry, slashSeparator) {
-					fi, err = fsStatDir(ctx, entry)
-				} else {
-					fi, err = fsStatFile(ctx, entry)
-				}
-				if err != nil {
-					return err
-				}
-				usage = usage + uint64(fi.Size())
-				return nil
+			if !globalUsageScanner.pause(doneCh, nil) {
+				return errWalkAbort
 			}
 
-			if err := getDiskUsage(context.Background(), fs.fsPath, usageFn); err != nil {
-				continue
+			var fi os.FileInfo
+			var err error
+			if hasSuffix(entry, slashSeparator) {
+				fi, err = fsStatDir(ctx, entry)
+			} else {
+				fi, err = fsStatFile(ctx, entry)
+			}
+			if err != nil {
+				return err
 			}
-			atomic.StoreUint64(&fs.totalUsed, usage)
+			usage = usage + uint64(fi.Size())
+			globalUsageScanner.entryScanned(fs.fsPath)
+			return nil
+		}
+
+		globalUsageScanner.startScan(fs.fsPath)
+		err = getDiskUsage(context.Background(), fs.fsPath, usageFn)
+		globalUsageScanner.endScan(fs.fsPath, err == nil)
+		lastEnd = UTCNow()
+		if err != nil {
+			continue
 		}
+		atomic.StoreUint64(&fs.totalUsed, usage)
 	}
 }
 
@@ -1279,7 +1288,7 @@ func (fs *FSObjects) HealFormat(ctx context.Context, dryRun bool) (madmin.HealRe
 }
 
 // HealObject - no-op for fs. Valid only for XL.
-func (fs *FSObjects) HealObject(ctx context.Context, bucket, object string, dryRun bool) (
+func (fs *FSObjects) HealObject(ctx context.Context, bucket, object string, dryRun bool, scanMode madmin.HealScanMode) (
 	res madmin.HealResultItem, err error) {
 	logger.LogIf(ctx, NotImplemented{})
 	return res, NotImplemented{}
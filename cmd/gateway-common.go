@@ -17,13 +17,20 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
 
 	minio "github.com/minio/minio-go"
 )
 
+// Time after which a gateway backend not answering is reported
+// offline.
+const gatewayBackendProbeTimeout = 5 * time.Second
+
 var (
 	// CanonicalizeETag provides canonicalizeETag function alias.
 	CanonicalizeETag = canonicalizeETag
@@ -310,3 +317,35 @@ func ErrorRespToObjectError(err error, params ...string) error {
 
 	return err
 }
+
+// probeGatewayBackend - checks that the backend of the gateway is
+// reachable by listing its buckets, the backend is reported offline if
+// it does not answer within gatewayBackendProbeTimeout.
+func probeGatewayBackend(ctx context.Context, objAPI ObjectLayer) madmin.GatewayBackendStatus {
+	status := madmin.GatewayBackendStatus{Type: globalGatewayName}
+	if objAPI == nil {
+		status.Error = errServerNotInitialized.Error()
+		return status
+	}
+
+	start := UTCNow()
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := objAPI.ListBuckets(ctx)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	case <-time.After(gatewayBackendProbeTimeout):
+		status.Error = "backend did not answer within " + gatewayBackendProbeTimeout.String()
+		return status
+	}
+	status.Online = true
+	status.Latency = UTCNow().Sub(start)
+	return status
+}
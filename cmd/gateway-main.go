@@ -107,6 +107,8 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 
 	// Validate if we have access, secret set through environment.
 	gatewayName := gw.Name()
+	globalIsGateway = true
+	globalGatewayName = gatewayName
 	if ctx.Args().First() == "help" {
 		cli.ShowCommandHelpAndExit(ctx, gatewayName, 1)
 	}
@@ -190,6 +192,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 
 	router := mux.NewRouter().SkipClean(true)
 
+	// Add admin router limited to the operations of gateways
+	registerGatewayAdminRouter(router)
+
 	// Add healthcheck router
 	registerHealthCheckRouter(router)
 
@@ -212,6 +217,7 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	globalHTTPServer = xhttp.NewServer([]string{gatewayAddr}, criticalErrorHandler{registerHandlers(router, globalHandlers...)}, getCert)
 	globalHTTPServer.UpdateBytesReadFunc = globalConnStats.incInputBytes
 	globalHTTPServer.UpdateBytesWrittenFunc = globalConnStats.incOutputBytes
+	globalHTTPServer.ConnLimiter = newConnLimiter()
 	go func() {
 		globalHTTPServerErrorCh <- globalHTTPServer.Start()
 	}()
@@ -234,6 +240,11 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
 
+	// Queue the undelivered events in the background.
+	if globalNotifyReplayEnabled {
+		go globalUndeliveredEvents.write(newObject)
+	}
+
 	// Prints the formatted startup message once object layer is initialized.
 	if !quietFlag {
 		mode := globalMinioModeGatewayPrefix + gatewayName
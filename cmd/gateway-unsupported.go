@@ -112,7 +112,7 @@ func (a GatewayUnsupported) ListBucketsHeal(ctx context.Context) (buckets []Buck
 }
 
 // HealObject - Not implemented stub
-func (a GatewayUnsupported) HealObject(ctx context.Context, bucket, object string, dryRun bool) (h madmin.HealResultItem, e error) {
+func (a GatewayUnsupported) HealObject(ctx context.Context, bucket, object string, dryRun bool, scanMode madmin.HealScanMode) (h madmin.HealResultItem, e error) {
 	logger.LogIf(ctx, NotImplemented{})
 	return h, NotImplemented{}
 }
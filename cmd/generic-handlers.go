@@ -278,7 +278,7 @@ func (h cacheControlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // Check to allow access to the reserved "bucket" `/minio` for Admin
 // API requests.
 func isAdminReq(r *http.Request) bool {
-	return strings.HasPrefix(r.URL.Path, adminAPIPathPrefix+"/")
+	return strings.HasPrefix(r.URL.Path, globalAdminAPIPathPrefix+"/")
 }
 
 // Adds verification for incoming paths.
@@ -365,10 +365,11 @@ func (h timeValidityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			writeErrorResponse(w, apiErr, r.URL)
 			return
 		}
-		// Verify if the request date header is shifted by less than globalMaxSkewTime parameter in the past
+		// Verify if the request date header is shifted by less than the maximum clock skew in the past
 		// or in the future, reject request otherwise.
 		curTime := UTCNow()
-		if curTime.Sub(amzDate) > globalMaxSkewTime || amzDate.Sub(curTime) > globalMaxSkewTime {
+		maxSkew := globalClockSkewTolerance.get()
+		if curTime.Sub(amzDate) > maxSkew || amzDate.Sub(curTime) > maxSkew {
 			writeErrorResponse(w, ErrRequestTimeTooSkewed, r.URL)
 			return
 		}
@@ -510,10 +511,34 @@ func (h resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 type httpResponseRecorder struct {
 	http.ResponseWriter
 	respStatusCode int
+
+	// time after which the slow request header is set, zero if
+	// the header is not to be set
+	slowAfter   time.Time
+	wroteHeader bool
+
+	// request being served, writes fail once it is cancelled
+	active *activeRequest
+}
+
+// Sets the slow request header if the budget was exceeded by the
+// time response headers are written.
+func (rww *httpResponseRecorder) setSlowRequestHeader() {
+	if rww.wroteHeader {
+		return
+	}
+	rww.wroteHeader = true
+	if !rww.slowAfter.IsZero() && UTCNow().After(rww.slowAfter) {
+		rww.Header().Set(slowRequestHeader, "true")
+	}
 }
 
 // Wraps ResponseWriter's Write()
 func (rww *httpResponseRecorder) Write(b []byte) (int, error) {
+	if rww.active != nil && rww.active.isCancelled() {
+		return 0, errRequestCancelled
+	}
+	rww.setSlowRequestHeader()
 	return rww.ResponseWriter.Write(b)
 }
 
@@ -525,6 +550,7 @@ func (rww *httpResponseRecorder) Flush() {
 // Wraps ResponseWriter's WriteHeader() and record
 // the response status code
 func (rww *httpResponseRecorder) WriteHeader(httpCode int) {
+	rww.setSlowRequestHeader()
 	rww.respStatusCode = httpCode
 	rww.ResponseWriter.WriteHeader(httpCode)
 }
@@ -550,6 +576,18 @@ func (h httpStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Time start before the call is about to start.
 	tBefore := UTCNow()
 
+	budget, checkBudget := globalSlowRequestBudget.get(r.Method)
+	if checkBudget && globalSlowRequestHeader {
+		ww.slowAfter = tBefore.Add(budget)
+	}
+
+	// Track the request until it was served, so that it can be
+	// listed and cancelled by administrators.
+	requestID := mustGetRequestID(tBefore)
+	w.Header().Set(responseRequestIDKey, requestID)
+	ww.active, r = globalActiveRequests.register(r, requestID)
+	defer globalActiveRequests.unregister(ww.active)
+
 	// Execute the request
 	h.handler.ServeHTTP(ww, r)
 
@@ -564,6 +602,11 @@ func (h httpStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Update http statistics
 	globalHTTPStats.updateStats(r, ww, durationSecs)
+
+	// Record requests which exceeded their latency budget
+	if duration := tAfter.Sub(tBefore); checkBudget && duration > budget {
+		logSlowRequest(r, ww.respStatusCode, duration, budget)
+	}
 }
 
 // pathValidityHandler validates all the incoming paths for
@@ -737,8 +780,11 @@ func addrequestIDHeader(h http.Handler) http.Handler {
 }
 
 func (s requestIDHeaderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Set unique request ID for each response.
-	w.Header().Set(responseRequestIDKey, mustGetRequestID(UTCNow()))
+	// Set unique request ID for each response, unless one was
+	// already assigned when the request was registered as active.
+	if w.Header().Get(responseRequestIDKey) == "" {
+		w.Header().Set(responseRequestIDKey, mustGetRequestID(UTCNow()))
+	}
 	s.handler.ServeHTTP(w, r)
 }
 
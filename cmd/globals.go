@@ -71,8 +71,8 @@ const (
 	// Limit memory allocation to store multipart data
 	maxFormMemory = int64(5 * humanize.MiByte)
 
-	// The maximum allowed time difference between the incoming request
-	// date and server date during signature verification.
+	// The default maximum allowed time difference between the incoming
+	// request date and server date during signature verification.
 	globalMaxSkewTime = 15 * time.Minute // 15 minutes skew allowed.
 
 	// Expiry duration after which the multipart uploads are deemed stale.
@@ -162,6 +162,36 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Global count of error responses per API error code
+	globalAPIErrorStats = newAPIErrorStats()
+
+	// Latency budget of requests per HTTP method, requests
+	// exceeding it are logged
+	globalSlowRequestBudget slowRequestBudget
+
+	// Is the slow request header set on responses
+	globalSlowRequestHeader bool
+
+	// Global most recent slow requests
+	globalSlowRequests = newSlowRequestLog()
+
+	// Global rolling window of connection and HTTP statistics,
+	// disabled unless a retention is set
+	globalMetricsHistory = newMetricsHistory(0)
+
+	// Global registry of requests being served
+	globalActiveRequests = newActiveRequests()
+
+	// Global limit of the requests served at once, disabled unless
+	// a limit is set
+	globalAdmissionController = newAdmissionController(0)
+
+	// Global request rate limits of buckets and prefixes
+	globalTenantRateLimiter = newTenantRateLimiter()
+
+	// Global credentials restricted to some admin actions
+	globalAdminCredentials = newAdminCredentialStore()
+
 	// Time when object layer was initialized on start up.
 	globalBootTime time.Time
 
@@ -189,6 +219,18 @@ var (
 	// Is worm enabled
 	globalWORMEnabled bool
 
+	// Is persisting undelivered events for replay enabled
+	globalNotifyReplayEnabled bool
+
+	// Undelivered events waiting to be queued for a replay
+	globalUndeliveredEvents = newUndeliveredEvents(notifyReplayBacklog)
+
+	// Are admin responses signed with the secret key
+	globalAdminSignResponses bool
+
+	// URL to which config changes are posted, if any
+	globalConfigHook configHook
+
 	// Is Disk Caching set up
 	globalIsDiskCacheEnabled bool
 
@@ -215,10 +257,19 @@ var (
 	// Allocated DNS config wrapper over etcd client.
 	globalDNSConfig dns.Config
 
+	// Set to true if minio runs as a gateway, to the backend named
+	// globalGatewayName.
+	globalIsGateway   = false
+	globalGatewayName = ""
+
 	// Default usage check interval value.
 	globalDefaultUsageCheckInterval = 12 * time.Hour // 12 hours
-	// Usage check interval value.
-	globalUsageCheckInterval = globalDefaultUsageCheckInterval
+	// Schedule and progress of the background usage scans.
+	globalUsageScanner = newUsageScanner()
+	// Periodic export of the server information.
+	globalServerInfoExporter = newServerInfoExporter()
+	// Watchers of the config changes persisted by this server.
+	globalConfigWatch = newConfigWatch()
 
 	// KMS key id
 	globalKMSKeyID string
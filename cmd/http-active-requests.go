@@ -0,0 +1,169 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errRequestCancelled = errors.New("request was cancelled by an administrator")
+
+// activeRequest - a request being served, which can be cancelled.
+type activeRequest struct {
+	info madmin.ActiveRequest
+
+	cancelOnce sync.Once
+	cancelCh   chan struct{}
+	cancelCtx  context.CancelFunc
+}
+
+// cancel - aborts the request. Reading the request body and writing
+// the response fail from now on, and the request context is done.
+func (a *activeRequest) cancel() {
+	a.cancelOnce.Do(func() {
+		close(a.cancelCh)
+		a.cancelCtx()
+	})
+}
+
+// isCancelled - returns true if the request was cancelled.
+func (a *activeRequest) isCancelled() bool {
+	select {
+	case <-a.cancelCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// activeRequestBody - wraps the body of an active request to stop
+// reading it once the request is cancelled.
+type activeRequestBody struct {
+	io.ReadCloser
+	req *activeRequest
+}
+
+func (b activeRequestBody) Read(p []byte) (int, error) {
+	if b.req.isCancelled() {
+		return 0, errRequestCancelled
+	}
+	return b.ReadCloser.Read(p)
+}
+
+// activeRequests - registry of the requests being served, indexed by
+// request ID.
+type activeRequests struct {
+	sync.Mutex
+	requests map[string]*activeRequest
+}
+
+// register - adds a request to the registry and returns the request
+// to serve in its place, whose body and context follow cancellation.
+func (l *activeRequests) register(r *http.Request, requestID string) (*activeRequest, *http.Request) {
+	ctx, cancelCtx := context.WithCancel(r.Context())
+	req := &activeRequest{
+		info: madmin.ActiveRequest{
+			ID:         requestID,
+			Time:       UTCNow(),
+			Method:     r.Method,
+			Path:       getRequestResource(r),
+			RemoteHost: handlers.GetSourceIP(r),
+		},
+		cancelCh:  make(chan struct{}),
+		cancelCtx: cancelCtx,
+	}
+
+	r = r.WithContext(ctx)
+	if r.Body != nil {
+		r.Body = activeRequestBody{ReadCloser: r.Body, req: req}
+	}
+
+	l.Lock()
+	l.requests[requestID] = req
+	l.Unlock()
+
+	return req, r
+}
+
+// unregister - removes a request once it was served.
+func (l *activeRequests) unregister(req *activeRequest) {
+	l.Lock()
+	// Only remove the request itself, another one may have been
+	// registered with the same ID meanwhile.
+	if l.requests[req.info.ID] == req {
+		delete(l.requests, req.info.ID)
+	}
+	l.Unlock()
+
+	req.cancelCtx()
+}
+
+// getRequests - returns the requests being served, oldest first.
+func (l *activeRequests) getRequests() []madmin.ActiveRequest {
+	l.Lock()
+	defer l.Unlock()
+
+	now := UTCNow()
+	requests := make([]madmin.ActiveRequest, 0, len(l.requests))
+	for _, req := range l.requests {
+		info := req.info
+		info.Duration = now.Sub(info.Time)
+		info.Cancelled = req.isCancelled()
+		requests = append(requests, info)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].Time.Equal(requests[j].Time) {
+			return requests[i].ID < requests[j].ID
+		}
+		return requests[i].Time.Before(requests[j].Time)
+	})
+	return requests
+}
+
+// count - returns the number of requests being served.
+func (l *activeRequests) count() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.requests)
+}
+
+// cancel - cancels the request with the given ID, returns false if no
+// such request is being served.
+func (l *activeRequests) cancel(requestID string) bool {
+	l.Lock()
+	req, ok := l.requests[requestID]
+	l.Unlock()
+
+	if !ok {
+		return false
+	}
+	req.cancel()
+	return true
+}
+
+// Prepare new activeRequests structure
+func newActiveRequests() *activeRequests {
+	return &activeRequests{requests: make(map[string]*activeRequest)}
+}
@@ -0,0 +1,96 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestActiveRequests(t *testing.T) {
+	l := newActiveRequests()
+
+	req, r := l.register(httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader("data")), "1")
+	l.register(httptest.NewRequest(http.MethodGet, "/bucket", nil), "2")
+
+	requests := l.getRequests()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 active requests, got %d", len(requests))
+	}
+	if requests[0].ID != "1" || requests[0].Method != http.MethodPut || requests[0].Path != "/bucket/object" {
+		t.Errorf("Unexpected active request %#v", requests[0])
+	}
+
+	if l.cancel("3") {
+		t.Fatal("Expected unknown request not to be cancelled")
+	}
+	if !l.cancel("1") {
+		t.Fatal("Expected request to be cancelled")
+	}
+	if !l.getRequests()[0].Cancelled {
+		t.Error("Expected request to be reported as cancelled")
+	}
+
+	// Reading the body and the context follow cancellation.
+	if _, err := ioutil.ReadAll(r.Body); err != errRequestCancelled {
+		t.Errorf("Expected %v reading body, got %v", errRequestCancelled, err)
+	}
+	select {
+	case <-r.Context().Done():
+	default:
+		t.Error("Expected request context to be done")
+	}
+
+	l.unregister(req)
+	if requests = l.getRequests(); len(requests) != 1 || requests[0].ID != "2" {
+		t.Errorf("Unexpected active requests %#v", requests)
+	}
+}
+
+func TestHTTPStatsHandlerActiveRequest(t *testing.T) {
+	tmpGlobalActiveRequests := globalActiveRequests
+	defer func() {
+		globalActiveRequests = tmpGlobalActiveRequests
+	}()
+	globalActiveRequests = newActiveRequests()
+
+	var writeErr error
+	handler := setHTTPStatsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests := globalActiveRequests.getRequests()
+		if len(requests) != 1 || requests[0].ID != w.Header().Get(responseRequestIDKey) {
+			t.Fatalf("Unexpected active requests %#v", requests)
+		}
+		globalActiveRequests.cancel(requests[0].ID)
+		_, writeErr = w.Write([]byte("data"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+
+	if writeErr != errRequestCancelled {
+		t.Errorf("Expected %v writing response, got %v", errRequestCancelled, writeErr)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected no response body, got %q", rec.Body.String())
+	}
+	if requests := globalActiveRequests.getRequests(); len(requests) != 0 {
+		t.Errorf("Expected no active requests once served, got %#v", requests)
+	}
+}
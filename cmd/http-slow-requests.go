@@ -0,0 +1,154 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Slow request budget environment variable.
+	slowRequestBudgetEnv = "MINIO_SLOW_REQUEST_BUDGET"
+
+	// Slow request header environment variable.
+	slowRequestHeaderEnv = "MINIO_SLOW_REQUEST_HEADER"
+
+	// Response header set when the budget was exceeded before the
+	// response headers were written.
+	slowRequestHeader = "X-Minio-Slow-Request"
+
+	// Number of most recent slow requests kept in memory.
+	maxSlowRequests = 100
+)
+
+// slowRequestBudget - latency budget of requests indexed by HTTP
+// method, the empty method holds the budget of all other methods.
+type slowRequestBudget map[string]time.Duration
+
+// get - returns the budget of the given method, if any.
+func (b slowRequestBudget) get(method string) (time.Duration, bool) {
+	budget, ok := b[method]
+	if !ok {
+		budget, ok = b[""]
+	}
+	return budget, ok
+}
+
+// parseSlowRequestBudget - parses a comma separated list of budgets
+// such as "GET=1s,PUT=10s". A budget without a method, e.g. "5s",
+// applies to every method without its own budget.
+func parseSlowRequestBudget(s string) (slowRequestBudget, error) {
+	budget := make(slowRequestBudget)
+	for _, entry := range strings.Split(s, ",") {
+		var method string
+		value := strings.TrimSpace(entry)
+		if i := strings.Index(value, "="); i >= 0 {
+			method = strings.ToUpper(strings.TrimSpace(value[:i]))
+			value = strings.TrimSpace(value[i+1:])
+			if method == "" {
+				return nil, fmt.Errorf("missing method in budget `%s`", entry)
+			}
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("budget `%s` must be positive", entry)
+		}
+		if _, ok := budget[method]; ok {
+			return nil, fmt.Errorf("duplicate budget `%s`", entry)
+		}
+		budget[method] = d
+	}
+	return budget, nil
+}
+
+// slowRequestLog - the most recent requests which exceeded their
+// budget.
+type slowRequestLog struct {
+	sync.Mutex
+	requests []madmin.SlowRequest
+	next     int
+}
+
+// add - records a slow request, replacing the oldest one once
+// maxSlowRequests are kept.
+func (l *slowRequestLog) add(req madmin.SlowRequest) {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(l.requests) < maxSlowRequests {
+		l.requests = append(l.requests, req)
+		return
+	}
+	l.requests[l.next] = req
+	l.next = (l.next + 1) % maxSlowRequests
+}
+
+// getRequests - returns the recorded slow requests, most recent first.
+func (l *slowRequestLog) getRequests() []madmin.SlowRequest {
+	l.Lock()
+	defer l.Unlock()
+
+	requests := make([]madmin.SlowRequest, 0, len(l.requests))
+	for i := len(l.requests) - 1; i >= 0; i-- {
+		requests = append(requests, l.requests[(l.next+i)%len(l.requests)])
+	}
+	return requests
+}
+
+// Prepare new slowRequestLog structure
+func newSlowRequestLog() *slowRequestLog {
+	return &slowRequestLog{}
+}
+
+// logSlowRequest - records and logs a request which exceeded its
+// budget.
+func logSlowRequest(r *http.Request, statusCode int, duration, budget time.Duration) {
+	req := madmin.SlowRequest{
+		Time:       UTCNow(),
+		Method:     r.Method,
+		Path:       getRequestResource(r),
+		StatusCode: statusCode,
+		Duration:   duration,
+		Budget:     budget,
+	}
+	globalSlowRequests.add(req)
+
+	reqInfo := &logger.ReqInfo{
+		RemoteHost: handlers.GetSourceIP(r),
+		UserAgent:  r.UserAgent(),
+		API:        "SlowRequest",
+	}
+	reqInfo.AppendTags("method", req.Method)
+	reqInfo.AppendTags("path", req.Path)
+	reqInfo.AppendTags("duration", duration.String())
+	reqInfo.AppendTags("node", globalMinioAddr)
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+	logger.LogEvent(ctx, "Request took %s, exceeding its budget of %s", duration, budget)
+}
@@ -0,0 +1,120 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseSlowRequestBudget(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedBudget slowRequestBudget
+		expectErr      bool
+	}{
+		{"5s", slowRequestBudget{"": 5 * time.Second}, false},
+		{"get=1s, PUT=10s", slowRequestBudget{"GET": time.Second, "PUT": 10 * time.Second}, false},
+		{"GET=500ms,2s", slowRequestBudget{"GET": 500 * time.Millisecond, "": 2 * time.Second}, false},
+		{"", nil, true},
+		{"GET", nil, true},
+		{"=1s", nil, true},
+		{"GET=0s", nil, true},
+		{"GET=1s,GET=2s", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		budget, err := parseSlowRequestBudget(testCase.value)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("Test %d: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if !testCase.expectErr && !reflect.DeepEqual(budget, testCase.expectedBudget) {
+			t.Errorf("Test %d: expected: %v, got: %v", i+1, testCase.expectedBudget, budget)
+		}
+	}
+}
+
+func TestSlowRequestLog(t *testing.T) {
+	l := newSlowRequestLog()
+	for i := 0; i < maxSlowRequests+10; i++ {
+		l.add(madmin.SlowRequest{StatusCode: i})
+	}
+
+	requests := l.getRequests()
+	if len(requests) != maxSlowRequests {
+		t.Fatalf("Expected %d slow requests, got %d", maxSlowRequests, len(requests))
+	}
+	for i, req := range requests {
+		if expected := maxSlowRequests + 9 - i; req.StatusCode != expected {
+			t.Fatalf("Request %d: expected %d, got %d", i, expected, req.StatusCode)
+		}
+	}
+}
+
+func TestHTTPStatsHandlerSlowRequest(t *testing.T) {
+	tmpGlobalSlowRequestBudget := globalSlowRequestBudget
+	tmpGlobalSlowRequestHeader := globalSlowRequestHeader
+	tmpGlobalSlowRequests := globalSlowRequests
+	defer func() {
+		globalSlowRequestBudget = tmpGlobalSlowRequestBudget
+		globalSlowRequestHeader = tmpGlobalSlowRequestHeader
+		globalSlowRequests = tmpGlobalSlowRequests
+	}()
+	globalSlowRequestBudget = slowRequestBudget{"PUT": 10 * time.Millisecond, "": time.Hour}
+	globalSlowRequestHeader = true
+	globalSlowRequests = newSlowRequestLog()
+
+	handler := setHTTPStatsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	testCases := []struct {
+		method       string
+		expectedSlow bool
+	}{
+		{http.MethodGet, false},
+		{http.MethodPut, true},
+	}
+	for i, testCase := range testCases {
+		globalSlowRequests = newSlowRequestLog()
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(testCase.method, "/bucket/object", nil))
+
+		if slow := rec.Header().Get(slowRequestHeader) != ""; slow != testCase.expectedSlow {
+			t.Errorf("Test %d: expected slow request header: %v, got: %v", i+1, testCase.expectedSlow, slow)
+		}
+
+		requests := globalSlowRequests.getRequests()
+		if slow := len(requests) > 0; slow != testCase.expectedSlow {
+			t.Fatalf("Test %d: expected slow request recorded: %v, got: %v", i+1, testCase.expectedSlow, slow)
+		}
+		if testCase.expectedSlow {
+			req := requests[0]
+			if req.Method != testCase.method || req.Path != "/bucket/object" ||
+				req.StatusCode != http.StatusCreated || req.Duration <= req.Budget {
+				t.Errorf("Test %d: unexpected slow request %#v", i+1, req)
+			}
+		}
+	}
+}
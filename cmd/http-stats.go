@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -131,48 +132,67 @@ func durationStr(totalDuration, totalCount float64) string {
 	return fmt.Sprint(time.Duration(totalDuration/totalCount) * time.Second)
 }
 
+// durationMillis returns the average duration in milliseconds, zero
+// when no calls were recorded.
+func durationMillis(totalDuration, totalCount float64) float64 {
+	if totalCount == 0 {
+		return 0
+	}
+	return totalDuration / totalCount * 1000
+}
+
 // Converts http stats into struct to be sent back to the client.
 func (st HTTPStats) toServerHTTPStats() ServerHTTPStats {
 	serverStats := ServerHTTPStats{}
 	serverStats.TotalHEADStats = ServerHTTPMethodStats{
-		Count:       st.totalHEADs.Counter.Load(),
-		AvgDuration: durationStr(st.totalHEADs.Duration.Load(), float64(st.totalHEADs.Counter.Load())),
+		Count:             st.totalHEADs.Counter.Load(),
+		AvgDuration:       durationStr(st.totalHEADs.Duration.Load(), float64(st.totalHEADs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.totalHEADs.Duration.Load(), float64(st.totalHEADs.Counter.Load())),
 	}
 	serverStats.SuccessHEADStats = ServerHTTPMethodStats{
-		Count:       st.successHEADs.Counter.Load(),
-		AvgDuration: durationStr(st.successHEADs.Duration.Load(), float64(st.successHEADs.Counter.Load())),
+		Count:             st.successHEADs.Counter.Load(),
+		AvgDuration:       durationStr(st.successHEADs.Duration.Load(), float64(st.successHEADs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.successHEADs.Duration.Load(), float64(st.successHEADs.Counter.Load())),
 	}
 	serverStats.TotalGETStats = ServerHTTPMethodStats{
-		Count:       st.totalGETs.Counter.Load(),
-		AvgDuration: durationStr(st.totalGETs.Duration.Load(), float64(st.totalGETs.Counter.Load())),
+		Count:             st.totalGETs.Counter.Load(),
+		AvgDuration:       durationStr(st.totalGETs.Duration.Load(), float64(st.totalGETs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.totalGETs.Duration.Load(), float64(st.totalGETs.Counter.Load())),
 	}
 	serverStats.SuccessGETStats = ServerHTTPMethodStats{
-		Count:       st.successGETs.Counter.Load(),
-		AvgDuration: durationStr(st.successGETs.Duration.Load(), float64(st.successGETs.Counter.Load())),
+		Count:             st.successGETs.Counter.Load(),
+		AvgDuration:       durationStr(st.successGETs.Duration.Load(), float64(st.successGETs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.successGETs.Duration.Load(), float64(st.successGETs.Counter.Load())),
 	}
 	serverStats.TotalPUTStats = ServerHTTPMethodStats{
-		Count:       st.totalPUTs.Counter.Load(),
-		AvgDuration: durationStr(st.totalPUTs.Duration.Load(), float64(st.totalPUTs.Counter.Load())),
+		Count:             st.totalPUTs.Counter.Load(),
+		AvgDuration:       durationStr(st.totalPUTs.Duration.Load(), float64(st.totalPUTs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.totalPUTs.Duration.Load(), float64(st.totalPUTs.Counter.Load())),
 	}
 	serverStats.SuccessPUTStats = ServerHTTPMethodStats{
-		Count:       st.successPUTs.Counter.Load(),
-		AvgDuration: durationStr(st.successPUTs.Duration.Load(), float64(st.successPUTs.Counter.Load())),
+		Count:             st.successPUTs.Counter.Load(),
+		AvgDuration:       durationStr(st.successPUTs.Duration.Load(), float64(st.successPUTs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.successPUTs.Duration.Load(), float64(st.successPUTs.Counter.Load())),
 	}
 	serverStats.TotalPOSTStats = ServerHTTPMethodStats{
-		Count:       st.totalPOSTs.Counter.Load(),
-		AvgDuration: durationStr(st.totalPOSTs.Duration.Load(), float64(st.totalPOSTs.Counter.Load())),
+		Count:             st.totalPOSTs.Counter.Load(),
+		AvgDuration:       durationStr(st.totalPOSTs.Duration.Load(), float64(st.totalPOSTs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.totalPOSTs.Duration.Load(), float64(st.totalPOSTs.Counter.Load())),
 	}
 	serverStats.SuccessPOSTStats = ServerHTTPMethodStats{
-		Count:       st.successPOSTs.Counter.Load(),
-		AvgDuration: durationStr(st.successPOSTs.Duration.Load(), float64(st.successPOSTs.Counter.Load())),
+		Count:             st.successPOSTs.Counter.Load(),
+		AvgDuration:       durationStr(st.successPOSTs.Duration.Load(), float64(st.successPOSTs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.successPOSTs.Duration.Load(), float64(st.successPOSTs.Counter.Load())),
 	}
 	serverStats.TotalDELETEStats = ServerHTTPMethodStats{
-		Count:       st.totalDELETEs.Counter.Load(),
-		AvgDuration: durationStr(st.totalDELETEs.Duration.Load(), float64(st.totalDELETEs.Counter.Load())),
+		Count:             st.totalDELETEs.Counter.Load(),
+		AvgDuration:       durationStr(st.totalDELETEs.Duration.Load(), float64(st.totalDELETEs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.totalDELETEs.Duration.Load(), float64(st.totalDELETEs.Counter.Load())),
 	}
 	serverStats.SuccessDELETEStats = ServerHTTPMethodStats{
-		Count:       st.successDELETEs.Counter.Load(),
-		AvgDuration: durationStr(st.successDELETEs.Duration.Load(), float64(st.successDELETEs.Counter.Load())),
+		Count:             st.successDELETEs.Counter.Load(),
+		AvgDuration:       durationStr(st.successDELETEs.Duration.Load(), float64(st.successDELETEs.Counter.Load())),
+		AvgDurationMillis: durationMillis(st.successDELETEs.Duration.Load(), float64(st.successDELETEs.Counter.Load())),
 	}
 	return serverStats
 }
@@ -227,3 +247,38 @@ func (st *HTTPStats) updateStats(r *http.Request, w *httpResponseRecorder, durat
 func newHTTPStats() *HTTPStats {
 	return &HTTPStats{}
 }
+
+// APIErrorStats counts the error responses sent, indexed by
+// their API error code.
+type APIErrorStats struct {
+	sync.Mutex
+	counts map[string]uint64
+}
+
+// Increment the count of the given API error code.
+func (s *APIErrorStats) inc(code string) {
+	s.Lock()
+	s.counts[code]++
+	s.Unlock()
+}
+
+// Returns a copy of the counts, which are reset to zero if reset is
+// set. Counting and resetting are atomic so that no error is lost.
+func (s *APIErrorStats) getCounts(reset bool) map[string]uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	counts := make(map[string]uint64, len(s.counts))
+	for code, count := range s.counts {
+		counts[code] = count
+	}
+	if reset {
+		s.counts = make(map[string]uint64)
+	}
+	return counts
+}
+
+// Prepare new APIErrorStats structure
+func newAPIErrorStats() *APIErrorStats {
+	return &APIErrorStats{counts: make(map[string]uint64)}
+}
@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"net"
+	"sync"
+)
+
+// ConnLimiter - counts the open connections of each client IP, and
+// rejects the new connections of a client which reached the limit.
+// Connections of exempt IPs, such as those of the other servers of a
+// cluster, are neither counted nor limited.
+type ConnLimiter struct {
+	mutex    sync.Mutex
+	limit    int
+	exempt   map[string]bool
+	conns    map[string]int
+	rejected uint64
+}
+
+// NewConnLimiter - creates a limiter allowing limit open connections
+// per client IP apart from the exempt ones, 0 counts connections
+// without limiting them.
+func NewConnLimiter(limit int, exemptIPs []string) *ConnLimiter {
+	exempt := make(map[string]bool, len(exemptIPs))
+	for _, ip := range exemptIPs {
+		exempt[ip] = true
+	}
+	return &ConnLimiter{
+		limit:  limit,
+		exempt: exempt,
+		conns:  make(map[string]int),
+	}
+}
+
+// Limit - returns the maximum number of open connections per client
+// IP, 0 if unlimited.
+func (l *ConnLimiter) Limit() int {
+	return l.limit
+}
+
+// Conns - returns the number of open connections of each client IP,
+// along with the number of connections rejected so far.
+func (l *ConnLimiter) Conns() (conns map[string]int, rejected uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	conns = make(map[string]int, len(l.conns))
+	for ip, n := range l.conns {
+		conns[ip] = n
+	}
+	return conns, l.rejected
+}
+
+// acquire - counts a new connection of ip, returns false if ip
+// reached the limit.
+func (l *ConnLimiter) acquire(ip string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.limit > 0 && l.conns[ip] >= l.limit {
+		l.rejected++
+		return false
+	}
+	l.conns[ip]++
+	return true
+}
+
+// release - counts a closed connection of ip.
+func (l *ConnLimiter) release(ip string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.conns[ip] <= 1 {
+		delete(l.conns, ip)
+		return
+	}
+	l.conns[ip]--
+}
+
+// limitedConn - connection counted by a ConnLimiter until closed.
+type limitedConn struct {
+	net.Conn
+	ip        string
+	limiter   *ConnLimiter
+	closeOnce sync.Once
+}
+
+// Close - closes the connection and releases it from the limiter.
+func (c *limitedConn) Close() error {
+	c.closeOnce.Do(func() { c.limiter.release(c.ip) })
+	return c.Conn.Close()
+}
+
+// limitConn - returns conn counted by the limiter, or false if its
+// client IP reached the limit. Connections of exempt IPs are returned
+// as is.
+func (l *ConnLimiter) limitConn(conn net.Conn) (net.Conn, bool) {
+	ip, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		ip = conn.RemoteAddr().String()
+	}
+	if l.exempt[ip] {
+		return conn, true
+	}
+	if !l.acquire(ip) {
+		return nil, false
+	}
+	return &limitedConn{Conn: conn, ip: ip, limiter: l}, true
+}
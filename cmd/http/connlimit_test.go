@@ -0,0 +1,150 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestConnLimiter(t *testing.T) {
+	limiter := NewConnLimiter(2, nil)
+	if !limiter.acquire("10.0.0.1") || !limiter.acquire("10.0.0.1") || !limiter.acquire("10.0.0.2") {
+		t.Fatal("expected connections below the limit to be accepted")
+	}
+	if limiter.acquire("10.0.0.1") {
+		t.Fatal("expected connection above the limit to be rejected")
+	}
+
+	conns, rejected := limiter.Conns()
+	if len(conns) != 2 || conns["10.0.0.1"] != 2 || conns["10.0.0.2"] != 1 || rejected != 1 {
+		t.Fatalf("unexpected connections %v, %d rejected", conns, rejected)
+	}
+
+	limiter.release("10.0.0.1")
+	limiter.release("10.0.0.2")
+	if !limiter.acquire("10.0.0.1") {
+		t.Fatal("expected connection to be accepted once another one was released")
+	}
+	if conns, _ = limiter.Conns(); len(conns) != 1 {
+		t.Fatalf("expected released clients to be removed, got %v", conns)
+	}
+
+	// Without limit, connections are only counted.
+	limiter = NewConnLimiter(0, nil)
+	for i := 0; i < 10; i++ {
+		if !limiter.acquire("10.0.0.1") {
+			t.Fatal("expected connections to be accepted without limit")
+		}
+	}
+}
+
+// remoteAddrConn - connection of the given client address.
+type remoteAddrConn struct {
+	net.Conn
+	addr net.Addr
+}
+
+func (c remoteAddrConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// Tests that the connections of exempt IPs are never rejected.
+func TestConnLimiterExempt(t *testing.T) {
+	limiter := NewConnLimiter(1, []string{"10.0.0.9"})
+	peer := remoteAddrConn{addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.9"), Port: 9000}}
+	for i := 0; i < 10; i++ {
+		conn, ok := limiter.limitConn(peer)
+		if !ok {
+			t.Fatalf("expected connection %d of the exempt IP to be accepted", i+1)
+		}
+		if conn != peer {
+			t.Fatalf("expected connection %d of the exempt IP not to be counted", i+1)
+		}
+	}
+
+	client := remoteAddrConn{addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9000}}
+	if _, ok := limiter.limitConn(client); !ok {
+		t.Fatal("expected connection below the limit to be accepted")
+	}
+	if _, ok := limiter.limitConn(client); ok {
+		t.Fatal("expected connection above the limit to be rejected")
+	}
+
+	if conns, rejected := limiter.Conns(); len(conns) != 1 || conns["10.0.0.1"] != 1 || rejected != 1 {
+		t.Fatalf("unexpected connections %v, %d rejected", conns, rejected)
+	}
+}
+
+func TestHTTPListenerConnLimit(t *testing.T) {
+	limiter := NewConnLimiter(1, nil)
+	listener, err := newHTTPListener(
+		[]string{"127.0.0.1:0"},
+		nil,
+		time.Duration(0),
+		time.Duration(0),
+		time.Duration(0),
+		DefaultMaxHeaderBytes,
+		nil,
+		nil,
+		limiter,
+	)
+	if err != nil {
+		t.Fatalf("error: expected = <nil>, got = %v", err)
+	}
+	defer listener.Close()
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			t.Fatalf("error: expected = <nil>, got = %v", err)
+		}
+		if _, err = io.WriteString(conn, "GET / HTTP/1.0\r\nHost: example.org\r\n\r\n"); err != nil {
+			t.Fatalf("request send: expected = <nil>, got = %v", err)
+		}
+		return conn
+	}
+
+	conn := dial()
+	defer conn.Close()
+	serverConn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("accept: expected = <nil>, got = %v", err)
+	}
+
+	// The second connection of the client is dropped.
+	rejectedConn := dial()
+	defer rejectedConn.Close()
+	rejectedConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err = rejectedConn.Read(make([]byte, 1)); err == nil {
+		t.Fatal("read: expected closed connection, got = <nil>")
+	}
+	if conns, rejected := limiter.Conns(); conns["127.0.0.1"] != 1 || rejected != 1 {
+		t.Fatalf("unexpected connections %v, %d rejected", conns, rejected)
+	}
+
+	// Closing the first connection allows a new one.
+	serverConn.Close()
+	newConn := dial()
+	defer newConn.Close()
+	if serverConn, err = listener.Accept(); err != nil {
+		t.Fatalf("accept: expected = <nil>, got = %v", err)
+	}
+	serverConn.Close()
+}
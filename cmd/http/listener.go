@@ -129,6 +129,7 @@ type httpListener struct {
 	maxHeaderBytes         int
 	updateBytesReadFunc    func(*http.Request, int) // function to be called to update bytes read in BufConn.
 	updateBytesWrittenFunc func(*http.Request, int) // function to be called to update bytes written in BufConn.
+	connLimiter            *ConnLimiter             // limiter of the open connections per client IP, if any.
 }
 
 // isRoutineNetErr returns true if error is due to a network timeout,
@@ -174,7 +175,18 @@ func (listener *httpListener) start() {
 		tcpConn.SetKeepAlive(true)
 		tcpConn.SetKeepAlivePeriod(listener.tcpKeepAliveTimeout)
 
-		bufconn := newBufConn(tcpConn, listener.readTimeout, listener.writeTimeout)
+		// Drop the connection if its client has too many open
+		// connections already.
+		var conn net.Conn = tcpConn
+		if listener.connLimiter != nil {
+			var ok bool
+			if conn, ok = listener.connLimiter.limitConn(tcpConn); !ok {
+				tcpConn.Close()
+				return
+			}
+		}
+
+		bufconn := newBufConn(conn, listener.readTimeout, listener.writeTimeout)
 
 		// Peek bytes of maximum length of all HTTP methods.
 		data, err := bufconn.Peek(methodMaxLen)
@@ -385,7 +397,8 @@ func newHTTPListener(serverAddrs []string,
 	writeTimeout time.Duration,
 	maxHeaderBytes int,
 	updateBytesReadFunc func(*http.Request, int),
-	updateBytesWrittenFunc func(*http.Request, int)) (listener *httpListener, err error) {
+	updateBytesWrittenFunc func(*http.Request, int),
+	connLimiter *ConnLimiter) (listener *httpListener, err error) {
 
 	var tcpListeners []*net.TCPListener
 
@@ -424,6 +437,7 @@ func newHTTPListener(serverAddrs []string,
 		maxHeaderBytes:         maxHeaderBytes,
 		updateBytesReadFunc:    updateBytesReadFunc,
 		updateBytesWrittenFunc: updateBytesWrittenFunc,
+		connLimiter:            connLimiter,
 	}
 	listener.start()
 
@@ -248,6 +248,7 @@ func TestNewHTTPListener(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			testCase.updateBytesReadFunc,
 			testCase.updateBytesWrittenFunc,
+			nil,
 		)
 
 		if testCase.expectedErr == nil {
@@ -300,6 +301,7 @@ func TestHTTPListenerStartClose(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -348,6 +350,7 @@ func TestHTTPListenerAddr(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -393,6 +396,7 @@ func TestHTTPListenerAddrs(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -443,6 +447,7 @@ func TestHTTPListenerAccept(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -523,6 +528,7 @@ func TestHTTPListenerAcceptPeekError(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -577,6 +583,7 @@ func TestHTTPListenerAcceptTLSError(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -644,6 +651,7 @@ func TestHTTPListenerAcceptError(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -770,6 +778,7 @@ func TestHTTPListenerAcceptParallel(t *testing.T) {
 			DefaultMaxHeaderBytes,
 			nil,
 			nil,
+			nil,
 		)
 		if err != nil {
 			t.Fatalf("Test %d: error: expected = <nil>, got = %v", i+1, err)
@@ -56,6 +56,7 @@ type Server struct {
 	TCPKeepAliveTimeout    time.Duration            // timeout used for underneath TCP connection.
 	UpdateBytesReadFunc    func(*http.Request, int) // function to be called to update bytes read in bufConn.
 	UpdateBytesWrittenFunc func(*http.Request, int) // function to be called to update bytes written in bufConn.
+	ConnLimiter            *ConnLimiter             // limiter of the open connections per client IP, if any.
 	listenerMutex          *sync.Mutex              // to guard 'listener' field.
 	listener               *httpListener            // HTTP listener for all 'Addrs' field.
 	inShutdown             uint32                   // indicates whether the server is in shutdown or not
@@ -82,6 +83,7 @@ func (srv *Server) Start() (err error) {
 	tcpKeepAliveTimeout := srv.TCPKeepAliveTimeout
 	updateBytesReadFunc := srv.UpdateBytesReadFunc
 	updateBytesWrittenFunc := srv.UpdateBytesWrittenFunc
+	connLimiter := srv.ConnLimiter
 
 	// Create new HTTP listener.
 	var listener *httpListener
@@ -94,6 +96,7 @@ func (srv *Server) Start() (err error) {
 		srv.MaxHeaderBytes,
 		updateBytesReadFunc,
 		updateBytesWrittenFunc,
+		connLimiter,
 	)
 	if err != nil {
 		return err
@@ -137,6 +137,17 @@ func webRequestAuthenticate(req *http.Request) error {
 	return nil
 }
 
+// newAuthTokenWith - returns an inter-node authentication token signed
+// with the given credential rather than the one of this server, for
+// peers which still run with another credential.
+func newAuthTokenWith(cred auth.Credentials) (string, error) {
+	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
+		ExpiresAt: UTCNow().Add(defaultInterNodeJWTExpiry).Unix(),
+		Subject:   cred.AccessKey,
+	})
+	return jwt.SignedString([]byte(cred.SecretKey))
+}
+
 func newAuthToken() string {
 	cred := globalServerConfig.GetCredential()
 	token, err := authenticateNode(cred.AccessKey, cred.SecretKey)
@@ -20,6 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // localAdminClient - represents admin operation to be executed locally.
@@ -36,6 +40,20 @@ func (lc localAdminClient) SignalService(s serviceSignal) error {
 	return nil
 }
 
+// ScheduleRestart - restarts the local server after the delay, the
+// restart can only be cancelled with the token if one is given.
+func (lc localAdminClient) ScheduleRestart(delay time.Duration, token string) error {
+	globalRestartScheduler.schedule(delay, token)
+	return nil
+}
+
+// CancelRestart - aborts the scheduled restart of the local server,
+// returns false if no restart was pending or if it was scheduled with
+// another token.
+func (lc localAdminClient) CancelRestart(token string) (bool, error) {
+	return globalRestartScheduler.cancel(token), nil
+}
+
 // ReInitFormat - re-initialize disk format.
 func (lc localAdminClient) ReInitFormat(dryRun bool) error {
 	objectAPI := newObjectLayerFn()
@@ -57,18 +75,23 @@ func (lc localAdminClient) ServerInfo() (sid ServerInfoData, e error) {
 		return sid, errServerNotInitialized
 	}
 	storage := objLayer.StorageInfo(context.Background())
+	uptime := UTCNow().Sub(globalBootTime)
 
 	return ServerInfoData{
 		StorageInfo: storage,
 		ConnStats:   globalConnStats.toServerConnStats(),
 		HTTPStats:   globalHTTPStats.toServerHTTPStats(),
 		Properties: ServerProperties{
-			Uptime:   UTCNow().Sub(globalBootTime),
-			Version:  Version,
-			CommitID: CommitID,
-			SQSARN:   globalNotificationSys.GetARNList(),
-			Region:   globalServerConfig.GetRegion(),
+			Uptime:         uptime,
+			UptimeSeconds:  uptime.Seconds(),
+			Version:        Version,
+			CommitID:       CommitID,
+			SQSARN:         globalNotificationSys.GetARNList(),
+			Region:         globalServerConfig.GetRegion(),
+			ConfigChecksum: globalServerConfigChecksum,
+			SafeMode:       globalSafeMode,
 		},
+		Load: getServerLoad(),
 	}, nil
 }
 
@@ -80,3 +103,287 @@ func (lc localAdminClient) GetConfig() ([]byte, error) {
 
 	return json.Marshal(globalServerConfig)
 }
+
+// SetLogLevel - sets the log level of a subsystem of the local server,
+// or its default level if subsystem is empty.
+func (lc localAdminClient) SetLogLevel(subsystem string, level logger.Level) error {
+	if !isValidLogSubsystem(subsystem) {
+		return fmt.Errorf("unknown log subsystem `%s`", subsystem)
+	}
+	logger.SetLevel(subsystem, level)
+	return nil
+}
+
+// isValidLogSubsystem - returns true for known log subsystems, the
+// empty subsystem stands for the default level.
+func isValidLogSubsystem(subsystem string) bool {
+	if subsystem == "" {
+		return true
+	}
+	for _, s := range logger.Subsystems {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+// APIErrorStats - returns the count of error responses per API error
+// code of the local server, resetting them if reset is set.
+func (lc localAdminClient) APIErrorStats(reset bool) (map[string]uint64, error) {
+	return globalAPIErrorStats.getCounts(reset), nil
+}
+
+// SlowRequests - returns the most recent slow requests of the local
+// server.
+func (lc localAdminClient) SlowRequests() ([]madmin.SlowRequest, error) {
+	return globalSlowRequests.getRequests(), nil
+}
+
+// MetricsHistory - returns the metrics samples of the local server
+// taken between from and to.
+func (lc localAdminClient) MetricsHistory(from, to time.Time) ([]metricsSample, error) {
+	return globalMetricsHistory.getSamples(from, to), nil
+}
+
+// TestDisk - tests read and write on a disk of the local server.
+func (lc localAdminClient) TestDisk(disk string) (madmin.DiskTestResult, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return madmin.DiskTestResult{}, errServerNotInitialized
+	}
+
+	sets, ok := objectAPI.(*xlSets)
+	if !ok {
+		return madmin.DiskTestResult{}, NotImplemented{}
+	}
+
+	storage := sets.getLocalDisk(disk)
+	if storage == nil {
+		return madmin.DiskTestResult{Error: errDiskNotFound.Error()}, nil
+	}
+	return testDisk(storage)
+}
+
+// Speedtest - writes objects of the given size with concurrency
+// workers during duration on the local server, then reads them back
+// during duration.
+func (lc localAdminClient) Speedtest(size int64, concurrency int, duration time.Duration) (madmin.SpeedtestNodeResult, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return madmin.SpeedtestNodeResult{}, errServerNotInitialized
+	}
+
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "Speedtest"})
+	return runSpeedtest(ctx, objectAPI, size, concurrency, duration)
+}
+
+// NetPerf - measures the links from the local server to the other
+// servers.
+func (lc localAdminClient) NetPerf(size int64) ([]madmin.NetPerfLink, error) {
+	return measureNetPerf(globalAdminPeers, size), nil
+}
+
+// NetPerfPing - the local server has no link to itself to measure.
+func (lc localAdminClient) NetPerfPing(payload []byte) error {
+	return nil
+}
+
+// ActiveRequests - returns the requests being served by the local
+// server.
+func (lc localAdminClient) ActiveRequests() ([]madmin.ActiveRequest, error) {
+	return globalActiveRequests.getRequests(), nil
+}
+
+// CancelRequest - cancels a request being served by the local server,
+// returns false if no such request is being served.
+func (lc localAdminClient) CancelRequest(requestID string) (bool, error) {
+	return globalActiveRequests.cancel(requestID), nil
+}
+
+// RecentLogs - returns the most recent log entries of the local
+// server.
+func (lc localAdminClient) RecentLogs(lines int, since time.Time) ([]string, error) {
+	return globalRecentLogs.Recent(lines, since), nil
+}
+
+// LoadRateLimits - loads the saved rate limits of tenants into the
+// local server.
+func (lc localAdminClient) LoadRateLimits() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	return loadTenantRateLimits(objectAPI)
+}
+
+// RateLimits - returns the rate limits of tenants of the local server
+// along with their observed request rate.
+func (lc localAdminClient) RateLimits() ([]madmin.RateLimitStatus, error) {
+	return globalTenantRateLimiter.status(), nil
+}
+
+// LoadScannerSchedule - loads the saved schedule of the background
+// usage scans into the local server.
+func (lc localAdminClient) LoadScannerSchedule() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	return loadScannerSchedule(objectAPI)
+}
+
+// ScannerProgress - returns the progress of the background usage
+// scans of the local server.
+func (lc localAdminClient) ScannerProgress() ([]madmin.ScannerProgress, error) {
+	return globalUsageScanner.progress(), nil
+}
+
+// RefreshUsage - makes the background usage scans of the local server
+// run again right away, returns the time of the request.
+func (lc localAdminClient) RefreshUsage() (time.Time, error) {
+	return globalUsageScanner.requestRefresh(), nil
+}
+
+// LoadConfigSection - loads a section of the config.json saved into
+// the local server.
+func (lc localAdminClient) LoadConfigSection(section string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	return loadConfigSection(context.Background(), objectAPI, section)
+}
+
+// Connections - returns the open connections of each client IP of the
+// local server.
+func (lc localAdminClient) Connections() (madmin.ServerConnections, error) {
+	return getLocalConnections(), nil
+}
+
+// PartSizeStats - returns the sizes of the parts uploaded to the local
+// server since it started.
+func (lc localAdminClient) PartSizeStats() (madmin.PartSizeStats, error) {
+	return globalMultipartPolicy.stats(), nil
+}
+
+// CompressionStats - returns the GET responses compressed by the local
+// server since it started.
+func (lc localAdminClient) CompressionStats() (madmin.CompressionStats, error) {
+	return globalResponseCompression.stats(), nil
+}
+
+// AdmissionStatus - returns the state of the admission control of the
+// local server.
+func (lc localAdminClient) AdmissionStatus() (madmin.AdmissionStatus, error) {
+	return globalAdmissionController.status(), nil
+}
+
+// SetMaxRequests - sets the maximum number of requests served at once
+// by the local server, 0 disables the limit.
+func (lc localAdminClient) SetMaxRequests(limit int) error {
+	if limit < 0 {
+		return errInvalidArgument
+	}
+	globalAdmissionController.setLimit(limit)
+	return nil
+}
+
+// LoadServerInfoExport - loads the saved configuration of the export
+// of the server information into the local server.
+func (lc localAdminClient) LoadServerInfoExport() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	return loadServerInfoExport(objectAPI)
+}
+
+// ServerInfoExporterState - returns the state of the export of the
+// server information on the local server.
+func (lc localAdminClient) ServerInfoExporterState() (madmin.ServerInfoExporterState, error) {
+	return globalServerInfoExporter.state(), nil
+}
+
+// SimulateOffline - makes the local server simulate being offline for
+// the given duration, 0 makes it recover right away. Fails unless
+// chaos testing is enabled.
+func (lc localAdminClient) SimulateOffline(duration time.Duration) (time.Time, error) {
+	if !globalChaosTestingEnabled {
+		return time.Time{}, errChaosTestingDisabled
+	}
+	if duration < 0 || duration > maxSimulatedOfflineDuration {
+		return time.Time{}, errInvalidArgument
+	}
+	return globalSimulatedOffline.set(duration), nil
+}
+
+// LoadAdminCredentials - loads the saved admin credentials into the
+// local server.
+func (lc localAdminClient) LoadAdminCredentials() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	return loadAdminCredentials(objectAPI)
+}
+
+// NotifyQueues - returns the backlog of events being sent to each
+// notification target of the local server.
+func (lc localAdminClient) NotifyQueues() ([]madmin.NotifyQueue, error) {
+	if globalNotificationSys == nil {
+		return nil, errServerNotInitialized
+	}
+	return globalNotificationSys.NotifyQueues(), nil
+}
+
+// ReloadTLSCerts - reloads the TLS certificate and private key files
+// of the local server, returns the certificate chain it serves.
+func (lc localAdminClient) ReloadTLSCerts() ([]madmin.TLSCertificate, error) {
+	return reloadTLSCerts()
+}
+
+// DisksUsage - returns the capacity, used and free space of the local
+// disks.
+func (lc localAdminClient) DisksUsage() ([]madmin.DiskUsage, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	return getLocalDisksUsage(objectAPI)
+}
+
+// DisksLayout - returns the expected and actual position in the
+// erasure sets of the local disks.
+func (lc localAdminClient) DisksLayout() ([]madmin.DiskLayout, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	return getLocalDisksLayout(objectAPI)
+}
+
+// BitrotStats - returns the bitrot detected on the local disks since
+// the counters were reset.
+func (lc localAdminClient) BitrotStats() (madmin.ServerBitrotStats, error) {
+	return globalBitrotStats.get(), nil
+}
+
+// ResetBitrotStats - resets the bitrot counters of the local disks.
+func (lc localAdminClient) ResetBitrotStats() error {
+	globalBitrotStats.reset()
+	return nil
+}
+
+// ReadConsistencyTestObject - reads a consistency test object through
+// the local object layer and returns the SHA256 of its data, or an
+// empty string if it does not exist.
+func (lc localAdminClient) ReadConsistencyTestObject(object string) (string, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return "", errServerNotInitialized
+	}
+
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "ReadConsistencyTestObject"})
+	return readConsistencyTestObject(ctx, objectAPI, object)
+}
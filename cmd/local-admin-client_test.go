@@ -35,3 +35,107 @@ func TestLocalAdminClientServerInfo(t *testing.T) {
 func TestLocalAdminClientGetConfig(t *testing.T) {
 	testAdminCmdRunnerGetConfig(t, &localAdminClient{})
 }
+
+func TestLocalAdminClientSetLogLevel(t *testing.T) {
+	testAdminCmdRunnerSetLogLevel(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientAPIErrorStats(t *testing.T) {
+	testAdminCmdRunnerAPIErrorStats(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSlowRequests(t *testing.T) {
+	testAdminCmdRunnerSlowRequests(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientMetricsHistory(t *testing.T) {
+	testAdminCmdRunnerMetricsHistory(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientTestDisk(t *testing.T) {
+	testAdminCmdRunnerTestDisk(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSpeedtest(t *testing.T) {
+	testAdminCmdRunnerSpeedtest(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientScheduleRestart(t *testing.T) {
+	testAdminCmdRunnerScheduleRestart(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientActiveRequests(t *testing.T) {
+	testAdminCmdRunnerActiveRequests(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientRecentLogs(t *testing.T) {
+	testAdminCmdRunnerRecentLogs(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientRateLimits(t *testing.T) {
+	testAdminCmdRunnerRateLimits(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientScannerSchedule(t *testing.T) {
+	testAdminCmdRunnerScannerSchedule(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientLoadAdminCredentials(t *testing.T) {
+	testAdminCmdRunnerLoadAdminCredentials(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientLoadConfigSection(t *testing.T) {
+	testAdminCmdRunnerLoadConfigSection(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientNotifyQueues(t *testing.T) {
+	testAdminCmdRunnerNotifyQueues(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientReloadTLSCerts(t *testing.T) {
+	testAdminCmdRunnerReloadTLSCerts(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientDisksUsage(t *testing.T) {
+	testAdminCmdRunnerDisksUsage(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientNetPerf(t *testing.T) {
+	testAdminCmdRunnerNetPerf(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientDisksLayout(t *testing.T) {
+	testAdminCmdRunnerDisksLayout(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientBitrotStats(t *testing.T) {
+	testAdminCmdRunnerBitrotStats(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientReadConsistencyTestObject(t *testing.T) {
+	testAdminCmdRunnerReadConsistencyTestObject(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientConnections(t *testing.T) {
+	testAdminCmdRunnerConnections(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientPartSizeStats(t *testing.T) {
+	testAdminCmdRunnerPartSizeStats(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientCompressionStats(t *testing.T) {
+	testAdminCmdRunnerCompressionStats(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientAdmission(t *testing.T) {
+	testAdminCmdRunnerAdmission(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientServerInfoExport(t *testing.T) {
+	testAdminCmdRunnerServerInfoExport(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSimulateOffline(t *testing.T) {
+	testAdminCmdRunnerSimulateOffline(t, &localAdminClient{})
+}
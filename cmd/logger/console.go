@@ -19,6 +19,7 @@ package logger
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -37,6 +38,24 @@ func (c *ConsoleTarget) send(entry logEntry) error {
 		return nil
 	}
 
+	// Entries without a trace are plain messages such as debug logs.
+	if entry.Trace == nil {
+		var tags []string
+		for key, value := range entry.Tags {
+			tags = append(tags, key+"="+value)
+		}
+		sort.Strings(tags)
+
+		var tagString string
+		if len(tags) > 0 {
+			tagString = "\n       " + strings.Join(tags, ", ")
+		}
+
+		fmt.Printf("\n%s: %s\nTime: %s\n%s%s\n", entry.Level, entry.API.Name,
+			time.Now().Format(loggerTimeFormat), entry.Message, tagString)
+		return nil
+	}
+
 	trace := make([]string, len(entry.Trace.Source))
 
 	// Add a sequence number and formatting for each stack trace
@@ -0,0 +1,131 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Subsystems whose verbosity can be set independently of the
+// default level.
+const (
+	LockSubsystem   = "lock"
+	NotifySubsystem = "notify"
+	HealSubsystem   = "heal"
+)
+
+// Subsystems - list of all subsystems.
+var Subsystems = []string{LockSubsystem, NotifySubsystem, HealSubsystem}
+
+// DefaultLevel - minimum level logged by default, errors passed to
+// LogIf are logged.
+const DefaultLevel = ErrorLvl
+
+var (
+	levelsMu sync.RWMutex
+	// minimum level logged, indexed by subsystem. The empty
+	// subsystem holds the default level.
+	levels = map[string]Level{"": DefaultLevel}
+)
+
+// ParseLevel - parses a level name such as "DEBUG" or "error".
+func ParseLevel(s string) (Level, error) {
+	for _, level := range []Level{DebugLvl, InformationLvl, ErrorLvl, FatalLvl} {
+		if strings.EqualFold(s, level.String()) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level `%s`", s)
+}
+
+// SetLevel - sets the minimum level logged for the subsystem, or the
+// default level of all subsystems if subsystem is empty. A zero level
+// removes the level of the subsystem, which then logs at the default
+// level, or restores DefaultLevel as the default level.
+func SetLevel(subsystem string, level Level) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	switch {
+	case level != 0:
+		levels[subsystem] = level
+	case subsystem == "":
+		levels[subsystem] = DefaultLevel
+	default:
+		delete(levels, subsystem)
+	}
+}
+
+// GetLevels - returns the minimum level logged for each subsystem
+// which has one set, the default level is indexed by the empty
+// subsystem.
+func GetLevels() map[string]Level {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	m := make(map[string]Level, len(levels))
+	for subsystem, level := range levels {
+		m[subsystem] = level
+	}
+	return m
+}
+
+// IsEnabled - returns true if messages of the given level are logged
+// for the subsystem.
+func IsEnabled(subsystem string, level Level) bool {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	minLevel, ok := levels[subsystem]
+	if !ok {
+		minLevel = levels[""]
+	}
+	return level >= minLevel
+}
+
+// Debug - logs a debug message of the subsystem, if its verbosity
+// was raised to the debug level.
+func Debug(ctx context.Context, subsystem, msg string, data ...interface{}) {
+	if Disable || !IsEnabled(subsystem, DebugLvl) {
+		return
+	}
+
+	req := GetReqInfo(ctx)
+	if req == nil {
+		req = &ReqInfo{API: "SYSTEM"}
+	}
+
+	entry := logEntry{
+		DeploymentID: deploymentID,
+		Level:        DebugLvl.String(),
+		RemoteHost:   req.RemoteHost,
+		RequestID:    req.RequestID,
+		UserAgent:    req.UserAgent,
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+		API:          &api{Name: subsystem, Args: &args{Bucket: req.BucketName, Object: req.ObjectName}},
+		Message:      fmt.Sprintf(msg, data...),
+	}
+
+	// Iterate over all logger targets to send the log entry
+	for _, t := range Targets {
+		t.send(entry)
+	}
+}
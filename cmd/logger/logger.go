@@ -40,7 +40,8 @@ type Level int8
 
 // Enumerated level types
 const (
-	InformationLvl Level = iota + 1
+	DebugLvl Level = iota + 1
+	InformationLvl
 	ErrorLvl
 	FatalLvl
 )
@@ -81,6 +82,8 @@ var matchingFuncNames = [...]string{
 func (level Level) String() string {
 	var lvlStr string
 	switch level {
+	case DebugLvl:
+		lvlStr = "DEBUG"
 	case InformationLvl:
 		lvlStr = "INFO"
 	case ErrorLvl:
@@ -127,15 +130,16 @@ type api struct {
 }
 
 type logEntry struct {
-	DeploymentID string      `json:"deploymentid,omitempty"`
-	Level        string      `json:"level"`
-	Time         string      `json:"time"`
-	API          *api        `json:"api,omitempty"`
-	RemoteHost   string      `json:"remotehost,omitempty"`
-	RequestID    string      `json:"requestID,omitempty"`
-	UserAgent    string      `json:"userAgent,omitempty"`
-	Message      string      `json:"message,omitempty"`
-	Trace        *traceEntry `json:"error,omitempty"`
+	DeploymentID string            `json:"deploymentid,omitempty"`
+	Level        string            `json:"level"`
+	Time         string            `json:"time"`
+	API          *api              `json:"api,omitempty"`
+	RemoteHost   string            `json:"remotehost,omitempty"`
+	RequestID    string            `json:"requestID,omitempty"`
+	UserAgent    string            `json:"userAgent,omitempty"`
+	Message      string            `json:"message,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	Trace        *traceEntry       `json:"error,omitempty"`
 }
 
 // quiet: Hide startup messages if enabled
@@ -298,7 +302,7 @@ func LogIf(ctx context.Context, err error) {
 // logIf prints a detailed error message during
 // the execution of the server.
 func logIf(ctx context.Context, err error) {
-	if Disable {
+	if Disable || !IsEnabled("", ErrorLvl) {
 		return
 	}
 
@@ -341,6 +345,42 @@ func logIf(ctx context.Context, err error) {
 	}
 }
 
+// LogEvent logs a message along with the tags of the request,
+// regardless of the level set. It is meant for events the operator
+// explicitly asked to be notified of, such as slow requests.
+func LogEvent(ctx context.Context, msg string, data ...interface{}) {
+	if Disable {
+		return
+	}
+
+	req := GetReqInfo(ctx)
+	if req == nil {
+		req = &ReqInfo{API: "SYSTEM"}
+	}
+
+	tags := make(map[string]string)
+	for _, entry := range req.GetTags() {
+		tags[entry.Key] = entry.Val
+	}
+
+	entry := logEntry{
+		DeploymentID: deploymentID,
+		Level:        InformationLvl.String(),
+		RemoteHost:   req.RemoteHost,
+		RequestID:    req.RequestID,
+		UserAgent:    req.UserAgent,
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+		API:          &api{Name: req.API, Args: &args{Bucket: req.BucketName, Object: req.ObjectName}},
+		Message:      fmt.Sprintf(msg, data...),
+		Tags:         tags,
+	}
+
+	// Iterate over all logger targets to send the log entry
+	for _, t := range Targets {
+		t.send(entry)
+	}
+}
+
 // ErrCritical is the value panic'd whenever CriticalIf is called.
 var ErrCritical struct{}
 
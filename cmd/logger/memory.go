@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// MemoryTarget implements loggerTarget and keeps the most
+// recent log entries in memory, in json format, so that
+// they can be collected without access to the server's
+// output.
+type MemoryTarget struct {
+	sync.Mutex
+	entries []memoryEntry
+	next    int
+	size    int
+}
+
+type memoryEntry struct {
+	time time.Time
+	log  string
+}
+
+func (m *MemoryTarget) send(entry logEntry) error {
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	e := memoryEntry{time: time.Now().UTC(), log: string(logJSON)}
+	if len(m.entries) < m.size {
+		m.entries = append(m.entries, e)
+		return nil
+	}
+	m.entries[m.next] = e
+	m.next = (m.next + 1) % m.size
+	return nil
+}
+
+// Recent returns, oldest first, the most recent entries
+// logged since the given time, at most the given number of
+// them. A zero time or number of lines means no limit.
+func (m *MemoryTarget) Recent(lines int, since time.Time) []string {
+	m.Lock()
+	defer m.Unlock()
+
+	var logs []string
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if lines > 0 && len(logs) == lines {
+			break
+		}
+		e := m.entries[(m.next+i)%len(m.entries)]
+		if e.time.Before(since) {
+			break
+		}
+		logs = append(logs, e.log)
+	}
+
+	// Reverse to return the oldest entry first.
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	return logs
+}
+
+// NewMemory initializes a new logger target which
+// keeps the given number of most recent entries.
+func NewMemory(size int) *MemoryTarget {
+	return &MemoryTarget{size: size}
+}
@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// Metrics history retention environment variable.
+	metricsHistoryEnv = "MINIO_METRICS_HISTORY"
+
+	// Interval between two samples of the metrics history.
+	metricsHistoryInterval = 10 * time.Second
+
+	// Maximum retention of the metrics history.
+	maxMetricsHistoryRetention = 24 * time.Hour
+)
+
+// metricsSample - connection and HTTP statistics of a server at a
+// point in time.
+type metricsSample struct {
+	Time      time.Time       `json:"time"`
+	ConnStats ServerConnStats `json:"network"`
+	HTTPStats ServerHTTPStats `json:"http"`
+}
+
+// metricsHistory - rolling window of the most recent metrics samples.
+type metricsHistory struct {
+	sync.Mutex
+	samples []metricsSample
+	size    int
+	next    int
+}
+
+// parseMetricsHistoryRetention - parses the retention of the metrics
+// history, such as "1h", which must be at least one sample interval
+// and at most maxMetricsHistoryRetention.
+func parseMetricsHistoryRetention(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < metricsHistoryInterval || d > maxMetricsHistoryRetention {
+		return 0, fmt.Errorf("retention `%s` must be between %s and %s", s, metricsHistoryInterval, maxMetricsHistoryRetention)
+	}
+	return d, nil
+}
+
+// add - records a sample, replacing the oldest one once the history
+// is full.
+func (h *metricsHistory) add(sample metricsSample) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.size == 0 {
+		return
+	}
+	if len(h.samples) < h.size {
+		h.samples = append(h.samples, sample)
+		return
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % h.size
+}
+
+// getSamples - returns the samples taken between from and to, oldest
+// first. A zero from or to leaves the range open on that side.
+func (h *metricsHistory) getSamples(from, to time.Time) []metricsSample {
+	h.Lock()
+	defer h.Unlock()
+
+	samples := []metricsSample{}
+	for i := 0; i < len(h.samples); i++ {
+		sample := h.samples[(h.next+i)%len(h.samples)]
+		if !from.IsZero() && sample.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && sample.Time.After(to) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// Prepare new metricsHistory structure keeping the samples taken
+// during retention, the history is disabled without retention.
+func newMetricsHistory(retention time.Duration) *metricsHistory {
+	return &metricsHistory{size: int(retention / metricsHistoryInterval)}
+}
+
+// startMetricsHistory - periodically samples the connection and HTTP
+// statistics of this server, until the server exits.
+func startMetricsHistory() {
+	ticker := time.NewTicker(metricsHistoryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			globalMetricsHistory.add(metricsSample{
+				Time:      UTCNow(),
+				ConnStats: globalConnStats.toServerConnStats(),
+				HTTPStats: globalHTTPStats.toServerHTTPStats(),
+			})
+		case <-globalServiceDoneCh:
+			return
+		}
+	}
+}
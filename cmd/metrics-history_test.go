@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseMetricsHistoryRetention(t *testing.T) {
+	testCases := []struct {
+		value             string
+		expectedRetention time.Duration
+		expectErr         bool
+	}{
+		{"1h", time.Hour, false},
+		{"10s", 10 * time.Second, false},
+		{"24h", 24 * time.Hour, false},
+		{"5s", 0, true},
+		{"25h", 0, true},
+		{"-1h", 0, true},
+		{"hour", 0, true},
+	}
+
+	for i, testCase := range testCases {
+		retention, err := parseMetricsHistoryRetention(testCase.value)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("Test %d: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if retention != testCase.expectedRetention {
+			t.Errorf("Test %d: expected: %v, got: %v", i+1, testCase.expectedRetention, retention)
+		}
+	}
+}
+
+func TestMetricsHistory(t *testing.T) {
+	h := newMetricsHistory(time.Minute)
+	size := int(time.Minute / metricsHistoryInterval)
+	start := UTCNow()
+	for i := 0; i < size+4; i++ {
+		h.add(metricsSample{Time: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	samples := h.getSamples(time.Time{}, time.Time{})
+	if len(samples) != size {
+		t.Fatalf("Expected %d samples, got %d", size, len(samples))
+	}
+	for i, sample := range samples {
+		if expected := start.Add(time.Duration(i+4) * time.Second); !sample.Time.Equal(expected) {
+			t.Fatalf("Sample %d: expected %v, got %v", i, expected, sample.Time)
+		}
+	}
+
+	samples = h.getSamples(start.Add(5*time.Second), start.Add(6*time.Second))
+	if len(samples) != 2 {
+		t.Errorf("Expected 2 samples in range, got %d", len(samples))
+	}
+
+	// Nothing is kept without retention.
+	h = newMetricsHistory(0)
+	h.add(metricsSample{Time: start})
+	if samples = h.getSamples(time.Time{}, time.Time{}); len(samples) != 0 {
+		t.Errorf("Expected no samples, got %d", len(samples))
+	}
+}
+
+func TestExtractMetricsHistoryParams(t *testing.T) {
+	testCases := []struct {
+		from, to    string
+		expectedErr APIErrorCode
+	}{
+		{"", "", ErrNone},
+		{"2018-08-01T10:00:00Z", "", ErrNone},
+		{"2018-08-01T10:00:00Z", "2018-08-01T11:00:00Z", ErrNone},
+		{"2018-08-01T11:00:00Z", "2018-08-01T10:00:00Z", ErrAdminInvalidArgument},
+		{"1h", "", ErrAdminInvalidArgument},
+		{"", "yesterday", ErrAdminInvalidArgument},
+	}
+
+	for i, testCase := range testCases {
+		qParms := url.Values{}
+		if testCase.from != "" {
+			qParms.Set(string(mgmtFrom), testCase.from)
+		}
+		if testCase.to != "" {
+			qParms.Set(string(mgmtTo), testCase.to)
+		}
+		if _, _, apiErr := extractMetricsHistoryParams(qParms); apiErr != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, apiErr)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// Environment variable setting the deployment label of the
+	// Prometheus metrics, so that the metrics of several deployments
+	// scraped by the same Prometheus can be told apart.
+	prometheusDeploymentEnv = "MINIO_PROMETHEUS_DEPLOYMENT"
+
+	// Labels added to all Prometheus metrics.
+	metricsNodeLabel       = "node"
+	metricsRegionLabel     = "region"
+	metricsDeploymentLabel = "deployment"
+)
+
+// Deployment label of the Prometheus metrics, none by default.
+var globalPrometheusDeployment string
+
+// parsePrometheusDeployment - validates the deployment label of the
+// Prometheus metrics.
+func parsePrometheusDeployment(s string) (string, error) {
+	if !utf8.ValidString(s) {
+		return "", errors.New("deployment label must be valid UTF-8")
+	}
+	return s, nil
+}
+
+// getMetricsLabels - returns the labels identifying this server added
+// to all Prometheus metrics: the address of the node, the region and
+// the deployment, the empty ones being left out.
+func getMetricsLabels() map[string]string {
+	labels := map[string]string{
+		metricsNodeLabel: GetLocalPeer(globalEndpoints),
+	}
+	if region := globalServerConfig.GetRegion(); region != "" {
+		labels[metricsRegionLabel] = region
+	}
+	if globalPrometheusDeployment != "" {
+		labels[metricsDeploymentLabel] = globalPrometheusDeployment
+	}
+	return labels
+}
+
+// labeledGatherer - gathers the metrics of a gatherer and adds labels
+// to each of them, metrics already carrying a label keep their value.
+type labeledGatherer struct {
+	gatherer prometheus.Gatherer
+	labels   func() map[string]string
+}
+
+// Gather implements prometheus.Gatherer.
+func (g labeledGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	labels := g.labels()
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			addMetricLabels(metric, labels)
+		}
+	}
+	return families, err
+}
+
+// addMetricLabels - adds the labels the metric does not carry yet,
+// keeping its labels sorted by name as the exposition expects.
+func addMetricLabels(metric *dto.Metric, labels map[string]string) {
+	present := make(map[string]struct{}, len(metric.Label))
+	for _, pair := range metric.Label {
+		present[pair.GetName()] = struct{}{}
+	}
+	for name, value := range labels {
+		if _, ok := present[name]; ok {
+			continue
+		}
+		metric.Label = append(metric.Label, &dto.LabelPair{
+			Name:  proto.String(name),
+			Value: proto.String(value),
+		})
+	}
+	sort.Slice(metric.Label, func(i, j int) bool {
+		return metric.Label[i].GetName() < metric.Label[j].GetName()
+	})
+}
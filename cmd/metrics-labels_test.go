@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestParsePrometheusDeployment(t *testing.T) {
+	if label, err := parsePrometheusDeployment("eu-prod"); err != nil || label != "eu-prod" {
+		t.Fatalf("Expected `eu-prod`, got `%s`, %v", label, err)
+	}
+	if _, err := parsePrometheusDeployment("\xff"); err == nil {
+		t.Fatal("Expected an error for an invalid UTF-8 label")
+	}
+}
+
+func TestLabeledGatherer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "minio_test_total",
+		Help: "Test counter",
+	}, []string{"api", "region"})
+	registry.MustRegister(counter)
+	counter.WithLabelValues("GetObject", "eu-west-1").Inc()
+
+	gatherer := labeledGatherer{registry, func() map[string]string {
+		return map[string]string{
+			"node":       "server1:9000",
+			"region":     "us-east-1",
+			"deployment": "eu-prod",
+		}
+	}}
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || len(families[0].Metric) != 1 {
+		t.Fatalf("Expected a single metric, got %v", families)
+	}
+
+	labels := make(map[string]string)
+	var names []string
+	for _, pair := range families[0].Metric[0].Label {
+		labels[pair.GetName()] = pair.GetValue()
+		names = append(names, pair.GetName())
+	}
+	// The region label of the metric is kept.
+	expected := map[string]string{
+		"api":        "GetObject",
+		"deployment": "eu-prod",
+		"node":       "server1:9000",
+		"region":     "eu-west-1",
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Fatalf("Expected labels %v, got %v", expected, labels)
+	}
+	if expectedNames := []string{"api", "deployment", "node", "region"}; !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("Expected labels sorted as %v, got %v", expectedNames, names)
+	}
+}
@@ -84,6 +84,41 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		float64(globalConnStats.getTotalInputBytes()),
 	)
 
+	// Compressed GET responses and their sizes before and after
+	// compression, the network stats counting the latter.
+	compressionStats := globalResponseCompression.stats()
+	var savedBytes uint64
+	if compressionStats.BytesIn > compressionStats.BytesOut {
+		savedBytes = compressionStats.BytesIn - compressionStats.BytesOut
+	}
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("minio", "compression", "responses_total"),
+			"Total number of GET responses compressed by current Minio server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(compressionStats.Compressed),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("minio", "compression", "saved_bytes_total"),
+			"Total number of bytes saved by compressing GET responses on current Minio server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(savedBytes),
+	)
+
+	// Undelivered events dropped instead of being queued for a
+	// replay.
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("minio", "notify", "replay_dropped_events_total"),
+			"Total number of undelivered events dropped instead of being queued for a replay by current Minio server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(globalUndeliveredEvents.getDropped()),
+	)
+
 	// Expose cache stats only if available
 	cacheObjLayer := newCacheObjectsFn()
 	if cacheObjLayer != nil {
@@ -174,10 +209,13 @@ func metricsHandler() http.Handler {
 		prometheus.DefaultGatherer,
 		registry,
 	}
+	// Label all metrics with the node, region and deployment so that
+	// a Prometheus scraping several servers can tell them apart.
+	gatherer := labeledGatherer{gatherers, getMetricsLabels}
 	// Delegate http serving to Prometheus client library, which will call collector.Collect.
 	return promhttp.InstrumentMetricHandler(
 		registry,
-		promhttp.HandlerFor(gatherers,
+		promhttp.HandlerFor(gatherer,
 			promhttp.HandlerOpts{
 				ErrorHandling: promhttp.ContinueOnError,
 			}),
@@ -0,0 +1,140 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Config section holding the minimum part size of multipart uploads.
+const configSectionMinPartSize = "minPartSize"
+
+// Upper bounds of the part size ranges parts are counted in, parts of
+// the last range are larger than all bounds.
+var partSizeBounds = []int64{
+	1 * humanize.MiByte,
+	5 * humanize.MiByte,
+	16 * humanize.MiByte,
+	64 * humanize.MiByte,
+	256 * humanize.MiByte,
+	1 * humanize.GiByte,
+}
+
+// validateMinPartSize - returns an error unless the minimum part size
+// is 0, for the default, or between the S3 minimum and maximum part
+// sizes.
+func validateMinPartSize(size int64) error {
+	if size != 0 && (size < globalMinPartSize || size > globalMaxPartSize) {
+		return fmt.Errorf("minimum part size %d must be between %d and %d", size, int64(globalMinPartSize), int64(globalMaxPartSize))
+	}
+	return nil
+}
+
+// multipartPolicy - minimum size of the parts of multipart uploads,
+// apart from the last one, and the sizes of the parts uploaded to
+// this server.
+type multipartPolicy struct {
+	sync.Mutex
+
+	// configured minimum part size, 0 for the default
+	minPartSize int64
+
+	// number of parts uploaded per part size range
+	parts []uint64
+	bytes uint64
+
+	// number of completions rejected since a part was too small
+	rejected uint64
+}
+
+// Multipart upload policy loaded from config.json.
+var globalMultipartPolicy = newMultipartPolicy()
+
+// Prepare new multipartPolicy structure, with the default minimum
+// part size.
+func newMultipartPolicy() *multipartPolicy {
+	return &multipartPolicy{parts: make([]uint64, len(partSizeBounds)+1)}
+}
+
+// setMinPartSize - replaces the minimum part size, 0 restores the
+// default.
+func (p *multipartPolicy) setMinPartSize(size int64) {
+	p.Lock()
+	defer p.Unlock()
+	p.minPartSize = size
+}
+
+// getMinPartSize - returns the minimum size of the parts of multipart
+// uploads apart from the last one.
+func (p *multipartPolicy) getMinPartSize() int64 {
+	p.Lock()
+	defer p.Unlock()
+	if p.minPartSize == 0 {
+		return globalMinPartSize
+	}
+	return p.minPartSize
+}
+
+// recordPart - counts an uploaded part of the given size.
+func (p *multipartPolicy) recordPart(size int64) {
+	i := 0
+	for i < len(partSizeBounds) && size >= partSizeBounds[i] {
+		i++
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.parts[i]++
+	p.bytes += uint64(size)
+}
+
+// recordRejected - counts a completion rejected since a part was too
+// small.
+func (p *multipartPolicy) recordRejected() {
+	p.Lock()
+	defer p.Unlock()
+	p.rejected++
+}
+
+// stats - returns the number of parts uploaded to this server per part
+// size range.
+func (p *multipartPolicy) stats() madmin.PartSizeStats {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := madmin.PartSizeStats{
+		Bytes:           p.bytes,
+		RejectedUploads: p.rejected,
+		PartsBySize:     make([]madmin.PartSizeRange, len(p.parts)),
+	}
+	for i, count := range p.parts {
+		r := &stats.PartsBySize[i]
+		if i > 0 {
+			r.Min = partSizeBounds[i-1]
+		}
+		if i < len(partSizeBounds) {
+			r.Max = partSizeBounds[i]
+		}
+		r.Parts = count
+		stats.Parts += count
+	}
+	return stats
+}
@@ -0,0 +1,128 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestValidateMinPartSize(t *testing.T) {
+	testCases := []struct {
+		size      int64
+		expectErr bool
+	}{
+		{0, false},
+		{globalMinPartSize, false},
+		{64 * humanize.MiByte, false},
+		{globalMaxPartSize, false},
+		{humanize.MiByte, true},
+		{-1, true},
+		{globalMaxPartSize + 1, true},
+	}
+	for i, testCase := range testCases {
+		if err := validateMinPartSize(testCase.size); (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: Expected error %v but got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestMultipartPolicyStats(t *testing.T) {
+	p := newMultipartPolicy()
+	for _, size := range []int64{0, humanize.MiByte - 1, humanize.MiByte, 5 * humanize.MiByte, 2 * humanize.GiByte} {
+		p.recordPart(size)
+	}
+	p.recordRejected()
+
+	stats := p.stats()
+	expected := []madmin.PartSizeRange{
+		{Min: 0, Max: humanize.MiByte, Parts: 2},
+		{Min: humanize.MiByte, Max: 5 * humanize.MiByte, Parts: 1},
+		{Min: 5 * humanize.MiByte, Max: 16 * humanize.MiByte, Parts: 1},
+		{Min: 16 * humanize.MiByte, Max: 64 * humanize.MiByte},
+		{Min: 64 * humanize.MiByte, Max: 256 * humanize.MiByte},
+		{Min: 256 * humanize.MiByte, Max: humanize.GiByte},
+		{Min: humanize.GiByte, Parts: 1},
+	}
+	if len(stats.PartsBySize) != len(expected) {
+		t.Fatalf("Expected %d part size ranges but got %d", len(expected), len(stats.PartsBySize))
+	}
+	for i := range expected {
+		if stats.PartsBySize[i] != expected[i] {
+			t.Errorf("Range %d: Expected %#v but got %#v", i+1, expected[i], stats.PartsBySize[i])
+		}
+	}
+	if stats.Parts != 5 || stats.RejectedUploads != 1 {
+		t.Errorf("Unexpected part size stats %#v", stats)
+	}
+	if stats.Bytes != uint64(2*humanize.MiByte-1+5*humanize.MiByte+2*humanize.GiByte) {
+		t.Errorf("Unexpected bytes uploaded %d", stats.Bytes)
+	}
+}
+
+// Test that completing a multipart upload fails once a part other than
+// the last is smaller than the configured minimum part size.
+func TestMultipartPolicyMinPartSize(t *testing.T) {
+	tmpGlobalMultipartPolicy := globalMultipartPolicy
+	defer func() {
+		globalMultipartPolicy = tmpGlobalMultipartPolicy
+	}()
+	globalMultipartPolicy = newMultipartPolicy()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	upload := func() error {
+		uploadID, err := objLayer.NewMultipartUpload(ctx, bucket, object, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := bytes.Repeat([]byte("a"), 6*humanize.MiByte)
+		var parts []CompletePart
+		for partID := 1; partID <= 2; partID++ {
+			info, err := objLayer.PutObjectPart(ctx, bucket, object, uploadID, partID, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			parts = append(parts, CompletePart{PartNumber: partID, ETag: info.ETag})
+		}
+		_, err = objLayer.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts)
+		return err
+	}
+
+	if err = upload(); err != nil {
+		t.Fatalf("Expected 6MiB parts to be accepted by default but got %v", err)
+	}
+
+	globalMultipartPolicy.setMinPartSize(16 * humanize.MiByte)
+	if _, ok := upload().(PartTooSmall); !ok {
+		t.Fatal("Expected 6MiB parts to be rejected with a 16MiB minimum part size")
+	}
+}
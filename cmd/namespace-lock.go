@@ -287,6 +287,8 @@ func (li *lockInstance) GetLock(timeout *dynamicTimeout) (timedOutErr error) {
 	readLock := false
 	if !li.ns.lock(li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout()) {
 		timeout.LogFailure()
+		logger.Debug(context.Background(), logger.LockSubsystem, "write lock on %s/%s requested at %s timed out",
+			li.volume, li.path, lockSource)
 		return OperationTimedOut{Path: li.path}
 	}
 	timeout.LogSuccess(UTCNow().Sub(start))
@@ -306,6 +308,8 @@ func (li *lockInstance) GetRLock(timeout *dynamicTimeout) (timedOutErr error) {
 	readLock := true
 	if !li.ns.lock(li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout()) {
 		timeout.LogFailure()
+		logger.Debug(context.Background(), logger.LockSubsystem, "read lock on %s/%s requested at %s timed out",
+			li.volume, li.path, lockSource)
 		return OperationTimedOut{Path: li.path}
 	}
 	timeout.LogSuccess(UTCNow().Sub(start))
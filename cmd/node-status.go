@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// endpointError - last error observed on an endpoint, along with the
+// time it has been failing since.
+type endpointError struct {
+	reason string
+	err    string
+	time   time.Time
+	since  time.Time
+}
+
+// endpointErrors - last error observed on each failing endpoint, keyed
+// by the endpoint as returned by its String method. An endpoint is
+// removed once it is seen online again.
+type endpointErrors struct {
+	sync.Mutex
+	errs map[string]endpointError
+}
+
+// Errors observed on the endpoints by this server.
+var globalEndpointErrors = newEndpointErrors()
+
+// Prepare new endpointErrors structure
+func newEndpointErrors() *endpointErrors {
+	return &endpointErrors{errs: make(map[string]endpointError)}
+}
+
+// record - records an error observed on an endpoint for the given
+// reason.
+func (e *endpointErrors) record(endpoint, reason string, err error) {
+	e.Lock()
+	defer e.Unlock()
+	now := UTCNow()
+	since := now
+	if prev, ok := e.errs[endpoint]; ok {
+		since = prev.since
+	}
+	e.errs[endpoint] = endpointError{reason: reason, err: err.Error(), time: now, since: since}
+}
+
+// clear - records that an endpoint is online again.
+func (e *endpointErrors) clear(endpoint string) {
+	e.Lock()
+	defer e.Unlock()
+	delete(e.errs, endpoint)
+}
+
+// get - returns the last error observed on an endpoint, if any.
+func (e *endpointErrors) get(endpoint string) (endpointError, bool) {
+	e.Lock()
+	defer e.Unlock()
+	err, ok := e.errs[endpoint]
+	return err, ok
+}
+
+// offlineReason - returns why an endpoint failing with the given error
+// is offline.
+func offlineReason(endpoint Endpoint, err error) string {
+	switch {
+	case !endpoint.IsLocal && (err == errDiskNotFound || isNetworkDisconnectError(err)):
+		return madmin.OfflineReasonNetwork
+	case err == errUnformattedDisk || err == errCorruptedFormat:
+		return madmin.OfflineReasonFormat
+	case err == errDiskNotFound || err == errFaultyDisk || err == errFaultyRemoteDisk ||
+		err == errDiskAccessDenied || err == errDiskFull:
+		return madmin.OfflineReasonDiskIO
+	}
+	return madmin.OfflineReasonUnknown
+}
+
+// getDiskStatus - returns whether the disk of an endpoint is online,
+// probing it if connected, or else why it is offline.
+func getDiskStatus(endpoint Endpoint, disk StorageAPI) madmin.DiskStatus {
+	key := endpoint.String()
+	status := madmin.DiskStatus{Endpoint: key}
+	if disk != nil && disk.IsOnline() {
+		_, err := disk.DiskInfo()
+		if err == nil {
+			globalEndpointErrors.clear(key)
+			status.Online = true
+			return status
+		}
+		globalEndpointErrors.record(key, offlineReason(endpoint, err), err)
+	}
+
+	status.Reason = madmin.OfflineReasonUnknown
+	if err, ok := globalEndpointErrors.get(key); ok {
+		status.Reason = err.reason
+		status.LastError = err.err
+		status.LastErrorTime = err.time
+		status.OfflineSince = err.since
+	}
+	return status
+}
+
+// getNodeStatus - returns whether the given node answers admin RPCs
+// and whether each of its disks is online as seen by this server,
+// with the reason and last error of the offline ones.
+func getNodeStatus(s *xlSets, peer adminPeer) madmin.NodeStatus {
+	status := madmin.NodeStatus{Node: peer.addr, Online: true, Disks: []madmin.DiskStatus{}}
+	if err := peer.cmdRunner.NetPerfPing(nil); err != nil {
+		status.Online = false
+		status.Reason = madmin.OfflineReasonNetwork
+		status.LastError = err.Error()
+		status.LastErrorTime = UTCNow()
+	}
+
+	for _, endpoint := range s.endpoints {
+		if !isEndpointOfPeer(peer, endpoint) {
+			continue
+		}
+		status.Disks = append(status.Disks, getDiskStatus(endpoint, s.getEndpointDisk(endpoint)))
+	}
+	return status
+}
@@ -0,0 +1,112 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestOfflineReason(t *testing.T) {
+	local := Endpoint{IsLocal: true}
+	remote := Endpoint{IsLocal: false}
+	testCases := []struct {
+		endpoint Endpoint
+		err      error
+		reason   string
+	}{
+		{remote, errDiskNotFound, madmin.OfflineReasonNetwork},
+		{remote, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, madmin.OfflineReasonNetwork},
+		{local, errDiskNotFound, madmin.OfflineReasonDiskIO},
+		{remote, errFaultyRemoteDisk, madmin.OfflineReasonDiskIO},
+		{local, errFaultyDisk, madmin.OfflineReasonDiskIO},
+		{local, errUnformattedDisk, madmin.OfflineReasonFormat},
+		{remote, errCorruptedFormat, madmin.OfflineReasonFormat},
+		{local, errors.New("unexpected"), madmin.OfflineReasonUnknown},
+	}
+	for i, testCase := range testCases {
+		if reason := offlineReason(testCase.endpoint, testCase.err); reason != testCase.reason {
+			t.Errorf("Test %d: Expected reason %s, got %s", i+1, testCase.reason, reason)
+		}
+	}
+}
+
+// Tests that an endpoint failing repeatedly keeps the time it has been
+// failing since, until it is online again.
+func TestEndpointErrors(t *testing.T) {
+	errs := newEndpointErrors()
+	errs.record("http://server2:9000/data1", madmin.OfflineReasonNetwork, errDiskNotFound)
+	first, ok := errs.get("http://server2:9000/data1")
+	if !ok || first.reason != madmin.OfflineReasonNetwork || first.err != errDiskNotFound.Error() {
+		t.Fatalf("Unexpected endpoint error %v", first)
+	}
+
+	errs.record("http://server2:9000/data1", madmin.OfflineReasonFormat, errUnformattedDisk)
+	last, _ := errs.get("http://server2:9000/data1")
+	if last.reason != madmin.OfflineReasonFormat || last.err != errUnformattedDisk.Error() ||
+		!last.since.Equal(first.since) || last.time.Before(first.time) {
+		t.Errorf("Unexpected endpoint error %v after %v", last, first)
+	}
+
+	errs.clear("http://server2:9000/data1")
+	if _, ok = errs.get("http://server2:9000/data1"); ok {
+		t.Error("Expected no error once the endpoint is online")
+	}
+}
+
+// Tests that disks failing when probed and unreachable nodes are
+// reported offline with their reason.
+func TestGetNodeStatus(t *testing.T) {
+	objLayer, fsDirs, err := initTestXLObjLayer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	s := objLayer.(*xlSets)
+
+	peer := adminPeer{addr: "server1", cmdRunner: localAdminClient{}, isLocal: true}
+	status := getNodeStatus(s, peer)
+	if !status.Online || len(status.Disks) != len(fsDirs) {
+		t.Fatalf("Unexpected node status %v", status)
+	}
+	for _, disk := range status.Disks {
+		if !disk.Online || disk.Reason != "" {
+			t.Fatalf("Expected disk to be online, got %v", disk)
+		}
+	}
+
+	os.RemoveAll(fsDirs[0])
+	defer globalEndpointErrors.clear(s.endpoints[0].String())
+	status = getNodeStatus(s, peer)
+	disk := status.Disks[0]
+	if disk.Online || disk.Reason != madmin.OfflineReasonDiskIO || disk.LastError == "" || disk.OfflineSince.IsZero() {
+		t.Errorf("Expected disk to be offline with a disk I/O error, got %v", disk)
+	}
+	if !status.Disks[1].Online {
+		t.Errorf("Expected disk to be online, got %v", status.Disks[1])
+	}
+
+	peer = adminPeer{addr: "server2:9000", cmdRunner: &netperfTestRunner{err: errors.New("unreachable")}}
+	status = getNodeStatus(s, peer)
+	if status.Online || status.Reason != madmin.OfflineReasonNetwork || status.LastError != "unreachable" || len(status.Disks) != 0 {
+		t.Errorf("Expected unreachable node, got %v", status)
+	}
+}
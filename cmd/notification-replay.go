@@ -0,0 +1,194 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sync/atomic"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+)
+
+const (
+	// Prefix under minioMetaBucket where undelivered events are queued.
+	notifyReplayPrefix = "notify/replay"
+
+	// Notification replay environment variable.
+	notifyReplayEnv = "MINIO_NOTIFY_REPLAY"
+
+	// Maximum number of undelivered events waiting to be queued,
+	// further events are dropped.
+	notifyReplayBacklog = 10000
+)
+
+var errNotifyReplayBacklogFull = errors.New("too many undelivered events waiting to be queued, dropping events")
+
+// queuedEvent - an event which could not be delivered to its target,
+// persisted until it is replayed.
+type queuedEvent struct {
+	TargetID event.TargetID `json:"targetID"`
+	Event    event.Event    `json:"event"`
+}
+
+// queueEvent - persists an undelivered event for a later replay. Queued
+// events are named after the time they were queued so that listing
+// them replays events in order.
+func queueEvent(objAPI ObjectLayer, targetID event.TargetID, eventData event.Event) error {
+	data, err := json.Marshal(queuedEvent{TargetID: targetID, Event: eventData})
+	if err != nil {
+		return err
+	}
+
+	eventFile := path.Join(notifyReplayPrefix, fmt.Sprintf("%020d-%s.json", UTCNow().UnixNano(), mustGetUUID()))
+	return saveConfig(objAPI, eventFile, data)
+}
+
+// undeliveredEvent - an event which could not be delivered to its
+// target, waiting to be queued.
+type undeliveredEvent struct {
+	bucketName string
+	targetID   event.TargetID
+	eventData  event.Event
+}
+
+// undeliveredEvents - undelivered events handed to a background writer
+// which queues them, so that sending events never waits on the disks.
+type undeliveredEvents struct {
+	backlog chan undeliveredEvent
+
+	// number of events dropped as the backlog was full
+	dropped uint64
+}
+
+// newUndeliveredEvents - returns a backlog of at most size undelivered
+// events.
+func newUndeliveredEvents(size int) *undeliveredEvents {
+	return &undeliveredEvents{backlog: make(chan undeliveredEvent, size)}
+}
+
+// add - hands an event to the background writer, the event is dropped
+// and counted if the backlog is full.
+func (u *undeliveredEvents) add(e undeliveredEvent) bool {
+	select {
+	case u.backlog <- e:
+		return true
+	default:
+		atomic.AddUint64(&u.dropped, 1)
+		reqInfo := (&logger.ReqInfo{BucketName: e.bucketName}).AppendTags("targetID", e.targetID.String())
+		logger.LogOnceIf(logger.SetReqInfo(context.Background(), reqInfo), errNotifyReplayBacklogFull, errNotifyReplayBacklogFull)
+		return false
+	}
+}
+
+// getDropped - returns the number of events dropped as the backlog was
+// full.
+func (u *undeliveredEvents) getDropped() uint64 {
+	return atomic.LoadUint64(&u.dropped)
+}
+
+// write - queues the events of the backlog one after the other, until
+// the server exits.
+func (u *undeliveredEvents) write(objAPI ObjectLayer) {
+	for e := range u.backlog {
+		if err := queueEvent(objAPI, e.targetID, e.eventData); err != nil {
+			reqInfo := (&logger.ReqInfo{BucketName: e.bucketName}).AppendTags("targetID", e.targetID.String())
+			logger.LogIf(logger.SetReqInfo(context.Background(), reqInfo), err)
+		}
+	}
+}
+
+// queueUndeliveredEvents - hands the event of every given target which
+// failed to receive it to the background writer, when notification
+// replay is enabled.
+func (sys *NotificationSys) queueUndeliveredEvents(bucketName string, eventData event.Event, targetIDs ...event.TargetID) {
+	if !globalNotifyReplayEnabled {
+		return
+	}
+
+	for _, targetID := range targetIDs {
+		globalUndeliveredEvents.add(undeliveredEvent{
+			bucketName: bucketName,
+			targetID:   targetID,
+			eventData:  eventData,
+		})
+	}
+}
+
+// ReplayEvents - redrives queued events to their targets. Events
+// successfully delivered are removed from the queue, others are kept
+// for a later replay.
+func (sys *NotificationSys) ReplayEvents(ctx context.Context, objAPI ObjectLayer) (replayed, failed int, err error) {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, notifyReplayPrefix+slashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return replayed, failed, err
+		}
+
+		for _, obj := range result.Objects {
+			if sys.replayEvent(ctx, objAPI, obj.Name) {
+				replayed++
+			} else {
+				failed++
+			}
+		}
+
+		if !result.IsTruncated {
+			return replayed, failed, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// replayEvent - sends a single queued event, returns true if it was
+// delivered and removed from the queue.
+func (sys *NotificationSys) replayEvent(ctx context.Context, objAPI ObjectLayer, eventFile string) bool {
+	buffer, err := readConfig(ctx, objAPI, eventFile)
+	if err != nil {
+		return false
+	}
+
+	var qe queuedEvent
+	if err = json.Unmarshal(buffer.Bytes(), &qe); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("eventFile", eventFile)
+		logger.LogIf(ctx, err)
+		return false
+	}
+
+	// Keep events of targets which are not configured anymore, they
+	// are replayed if the target is configured back.
+	if !sys.targetList.Exists(qe.TargetID) {
+		return false
+	}
+
+	for terr := range sys.targetList.Send(qe.Event, qe.TargetID) {
+		if terr.Err != nil {
+			return false
+		}
+	}
+
+	if err = objAPI.DeleteObject(ctx, minioMetaBucket, eventFile); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("eventFile", eventFile)
+		logger.LogIf(ctx, err)
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+type replayTestTarget struct {
+	id      event.TargetID
+	sendErr bool
+	sent    int
+}
+
+func (target *replayTestTarget) ID() event.TargetID {
+	return target.id
+}
+
+func (target *replayTestTarget) Send(eventData event.Event) error {
+	if target.sendErr {
+		return errors.New("send error")
+	}
+	target.sent++
+	return nil
+}
+
+func (target *replayTestTarget) Close() error {
+	return nil
+}
+
+func TestNotificationReplayEvents(t *testing.T) {
+	objAPI, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	target := &replayTestTarget{id: event.TargetID{ID: "1", Name: "webhook"}, sendErr: true}
+	sys := &NotificationSys{targetList: event.NewTargetList()}
+	if err = sys.targetList.Add(target); err != nil {
+		t.Fatal(err)
+	}
+
+	eventData := event.Event{EventName: event.ObjectCreatedPut}
+	for i := 0; i < 3; i++ {
+		if err = queueEvent(objAPI, target.id, eventData); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Events of unknown targets are kept queued.
+	if err = queueEvent(objAPI, event.TargetID{ID: "2", Name: "amqp"}, eventData); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		sendErr          bool
+		expectedReplayed int
+		expectedFailed   int
+	}{
+		{true, 0, 4},
+		{false, 3, 1},
+		{false, 0, 1},
+	}
+	for i, testCase := range testCases {
+		target.sendErr = testCase.sendErr
+		replayed, failed, err := sys.ReplayEvents(context.Background(), objAPI)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if replayed != testCase.expectedReplayed || failed != testCase.expectedFailed {
+			t.Errorf("Test %d: Expected %d replayed, %d failed but got %d, %d", i+1,
+				testCase.expectedReplayed, testCase.expectedFailed, replayed, failed)
+		}
+	}
+	if target.sent != 3 {
+		t.Errorf("Expected 3 events to be sent, got %d", target.sent)
+	}
+}
+
+func TestUndeliveredEvents(t *testing.T) {
+	objAPI, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	u := newUndeliveredEvents(2)
+	e := undeliveredEvent{
+		bucketName: "bucket",
+		targetID:   event.TargetID{ID: "1", Name: "webhook"},
+		eventData:  event.Event{EventName: event.ObjectCreatedPut},
+	}
+	// Events beyond the backlog are dropped and counted.
+	for i, expected := range []bool{true, true, false} {
+		if added := u.add(e); added != expected {
+			t.Fatalf("Test %d: Expected event added %v, got %v", i+1, expected, added)
+		}
+	}
+	if dropped := u.getDropped(); dropped != 1 {
+		t.Fatalf("Expected 1 dropped event, got %d", dropped)
+	}
+
+	// The background writer queues the events of the backlog.
+	close(u.backlog)
+	u.write(objAPI)
+	result, err := objAPI.ListObjects(context.Background(), minioMetaBucket, notifyReplayPrefix+slashSeparator, "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 2 {
+		t.Errorf("Expected 2 queued events, got %d", len(result.Objects))
+	}
+}
@@ -23,12 +23,15 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 	"github.com/minio/minio/pkg/policy"
 )
@@ -59,6 +62,45 @@ func (sys *NotificationSys) GetARNList() []string {
 	return arns
 }
 
+// targetIDForARN - returns the ID of the target with the given ARN, as
+// listed by GetARNList.
+func (sys *NotificationSys) targetIDForARN(arn string) (event.TargetID, bool) {
+	region := globalServerConfig.GetRegion()
+	for _, targetID := range sys.targetList.List() {
+		if !strings.HasPrefix(targetID.ID, "httpclient+") && targetID.ToARN(region).String() == arn {
+			return targetID, true
+		}
+	}
+	return event.TargetID{}, false
+}
+
+// NotifyQueues - returns the backlog of events being sent to each
+// target listed by GetARNList, sorted by ARN.
+func (sys *NotificationSys) NotifyQueues() []madmin.NotifyQueue {
+	queues := []madmin.NotifyQueue{}
+	region := globalServerConfig.GetRegion()
+	now := UTCNow()
+	for targetID, stats := range sys.targetList.Stats() {
+		if strings.HasPrefix(targetID.ID, "httpclient+") {
+			continue
+		}
+		queue := madmin.NotifyQueue{
+			ARN:       targetID.ToARN(region).String(),
+			Queued:    stats.Queued,
+			ErrorRate: stats.ErrorRate,
+		}
+		if !stats.OldestQueued.IsZero() {
+			queue.OldestEventAge = now.Sub(stats.OldestQueued)
+		}
+		queues = append(queues, queue)
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].ARN < queues[j].ARN
+	})
+
+	return queues
+}
+
 // GetPeerRPCClient - returns PeerRPCClient of addr.
 func (sys *NotificationSys) GetPeerRPCClient(addr xnet.Host) *PeerRPCClient {
 	return sys.peerRPCClientMap[addr]
@@ -90,24 +132,50 @@ func (sys *NotificationSys) DeleteBucket(ctx context.Context, bucketName string)
 
 // LoadCredentials - calls LoadCredentials RPC call on all peers.
 func (sys *NotificationSys) LoadCredentials() map[xnet.Host]error {
+	return sys.loadCredentials(nil, (*PeerRPCClient).LoadCredentials)
+}
+
+// LoadCredentialsWith - calls LoadCredentials RPC call on all peers but
+// the skipped ones, authenticated with the given credential which the
+// peers run with, so that the call is accepted whichever credential
+// this server runs with.
+func (sys *NotificationSys) LoadCredentialsWith(cred auth.Credentials, skip map[xnet.Host]error) map[xnet.Host]error {
+	return sys.loadCredentials(skip, func(client *PeerRPCClient) error {
+		return client.LoadCredentialsWith(cred)
+	})
+}
+
+// loadCredentials - makes all peers but the skipped ones load the
+// credentials with the given call.
+func (sys *NotificationSys) loadCredentials(skip map[xnet.Host]error, loadCredentials func(*PeerRPCClient) error) map[xnet.Host]error {
 	errors := make(map[xnet.Host]error)
+	var errorsMu sync.Mutex
 	var wg sync.WaitGroup
 	for addr, client := range sys.peerRPCClientMap {
+		if _, ok := skip[addr]; ok {
+			continue
+		}
 		wg.Add(1)
 		go func(addr xnet.Host, client *PeerRPCClient) {
 			defer wg.Done()
 			// Try to set credentials in three attempts.
+			var err error
 			for i := 0; i < 3; i++ {
-				err := client.LoadCredentials()
+				err = loadCredentials(client)
 				if err == nil {
 					break
 				}
-				errors[addr] = err
 				// Wait for one second and no need wait after last attempt.
 				if i < 2 {
 					time.Sleep(1 * time.Second)
 				}
 			}
+			// Only report peers which failed all attempts.
+			if err != nil {
+				errorsMu.Lock()
+				errors[addr] = err
+				errorsMu.Unlock()
+			}
 		}(addr, client)
 	}
 	wg.Wait()
@@ -417,13 +485,17 @@ func (sys *NotificationSys) RemoveRemoteTarget(bucketName string, targetID event
 }
 
 func (sys *NotificationSys) send(bucketName string, eventData event.Event, targetIDs ...event.TargetID) (errs []event.TargetIDErr) {
+	var undelivered []event.TargetID
 	errCh := sys.targetList.Send(eventData, targetIDs...)
 	for terr := range errCh {
 		errs = append(errs, terr)
 		if sys.RemoteTargetExist(bucketName, terr.ID) {
 			sys.RemoveRemoteTarget(bucketName, terr.ID)
+		} else {
+			undelivered = append(undelivered, terr.ID)
 		}
 	}
+	sys.queueUndeliveredEvents(bucketName, eventData, undelivered...)
 
 	return errs
 }
@@ -438,6 +510,9 @@ func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
 	}
 
 	targetIDs := targetIDSet.ToSlice()
+	reqInfo := &logger.ReqInfo{BucketName: args.BucketName, ObjectName: args.Object.Name}
+	logger.Debug(logger.SetReqInfo(context.Background(), reqInfo), logger.NotifySubsystem,
+		"sending event %s to targets %v", args.EventName, targetIDs)
 	return sys.send(args.BucketName, args.ToEvent(), targetIDs...)
 }
 
@@ -466,18 +541,20 @@ type eventArgs struct {
 	UserAgent    string
 }
 
-// ToEvent - converts to notification event.
-func (args eventArgs) ToEvent() event.Event {
-	getOriginEndpoint := func() string {
-		host := globalMinioHost
-		if host == "" {
-			// FIXME: Send FQDN or hostname of this machine than sending IP address.
-			host = localIP4.ToSlice()[0]
-		}
-
-		return fmt.Sprintf("%s://%s:%s", getURLScheme(globalIsSSL), host, globalMinioPort)
+// getOriginEndpoint - returns the endpoint of this server sent in
+// notification events.
+func getOriginEndpoint() string {
+	host := globalMinioHost
+	if host == "" {
+		// FIXME: Send FQDN or hostname of this machine than sending IP address.
+		host = localIP4.ToSlice()[0]
 	}
 
+	return fmt.Sprintf("%s://%s:%s", getURLScheme(globalIsSSL), host, globalMinioPort)
+}
+
+// ToEvent - converts to notification event.
+func (args eventArgs) ToEvent() event.Event {
 	creds := globalServerConfig.GetCredential()
 	eventTime := UTCNow()
 	uniqueID := fmt.Sprintf("%X", eventTime.UnixNano())
@@ -194,9 +194,11 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, objInfo ObjectIn
 
 	// If-None-Match : Return the object only if its entity tag (ETag) is different from the
 	// one specified otherwise, return a 304 (not modified).
+	// Compressed responses carry a weak ETag, which If-None-Match
+	// compares as the ETag of the object.
 	ifNoneMatchETagHeader := r.Header.Get("If-None-Match")
 	if ifNoneMatchETagHeader != "" {
-		if isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+		if isETagEqual(objInfo.ETag, strings.TrimPrefix(ifNoneMatchETagHeader, "W/")) {
 			// If the object ETag matches with the specified ETag.
 			writeHeaders()
 			w.WriteHeader(http.StatusNotModified)
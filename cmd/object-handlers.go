@@ -442,9 +442,22 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		w.WriteHeader(http.StatusPartialContent)
 	}
 
+	// Compress the object content if configured and accepted.
+	var body io.Writer = httpWriter
+	gzipWriter := globalResponseCompression.compressResponse(w, r, objInfo, rs, httpWriter)
+	if gzipWriter != nil {
+		body = gzipWriter
+	}
+
 	// Write object content to response body
-	if _, err = io.Copy(httpWriter, reader); err != nil {
+	if _, err = io.Copy(body, reader); err == nil && gzipWriter != nil {
+		err = gzipWriter.Close()
+	}
+	if err != nil {
 		if !httpWriter.HasWritten() && !statusCodeWritten { // write error response only if no data or headers has been written to client yet
+			if gzipWriter != nil {
+				w.Header().Del("Content-Encoding")
+			}
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		}
 		httpWriter.Close()
@@ -1057,6 +1070,11 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	if s3Err = checkTenantRateLimit(r, bucket, object); s3Err != ErrNone {
+		writeErrorResponse(w, s3Err, r.URL)
+		return
+	}
+
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
@@ -1408,6 +1426,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	globalMultipartPolicy.recordPart(partInfo.Size)
 
 	// Close the pipe after successful operation.
 	pipeReader.Close()
@@ -1542,6 +1561,11 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		}
 	}
 
+	if s3Error := checkTenantRateLimit(r, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
@@ -1618,6 +1642,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	globalMultipartPolicy.recordPart(partInfo.Size)
 	if partInfo.ETag != "" {
 		w.Header().Set("ETag", "\""+partInfo.ETag+"\"")
 	}
@@ -1769,6 +1794,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		switch oErr := err.(type) {
 		case PartTooSmall:
 			// Write part too small error.
+			globalMultipartPolicy.recordRejected()
 			writePartSmallErrorResponse(w, r, oErr)
 		default:
 			// Handle all other generic issues.
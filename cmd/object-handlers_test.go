@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/xml"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
@@ -465,6 +467,102 @@ func testAPIGetObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling GetObject API handler tests of compressed responses for both XL multiple disks and FS single drive setup.
+func TestAPIGetObjectCompressedHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectCompressedHandler, []string{"GetObject"})
+}
+
+func testAPIGetObjectCompressedHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	tmpGlobalResponseCompression := globalResponseCompression
+	defer func() {
+		globalResponseCompression = tmpGlobalResponseCompression
+	}()
+	globalResponseCompression = &responseCompression{}
+	globalResponseCompression.set(&compressionConfig{Enabled: true})
+
+	data := bytes.Repeat([]byte("minio"), 64*humanize.KiByte)
+	objects := map[string]string{
+		"text-object":   "text/plain",
+		"binary-object": "application/octet-stream",
+	}
+	for object, contentType := range objects {
+		_, err := obj.PutObject(context.Background(), bucketName, object,
+			mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), map[string]string{"content-type": contentType})
+		if err != nil {
+			t.Fatalf("%s: Error uploading object %s: <ERROR> %v", instanceType, object, err)
+		}
+	}
+
+	testCases := []struct {
+		objectName string
+		byteRange  string
+		compressed bool
+	}{
+		{"text-object", "", true},
+		{"text-object", "bytes=0-9", false},
+		{"binary-object", "", false},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Get Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("Accept-Encoding", "gzip")
+		if testCase.byteRange != "" {
+			req.Header.Set("Range", testCase.byteRange)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK && rec.Code != http.StatusPartialContent {
+			t.Fatalf("Test %d: %s: Unexpected response status %d", i+1, instanceType, rec.Code)
+		}
+
+		compressed := rec.Header().Get("Content-Encoding") == "gzip"
+		if compressed != testCase.compressed {
+			t.Fatalf("Test %d: %s: Expected compressed %v, got %v", i+1, instanceType, testCase.compressed, compressed)
+		}
+		if !compressed {
+			continue
+		}
+		if rec.Body.Len() >= len(data) {
+			t.Errorf("Test %d: %s: Expected a compressed body smaller than the object", i+1, instanceType)
+		}
+		gzipReader, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+		}
+		content, err := ioutil.ReadAll(gzipReader)
+		if err != nil {
+			t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+		}
+		if !bytes.Equal(content, data) {
+			t.Errorf("Test %d: %s: Decompressed content differs from the object", i+1, instanceType)
+		}
+
+		// The compressed response carries a weak ETag, which
+		// revalidates it.
+		etag := rec.Header().Get("ETag")
+		if !strings.HasPrefix(etag, `W/"`) {
+			t.Fatalf("Test %d: %s: Expected a weak ETag, got %s", i+1, instanceType, etag)
+		}
+		rec = httptest.NewRecorder()
+		req, err = newTestSignedRequestV4("GET", getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Get Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("If-None-Match", etag)
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("Test %d: %s: Expected status %d revalidating the weak ETag, got %d", i+1, instanceType, http.StatusNotModified, rec.Code)
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests using streaming signature v4 for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectStreamSigV4Handler(t *testing.T) {
 	defer DetectTestLeak(t)()
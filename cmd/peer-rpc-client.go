@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/event"
 	xnet "github.com/minio/minio/pkg/net"
 	"github.com/minio/minio/pkg/policy"
@@ -120,7 +121,21 @@ func (rpcClient *PeerRPCClient) LoadCredentials() error {
 	args := AuthArgs{}
 	reply := VoidReply{}
 
-	return rpcClient.Call(peerServiceName+".SetCredentials", &args, &reply)
+	return rpcClient.Call(peerServiceName+".LoadCredentials", &args, &reply)
+}
+
+// LoadCredentialsWith - calls load credentials RPC authenticated with
+// the given credential, the one the peer runs with.
+func (rpcClient *PeerRPCClient) LoadCredentialsWith(cred auth.Credentials) error {
+	authToken, err := newAuthTokenWith(cred)
+	if err != nil {
+		return err
+	}
+
+	args := AuthArgs{}
+	reply := VoidReply{}
+
+	return rpcClient.CallWithAuthToken(peerServiceName+".LoadCredentials", authToken, &args, &reply)
 }
 
 // NewPeerRPCClient - returns new peer RPC client.
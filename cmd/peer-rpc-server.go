@@ -188,5 +188,5 @@ func registerPeerRPCRouter(router *mux.Router) {
 	rpcServer, err := NewPeerRPCServer()
 	logger.FatalIf(err, "Unable to initialize peer RPC Server", context.Background())
 	subrouter := router.PathPrefix(minioReservedBucketPath).Subrouter()
-	subrouter.Path(peerServiceSubPath).HandlerFunc(httpTraceHdrs(rpcServer.ServeHTTP))
+	subrouter.Path(peerServiceSubPath).HandlerFunc(httpTraceHdrs(rejectRPCWhileOffline(rpcServer.ServeHTTP)))
 }
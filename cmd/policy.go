@@ -75,6 +75,15 @@ func (sys *PolicySys) Remove(bucketName string) {
 	delete(sys.bucketPolicyMap, bucketName)
 }
 
+// Get - returns the policy of the given bucket, if any.
+func (sys *PolicySys) Get(bucketName string) (policy.Policy, bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	p, found := sys.bucketPolicyMap[bucketName]
+	return p, found
+}
+
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *PolicySys) IsAllowed(args policy.Args) bool {
 	sys.RLock()
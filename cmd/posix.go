@@ -346,10 +346,8 @@ func (s *posix) checkDiskFound() (err error) {
 }
 
 // diskUsage returns du information for the posix path, in a continuous routine.
+// Scans follow the schedule of globalUsageScanner.
 func (s *posix) diskUsage(doneCh chan struct{}) {
-	ticker := time.NewTicker(globalUsageCheckInterval)
-	defer ticker.Stop()
-
 	usageFn := func(ctx context.Context, entry string) error {
 		if globalHTTPServer != nil {
 			// Wait at max 1 minute for an inprogress request
@@ -362,66 +360,65 @@ func (s *posix) diskUsage(doneCh chan struct{}) {
 			}
 		}
 
-		select {
-		case <-doneCh:
-			return errWalkAbort
-		case <-s.stopUsageCh:
+		if !globalUsageScanner.pause(doneCh, s.stopUsageCh) {
 			return errWalkAbort
-		default:
-			fi, err := os.Stat(entry)
-			if err != nil {
-				return err
-			}
-			atomic.AddUint64(&s.totalUsed, uint64(fi.Size()))
-			return nil
 		}
+
+		fi, err := os.Stat(entry)
+		if err != nil {
+			return err
+		}
+		atomic.AddUint64(&s.totalUsed, uint64(fi.Size()))
+		globalUsageScanner.entryScanned(s.diskPath)
+		return nil
 	}
 
 	// Return this routine upon errWalkAbort, continue for any other error on purpose
-	// so that we can start the routine freshly in another 12 hours.
-	if err := getDiskUsage(context.Background(), s.diskPath, usageFn); err == errWalkAbort {
+	// so that we can start the routine freshly after the scan interval.
+	globalUsageScanner.startScan(s.diskPath)
+	err := getDiskUsage(context.Background(), s.diskPath, usageFn)
+	globalUsageScanner.endScan(s.diskPath, err == nil)
+	if err == errWalkAbort {
 		return
 	}
 
-	for {
-		select {
-		case <-s.stopUsageCh:
-			return
-		case <-doneCh:
-			return
-		case <-time.After(globalUsageCheckInterval):
-			var usage uint64
-			usageFn = func(ctx context.Context, entry string) error {
-				if globalHTTPServer != nil {
-					// Wait at max 1 minute for an inprogress request
-					// before proceeding to count the usage.
-					waitCount := 60
-					// Any requests in progress, delay the usage.
-					for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-						waitCount--
-						time.Sleep(1 * time.Second)
-					}
+	lastEnd := UTCNow()
+	for globalUsageScanner.waitNextScan(s.diskPath, lastEnd, doneCh, s.stopUsageCh) {
+		var usage uint64
+		usageFn = func(ctx context.Context, entry string) error {
+			if globalHTTPServer != nil {
+				// Wait at max 1 minute for an inprogress request
+				// before proceeding to count the usage.
+				waitCount := 60
+				// Any requests in progress, delay the usage.
+				for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
+					waitCount--
+					time.Sleep(1 * time.Second)
 				}
+			}
 
-				select {
-				case <-s.stopUsageCh:
-					return errWalkAbort
-				default:
-					fi, err := os.Stat(entry)
-					if err != nil {
-						return err
-					}
-					usage = usage + uint64(fi.Size())
-					return nil
-				}
+			if !globalUsageScanner.pause(doneCh, s.stopUsageCh) {
+				return errWalkAbort
 			}
 
-			if err := getDiskUsage(context.Background(), s.diskPath, usageFn); err != nil {
-				continue
+			fi, err := os.Stat(entry)
+			if err != nil {
+				return err
 			}
+			usage = usage + uint64(fi.Size())
+			globalUsageScanner.entryScanned(s.diskPath)
+			return nil
+		}
 
-			atomic.StoreUint64(&s.totalUsed, usage)
+		globalUsageScanner.startScan(s.diskPath)
+		err = getDiskUsage(context.Background(), s.diskPath, usageFn)
+		globalUsageScanner.endScan(s.diskPath, err == nil)
+		lastEnd = UTCNow()
+		if err != nil {
+			continue
 		}
+
+		atomic.StoreUint64(&s.totalUsed, usage)
 	}
 }
 
@@ -837,6 +834,7 @@ func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verif
 	}
 
 	if bytes.Compare(h.Sum(nil), verifier.sum) != 0 {
+		globalBitrotStats.record(s.diskPath)
 		return 0, hashMismatchError{hex.EncodeToString(verifier.sum), hex.EncodeToString(h.Sum(nil))}
 	}
 
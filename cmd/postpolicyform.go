@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -256,3 +257,56 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) APIE
 
 	return ErrNone
 }
+
+// validatePostPolicyForm - checks the constraints of a parsed POST
+// policy which would make the uploads using it fail, returns the
+// errors along with warnings for conditions which are never checked
+// or which leave the upload unconstrained.
+func validatePostPolicyForm(postPolicyForm PostPolicyForm, now time.Time) (errs []string, warnings []string) {
+	if !postPolicyForm.Expiration.After(now) {
+		errs = append(errs, fmt.Sprintf("policy expired at %s", postPolicyForm.Expiration.UTC().Format(time.RFC3339)))
+	}
+
+	conds := make([]string, 0, len(postPolicyForm.Conditions.Policies))
+	for cond := range postPolicyForm.Conditions.Policies {
+		conds = append(conds, cond)
+	}
+	sort.Strings(conds)
+
+	for _, cond := range conds {
+		v := postPolicyForm.Conditions.Policies[cond]
+		startsWithSupported, condFound := startsWithConds[cond]
+		switch {
+		case condFound:
+			if v.Operator == policyCondStartsWith && !startsWithSupported {
+				errs = append(errs, fmt.Sprintf("condition `%s` does not support the `%s` operator", cond, policyCondStartsWith))
+			}
+		case strings.HasPrefix(cond, "$x-amz-"):
+		default:
+			warnings = append(warnings, fmt.Sprintf("condition `%s` is unknown and never checked", cond))
+		}
+	}
+
+	if v, ok := postPolicyForm.Conditions.Policies["$bucket"]; !ok {
+		warnings = append(warnings, "no `$bucket` condition, the policy allows uploads to any bucket")
+	} else if v.Operator == policyCondEqual && !IsValidBucketName(v.Value) {
+		errs = append(errs, fmt.Sprintf("condition `$bucket` has an invalid bucket name `%s`", v.Value))
+	}
+	if _, ok := postPolicyForm.Conditions.Policies["$key"]; !ok {
+		warnings = append(warnings, "no `$key` condition, the policy allows uploads of any object")
+	}
+
+	if lengthRange := postPolicyForm.Conditions.ContentLengthRange; lengthRange.Valid {
+		if lengthRange.Min < 0 {
+			errs = append(errs, fmt.Sprintf("content-length-range minimum %d must not be negative", lengthRange.Min))
+		}
+		if lengthRange.Min > lengthRange.Max {
+			errs = append(errs, fmt.Sprintf("content-length-range minimum %d is greater than the maximum %d", lengthRange.Min, lengthRange.Max))
+		}
+		if lengthRange.Max > globalMaxObjectSize {
+			errs = append(errs, fmt.Sprintf("content-length-range maximum %d exceeds the maximum object size %d", lengthRange.Max, int64(globalMaxObjectSize)))
+		}
+	}
+
+	return errs, warnings
+}
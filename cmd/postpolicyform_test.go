@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"net/http"
 	"testing"
+	"time"
 
 	minio "github.com/minio/minio-go"
 )
@@ -101,3 +102,42 @@ func TestPostPolicyForm(t *testing.T) {
 		}
 	}
 }
+
+// Test validation of the constraints of POST policies.
+func TestValidatePostPolicyForm(t *testing.T) {
+	now := UTCNow()
+	expiration := now.Add(time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		policy           string
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{`{"expiration":"` + expiration + `","conditions":[["eq","$bucket","mybucket"],["starts-with","$key","uploads/"],["content-length-range",1,1024]]}`, 0, 0},
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"mybucket"},{"key":"a"},["eq","$x-amz-meta-uuid","1"]]}`, 0, 0},
+		// Expired.
+		{`{"expiration":"` + now.Add(-time.Hour).Format(time.RFC3339) + `","conditions":[{"bucket":"mybucket"},{"key":"a"}]}`, 1, 0},
+		// No bucket nor key condition.
+		{`{"expiration":"` + expiration + `","conditions":[]}`, 0, 2},
+		// Unknown condition.
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"mybucket"},{"key":"a"},{"unknown":"b"}]}`, 0, 1},
+		// starts-with not supported.
+		{`{"expiration":"` + expiration + `","conditions":[["starts-with","$bucket","my"],["starts-with","$success_action_status","2"],{"key":"a"}]}`, 2, 0},
+		// Invalid bucket name.
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"My_Bucket"},{"key":"a"}]}`, 1, 0},
+		// Invalid content length ranges.
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"mybucket"},{"key":"a"},["content-length-range",-1,10]]}`, 1, 0},
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"mybucket"},{"key":"a"},["content-length-range",10,1]]}`, 1, 0},
+		{`{"expiration":"` + expiration + `","conditions":[{"bucket":"mybucket"},{"key":"a"},["content-length-range",0,6597069766656]]}`, 1, 0},
+	}
+	for i, testCase := range testCases {
+		postPolicyForm, err := parsePostPolicyForm(testCase.policy)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to parse policy - %v", i+1, err)
+		}
+		errs, warnings := validatePostPolicyForm(postPolicyForm, now)
+		if len(errs) != testCase.expectedErrors || len(warnings) != testCase.expectedWarnings {
+			t.Errorf("Test %d: Unexpected errors %q and warnings %q", i+1, errs, warnings)
+		}
+	}
+}
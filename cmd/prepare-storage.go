@@ -264,7 +264,7 @@ func waitForFormatXL(ctx context.Context, firstDisk bool, endpoints EndpointList
 			}
 			return format, nil
 		case <-globalOSSignalCh:
-			return nil, fmt.Errorf("Initializing data volumes gracefully stopped")
+			return nil, errStartupStopped
 		}
 	}
 }
@@ -0,0 +1,250 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Config section holding the compression of GET responses.
+const configSectionCompression = "compression"
+
+// Content types compressed unless configured otherwise, those of text
+// based formats which compress well.
+var defaultCompressedContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/xml",
+	"application/javascript",
+	"application/x-javascript",
+	"image/svg+xml",
+}
+
+// compressionConfig - gzip compression of the objects returned by GET
+// requests to the clients accepting it. Only gzip is offered: release
+// builds disable cgo, which the zstd encoders available need, and the
+// vendored klauspost/compress predates its pure Go zstd package.
+type compressionConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Content types of the compressed objects, such as `text/*`
+	ContentTypes []string `json:"contentTypes,omitempty"`
+
+	// Sizes of the compressed objects, the default minimum when
+	// MinSize is unset and no maximum when MaxSize is 0
+	MinSize *int64 `json:"minSize,omitempty"`
+	MaxSize int64  `json:"maxSize,omitempty"`
+}
+
+// validateCompressionConfig - returns an error if a content type is not
+// of the form `type/subtype` or `type/*`, or if the sizes are invalid.
+func validateCompressionConfig(config *compressionConfig) error {
+	if config == nil {
+		return nil
+	}
+	for _, contentType := range config.ContentTypes {
+		parts := strings.Split(contentType, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[0] == "*" || parts[1] == "" ||
+			strings.ContainsAny(contentType, " ;,") {
+			return fmt.Errorf("invalid compressed content type `%s`", contentType)
+		}
+	}
+	if (config.MinSize != nil && *config.MinSize < 0) || config.MaxSize < 0 {
+		return fmt.Errorf("compressed object sizes must not be negative")
+	}
+	if config.MinSize != nil && config.MaxSize != 0 && config.MaxSize < *config.MinSize {
+		return fmt.Errorf("maximum compressed object size %d is less than the minimum %d", config.MaxSize, *config.MinSize)
+	}
+	return nil
+}
+
+// responseCompression - compression of GET responses in effect and the
+// responses compressed by this server.
+type responseCompression struct {
+	sync.Mutex
+	config compressionConfig
+
+	requests   uint64
+	compressed uint64
+	bytesIn    uint64
+	bytesOut   uint64
+}
+
+// Compression of GET responses loaded from config.json.
+var globalResponseCompression = &responseCompression{}
+
+// set - replaces the compression of GET responses, filling in the
+// defaults, nil disables it.
+func (c *responseCompression) set(config *compressionConfig) {
+	var effective compressionConfig
+	if config != nil {
+		effective = *config
+	}
+	if len(effective.ContentTypes) == 0 {
+		effective.ContentTypes = defaultCompressedContentTypes
+	}
+	if effective.MinSize == nil {
+		minSize := int64(minGzipResponseSize)
+		effective.MinSize = &minSize
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.config = effective
+}
+
+// get - returns the compression of GET responses in effect.
+func (c *responseCompression) get() compressionConfig {
+	c.Lock()
+	defer c.Unlock()
+	return c.config
+}
+
+// isCompressible - returns whether the object is compressed for the
+// given config, when the client accepts it.
+func (config compressionConfig) isCompressible(objInfo ObjectInfo, rs *HTTPRangeSpec) bool {
+	// Ranges of the compressed object have no meaning to the
+	// client, already encoded objects do not compress and
+	// compressing encrypted ones might leak their content.
+	if rs != nil || objInfo.ContentEncoding != "" || crypto.IsEncrypted(objInfo.UserDefined) {
+		return false
+	}
+	minSize := int64(minGzipResponseSize)
+	if config.MinSize != nil {
+		minSize = *config.MinSize
+	}
+	if objInfo.Size < minSize || (config.MaxSize != 0 && objInfo.Size > config.MaxSize) {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(objInfo.ContentType)
+	if err != nil {
+		return false
+	}
+	for _, contentType := range config.ContentTypes {
+		contentType = strings.ToLower(contentType)
+		if mediaType == contentType ||
+			(strings.HasSuffix(contentType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(contentType, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressResponse - returns a writer gzip compressing the object
+// written to w, its headers being set, or nil if the object is not to
+// be compressed for the request r. The writer must be closed once the
+// object is written.
+func (c *responseCompression) compressResponse(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, rs *HTTPRangeSpec, dst io.Writer) *compressingWriter {
+	config := c.get()
+	if !config.Enabled {
+		return nil
+	}
+	// The response depends on whether the client accepts gzip.
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(r) {
+		return nil
+	}
+
+	c.Lock()
+	c.requests++
+	c.Unlock()
+
+	if !config.isCompressible(objInfo, rs) {
+		return nil
+	}
+
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Encoding", "gzip")
+	// The compressed content differs from the object, whose ETag
+	// only identifies it weakly.
+	if etag := w.Header().Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		w.Header().Set("ETag", "W/"+etag)
+	}
+	out := &byteCountWriter{Writer: dst}
+	return &compressingWriter{Writer: gzip.NewWriter(out), out: out, c: c}
+}
+
+// record - counts a compressed response of the given sizes.
+func (c *responseCompression) record(bytesIn, bytesOut int64) {
+	c.Lock()
+	defer c.Unlock()
+	c.compressed++
+	c.bytesIn += uint64(bytesIn)
+	c.bytesOut += uint64(bytesOut)
+}
+
+// stats - returns the GET responses compressed by this server.
+func (c *responseCompression) stats() madmin.CompressionStats {
+	c.Lock()
+	defer c.Unlock()
+	stats := madmin.CompressionStats{
+		Requests:   c.requests,
+		Compressed: c.compressed,
+		BytesIn:    c.bytesIn,
+		BytesOut:   c.bytesOut,
+	}
+	if stats.Requests > 0 {
+		stats.HitRate = float64(stats.Compressed) / float64(stats.Requests)
+	}
+	return stats
+}
+
+// byteCountWriter - counts the bytes written to the underlying writer.
+type byteCountWriter struct {
+	io.Writer
+	n int64
+}
+
+func (w *byteCountWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// compressingWriter - gzip compresses a response, counting its bytes
+// before and after compression.
+type compressingWriter struct {
+	*gzip.Writer
+	out *byteCountWriter
+	in  int64
+	c   *responseCompression
+}
+
+func (w *compressingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.in += int64(n)
+	return n, err
+}
+
+// Close - writes the remaining compressed data and records the
+// response.
+func (w *compressingWriter) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		return err
+	}
+	w.c.record(w.in, w.out.n)
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+)
+
+// Returns a pointer to the given size.
+func compressionSize(size int64) *int64 {
+	return &size
+}
+
+func TestValidateCompressionConfig(t *testing.T) {
+	testCases := []struct {
+		config     *compressionConfig
+		shouldPass bool
+	}{
+		{nil, true},
+		{&compressionConfig{}, true},
+		{&compressionConfig{Enabled: true, ContentTypes: []string{"text/*", "application/json"}, MinSize: compressionSize(10), MaxSize: 100}, true},
+		{&compressionConfig{MinSize: compressionSize(0), MaxSize: 10}, true},
+		{&compressionConfig{ContentTypes: []string{"*/*"}}, false},
+		{&compressionConfig{ContentTypes: []string{"text"}}, false},
+		{&compressionConfig{ContentTypes: []string{"text/html; charset=utf-8"}}, false},
+		{&compressionConfig{MinSize: compressionSize(-1)}, false},
+		{&compressionConfig{MinSize: compressionSize(100), MaxSize: 10}, false},
+	}
+	for i, testCase := range testCases {
+		if err := validateCompressionConfig(testCase.config); (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: expected to pass %v, got %v", i+1, testCase.shouldPass, err)
+		}
+	}
+}
+
+func TestCompressionConfigIsCompressible(t *testing.T) {
+	config := compressionConfig{
+		Enabled:      true,
+		ContentTypes: []string{"text/*", "application/JSON"},
+		MinSize:      compressionSize(10),
+		MaxSize:      100,
+	}
+	testCases := []struct {
+		objInfo      ObjectInfo
+		rs           *HTTPRangeSpec
+		compressible bool
+	}{
+		{ObjectInfo{ContentType: "text/plain", Size: 50}, nil, true},
+		{ObjectInfo{ContentType: "Text/CSV; charset=utf-8", Size: 50}, nil, true},
+		{ObjectInfo{ContentType: "application/json", Size: 50}, nil, true},
+		{ObjectInfo{ContentType: "application/octet-stream", Size: 50}, nil, false},
+		{ObjectInfo{ContentType: "textual/plain", Size: 50}, nil, false},
+		{ObjectInfo{ContentType: "text/plain", Size: 5}, nil, false},
+		{ObjectInfo{ContentType: "text/plain", Size: 500}, nil, false},
+		{ObjectInfo{ContentType: "text/plain", Size: 50}, &HTTPRangeSpec{Start: 0, End: 10}, false},
+		{ObjectInfo{ContentType: "text/plain", ContentEncoding: "gzip", Size: 50}, nil, false},
+		{ObjectInfo{ContentType: "text/plain", Size: 50, UserDefined: map[string]string{crypto.SSESealAlgorithm: crypto.SealAlgorithm}}, nil, false},
+	}
+	for i, testCase := range testCases {
+		if compressible := config.isCompressible(testCase.objInfo, testCase.rs); compressible != testCase.compressible {
+			t.Errorf("Test %d: expected compressible %v, got %v", i+1, testCase.compressible, compressible)
+		}
+	}
+
+	// Without a minimum size, the default one applies while a zero
+	// minimum size compresses objects of any size.
+	tiny := ObjectInfo{ContentType: "text/plain", Size: 1}
+	config.MinSize = nil
+	if config.isCompressible(tiny, nil) {
+		t.Errorf("expected objects smaller than %d bytes not to be compressed by default", minGzipResponseSize)
+	}
+	config.MinSize = compressionSize(0)
+	if !config.isCompressible(tiny, nil) {
+		t.Errorf("expected objects of any size to be compressed with a zero minimum size")
+	}
+}
+
+func TestCompressResponse(t *testing.T) {
+	objInfo := ObjectInfo{ContentType: "text/plain", Size: 4096}
+	data := bytes.Repeat([]byte("minio"), 4096/5)
+
+	c := &responseCompression{}
+	c.set(nil)
+
+	// Compression is disabled by default.
+	req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	if c.compressResponse(rec, req, objInfo, nil, rec) != nil || rec.Header().Get("Vary") != "" {
+		t.Fatal("expected no compression while disabled")
+	}
+
+	c.set(&compressionConfig{Enabled: true})
+
+	// Clients not accepting gzip get the object as is.
+	req = httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	rec = httptest.NewRecorder()
+	if c.compressResponse(rec, req, objInfo, nil, rec) != nil {
+		t.Fatal("expected no compression without Accept-Encoding")
+	}
+	if rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatal("expected Vary header to be set")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Length", "4096")
+	rec.Header().Set("ETag", `"0123456789abcdef"`)
+	gzipWriter := c.compressResponse(rec, req, objInfo, nil, rec)
+	if gzipWriter == nil {
+		t.Fatal("expected response to be compressed")
+	}
+	if _, err := gzipWriter.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" || rec.Header().Get("Content-Length") != "" ||
+		rec.Header().Get("ETag") != `W/"0123456789abcdef"` {
+		t.Fatalf("unexpected response headers %v", rec.Header())
+	}
+
+	gzipReader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(gzipReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decompressed, data) {
+		t.Fatal("decompressed response differs from the object")
+	}
+
+	stats := c.stats()
+	if stats.Requests != 1 || stats.Compressed != 1 || stats.HitRate != 1 ||
+		stats.BytesIn != uint64(len(data)) || stats.BytesOut == 0 || stats.BytesOut >= stats.BytesIn {
+		t.Fatalf("unexpected compression stats %#v", stats)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// Config section holding the custom response headers.
+const configSectionHeaders = "headers"
+
+// Headers set by the server itself or carrying the S3 protocol, they
+// cannot be overridden by custom response headers.
+var protectedResponseHeaders = map[string]struct{}{
+	"Accept-Ranges":       {},
+	"Authorization":       {},
+	"Cache-Control":       {},
+	"Connection":          {},
+	"Content-Disposition": {},
+	"Content-Encoding":    {},
+	"Content-Language":    {},
+	"Content-Length":      {},
+	"Content-Md5":         {},
+	"Content-Range":       {},
+	"Content-Type":        {},
+	"Date":                {},
+	"Etag":                {},
+	"Expires":             {},
+	"Host":                {},
+	"Keep-Alive":          {},
+	"Last-Modified":       {},
+	"Location":            {},
+	"Range":               {},
+	"Retry-After":         {},
+	"Server":              {},
+	"Set-Cookie":          {},
+	"Trailer":             {},
+	"Transfer-Encoding":   {},
+	"Upgrade":             {},
+	"Vary":                {},
+	"Www-Authenticate":    {},
+}
+
+// Prefixes of protected header families: object and protocol headers
+// along with the CORS headers set by the CORS handler.
+var protectedResponseHeaderPrefixes = []string{
+	"X-Amz-",
+	"X-Minio-",
+	"Access-Control-",
+}
+
+// isProtectedResponseHeader - returns true if the given header cannot
+// be set as a custom response header.
+func isProtectedResponseHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	if _, ok := protectedResponseHeaders[name]; ok {
+		return true
+	}
+	for _, prefix := range protectedResponseHeaderPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateResponseHeaders - validates the names and values of the
+// headers config section.
+func validateResponseHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("invalid response header `%s`", name)
+		}
+		if isProtectedResponseHeader(name) {
+			return fmt.Errorf("response header `%s` is set by the server and cannot be overridden", name)
+		}
+	}
+	return nil
+}
+
+// responseHeaders - custom headers added to all responses.
+type responseHeaders struct {
+	sync.RWMutex
+	headers map[string]string
+}
+
+// Custom response headers loaded from config.json.
+var globalResponseHeaders = &responseHeaders{}
+
+// set - replaces the custom response headers.
+func (h *responseHeaders) set(headers map[string]string) {
+	canonical := make(map[string]string, len(headers))
+	for name, value := range headers {
+		canonical[http.CanonicalHeaderKey(name)] = value
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	h.headers = canonical
+}
+
+// get - returns a copy of the custom response headers.
+func (h *responseHeaders) get() map[string]string {
+	h.RLock()
+	defer h.RUnlock()
+	headers := make(map[string]string, len(h.headers))
+	for name, value := range h.headers {
+		headers[name] = value
+	}
+	return headers
+}
+
+// apply - adds the custom response headers to a response.
+func (h *responseHeaders) apply(w http.ResponseWriter) {
+	h.RLock()
+	defer h.RUnlock()
+	for name, value := range h.headers {
+		w.Header().Set(name, value)
+	}
+}
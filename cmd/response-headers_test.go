@@ -0,0 +1,88 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateResponseHeaders(t *testing.T) {
+	testCases := []struct {
+		headers    map[string]string
+		shouldPass bool
+	}{
+		{nil, true},
+		{map[string]string{"Strict-Transport-Security": "max-age=31536000"}, true},
+		{map[string]string{"content-security-policy": "default-src 'self'"}, true},
+		{map[string]string{"X-Frame-Options": "DENY", "Referrer-Policy": "no-referrer"}, true},
+		// Invalid names and values.
+		{map[string]string{"": "value"}, false},
+		{map[string]string{"Bad Header": "value"}, false},
+		{map[string]string{"X-Header": "line\r\nX-Injected: 1"}, false},
+		// Protected headers, whatever their case.
+		{map[string]string{"Server": "nginx"}, false},
+		{map[string]string{"etag": "abc"}, false},
+		{map[string]string{"Content-Type": "text/html"}, false},
+		{map[string]string{"x-amz-request-id": "1"}, false},
+		{map[string]string{"X-Minio-Deployment-Id": "1"}, false},
+		{map[string]string{"Access-Control-Allow-Origin": "*"}, false},
+		{map[string]string{"Accept-Ranges": "none"}, false},
+	}
+	for i, testCase := range testCases {
+		err := validateResponseHeaders(testCase.headers)
+		if (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+	}
+}
+
+func TestSetCommonHeadersResponseHeaders(t *testing.T) {
+	tmpGlobalResponseHeaders := globalResponseHeaders
+	defer func() {
+		globalResponseHeaders = tmpGlobalResponseHeaders
+	}()
+	globalResponseHeaders = &responseHeaders{}
+	globalResponseHeaders.set(map[string]string{
+		"strict-transport-security": "max-age=31536000",
+		"X-Frame-Options":           "DENY",
+	})
+
+	rec := httptest.NewRecorder()
+	setCommonHeaders(rec)
+	if v := rec.Header().Get("Strict-Transport-Security"); v != "max-age=31536000" {
+		t.Errorf("Expected Strict-Transport-Security header, got %q", v)
+	}
+	if v := rec.Header().Get("X-Frame-Options"); v != "DENY" {
+		t.Errorf("Expected X-Frame-Options header, got %q", v)
+	}
+	if v := rec.Header().Get("Accept-Ranges"); v != "bytes" {
+		t.Errorf("Expected Accept-Ranges header, got %q", v)
+	}
+
+	headers := globalResponseHeaders.get()
+	if len(headers) != 2 || headers["Strict-Transport-Security"] != "max-age=31536000" {
+		t.Errorf("Expected canonical headers, got %v", headers)
+	}
+
+	globalResponseHeaders.set(nil)
+	rec = httptest.NewRecorder()
+	setCommonHeaders(rec)
+	if v := rec.Header().Get("X-Frame-Options"); v != "" {
+		t.Errorf("Expected no X-Frame-Options header, got %q", v)
+	}
+}
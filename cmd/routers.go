@@ -29,6 +29,18 @@ func newObjectLayerFn() (layer ObjectLayer) {
 	return
 }
 
+// isServerStarting - returns true while the server is still
+// initializing its backend. Handlers which need the object layer fail
+// with ErrServerNotInitialized until then, the others may reply with
+// partial information.
+func isServerStarting() bool {
+	if newObjectLayerFn() == nil {
+		return true
+	}
+	// The uptime of a server is only known once it started.
+	return !globalIsGateway && globalBootTime.IsZero()
+}
+
 func newCacheObjectsFn() CacheObjectLayer {
 	return globalCacheObjectAPI
 }
@@ -55,6 +67,9 @@ var globalHandlers = []HandlerFunc{
 	setBucketForwardingHandler,
 	// Ratelimit the incoming requests using a token bucket algorithm
 	setRateLimitHandler,
+	// Rejects requests over the maximum number of requests
+	// served at once.
+	setAdmissionControlHandler,
 	// Validate all the incoming paths.
 	setPathValidityHandler,
 	// Network statistics
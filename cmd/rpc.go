@@ -240,6 +240,15 @@ func (client *RPCClient) Call(serviceMethod string, args interface {
 	return call()
 }
 
+// CallWithAuthToken - calls servicemethod on remote server authenticated
+// with the given token, the token of this client is left unchanged.
+func (client *RPCClient) CallWithAuthToken(serviceMethod, authToken string, args interface {
+	SetAuthArgs(args AuthArgs)
+}, reply interface{}) error {
+	args.SetAuthArgs(AuthArgs{authToken, client.args.RPCVersion, time.Now().UTC()})
+	return client.rpcClient.Call(serviceMethod, args, reply)
+}
+
 // Close - closes underneath RPC client.
 func (client *RPCClient) Close() error {
 	client.Lock()
@@ -0,0 +1,70 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+
+	"github.com/minio/minio/pkg/disk"
+	"github.com/minio/minio/pkg/sys"
+)
+
+// I/O requests in flight per drive from which a drive is considered
+// saturated, the queue depth of most SATA drives.
+const saturatedDiskQueueDepth = 32
+
+// loadScore - synthesizes the load of a server into a score, the sum
+// of its active requests and load average per CPU and of the I/O
+// requests in flight per drive relative to saturatedDiskQueueDepth.
+func loadScore(load ServerLoad) float64 {
+	var score float64
+	if load.CPUs > 0 {
+		score += (float64(load.ActiveRequests) + load.LoadAverage) / float64(load.CPUs)
+	}
+	return score + load.DiskQueueDepth/saturatedDiskQueueDepth
+}
+
+// getServerLoad - returns the current load of this server. Values
+// which cannot be collected on this platform are left to zero.
+func getServerLoad() ServerLoad {
+	load := ServerLoad{
+		ActiveRequests: globalActiveRequests.count(),
+		CPUs:           runtime.NumCPU(),
+	}
+	if loadAverage, err := sys.GetLoadAverage(); err == nil {
+		load.LoadAverage = loadAverage
+	}
+
+	var drives, inflight uint64
+	for _, endpoint := range globalEndpoints {
+		if !endpoint.IsLocal {
+			continue
+		}
+		n, err := disk.GetInflightIO(endpoint.Path)
+		if err != nil {
+			continue
+		}
+		drives++
+		inflight += n
+	}
+	if drives > 0 {
+		load.DiskQueueDepth = float64(inflight) / float64(drives)
+	}
+
+	load.Score = loadScore(load)
+	return load
+}
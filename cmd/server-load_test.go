@@ -0,0 +1,62 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+// Tests the score synthesizing the load of a server.
+func TestLoadScore(t *testing.T) {
+	testCases := []struct {
+		load  ServerLoad
+		score float64
+	}{
+		{ServerLoad{}, 0},
+		{ServerLoad{CPUs: 4}, 0},
+		{ServerLoad{ActiveRequests: 2, LoadAverage: 2, CPUs: 4}, 1},
+		{ServerLoad{CPUs: 4, DiskQueueDepth: 16}, 0.5},
+		{ServerLoad{ActiveRequests: 8, LoadAverage: 4, CPUs: 4, DiskQueueDepth: 32}, 4},
+	}
+	for i, testCase := range testCases {
+		if score := loadScore(testCase.load); score != testCase.score {
+			t.Errorf("Test %d: expected score %v, got %v", i+1, testCase.score, score)
+		}
+	}
+}
+
+// Tests the current load of this server.
+func TestGetServerLoad(t *testing.T) {
+	tmpGlobalActiveRequests := globalActiveRequests
+	defer func() {
+		globalActiveRequests = tmpGlobalActiveRequests
+	}()
+	globalActiveRequests = newActiveRequests()
+
+	req, _ := globalActiveRequests.register(httptest.NewRequest("GET", "/bucket/object", nil), "1")
+	defer globalActiveRequests.unregister(req)
+
+	load := getServerLoad()
+	if load.ActiveRequests != 1 || load.CPUs != runtime.NumCPU() {
+		t.Fatalf("Unexpected load %v", load)
+	}
+	if load.Score != loadScore(load) || load.Score <= 0 {
+		t.Fatalf("Unexpected load score %v", load.Score)
+	}
+}
@@ -232,6 +232,17 @@ func serverMain(ctx *cli.Context) {
 	// Create certs path.
 	logger.FatalIf(createConfigDir(), "Unable to initialize configuration files")
 
+	// Stop signals received before the startup completes exit
+	// without counting as a failed startup.
+	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
+
+	// Back off or enter safe mode if the previous startups failed.
+	if err := checkCrashLoop(); err == errStartupStopped {
+		exitStartupStopped()
+	} else {
+		logger.FatalIf(err, "Unable to record the server startup")
+	}
+
 	// Check and load SSL certificates.
 	var err error
 	globalPublicCerts, globalRootCAs, globalTLSCerts, globalIsSSL, err = getSSLConfig()
@@ -298,18 +309,20 @@ func serverMain(ctx *cli.Context) {
 	globalHTTPServer = xhttp.NewServer([]string{globalMinioAddr}, criticalErrorHandler{handler}, getCert)
 	globalHTTPServer.UpdateBytesReadFunc = globalConnStats.incInputBytes
 	globalHTTPServer.UpdateBytesWrittenFunc = globalConnStats.incOutputBytes
+	globalHTTPServer.ConnLimiter = newConnLimiter()
 	go func() {
 		globalHTTPServerErrorCh <- globalHTTPServer.Start()
 	}()
 
-	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
-
 	newObject, err := newObjectLayer(globalEndpoints)
 	if err != nil {
 		// Stop watching for any certificate changes.
 		globalTLSCerts.Stop()
 
 		globalHTTPServer.Shutdown()
+		if err == errStartupStopped {
+			exitStartupStopped()
+		}
 		logger.FatalIf(err, "Unable to initialize backend")
 	}
 
@@ -323,14 +336,23 @@ func serverMain(ctx *cli.Context) {
 
 	// Initialize config system.
 	if err = globalConfigSys.Init(newObject); err != nil {
-		logger.Fatal(err, "Unable to initialize config system")
+		// In safe mode, start with the default config and the saved
+		// credential so that a valid config can be set through the
+		// admin API, unless the credentials to use it are unknown.
+		if !globalSafeMode {
+			logger.Fatal(err, "Unable to initialize config system")
+		}
+		if cerr := loadSafeModeConfig(newObject); cerr != nil {
+			logger.Fatal(err, "Unable to initialize config system")
+		}
+		logger.Info("Unable to initialize config system, using the default config in safe mode: %v", err)
 	}
 
 	// Load logger subsystem
 	loadLoggers()
 
 	var cacheConfig = globalServerConfig.GetCacheConfig()
-	if len(cacheConfig.Drives) > 0 {
+	if len(cacheConfig.Drives) > 0 && !globalSafeMode {
 		// initialize the new disk cache objects.
 		globalCacheObjectAPI, err = newServerCacheObjects(cacheConfig)
 		logger.FatalIf(err, "Unable to initialize disk caching")
@@ -352,17 +374,70 @@ func serverMain(ctx *cli.Context) {
 
 	// Initialize notification system.
 	if err := globalNotificationSys.Init(newObject); err != nil {
-		logger.Fatal(err, "Unable to initialize notification system")
+		if !globalSafeMode {
+			logger.Fatal(err, "Unable to initialize notification system")
+		}
+		logger.LogIf(context.Background(), err)
+	}
+
+	// Load the request rate limits of tenants.
+	if err := loadTenantRateLimits(newObject); err != nil {
+		logger.LogIf(context.Background(), err)
+	}
+
+	// Load the schedule of the background usage scans.
+	if err := loadScannerSchedule(newObject); err != nil {
+		logger.LogIf(context.Background(), err)
+	}
+
+	// Load the configuration of the export of the server information.
+	if err := loadServerInfoExport(newObject); err != nil {
+		logger.LogIf(context.Background(), err)
+	}
+
+	// Load the credentials restricted to some admin actions.
+	if err := loadAdminCredentials(newObject); err != nil {
+		logger.LogIf(context.Background(), err)
 	}
 
 	globalObjLayerMutex.Lock()
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
 
+	// Warn about local disks mounted in the wrong position.
+	if globalIsXL {
+		logMisplacedDisks(newObject)
+	}
+
+	// Queue the undelivered events in the background.
+	if globalNotifyReplayEnabled {
+		go globalUndeliveredEvents.write(newObject)
+	}
+
+	// Heal automatically after drives come back online or are replaced,
+	// when enabled in the config.
+	if globalIsXL {
+		go startAutoHeal(newObject)
+	}
+
+	// Periodically export the server information when enabled.
+	go startServerInfoExport(newObject)
+
+	// Keep a rolling window of connection and HTTP statistics.
+	if globalMetricsHistory.size > 0 {
+		go startMetricsHistory()
+	}
+
 	// Prints the formatted startup message once object layer is initialized.
 	apiEndpoints := getAPIEndpoints(globalMinioAddr)
 	printStartupMessage(apiEndpoints)
 
+	// The startup completed, stop backing off.
+	logger.LogIf(context.Background(), clearStartupAttempts(getConfigDir()))
+	if globalSafeMode {
+		logger.Info("Started in safe mode after %d failed startups, restart the server once its config is fixed", globalCrashLoopLimit)
+	}
+
 	// Set uptime time after object layer has initialized.
 	globalBootTime = UTCNow()
 
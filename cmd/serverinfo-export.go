@@ -0,0 +1,236 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// File under the config prefix holding the configuration of the
+	// periodic export of the server information.
+	serverInfoExportFile = "serverinfo-export.json"
+
+	// Minimum time between two snapshots of the server information.
+	minServerInfoExportInterval = time.Minute
+)
+
+var errInvalidServerInfoExport = errors.New("server info export must have an interval of at least 1m along with a valid bucket name and prefix")
+
+// serverInfoSnapshot - server information of all servers at a given
+// time, written to the snapshot objects.
+type serverInfoSnapshot struct {
+	Time    time.Time    `json:"time"`
+	Servers []ServerInfo `json:"servers"`
+}
+
+// validateServerInfoExport - returns an error if an enabled export
+// has an interval below minServerInfoExportInterval or an invalid
+// bucket or prefix.
+func validateServerInfoExport(config madmin.ServerInfoExportConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if config.Interval < minServerInfoExportInterval || !IsValidBucketName(config.Bucket) ||
+		!IsValidObjectPrefix(config.Prefix) || hasPrefix(config.Prefix, slashSeparator) {
+		return errInvalidServerInfoExport
+	}
+	return nil
+}
+
+// isServerInfoExporter - returns true if this server takes the
+// snapshots of the server information, the one serving the first
+// endpoint so that a deployment takes each snapshot once.
+func isServerInfoExporter() bool {
+	return len(globalEndpoints) > 0 && globalEndpoints[0].IsLocal
+}
+
+// serverInfoSnapshotObject - returns the name of the object of the
+// snapshot taken at the given time.
+func serverInfoSnapshotObject(prefix string, t time.Time) string {
+	return prefix + "serverinfo-" + t.UTC().Format(iso8601Format) + ".json"
+}
+
+// serverInfoExporter - configuration of the periodic export of the
+// server information, and the last snapshot taken by this server.
+type serverInfoExporter struct {
+	mu     sync.Mutex
+	config madmin.ServerInfoExportConfig
+
+	// closed when the configuration changes, to wake up the export
+	updatedCh chan struct{}
+
+	lastSnapshot      time.Time
+	lastObject        string
+	lastSnapshotError string
+}
+
+// Prepare new serverInfoExporter structure, disabled.
+func newServerInfoExporter() *serverInfoExporter {
+	return &serverInfoExporter{updatedCh: make(chan struct{})}
+}
+
+// getConfig - returns the configuration along with a channel closed
+// when it changes.
+func (e *serverInfoExporter) getConfig() (madmin.ServerInfoExportConfig, <-chan struct{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.config, e.updatedCh
+}
+
+// setConfig - replaces the configuration, waking up the export.
+func (e *serverInfoExporter) setConfig(config madmin.ServerInfoExportConfig) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.config = config
+	close(e.updatedCh)
+	e.updatedCh = make(chan struct{})
+}
+
+// recordSnapshot - records a snapshot written to the given object, or
+// the error which prevented writing it.
+func (e *serverInfoExporter) recordSnapshot(t time.Time, object string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		e.lastSnapshotError = err.Error()
+		return
+	}
+	e.lastSnapshot = t
+	e.lastObject = object
+	e.lastSnapshotError = ""
+}
+
+// state - returns the state of the export on this server.
+func (e *serverInfoExporter) state() madmin.ServerInfoExporterState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return madmin.ServerInfoExporterState{
+		Exporting:         e.config.Enabled && isServerInfoExporter(),
+		LastSnapshot:      e.lastSnapshot,
+		LastObject:        e.lastObject,
+		LastSnapshotError: e.lastSnapshotError,
+	}
+}
+
+// takeServerInfoSnapshot - gathers the server information of all
+// peers and writes it to a new object of the export bucket, returns
+// the name of the object.
+func takeServerInfoSnapshot(ctx context.Context, objAPI ObjectLayer, config madmin.ServerInfoExportConfig, now time.Time) (string, error) {
+	data, err := json.Marshal(serverInfoSnapshot{
+		Time:    now,
+		Servers: getServerInfo(globalAdminPeers),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	object := serverInfoSnapshotObject(config.Prefix, now)
+	reader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "")
+	if err != nil {
+		return "", err
+	}
+	metadata := map[string]string{"content-type": "application/json"}
+	if _, err = objAPI.PutObject(ctx, config.Bucket, object, reader, metadata); err != nil {
+		return "", err
+	}
+	return object, nil
+}
+
+// startServerInfoExport - periodically writes snapshots of the server
+// information while the export is enabled, until the server exits.
+// Only the server serving the first endpoint takes snapshots.
+func startServerInfoExport(objAPI ObjectLayer) {
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "ServerInfoExport"})
+
+	var last time.Time
+	for {
+		config, updatedCh := globalServerInfoExporter.getConfig()
+		var nextCh <-chan time.Time
+		if config.Enabled && isServerInfoExporter() {
+			wait := last.Add(config.Interval).Sub(UTCNow())
+			if wait <= 0 {
+				last = UTCNow()
+				object, err := takeServerInfoSnapshot(ctx, objAPI, config, last)
+				logger.LogIf(ctx, err)
+				globalServerInfoExporter.recordSnapshot(last, object, err)
+				continue
+			}
+			nextCh = time.After(wait)
+		}
+
+		select {
+		case <-globalServiceDoneCh:
+			return
+		case <-updatedCh:
+		case <-nextCh:
+		}
+	}
+}
+
+// readServerInfoExport - reads the saved configuration of the export
+// of the server information, disabled if none is saved.
+func readServerInfoExport(ctx context.Context, objAPI ObjectLayer) (madmin.ServerInfoExportConfig, error) {
+	var config madmin.ServerInfoExportConfig
+	reader, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, serverInfoExportFile))
+	if err == errConfigNotFound {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return config, err
+	}
+
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// saveServerInfoExport - saves the configuration of the export of the
+// server information.
+func saveServerInfoExport(objAPI ObjectLayer, config madmin.ServerInfoExportConfig) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return saveConfig(objAPI, path.Join(minioConfigPrefix, serverInfoExportFile), data)
+}
+
+// loadServerInfoExport - loads the saved configuration of the export
+// of the server information into this server.
+func loadServerInfoExport(objAPI ObjectLayer) error {
+	config, err := readServerInfoExport(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	globalServerInfoExporter.setConfig(config)
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestValidateServerInfoExport(t *testing.T) {
+	testCases := []struct {
+		config     madmin.ServerInfoExportConfig
+		shouldPass bool
+	}{
+		{madmin.ServerInfoExportConfig{}, true},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Hour, Bucket: "monitoring"}, true},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Minute, Bucket: "monitoring", Prefix: "serverinfo/"}, true},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Second, Bucket: "monitoring"}, false},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Hour}, false},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Hour, Bucket: "monitoring", Prefix: "/serverinfo/"}, false},
+		{madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Hour, Bucket: "monitoring", Prefix: "../serverinfo/"}, false},
+	}
+	for i, testCase := range testCases {
+		if err := validateServerInfoExport(testCase.config); (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+	}
+}
+
+// Tests that a snapshot of the server information is written to an
+// object named after the time it was taken.
+func TestTakeServerInfoSnapshot(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("Unable to initialize FS backend: %v", err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	if err = objLayer.MakeBucketWithLocation(context.Background(), "monitoring", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	config := madmin.ServerInfoExportConfig{
+		Enabled:  true,
+		Interval: time.Hour,
+		Bucket:   "monitoring",
+		Prefix:   "serverinfo/",
+	}
+	now := time.Date(2018, time.October, 16, 10, 30, 0, 0, time.UTC)
+	object, err := takeServerInfoSnapshot(context.Background(), objLayer, config, now)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if object != "serverinfo/serverinfo-20181016T103000Z.json" {
+		t.Fatalf("Unexpected snapshot object %s", object)
+	}
+
+	var buf bytes.Buffer
+	if err = objLayer.GetObject(context.Background(), "monitoring", object, 0, -1, &buf, ""); err != nil {
+		t.Fatal(err)
+	}
+	var snapshot serverInfoSnapshot
+	if err = json.Unmarshal(buf.Bytes(), &snapshot); err != nil {
+		t.Fatalf("Unable to decode snapshot: %v", err)
+	}
+	if !snapshot.Time.Equal(now) {
+		t.Errorf("Expected snapshot time %v, got %v", now, snapshot.Time)
+	}
+
+	config.Bucket = "missing"
+	if _, err = takeServerInfoSnapshot(context.Background(), objLayer, config, now); err == nil {
+		t.Error("Expected error writing to a missing bucket")
+	}
+}
+
+func TestServerInfoExporterState(t *testing.T) {
+	e := newServerInfoExporter()
+	_, updatedCh := e.getConfig()
+	e.setConfig(madmin.ServerInfoExportConfig{Enabled: true, Interval: time.Hour, Bucket: "monitoring"})
+	select {
+	case <-updatedCh:
+	default:
+		t.Fatal("Expected the export to be woken up by a new config")
+	}
+
+	now := UTCNow()
+	e.recordSnapshot(now, "serverinfo-1.json", nil)
+	e.recordSnapshot(now.Add(time.Hour), "", errors.New("disk full"))
+	state := e.state()
+	if !state.LastSnapshot.Equal(now) || state.LastObject != "serverinfo-1.json" || state.LastSnapshotError != "disk full" {
+		t.Errorf("Unexpected state %#v", state)
+	}
+}
@@ -19,6 +19,8 @@ package cmd
 import (
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 )
 
 // Type of service signals currently supported.
@@ -43,6 +45,63 @@ func init() {
 	globalServiceSignalCh = make(chan serviceSignal)
 }
 
+// restartScheduler - holds the restart of the local server scheduled
+// to happen after a delay, if any.
+type restartScheduler struct {
+	sync.Mutex
+	timer *time.Timer
+
+	// token required to cancel the scheduled restart, if any
+	token string
+}
+
+// Global restart scheduler.
+var globalRestartScheduler = &restartScheduler{}
+
+// schedule - restarts the server after the delay, replacing any
+// previously scheduled restart. A non empty token restricts the
+// cancellation of the restart to requests holding it.
+func (s *restartScheduler) schedule(delay time.Duration, token string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		s.Lock()
+		// The restart was cancelled or rescheduled meanwhile.
+		if s.timer != timer {
+			s.Unlock()
+			return
+		}
+		s.timer = nil
+		s.Unlock()
+
+		globalServiceSignalCh <- serviceRestart
+	})
+	s.timer = timer
+	s.token = token
+}
+
+// cancel - aborts the scheduled restart, returns false if no restart
+// was pending. A restart scheduled with a token is only aborted if
+// the given token matches it, or if the given token is empty.
+func (s *restartScheduler) cancel(token string) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.timer == nil || token != "" && token != s.token {
+		return false
+	}
+	s.timer.Stop()
+	s.timer = nil
+	s.token = ""
+	return true
+}
+
 // restartProcess starts a new process passing it the active fd's. It
 // doesn't fork, but starts a new process using the same environment and
 // arguments as when it was originally started. This allows for a newly
@@ -36,6 +36,7 @@ import (
 	"time"
 
 	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/minio/minio/pkg/auth"
 	sha256 "github.com/minio/sha256-simd"
 )
 
@@ -227,9 +228,9 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 
 	query.Set("X-Amz-Algorithm", signV4Algorithm)
 
-	// If the host which signed the request is slightly ahead in time (by less than globalMaxSkewTime) the
+	// If the host which signed the request is slightly ahead in time (by less than the maximum clock skew) the
 	// request should still be allowed.
-	if pSignValues.Date.After(UTCNow().Add(globalMaxSkewTime)) {
+	if pSignValues.Date.After(UTCNow().Add(globalClockSkewTolerance.get())) {
 		return ErrRequestNotReadyYet
 	}
 
@@ -312,9 +313,12 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
 // returns ErrNone if signature matches.
 func doesSignatureMatch(hashedPayload string, r *http.Request, region string) APIErrorCode {
-	// Access credentials.
-	cred := globalServerConfig.GetCredential()
+	return doesSignatureMatchCred(globalServerConfig.GetCredential(), hashedPayload, r, region)
+}
 
+// doesSignatureMatchCred - same as doesSignatureMatch but verifies the
+// signature against the given credential.
+func doesSignatureMatchCred(cred auth.Credentials, hashedPayload string, r *http.Request, region string) APIErrorCode {
 	// Copy request.
 	req := *r
 
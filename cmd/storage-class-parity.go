@@ -0,0 +1,53 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var errParityFromEnv = errors.New("parity of the standard storage class is set through the environment")
+
+// getParityInfo - returns the parity applied to new writes of the
+// standard storage class, along with the range it may be set to.
+func getParityInfo() madmin.ParityInfo {
+	sc := getStorageClassParity(standardStorageClass, globalXLSetDriveCount)
+	return madmin.ParityInfo{
+		SetDriveCount: globalXLSetDriveCount,
+		Parity:        sc.Parity,
+		Source:        sc.Source,
+		DefaultParity: globalXLSetDriveCount / 2,
+		MinParity:     minimumParityDisks,
+		MaxParity:     globalXLSetDriveCount / 2,
+	}
+}
+
+// setStandardParity - sets the parity of the standard storage class
+// in the given config, a zero parity restores the default. Only objects
+// written afterwards use the new parity, existing objects keep theirs
+// until rewritten.
+func setStandardParity(config *serverConfig, parity int) {
+	config.StorageClass.Standard = storageClass{}
+	if parity != 0 {
+		config.StorageClass.Standard = storageClass{
+			Scheme: supportedStorageClassScheme,
+			Parity: parity,
+		}
+	}
+}
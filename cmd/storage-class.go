@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
@@ -194,6 +196,32 @@ func getRedundancyCount(sc string, totalDisks int) (data, parity int) {
 	return totalDisks - parity, parity
 }
 
+// getStorageClassParity - returns the data and parity shard counts in
+// effect for storage class sc on sets of totalDisks drives, along with
+// where the parity was configured from.
+func getStorageClassParity(sc string, totalDisks int) madmin.StorageClassParity {
+	configured := globalStandardStorageClass
+	if sc == reducedRedundancyStorageClass {
+		configured = globalRRStorageClass
+	}
+
+	source := madmin.StorageClassFromDefault
+	if configured.Parity != 0 {
+		source = madmin.StorageClassFromConfig
+		if globalIsStorageClass {
+			source = madmin.StorageClassFromEnv
+		}
+	}
+
+	data, parity := getRedundancyCount(sc, totalDisks)
+	return madmin.StorageClassParity{
+		Name:   sc,
+		Data:   data,
+		Parity: parity,
+		Source: source,
+	}
+}
+
 // Returns per object readQuorum and writeQuorum
 // readQuorum is the minimum required disks to read data.
 // writeQuorum is the minimum required disks to write data.
@@ -48,6 +48,9 @@ var errDiskAccessDenied = errors.New("disk access denied")
 // errFileNotFound - cannot find the file.
 var errFileNotFound = errors.New("file not found")
 
+// errFileCorrupt - file has an unexpected size or content.
+var errFileCorrupt = errors.New("file is corrupted")
+
 // errFileNameTooLong - given file name is too long than supported length.
 var errFileNameTooLong = errors.New("file name too long")
 
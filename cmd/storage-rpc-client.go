@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 )
 
@@ -66,6 +67,8 @@ func toStorageErr(err error) error {
 		return io.ErrUnexpectedEOF
 	case errUnexpected.Error():
 		return errUnexpected
+	case errDiskNotFound.Error():
+		return errDiskNotFound
 	case errDiskFull.Error():
 		return errDiskFull
 	case errVolumeNotFound.Error():
@@ -139,6 +142,7 @@ func (client *StorageRPCClient) call(handler string, args interface {
 
 	if isNetworkDisconnectError(err) {
 		client.connected = false
+		globalEndpointErrors.record(client.String(), madmin.OfflineReasonNetwork, err)
 	}
 
 	return toStorageErr(err)
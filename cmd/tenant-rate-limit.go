@@ -0,0 +1,335 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// File under minioMetaBucket holding the request rate limits
+	// of tenants.
+	tenantRateLimitsFile = "ratelimit.json"
+
+	// Window over which the request rate of a tenant is observed.
+	tenantRateWindow = 10 * time.Second
+)
+
+var errInvalidTenantRateLimit = errors.New("rate limit must have a valid bucket name and a non negative rate")
+
+// tenantRateCounter - estimates the request rate of a tenant from the
+// number of requests in the current and previous windows.
+type tenantRateCounter struct {
+	windowStart time.Time
+	current     float64
+	previous    float64
+}
+
+// advance - moves the window forward up to the given time.
+func (c *tenantRateCounter) advance(now time.Time) {
+	elapsed := now.Sub(c.windowStart)
+	if elapsed < tenantRateWindow {
+		return
+	}
+	if elapsed < 2*tenantRateWindow {
+		c.previous = c.current
+	} else {
+		c.previous = 0
+	}
+	c.current = 0
+	c.windowStart = now.Truncate(tenantRateWindow)
+}
+
+// inc - counts a request made at the given time.
+func (c *tenantRateCounter) inc(now time.Time) {
+	c.advance(now)
+	c.current++
+}
+
+// rate - returns the requests per second observed at the given time,
+// weighting the previous window by the part of it still covered by a
+// sliding window.
+func (c *tenantRateCounter) rate(now time.Time) float64 {
+	c.advance(now)
+	weight := 1 - float64(now.Sub(c.windowStart))/float64(tenantRateWindow)
+	return (c.previous*weight + c.current) / tenantRateWindow.Seconds()
+}
+
+// tenantLimit - the limiter of a tenant and its observed rate.
+type tenantLimit struct {
+	limit   madmin.RateLimit
+	limiter *rate.Limiter
+
+	// requests admitted by the limiter
+	counterMu sync.Mutex
+	counter   tenantRateCounter
+}
+
+// admit - returns false if a request made at the given time exceeds
+// the rate limit, admitted requests are counted.
+func (t *tenantLimit) admit(now time.Time) bool {
+	if !t.limiter.AllowN(now, 1) {
+		return false
+	}
+	t.counterMu.Lock()
+	t.counter.inc(now)
+	t.counterMu.Unlock()
+	return true
+}
+
+// observedRate - returns the rate of admitted requests at the given
+// time.
+func (t *tenantLimit) observedRate(now time.Time) float64 {
+	t.counterMu.Lock()
+	defer t.counterMu.Unlock()
+	return t.counter.rate(now)
+}
+
+// tenantRateLimiter - request rate limits of tenants, each tenant
+// being a bucket or a prefix of a bucket.
+type tenantRateLimiter struct {
+	// number of limits, read without locking so that requests do not
+	// wait on the lock while no tenant is limited
+	count int32
+
+	sync.RWMutex
+
+	// limits of the tenants of each bucket, longest prefix first
+	limits map[string][]*tenantLimit
+}
+
+// tenantKey - returns the key of the tenant of a bucket and prefix.
+func tenantKey(bucket, prefix string) string {
+	return bucket + slashSeparator + prefix
+}
+
+// newTenantLimit - returns the limiter of a rate limit, allowing a
+// burst of one second worth of requests.
+func newTenantLimit(limit madmin.RateLimit) *tenantLimit {
+	burst := int(math.Ceil(limit.RequestsPerSecond))
+	if burst < 1 {
+		burst = 1
+	}
+	return &tenantLimit{
+		limit:   limit,
+		limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst),
+	}
+}
+
+// set - replaces all rate limits, limiters of unchanged limits are
+// kept along with their observed rate.
+func (l *tenantRateLimiter) set(limits []madmin.RateLimit) {
+	l.Lock()
+	defer l.Unlock()
+
+	prevLimits := make(map[string]*tenantLimit)
+	for _, tenants := range l.limits {
+		for _, t := range tenants {
+			prevLimits[tenantKey(t.limit.Bucket, t.limit.Prefix)] = t
+		}
+	}
+
+	newLimits := make(map[string][]*tenantLimit)
+	for _, limit := range limits {
+		t, ok := prevLimits[tenantKey(limit.Bucket, limit.Prefix)]
+		if !ok || t.limit != limit {
+			t = newTenantLimit(limit)
+		}
+		newLimits[limit.Bucket] = append(newLimits[limit.Bucket], t)
+	}
+	for _, tenants := range newLimits {
+		sort.Slice(tenants, func(i, j int) bool {
+			return len(tenants[i].limit.Prefix) > len(tenants[j].limit.Prefix)
+		})
+	}
+	l.limits = newLimits
+	atomic.StoreInt32(&l.count, int32(len(limits)))
+}
+
+// find - returns the limit of the most specific tenant of an object,
+// nil if none is limited.
+func (l *tenantRateLimiter) find(bucket, object string) *tenantLimit {
+	for _, t := range l.limits[bucket] {
+		if strings.HasPrefix(object, t.limit.Prefix) {
+			return t
+		}
+	}
+	return nil
+}
+
+// allow - returns false if a request on the given object exceeds the
+// rate limit of its tenant.
+func (l *tenantRateLimiter) allow(bucket, object string) bool {
+	if atomic.LoadInt32(&l.count) == 0 {
+		return true
+	}
+
+	l.RLock()
+	t := l.find(bucket, object)
+	l.RUnlock()
+	if t == nil {
+		return true
+	}
+	return t.admit(UTCNow())
+}
+
+// status - returns the rate limits along with the rate of admitted
+// requests observed for each of them, sorted by tenant.
+func (l *tenantRateLimiter) status() []madmin.RateLimitStatus {
+	l.RLock()
+	defer l.RUnlock()
+
+	now := UTCNow()
+	status := make([]madmin.RateLimitStatus, 0, atomic.LoadInt32(&l.count))
+	for _, tenants := range l.limits {
+		for _, t := range tenants {
+			status = append(status, madmin.RateLimitStatus{
+				RateLimit:    t.limit,
+				ObservedRate: t.observedRate(now),
+			})
+		}
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return tenantKey(status[i].Bucket, status[i].Prefix) < tenantKey(status[j].Bucket, status[j].Prefix)
+	})
+	return status
+}
+
+// Prepare new tenantRateLimiter structure
+func newTenantRateLimiter() *tenantRateLimiter {
+	return &tenantRateLimiter{limits: make(map[string][]*tenantLimit)}
+}
+
+// validateTenantRateLimit - returns an error if a rate limit does not
+// name a valid bucket or has a negative rate.
+func validateTenantRateLimit(limit madmin.RateLimit) error {
+	if !IsValidBucketName(limit.Bucket) || limit.RequestsPerSecond < 0 ||
+		math.IsNaN(limit.RequestsPerSecond) || math.IsInf(limit.RequestsPerSecond, 0) {
+		return errInvalidTenantRateLimit
+	}
+	return nil
+}
+
+// updateTenantRateLimits - returns the given rate limits updated with
+// a new limit, a zero rate removing the limit of the tenant.
+func updateTenantRateLimits(limits []madmin.RateLimit, limit madmin.RateLimit) []madmin.RateLimit {
+	key := tenantKey(limit.Bucket, limit.Prefix)
+	updated := make([]madmin.RateLimit, 0, len(limits)+1)
+	for _, l := range limits {
+		if tenantKey(l.Bucket, l.Prefix) != key {
+			updated = append(updated, l)
+		}
+	}
+	if limit.RequestsPerSecond > 0 {
+		updated = append(updated, limit)
+	}
+	return updated
+}
+
+// readTenantRateLimits - reads the saved rate limits of tenants.
+func readTenantRateLimits(ctx context.Context, objAPI ObjectLayer) ([]madmin.RateLimit, error) {
+	reader, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, tenantRateLimitsFile))
+	if err == errConfigNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits []madmin.RateLimit
+	if err = json.Unmarshal(data, &limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// saveTenantRateLimits - saves the rate limits of tenants.
+func saveTenantRateLimits(objAPI ObjectLayer, limits []madmin.RateLimit) error {
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+	return saveConfig(objAPI, path.Join(minioConfigPrefix, tenantRateLimitsFile), data)
+}
+
+// setTenantRateLimit - saves a new rate limit of a tenant along with
+// the existing limits of other tenants. The saved limits are locked
+// across servers while they are updated.
+func setTenantRateLimit(ctx context.Context, objAPI ObjectLayer, limit madmin.RateLimit) error {
+	unlock, err := lockConfigFile(path.Join(minioConfigPrefix, tenantRateLimitsFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	limits, err := readTenantRateLimits(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	return saveTenantRateLimits(objAPI, updateTenantRateLimits(limits, limit))
+}
+
+// loadTenantRateLimits - loads the saved rate limits of tenants into
+// the limiter of this server.
+func loadTenantRateLimits(objAPI ObjectLayer) error {
+	limits, err := readTenantRateLimits(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	globalTenantRateLimiter.set(limits)
+	return nil
+}
+
+// checkTenantRateLimit - returns ErrTenantRateLimitExceeded if a
+// request on the given object exceeds the rate limit of its tenant.
+// Requests are counted by each server on its own, so the limit
+// applies per server and a cluster of N servers admits up to N times
+// the limit of a tenant. It is called once the request is authenticated and authorized, so
+// that requests with an invalid signature do not use up the limit of
+// a tenant.
+func checkTenantRateLimit(r *http.Request, bucket, object string) APIErrorCode {
+	if bucket == "" {
+		return ErrNone
+	}
+	if object == "" {
+		// Listing objects under a prefix.
+		object = r.URL.Query().Get("prefix")
+	}
+	if !globalTenantRateLimiter.allow(bucket, object) {
+		return ErrTenantRateLimitExceeded
+	}
+	return ErrNone
+}
@@ -0,0 +1,315 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests the request rate observed over a sliding window.
+func TestTenantRateCounter(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	var c tenantRateCounter
+	for i := 0; i < 20; i++ {
+		c.inc(start)
+	}
+
+	testCases := []struct {
+		elapsed      time.Duration
+		expectedRate float64
+	}{
+		{0, 2},
+		{tenantRateWindow, 2},
+		{tenantRateWindow + tenantRateWindow/2, 1},
+		{3 * tenantRateWindow, 0},
+	}
+	for i, testCase := range testCases {
+		if rate := c.rate(start.Add(testCase.elapsed)); rate != testCase.expectedRate {
+			t.Errorf("Test %d: Expected rate %v but got %v", i+1, testCase.expectedRate, rate)
+		}
+	}
+}
+
+// Tests that requests are limited by the most specific tenant.
+func TestTenantRateLimiter(t *testing.T) {
+	l := newTenantRateLimiter()
+	l.set([]madmin.RateLimit{
+		{Bucket: "bucket", RequestsPerSecond: 2},
+		{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 1},
+	})
+
+	testCases := []struct {
+		bucket, object string
+		expectedAllow  bool
+	}{
+		{"bucket", "tenant/a", true},
+		{"bucket", "tenant/b", false},
+		{"bucket", "other", true},
+		{"bucket", "other", true},
+		{"bucket", "other", false},
+		{"unlimited", "tenant/a", true},
+		{"unlimited", "tenant/a", true},
+	}
+	for i, testCase := range testCases {
+		if allow := l.allow(testCase.bucket, testCase.object); allow != testCase.expectedAllow {
+			t.Errorf("Test %d: Expected allow %v but got %v", i+1, testCase.expectedAllow, allow)
+		}
+	}
+
+	status := l.status()
+	if len(status) != 2 || status[0].Prefix != "" || status[1].Prefix != "tenant/" {
+		t.Fatalf("Unexpected rate limits status %v", status)
+	}
+	if status[0].ObservedRate <= 0 || status[1].ObservedRate <= 0 {
+		t.Errorf("Expected requests to be observed, got %v", status)
+	}
+
+	// Only admitted requests are counted.
+	if tenant := l.find("bucket", "tenant/a"); tenant.counter.current != 1 {
+		t.Errorf("Expected 1 admitted request but got %v", tenant.counter.current)
+	}
+
+	// Unchanged limits keep their limiter.
+	l.set([]madmin.RateLimit{{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 1}})
+	if l.allow("bucket", "tenant/a") {
+		t.Errorf("Expected unchanged limit to keep rejecting requests")
+	}
+	if !l.allow("bucket", "other") {
+		t.Errorf("Expected removed limit to allow requests")
+	}
+
+	// Requests are not looked up while no tenant is limited.
+	l.set(nil)
+	if l.count != 0 || !l.allow("bucket", "tenant/a") {
+		t.Errorf("Expected requests to be allowed without limits")
+	}
+}
+
+// Tests adding, replacing and removing rate limits.
+func TestUpdateTenantRateLimits(t *testing.T) {
+	limits := []madmin.RateLimit{
+		{Bucket: "bucket", RequestsPerSecond: 10},
+		{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 1},
+	}
+
+	testCases := []struct {
+		limit    madmin.RateLimit
+		expected []madmin.RateLimit
+	}{
+		{
+			madmin.RateLimit{Bucket: "other", RequestsPerSecond: 5},
+			append(limits, madmin.RateLimit{Bucket: "other", RequestsPerSecond: 5}),
+		},
+		{
+			madmin.RateLimit{Bucket: "bucket", RequestsPerSecond: 5},
+			[]madmin.RateLimit{limits[1], {Bucket: "bucket", RequestsPerSecond: 5}},
+		},
+		{
+			madmin.RateLimit{Bucket: "bucket", Prefix: "tenant/"},
+			limits[:1],
+		},
+	}
+	for i, testCase := range testCases {
+		if updated := updateTenantRateLimits(limits, testCase.limit); !reflect.DeepEqual(updated, testCase.expected) {
+			t.Errorf("Test %d: Expected %v but got %v", i+1, testCase.expected, updated)
+		}
+	}
+}
+
+func TestValidateTenantRateLimit(t *testing.T) {
+	testCases := []struct {
+		limit      madmin.RateLimit
+		shouldPass bool
+	}{
+		{madmin.RateLimit{Bucket: "bucket", RequestsPerSecond: 1.5}, true},
+		{madmin.RateLimit{Bucket: "bucket", Prefix: "a/b", RequestsPerSecond: 0}, true},
+		{madmin.RateLimit{Bucket: "", RequestsPerSecond: 1}, false},
+		{madmin.RateLimit{Bucket: "bucket", RequestsPerSecond: -1}, false},
+		{madmin.RateLimit{Bucket: "bucket", RequestsPerSecond: math.Inf(1)}, false},
+		{madmin.RateLimit{Bucket: "bucket", RequestsPerSecond: math.NaN()}, false},
+	}
+	for i, testCase := range testCases {
+		if err := validateTenantRateLimit(testCase.limit); (err == nil) != testCase.shouldPass {
+			t.Errorf("Test %d: Unexpected result %v", i+1, err)
+		}
+	}
+}
+
+// Tests that authenticated requests over the limit of their tenant
+// are rejected, while unsigned requests do not use up the limit.
+// Tests that updates of the saved rate limits wait for the lock held
+// on them across servers.
+func TestSetTenantRateLimitLock(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	initNSLock(false)
+
+	unlock, err := lockConfigFile(path.Join(minioConfigPrefix, tenantRateLimitsFile))
+	if err != nil {
+		t.Fatalf("Unable to lock the rate limits: %v", err)
+	}
+
+	limit := madmin.RateLimit{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 10}
+	saved := make(chan error)
+	go func() {
+		saved <- setTenantRateLimit(context.Background(), objLayer, limit)
+	}()
+
+	select {
+	case <-saved:
+		t.Fatal("Expected the update of the rate limits to wait for the lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	if err = <-saved; err != nil {
+		t.Fatalf("Unable to save the rate limit once unlocked: %v", err)
+	}
+	limits, err := readTenantRateLimits(context.Background(), objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(limits, []madmin.RateLimit{limit}) {
+		t.Fatalf("Expected rate limits %v, got %v", []madmin.RateLimit{limit}, limits)
+	}
+}
+
+func TestTenantRateLimitAPIHandlers(t *testing.T) {
+	defer DetectTestLeak(t)()
+
+	tmpGlobalPolicySys := globalPolicySys
+	defer func() {
+		globalPolicySys = tmpGlobalPolicySys
+	}()
+	globalPolicySys = NewPolicySys()
+
+	ExecObjectLayerAPITest(t, testTenantRateLimitAPIHandlers, []string{"GetObject", "PutObject", "DeleteObject"})
+}
+
+func testTenantRateLimitAPIHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	tmpGlobalTenantRateLimiter := globalTenantRateLimiter
+	defer func() {
+		globalTenantRateLimiter = tmpGlobalTenantRateLimiter
+	}()
+	globalTenantRateLimiter = newTenantRateLimiter()
+
+	data := []byte("hello")
+	_, err := obj.PutObject(context.Background(), bucketName, "tenant/object",
+		mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create object - %v", instanceType, err)
+	}
+	globalTenantRateLimiter.set([]madmin.RateLimit{{Bucket: bucketName, Prefix: "tenant/", RequestsPerSecond: 1}})
+
+	anonymousRequest := func(method, urlStr string) *http.Request {
+		req, err := newTestRequest(method, urlStr, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create request - %v", instanceType, err)
+		}
+		return req
+	}
+	signedRequest := func(method, urlStr string, body []byte) *http.Request {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(body)), bytes.NewReader(body),
+			credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create request - %v", instanceType, err)
+		}
+		return req
+	}
+	badSignedRequest := func(method, urlStr string) *http.Request {
+		req, err := newTestSignedRequestV4(method, urlStr, 0, nil, credentials.AccessKey, "bad-secret-key")
+		if err != nil {
+			t.Fatalf("%s: Failed to create request - %v", instanceType, err)
+		}
+		return req
+	}
+
+	testCases := []struct {
+		req          *http.Request
+		expectedCode int
+	}{
+		// Requests to a private bucket which are not signed, or
+		// not signed by a known secret key, are denied and do not
+		// count against the limit.
+		{anonymousRequest(http.MethodGet, getGetObjectURL("", bucketName, "tenant/object")), http.StatusForbidden},
+		{anonymousRequest(http.MethodGet, getGetObjectURL("", bucketName, "tenant/object")), http.StatusForbidden},
+		{anonymousRequest(http.MethodPut, getPutObjectURL("", bucketName, "tenant/other")), http.StatusForbidden},
+		{badSignedRequest(http.MethodGet, getGetObjectURL("", bucketName, "tenant/object")), http.StatusForbidden},
+		// The limit of the tenant is left to its requests.
+		{signedRequest(http.MethodGet, getGetObjectURL("", bucketName, "tenant/object"), nil), http.StatusOK},
+		{signedRequest(http.MethodGet, getGetObjectURL("", bucketName, "tenant/object"), nil), http.StatusTooManyRequests},
+		{signedRequest(http.MethodPut, getPutObjectURL("", bucketName, "tenant/other"), data), http.StatusTooManyRequests},
+		{signedRequest(http.MethodDelete, getDeleteObjectURL("", bucketName, "tenant/object"), nil), http.StatusTooManyRequests},
+		// Other objects of the bucket are not limited.
+		{signedRequest(http.MethodPut, getPutObjectURL("", bucketName, "other"), data), http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, testCase.req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected status %d but got %d", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: Test %d: Expected Retry-After header", instanceType, i+1)
+		}
+	}
+}
+
+// Tests that requests over the limit of their tenant are rejected.
+func TestCheckTenantRateLimit(t *testing.T) {
+	tmpGlobalTenantRateLimiter := globalTenantRateLimiter
+	defer func() {
+		globalTenantRateLimiter = tmpGlobalTenantRateLimiter
+	}()
+	globalTenantRateLimiter = newTenantRateLimiter()
+	globalTenantRateLimiter.set([]madmin.RateLimit{{Bucket: "bucket", Prefix: "tenant/", RequestsPerSecond: 1}})
+
+	testCases := []struct {
+		path           string
+		bucket, object string
+		expectedErr    APIErrorCode
+	}{
+		{"/bucket/tenant/object", "bucket", "tenant/object", ErrNone},
+		{"/bucket/tenant/object", "bucket", "tenant/object", ErrTenantRateLimitExceeded},
+		// Listing objects under a prefix.
+		{"/bucket?prefix=tenant/", "bucket", "", ErrTenantRateLimitExceeded},
+		{"/bucket/other", "bucket", "other", ErrNone},
+		{"/", "", "", ErrNone},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+		if errCode := checkTenantRateLimit(req, testCase.bucket, testCase.object); errCode != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v but got %v", i+1, testCase.expectedErr, errCode)
+		}
+	}
+}
@@ -41,6 +41,102 @@ var (
 		"WORM can only accept `on` and `off` values. To enable WORM, set this value to `on`",
 	)
 
+	uiErrInvalidNotifyReplayValue = newUIErrFn(
+		"Invalid notification replay value",
+		"Please check the passed value",
+		"MINIO_NOTIFY_REPLAY can only accept `on` and `off` values. To persist undelivered events for replay, set this value to `on`",
+	)
+
+	uiErrInvalidAdminSignResponsesValue = newUIErrFn(
+		"Invalid admin response signature value",
+		"Please check the passed value",
+		"MINIO_ADMIN_SIGN_RESPONSES can only accept `on` and `off` values. To sign admin responses, set this value to `on`",
+	)
+
+	uiErrInvalidConfigHook = newUIErrFn(
+		"Invalid config change hook",
+		"Please check the passed values",
+		"MINIO_CONFIG_HOOK_URL accepts an http or https URL, MINIO_CONFIG_HOOK_EVENTS a comma separated list of `config` and `credentials` events",
+	)
+
+	uiErrInvalidHealEarlyHeadersValue = newUIErrFn(
+		"Invalid heal early headers value",
+		"Please check the passed value",
+		"MINIO_HEAL_EARLY_HEADERS can only accept `on` and `off` values. To send the response headers of heal requests right away, set this value to `on`",
+	)
+
+	uiErrInvalidSlowRequestBudget = newUIErrFn(
+		"Invalid slow request budget",
+		"Please check the passed value",
+		"MINIO_SLOW_REQUEST_BUDGET accepts a comma separated list of budgets per HTTP method such as `GET=1s,PUT=10s`, a budget without a method such as `5s` applies to all other methods",
+	)
+
+	uiErrInvalidMetricsHistory = newUIErrFn(
+		"Invalid metrics history retention",
+		"Please check the passed value",
+		"MINIO_METRICS_HISTORY accepts a duration between `10s` and `24h` such as `1h`, during which connection and HTTP statistics are kept",
+	)
+
+	uiErrInvalidRestartBackoff = newUIErrFn(
+		"Invalid restart backoff",
+		"Please check the passed value",
+		"MINIO_RESTART_BACKOFF accepts a duration between `0s` and `5m` such as `1s`, doubled after each failed startup before retrying",
+	)
+
+	uiErrInvalidMaxConnsPerIP = newUIErrFn(
+		"Invalid connection limit",
+		"Please check the passed value",
+		"MINIO_MAX_CONNS_PER_IP accepts a number of open connections per client IP such as `100`, `0` disables the limit",
+	)
+
+	uiErrInvalidMaxRequests = newUIErrFn(
+		"Invalid request limit",
+		"Please check the passed value",
+		"MINIO_MAX_REQUESTS accepts a number of requests served at once such as `1000`, `0` disables the limit",
+	)
+
+	uiErrInvalidCrashLoopLimit = newUIErrFn(
+		"Invalid crash loop limit",
+		"Please check the passed value",
+		"MINIO_CRASH_LOOP_LIMIT accepts a number of failed startups such as `5` after which the server starts in safe mode, `0` disables safe mode",
+	)
+
+	uiErrInvalidChaosTestingValue = newUIErrFn(
+		"Invalid chaos testing value",
+		"Please check the passed value",
+		"MINIO_CHAOS_TESTING can only accept `on` and `off` values. To allow admins to make servers simulate failures on a test cluster, set this value to `on`",
+	)
+
+	uiErrInvalidAdminConfirmActions = newUIErrFn(
+		"Invalid admin actions requiring confirmation",
+		"Please check the passed value",
+		"MINIO_ADMIN_CONFIRM_ACTIONS accepts a comma separated list of `service-stop`, `service-restart` and `set-credentials`",
+	)
+
+	uiErrInvalidAdminDisabledActions = newUIErrFn(
+		"Invalid disabled admin actions",
+		"Please check the passed value",
+		"MINIO_ADMIN_DISABLED_ACTIONS accepts a comma separated list of admin actions such as `admin:UpdateCredentials,admin:Heal*`",
+	)
+
+	uiErrInvalidPrometheusDeployment = newUIErrFn(
+		"Invalid Prometheus deployment label",
+		"Please check the passed value",
+		"MINIO_PROMETHEUS_DEPLOYMENT accepts a label value such as `eu-prod`",
+	)
+
+	uiErrInvalidAdminAPIPath = newUIErrFn(
+		"Invalid admin API path",
+		"Please check the passed value",
+		"MINIO_ADMIN_API_PATH accepts a path under /minio/, such as `/minio/ops`, other than the paths of the health check, metrics, browser and internode APIs",
+	)
+
+	uiErrInvalidSlowRequestHeaderValue = newUIErrFn(
+		"Invalid slow request header value",
+		"Please check the passed value",
+		"MINIO_SLOW_REQUEST_HEADER can only accept `on` and `off` values. To mark responses exceeding their budget with the X-Minio-Slow-Request header, set this value to `on`",
+	)
+
 	uiErrInvalidCacheDrivesValue = newUIErrFn(
 		"Invalid cache drive value",
 		"Please check the value in this ENV variable",
@@ -0,0 +1,384 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// File under the config prefix holding the schedule of the
+	// background usage scans.
+	usageScannerFile = "scanner.json"
+
+	// Bounds of the schedule of the background usage scans.
+	minUsageScanInterval = time.Minute
+	maxUsageScanDelay    = time.Second
+
+	// Interval at which scans paused outside of their active hours
+	// check whether they may resume.
+	usageScanPollInterval = time.Minute
+
+	// Time to wait for the scans of all servers to complete after a
+	// refresh request.
+	usageRefreshTimeout = time.Minute
+
+	// Interval between two checks of the scans of a server after a
+	// refresh request.
+	usageRefreshInterval = time.Second
+)
+
+var (
+	errUsageNotRefreshed = errors.New("usage scans have not completed yet")
+	errUsageScanFailed   = errors.New("usage scans failed, the previous usage is reported")
+)
+
+var errInvalidScannerSchedule = errors.New("scanner schedule must have an interval of at least 1m, a delay of at most 1s and active hours between 0 and 23")
+
+// defaultScannerSchedule - returns the schedule of the background
+// usage scans when none is saved.
+func defaultScannerSchedule() madmin.ScannerSchedule {
+	return madmin.ScannerSchedule{Interval: globalDefaultUsageCheckInterval}
+}
+
+// validateScannerSchedule - returns an error if the schedule of the
+// background usage scans is out of bounds.
+func validateScannerSchedule(schedule madmin.ScannerSchedule) error {
+	validHour := func(h int) bool { return h >= 0 && h < 24 }
+	if schedule.Interval < minUsageScanInterval || schedule.Delay < 0 || schedule.Delay > maxUsageScanDelay ||
+		!validHour(schedule.ActiveHoursStart) || !validHour(schedule.ActiveHoursEnd) {
+		return errInvalidScannerSchedule
+	}
+	return nil
+}
+
+// isScannerActive - returns whether scans may run at the given time,
+// active hours wrap around midnight when the end is before the start.
+func isScannerActive(schedule madmin.ScannerSchedule, now time.Time) bool {
+	start, end := schedule.ActiveHoursStart, schedule.ActiveHoursEnd
+	if start == end {
+		return true
+	}
+	hour := now.UTC().Hour()
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// usageScanner - schedule of the background scans computing the disk
+// usage, and the progress of the scans of this server.
+type usageScanner struct {
+	mu       sync.Mutex
+	schedule madmin.ScannerSchedule
+
+	// closed when the schedule changes or a refresh is requested, to
+	// wake up waiting scans
+	updatedCh chan struct{}
+
+	// time of the last refresh requested, scans which started before
+	// it run again right away
+	refreshRequested time.Time
+
+	// progress of the scans by path
+	scans map[string]*madmin.ScannerProgress
+}
+
+// Prepare new usageScanner structure with the default schedule.
+func newUsageScanner() *usageScanner {
+	return &usageScanner{
+		schedule:  defaultScannerSchedule(),
+		updatedCh: make(chan struct{}),
+		scans:     make(map[string]*madmin.ScannerProgress),
+	}
+}
+
+// getSchedule - returns the schedule along with a channel closed when
+// it changes.
+func (u *usageScanner) getSchedule() (madmin.ScannerSchedule, <-chan struct{}) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.schedule, u.updatedCh
+}
+
+// setSchedule - replaces the schedule, waking up waiting scans.
+func (u *usageScanner) setSchedule(schedule madmin.ScannerSchedule) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.schedule = schedule
+	close(u.updatedCh)
+	u.updatedCh = make(chan struct{})
+}
+
+// requestRefresh - makes the scans which started before now run again
+// right away, regardless of the schedule. Returns the time of the
+// request.
+func (u *usageScanner) requestRefresh() time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.refreshRequested = UTCNow()
+	close(u.updatedCh)
+	u.updatedCh = make(chan struct{})
+	return u.refreshRequested
+}
+
+// isRefreshPending - returns true if a refresh was requested after the
+// start of the last scan of the given path.
+func (u *usageScanner) isRefreshPending(scanPath string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.refreshRequested.IsZero() {
+		return false
+	}
+	scan, ok := u.scans[scanPath]
+	return !ok || scan.ScanStart.Before(u.refreshRequested)
+}
+
+// waitNextScan - waits until the interval elapsed since the end of
+// the previous scan of the given path and the scans are within their
+// active hours, or until a refresh is requested. Returns false if
+// doneCh or stopCh is closed first.
+func (u *usageScanner) waitNextScan(scanPath string, lastEnd time.Time, doneCh, stopCh <-chan struct{}) bool {
+	for {
+		schedule, updatedCh := u.getSchedule()
+		if u.isRefreshPending(scanPath) {
+			return true
+		}
+		now := UTCNow()
+		wait := lastEnd.Add(schedule.Interval).Sub(now)
+		if wait <= 0 {
+			if isScannerActive(schedule, now) {
+				return true
+			}
+			wait = usageScanPollInterval
+		}
+
+		select {
+		case <-doneCh:
+			return false
+		case <-stopCh:
+			return false
+		case <-updatedCh:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// pause - pauses a scan after an entry is scanned, for the delay of
+// the schedule and then until the scans are within their active
+// hours. Returns false if doneCh or stopCh is closed first.
+func (u *usageScanner) pause(doneCh, stopCh <-chan struct{}) bool {
+	schedule, updatedCh := u.getSchedule()
+	delay := schedule.Delay
+	for {
+		if delay <= 0 && isScannerActive(schedule, UTCNow()) {
+			return true
+		}
+		wait := delay
+		if wait <= 0 {
+			wait = usageScanPollInterval
+		}
+
+		select {
+		case <-doneCh:
+			return false
+		case <-stopCh:
+			return false
+		case <-updatedCh:
+		case <-time.After(wait):
+		}
+		delay = 0
+		schedule, updatedCh = u.getSchedule()
+	}
+}
+
+// startScan - records the start of a scan of the given path.
+func (u *usageScanner) startScan(scanPath string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	scan, ok := u.scans[scanPath]
+	if !ok {
+		scan = &madmin.ScannerProgress{Path: scanPath}
+		u.scans[scanPath] = scan
+	}
+	scan.Running = true
+	scan.ScanStart = UTCNow()
+	scan.EntriesScanned = 0
+}
+
+// entryScanned - records an entry scanned by the scan of the given
+// path.
+func (u *usageScanner) entryScanned(scanPath string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if scan, ok := u.scans[scanPath]; ok {
+		scan.EntriesScanned++
+	}
+}
+
+// endScan - records the end of the scan of the given path, its
+// completion time is only updated if the scan completed.
+func (u *usageScanner) endScan(scanPath string, completed bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	scan, ok := u.scans[scanPath]
+	if !ok {
+		return
+	}
+	scan.Running = false
+	if completed {
+		scan.LastCompletion = UTCNow()
+		scan.LastDuration = scan.LastCompletion.Sub(scan.ScanStart)
+	}
+}
+
+// progress - returns the progress of the scans of this server, sorted
+// by path.
+func (u *usageScanner) progress() []madmin.ScannerProgress {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	scans := make([]madmin.ScannerProgress, 0, len(u.scans))
+	for _, scan := range u.scans {
+		scans = append(scans, *scan)
+	}
+	sort.Slice(scans, func(i, j int) bool {
+		return scans[i].Path < scans[j].Path
+	})
+	return scans
+}
+
+// getUsageRefreshState - returns whether all the given scans ended
+// after starting since the given time of a refresh request, and
+// whether they all completed.
+func getUsageRefreshState(scans []madmin.ScannerProgress, since time.Time) (ended, completed bool) {
+	completed = true
+	for _, scan := range scans {
+		if scan.Running || scan.ScanStart.Before(since) {
+			return false, false
+		}
+		if scan.LastCompletion.Before(scan.ScanStart) {
+			completed = false
+		}
+	}
+	return true, completed
+}
+
+// refreshPeersUsage - makes the background usage scans of the given
+// peers run again right away and waits until they ended or the
+// timeout elapses, returns whether each peer refreshed its usage in
+// the same order.
+func refreshPeersUsage(peers adminPeers, timeout, interval time.Duration) []madmin.ServerUsageRefresh {
+	refreshes := make([]madmin.ServerUsageRefresh, len(peers))
+	deadline := UTCNow().Add(timeout)
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			refreshes[idx].Addr = peer.addr
+			since, err := peer.cmdRunner.RefreshUsage()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				refreshes[idx].Error = err.Error()
+				return
+			}
+			for {
+				scans, err := peer.cmdRunner.ScannerProgress()
+				if err == nil {
+					ended, completed := getUsageRefreshState(scans, since)
+					if ended {
+						// Failed scans keep the previous usage.
+						refreshes[idx].Refreshed = completed
+						refreshes[idx].Error = ""
+						if !completed {
+							refreshes[idx].Error = errUsageScanFailed.Error()
+						}
+						return
+					}
+					err = errUsageNotRefreshed
+				}
+				refreshes[idx].Error = err.Error()
+
+				if UTCNow().Add(interval).After(deadline) {
+					return
+				}
+				time.Sleep(interval)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return refreshes
+}
+
+// readScannerSchedule - reads the saved schedule of the background
+// usage scans, the default one if none is saved.
+func readScannerSchedule(ctx context.Context, objAPI ObjectLayer) (madmin.ScannerSchedule, error) {
+	reader, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, usageScannerFile))
+	if err == errConfigNotFound {
+		return defaultScannerSchedule(), nil
+	}
+	if err != nil {
+		return madmin.ScannerSchedule{}, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return madmin.ScannerSchedule{}, err
+	}
+
+	var schedule madmin.ScannerSchedule
+	if err = json.Unmarshal(data, &schedule); err != nil {
+		return madmin.ScannerSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// saveScannerSchedule - saves the schedule of the background usage
+// scans.
+func saveScannerSchedule(objAPI ObjectLayer, schedule madmin.ScannerSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	return saveConfig(objAPI, path.Join(minioConfigPrefix, usageScannerFile), data)
+}
+
+// loadScannerSchedule - loads the saved schedule of the background
+// usage scans into this server.
+func loadScannerSchedule(objAPI ObjectLayer) error {
+	schedule, err := readScannerSchedule(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	globalUsageScanner.setSchedule(schedule)
+	return nil
+}
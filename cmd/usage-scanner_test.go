@@ -0,0 +1,189 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests the validation of the schedule of the background usage scans.
+func TestValidateScannerSchedule(t *testing.T) {
+	testCases := []struct {
+		schedule  madmin.ScannerSchedule
+		expectErr bool
+	}{
+		{defaultScannerSchedule(), false},
+		{madmin.ScannerSchedule{Interval: time.Hour, Delay: time.Millisecond, ActiveHoursStart: 22, ActiveHoursEnd: 6}, false},
+		{madmin.ScannerSchedule{Interval: time.Second}, true},
+		{madmin.ScannerSchedule{Interval: time.Hour, Delay: -time.Millisecond}, true},
+		{madmin.ScannerSchedule{Interval: time.Hour, Delay: time.Minute}, true},
+		{madmin.ScannerSchedule{Interval: time.Hour, ActiveHoursStart: 24}, true},
+		{madmin.ScannerSchedule{Interval: time.Hour, ActiveHoursEnd: -1}, true},
+	}
+	for i, testCase := range testCases {
+		err := validateScannerSchedule(testCase.schedule)
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+// Tests the active hours of the background usage scans.
+func TestIsScannerActive(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2018, 10, 1, hour, 30, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		start, end int
+		hour       int
+		active     bool
+	}{
+		{0, 0, 12, true},
+		{1, 5, 1, true},
+		{1, 5, 4, true},
+		{1, 5, 5, false},
+		{1, 5, 12, false},
+		{22, 6, 23, true},
+		{22, 6, 3, true},
+		{22, 6, 6, false},
+		{22, 6, 12, false},
+	}
+	for i, testCase := range testCases {
+		schedule := madmin.ScannerSchedule{ActiveHoursStart: testCase.start, ActiveHoursEnd: testCase.end}
+		if active := isScannerActive(schedule, at(testCase.hour)); active != testCase.active {
+			t.Errorf("Test %d: expected active %v, got %v", i+1, testCase.active, active)
+		}
+	}
+}
+
+// Tests that paused and waiting scans resume on schedule changes and
+// stop when signaled.
+func TestUsageScannerWait(t *testing.T) {
+	scanner := newUsageScanner()
+
+	// Scans outside of their active hours pause until the schedule
+	// lets them run.
+	hour := UTCNow().Hour()
+	scanner.setSchedule(madmin.ScannerSchedule{Interval: time.Hour, ActiveHoursStart: (hour + 1) % 24, ActiveHoursEnd: (hour + 2) % 24})
+	resumedCh := make(chan bool)
+	go func() { resumedCh <- scanner.pause(nil, nil) }()
+	select {
+	case <-resumedCh:
+		t.Fatal("expected scan to pause outside of its active hours")
+	case <-time.After(100 * time.Millisecond):
+	}
+	scanner.setSchedule(madmin.ScannerSchedule{Interval: time.Hour})
+	select {
+	case resumed := <-resumedCh:
+		if !resumed {
+			t.Fatal("expected scan to resume")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected scan to resume on schedule change")
+	}
+
+	// Scans wait for the interval since the end of the previous one.
+	if !scanner.waitNextScan("/disk1", UTCNow().Add(-time.Hour), nil, nil) {
+		t.Fatal("expected scan to start once the interval elapsed")
+	}
+	stopCh := make(chan struct{})
+	go func() { resumedCh <- scanner.waitNextScan("/disk1", UTCNow(), nil, stopCh) }()
+	close(stopCh)
+	if <-resumedCh {
+		t.Fatal("expected waiting scan to stop")
+	}
+}
+
+// Tests the progress recorded for the background usage scans.
+func TestUsageScannerProgress(t *testing.T) {
+	scanner := newUsageScanner()
+
+	scanner.startScan("/disk2")
+	scanner.startScan("/disk1")
+	scanner.entryScanned("/disk1")
+	scanner.entryScanned("/disk1")
+	scanner.endScan("/disk1", true)
+	scanner.entryScanned("/disk2")
+	scanner.endScan("/disk2", false)
+
+	progress := scanner.progress()
+	if len(progress) != 2 || progress[0].Path != "/disk1" || progress[1].Path != "/disk2" {
+		t.Fatalf("Unexpected progress %v", progress)
+	}
+	if progress[0].Running || progress[0].EntriesScanned != 2 || progress[0].LastCompletion.IsZero() {
+		t.Errorf("Unexpected progress of completed scan %v", progress[0])
+	}
+	if progress[1].Running || progress[1].EntriesScanned != 1 || !progress[1].LastCompletion.IsZero() {
+		t.Errorf("Unexpected progress of aborted scan %v", progress[1])
+	}
+}
+
+// Tests that refresh requests make the background usage scans run
+// again right away.
+func TestUsageScannerRefresh(t *testing.T) {
+	scanner := newUsageScanner()
+	scanner.startScan("/disk1")
+	scanner.endScan("/disk1", true)
+	if scanner.isRefreshPending("/disk1") {
+		t.Fatal("expected no refresh to be pending")
+	}
+
+	resumedCh := make(chan bool)
+	go func() { resumedCh <- scanner.waitNextScan("/disk1", UTCNow(), nil, nil) }()
+	select {
+	case <-resumedCh:
+		t.Fatal("expected scan to wait for the interval")
+	case <-time.After(100 * time.Millisecond):
+	}
+	since := scanner.requestRefresh()
+	select {
+	case resumed := <-resumedCh:
+		if !resumed {
+			t.Fatal("expected scan to resume")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected scan to resume on refresh request")
+	}
+
+	// Scans started since the refresh request are up to date.
+	scanner.startScan("/disk1")
+	if scanner.isRefreshPending("/disk1") {
+		t.Fatal("expected no refresh to be pending once the scan started")
+	}
+	if ended, _ := getUsageRefreshState(scanner.progress(), since); ended {
+		t.Fatal("expected running scan not to have ended")
+	}
+	scanner.endScan("/disk1", true)
+	if ended, completed := getUsageRefreshState(scanner.progress(), since); !ended || !completed {
+		t.Fatal("expected scan to have completed")
+	}
+
+	scanner.startScan("/disk2")
+	scanner.endScan("/disk2", false)
+	if ended, completed := getUsageRefreshState(scanner.progress(), since); !ended || completed {
+		t.Fatal("expected failed scan to have ended without completing")
+	}
+
+	// Scans which started before the refresh request have not
+	// refreshed the usage.
+	if ended, _ := getUsageRefreshState(scanner.progress(), since.Add(time.Hour)); ended {
+		t.Fatal("expected scans started before the request not to count")
+	}
+}
@@ -160,7 +160,7 @@ func isMaxAllowedPartSize(size int64) bool {
 
 // Check if part size is more than or equal to minimum allowed size.
 func isMinAllowedPartSize(size int64) bool {
-	return size >= globalMinPartSize
+	return size >= globalMultipartPolicy.getMinPartSize()
 }
 
 // isMaxPartNumber - Check if part ID is greater than the maximum allowed ID.
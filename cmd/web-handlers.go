@@ -523,6 +523,11 @@ func (web *webAPIHandlers) SetAuth(r *http.Request, args *SetAuthArgs, reply *Se
 	}
 
 	// Acquire lock before updating global configuration.
+	unlock, err := lockConfigSections(configSectionCredential)
+	if err != nil {
+		return toJSONError(err)
+	}
+	defer unlock()
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
 
@@ -530,7 +535,7 @@ func (web *webAPIHandlers) SetAuth(r *http.Request, args *SetAuthArgs, reply *Se
 	prevCred := globalServerConfig.SetCredential(creds)
 
 	// Persist updated credentials.
-	if err = saveServerConfig(newObjectLayerFn(), globalServerConfig); err != nil {
+	if err = saveConfigCredential(context.Background(), newObjectLayerFn(), creds); err != nil {
 		// Save the current creds when failed to update.
 		globalServerConfig.SetCredential(prevCred)
 		logger.LogIf(context.Background(), err)
@@ -702,6 +702,11 @@ func testSetAuthWebHandler(obj ObjectLayer, instanceType string, t TestErrHandle
 		t.Fatal("Cannot authenticate")
 	}
 
+	// Credentials are updated in the saved config.
+	if err = saveServerConfig(obj, globalServerConfig); err != nil {
+		t.Fatal(err)
+	}
+
 	testCases := []struct {
 		username string
 		password string
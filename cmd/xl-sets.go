@@ -178,6 +178,7 @@ func (s *xlSets) connectDisks() {
 		disk, format, err := connectEndpoint(endpoint)
 		if err != nil {
 			printEndpointError(endpoint, err)
+			globalEndpointErrors.record(endpoint.String(), offlineReason(endpoint, err), err)
 			continue
 		}
 		i, j, err := findDiskIndex(s.format, format)
@@ -185,11 +186,13 @@ func (s *xlSets) connectDisks() {
 			// Close the internal connection to avoid connection leaks.
 			disk.Close()
 			printEndpointError(endpoint, err)
+			globalEndpointErrors.record(endpoint.String(), madmin.OfflineReasonFormat, err)
 			continue
 		}
 		s.xlDisksMu.Lock()
 		s.xlDisks[i][j] = disk
 		s.xlDisksMu.Unlock()
+		globalEndpointErrors.clear(endpoint.String())
 	}
 }
 
@@ -433,9 +436,14 @@ func hashKey(algo string, key string, cardinality int) int {
 	}
 }
 
+// Returns always a same erasure coded set index for a given input.
+func (s *xlSets) getHashedSetIndex(input string) int {
+	return hashKey(s.distributionAlgo, input, len(s.sets))
+}
+
 // Returns always a same erasure coded set for a given input.
 func (s *xlSets) getHashedSet(input string) (set *xlObjects) {
-	return s.sets[hashKey(s.distributionAlgo, input, len(s.sets))]
+	return s.sets[s.getHashedSetIndex(input)]
 }
 
 // GetBucketInfo - returns bucket info from one of the erasure coded set.
@@ -1246,8 +1254,8 @@ func (s *xlSets) HealBucket(ctx context.Context, bucket string, dryRun bool) (re
 }
 
 // HealObject - heals inconsistent object on a hashedSet based on object name.
-func (s *xlSets) HealObject(ctx context.Context, bucket, object string, dryRun bool) (madmin.HealResultItem, error) {
-	return s.getHashedSet(object).HealObject(ctx, bucket, object, dryRun)
+func (s *xlSets) HealObject(ctx context.Context, bucket, object string, dryRun bool, scanMode madmin.HealScanMode) (madmin.HealResultItem, error) {
+	return s.getHashedSet(object).HealObject(ctx, bucket, object, dryRun, scanMode)
 }
 
 // Lists all buckets which need healing.
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // commonTime returns a maximally occurring time from a list of time.
@@ -157,12 +158,16 @@ func getLatestXLMeta(ctx context.Context, partsMetadata []xlMetaV1, errs []error
 // - disks which have all parts specified in the latest xl.json.
 //
 // - slice of errors about the state of data files on disk - can have
-//   a not-found error or a hash-mismatch error.
+//   a not-found error, a size mismatch or a hash-mismatch error.
+//
+// With madmin.HealQuickScan only the presence and size of each part
+// is checked, madmin.HealDeepScan additionally reads every part to
+// verify its bitrot checksum.
 //
 // - non-nil error if any of the disks failed unexpectedly (i.e. error
 //   other than file not found and not a checksum error).
 func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetadata []xlMetaV1, errs []error, bucket,
-	object string) ([]StorageAPI, []error, error) {
+	object string, scanMode madmin.HealScanMode) ([]StorageAPI, []error, error) {
 	availableDisks := make([]StorageAPI, len(onlineDisks))
 	buffer := []byte{}
 	dataErrs := make([]error, len(onlineDisks))
@@ -175,21 +180,29 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 		// disk has a valid xl.json but may not have all the
 		// parts. This is considered an outdated disk, since
 		// it needs healing too.
+		erasureInfo := partsMetadata[i].Erasure
 		for _, part := range partsMetadata[i].Parts {
 			partPath := filepath.Join(object, part.Name)
-			checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(part.Name)
-			verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
-
-			// verification happens even if a 0-length
-			// buffer is passed
-			_, hErr := onlineDisk.ReadFile(bucket, partPath, 0, buffer, verifier)
 
-			isCorrupt := false
-			if hErr != nil {
-				isCorrupt = strings.HasPrefix(hErr.Error(), "Bitrot verification mismatch - expected ")
+			var hErr error
+			if scanMode == madmin.HealDeepScan {
+				checksumInfo := erasureInfo.GetChecksumInfo(part.Name)
+				verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
+
+				// verification happens even if a 0-length
+				// buffer is passed
+				_, hErr = onlineDisk.ReadFile(bucket, partPath, 0, buffer, verifier)
+			} else {
+				var fi FileInfo
+				fi, hErr = onlineDisk.StatFile(bucket, partPath)
+				if hErr == nil && fi.Size != getErasureShardFileSize(erasureInfo.BlockSize,
+					part.Size, erasureInfo.DataBlocks) {
+					hErr = errFileCorrupt
+				}
 			}
+
 			switch {
-			case isCorrupt:
+			case isBitrotMismatchErr(hErr), hErr == errFileCorrupt:
 				fallthrough
 			case hErr == errFileNotFound, hErr == errVolumeNotFound:
 				dataErrs[i] = hErr
@@ -209,3 +222,13 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 
 	return availableDisks, dataErrs, nil
 }
+
+// isBitrotMismatchErr - returns true if err is a bitrot checksum
+// verification failure. Errors from remote disks lose their type
+// across RPC, hence the comparison on the error message.
+func isBitrotMismatchErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.HasPrefix(err.Error(), "Bitrot verification mismatch - expected ")
+}
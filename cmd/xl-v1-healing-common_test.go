@@ -22,6 +22,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // validates functionality provided to find most common
@@ -258,7 +260,7 @@ func TestListOnlineDisks(t *testing.T) {
 				i+1, test.expectedTime, modTime)
 		}
 
-		availableDisks, newErrs, _ := disksWithAllParts(context.Background(), onlineDisks, partsMetadata, test.errs, bucket, object)
+		availableDisks, newErrs, _ := disksWithAllParts(context.Background(), onlineDisks, partsMetadata, test.errs, bucket, object, madmin.HealDeepScan)
 		test.errs = newErrs
 
 		if test._tamperBackend != noTamper {
@@ -317,8 +319,9 @@ func TestDisksWithAllParts(t *testing.T) {
 		}
 	}
 
+	// The default heal verifies checksums.
 	errs = make([]error, len(xlDisks))
-	filteredDisks, errs, err := disksWithAllParts(ctx, xlDisks, partsMetadata, errs, bucket, object)
+	filteredDisks, errs, err := disksWithAllParts(ctx, xlDisks, partsMetadata, errs, bucket, object, madmin.HealOpts{}.ScanMode)
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err)
 	}
@@ -346,6 +349,20 @@ func TestDisksWithAllParts(t *testing.T) {
 		}
 	}
 
+	// A quick scan does not read part data, so checksum
+	// mismatches must go unnoticed.
+	errs = make([]error, len(xlDisks))
+	filteredDisks, errs, err = disksWithAllParts(ctx, xlDisks, partsMetadata, errs, bucket, object, madmin.HealQuickScan)
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	for diskIndex, disk := range filteredDisks {
+		if disk == nil || errs[diskIndex] != nil {
+			t.Errorf("Disk erroneously filtered by quick scan, diskIndex: %d", diskIndex)
+		}
+	}
+
 	// Test that all disks are returned without any failures with
 	// unmodified meta data
 	partsMetadata, errs = readAllXLMetadata(ctx, xlDisks, bucket, object)
@@ -353,7 +370,7 @@ func TestDisksWithAllParts(t *testing.T) {
 		t.Fatalf("Failed to read xl meta data %v", err)
 	}
 
-	filteredDisks, errs, err = disksWithAllParts(ctx, xlDisks, partsMetadata, errs, bucket, object)
+	filteredDisks, errs, err = disksWithAllParts(ctx, xlDisks, partsMetadata, errs, bucket, object, madmin.HealDeepScan)
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err)
 	}
@@ -204,7 +204,7 @@ func healBucketMetadata(xl xlObjects, bucket string, dryRun bool) (
 	healBucketMetaFn := func(metaPath string) error {
 		reqInfo := &logger.ReqInfo{BucketName: bucket}
 		ctx := logger.SetReqInfo(context.Background(), reqInfo)
-		result, healErr := xl.HealObject(ctx, minioMetaBucket, metaPath, dryRun)
+		result, healErr := xl.HealObject(ctx, minioMetaBucket, metaPath, dryRun, madmin.HealDeepScan)
 		// If object is not found, no result to add.
 		if isErrObjectNotFound(healErr) {
 			return nil
@@ -279,7 +279,7 @@ func listAllBuckets(storageDisks []StorageAPI) (buckets map[string]VolInfo,
 
 // Heals an object by re-writing corrupt/missing erasure blocks.
 func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, object string,
-	quorum int, dryRun bool) (result madmin.HealResultItem, err error) {
+	quorum int, dryRun bool, scanMode madmin.HealScanMode) (result madmin.HealResultItem, err error) {
 
 	partsMetadata, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
 
@@ -304,7 +304,7 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 	latestDisks, modTime := listOnlineDisks(storageDisks, partsMetadata, errs)
 
 	// List of disks having all parts as per latest xl.json.
-	availableDisks, dataErrs, aErr := disksWithAllParts(ctx, latestDisks, partsMetadata, errs, bucket, object)
+	availableDisks, dataErrs, aErr := disksWithAllParts(ctx, latestDisks, partsMetadata, errs, bucket, object, scanMode)
 	if aErr != nil {
 		return result, toObjectErr(aErr, bucket, object)
 	}
@@ -347,6 +347,9 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 		default:
 			// all remaining cases imply corrupt data/metadata
 			driveState = madmin.DriveStateCorrupt
+			if isBitrotMismatchErr(dataErrs[i]) {
+				result.ChecksumFailures++
+			}
 		}
 
 		// an online disk without valid data/metadata is
@@ -607,7 +610,7 @@ func (xl xlObjects) healObjectDir(ctx context.Context, bucket, object string, dr
 // FIXME: If an object object was deleted and one disk was down,
 // and later the disk comes back up again, heal on the object
 // should delete it.
-func (xl xlObjects) HealObject(ctx context.Context, bucket, object string, dryRun bool) (hr madmin.HealResultItem, err error) {
+func (xl xlObjects) HealObject(ctx context.Context, bucket, object string, dryRun bool, scanMode madmin.HealScanMode) (hr madmin.HealResultItem, err error) {
 
 	// Create context that also contains information about the object and bucket.
 	// The top level handler might not have this information.
@@ -642,5 +645,5 @@ func (xl xlObjects) HealObject(ctx context.Context, bucket, object string, dryRu
 	defer objectLock.RUnlock()
 
 	// Heal the object.
-	return healObject(healCtx, xl.getDisks(), bucket, object, latestXLMeta.Erasure.DataBlocks, dryRun)
+	return healObject(healCtx, xl.getDisks(), bucket, object, latestXLMeta.Erasure.DataBlocks, dryRun, scanMode)
 }
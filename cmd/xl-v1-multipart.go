@@ -189,6 +189,7 @@ func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, object, ke
 // operation(s) on the object.
 func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, object string, meta map[string]string) (string, error) {
 
+	applyBucketStorageClass(bucket, meta)
 	dataBlocks, parityBlocks := getRedundancyCount(meta[amzStorageClass], len(xl.getDisks()))
 
 	xlMeta := newXLMetaV1(object, dataBlocks, parityBlocks)
@@ -208,6 +209,7 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 		}
 		meta["content-type"] = contentType
 	}
+	meta[multipartUploadBucketKey] = bucket
 	xlMeta.Stat.ModTime = UTCNow()
 	xlMeta.Meta = meta
 
@@ -725,6 +727,7 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Save successfully calculated md5sum.
 	xlMeta.Meta["etag"] = s3MD5
+	delete(xlMeta.Meta, multipartUploadBucketKey)
 
 	tempUploadIDPath := uploadID
 
@@ -584,6 +584,7 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	if metadata == nil {
 		metadata = make(map[string]string)
 	}
+	applyBucketStorageClass(bucket, metadata)
 
 	// Get parity and data drive count based on storage class metadata
 	dataDrives, parityDrives := getRedundancyCount(metadata[amzStorageClass], len(xl.getDisks()))
@@ -76,6 +76,12 @@ func getDisksInfo(disks []StorageAPI) (disksInfo []DiskInfo, onlineDisks int, of
 			offlineDisks++
 			continue
 		}
+		if _, isLocal := storageDisk.(*posix); isLocal && globalSimulatedOffline.isOffline() {
+			// Local disks are offline while the server simulates
+			// being offline.
+			offlineDisks++
+			continue
+		}
 		info, err := storageDisk.DiskInfo()
 		if err != nil {
 			logger.LogIf(context.Background(), err)
@@ -180,6 +180,20 @@ func (c *Certs) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, er
 	return &c.cert, nil
 }
 
+// Reload reloads the certificate and key files right away, instead
+// of waiting for a change to be noticed. The current certificate and
+// key continue to be used if the files cannot be loaded.
+func (c *Certs) Reload() error {
+	cert, err := c.loadCert(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	c.cert = cert
+	c.Unlock()
+	return nil
+}
+
 // Stop tells loader to stop watching for changes to the
 // certificate and key files.
 func (c *Certs) Stop() {
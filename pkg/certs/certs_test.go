@@ -18,6 +18,7 @@ package certs_test
 
 import (
 	"crypto/tls"
+	"errors"
 	"io"
 	"os"
 	"reflect"
@@ -122,3 +123,52 @@ func TestStop(t *testing.T) {
 		t.Error("certificate shouldn't match, but matched")
 	}
 }
+
+func TestReload(t *testing.T) {
+	expectedCert, err := tls.LoadX509KeyPair("server2.crt", "server2.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failLoad := false
+	loadCert := func(certFile, keyFile string) (tls.Certificate, error) {
+		if failLoad {
+			return tls.Certificate{}, errors.New("unable to load certificate")
+		}
+		return tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	c, err := certs.New("server.crt", "server.key", loadCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stop watching so that only Reload picks up the new files.
+	c.Stop()
+
+	updateCerts("server2.crt", "server2.key")
+	defer updateCerts("server1.crt", "server1.key")
+
+	if err = c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	hello := &tls.ClientHelloInfo{}
+	gcert, err := c.GetCertificate(hello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+
+	// The current certificate is kept if the files cannot be loaded.
+	failLoad = true
+	if err = c.Reload(); err == nil {
+		t.Fatal("Expected to fail but got success")
+	}
+	gcert, err = c.GetCertificate(hello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+}
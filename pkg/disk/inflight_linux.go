@@ -0,0 +1,60 @@
+// +build linux
+
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package disk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetInflightIO returns the number of I/O requests issued to the block
+// device holding the given path which have not completed yet.
+func GetInflightIO(path string) (uint64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return 0, err
+	}
+
+	dev := uint64(st.Dev)
+	inflightFile := fmt.Sprintf("/sys/dev/block/%d:%d/inflight", unix.Major(dev), unix.Minor(dev))
+	inflight, err := ioutil.ReadFile(inflightFile)
+	if err != nil {
+		return 0, err
+	}
+
+	// Reads and writes in flight.
+	var total uint64
+	fields := strings.Fields(string(inflight))
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("unexpected %s content %q", inflightFile, inflight)
+	}
+	for _, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
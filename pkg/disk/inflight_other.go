@@ -0,0 +1,27 @@
+// +build !linux
+
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package disk
+
+import "errors"
+
+// GetInflightIO returns the number of I/O requests issued to the block
+// device holding the given path which have not completed yet.
+func GetInflightIO(path string) (uint64, error) {
+	return 0, errors.New("getting in flight I/O requests is not supported")
+}
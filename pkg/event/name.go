@@ -37,6 +37,10 @@ const (
 	ObjectCreatedPut
 	ObjectRemovedAll
 	ObjectRemovedDelete
+	HealStarted
+	HealProgress
+	HealCompleted
+	HealFailed
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -76,6 +80,14 @@ func (name Name) String() string {
 		return "s3:ObjectRemoved:*"
 	case ObjectRemovedDelete:
 		return "s3:ObjectRemoved:Delete"
+	case HealStarted:
+		return "minio:Heal:Started"
+	case HealProgress:
+		return "minio:Heal:Progress"
+	case HealCompleted:
+		return "minio:Heal:Completed"
+	case HealFailed:
+		return "minio:Heal:Failed"
 	}
 
 	return ""
@@ -146,6 +158,14 @@ func ParseName(s string) (Name, error) {
 		return ObjectRemovedAll, nil
 	case "s3:ObjectRemoved:Delete":
 		return ObjectRemovedDelete, nil
+	case "minio:Heal:Started":
+		return HealStarted, nil
+	case "minio:Heal:Progress":
+		return HealProgress, nil
+	case "minio:Heal:Completed":
+		return HealCompleted, nil
+	case "minio:Heal:Failed":
+		return HealFailed, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
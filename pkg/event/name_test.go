@@ -60,6 +60,8 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{HealStarted, "minio:Heal:Started"},
+		{HealFailed, "minio:Heal:Failed"},
 		{blankName, ""},
 	}
 
@@ -200,6 +202,7 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"minio:Heal:Progress", HealProgress, false},
 		{"", blankName, true},
 	}
 
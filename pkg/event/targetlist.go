@@ -19,8 +19,13 @@ package event
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
+// Number of most recent deliveries of a target over which its
+// delivery error rate is computed.
+const recentDeliveries = 100
+
 // Target - event target interface
 type Target interface {
 	ID() TargetID
@@ -28,10 +33,101 @@ type Target interface {
 	Close() error
 }
 
+// TargetStats - delivery statistics of a target.
+type TargetStats struct {
+	// Number of events being sent to the target.
+	Queued int
+	// Time at which the oldest event being sent was queued, zero if
+	// no event is being sent.
+	OldestQueued time.Time
+	// Fraction of the most recent deliveries which failed.
+	ErrorRate float64
+}
+
+// targetQueue - events being sent to a target and the outcome of its
+// most recent deliveries.
+type targetQueue struct {
+	nextSeq  uint64
+	queued   map[uint64]time.Time
+	failures [recentDeliveries]bool
+	count    int
+	next     int
+}
+
 // TargetList - holds list of targets indexed by target ID.
 type TargetList struct {
 	sync.RWMutex
 	targets map[TargetID]Target
+
+	queuesMu sync.Mutex
+	queues   map[TargetID]*targetQueue
+}
+
+// enqueue - records an event being sent to a target, returns the
+// sequence number of the event in the queue of the target.
+func (list *TargetList) enqueue(id TargetID) uint64 {
+	list.queuesMu.Lock()
+	defer list.queuesMu.Unlock()
+
+	q, ok := list.queues[id]
+	if !ok {
+		q = &targetQueue{queued: make(map[uint64]time.Time)}
+		list.queues[id] = q
+	}
+	seq := q.nextSeq
+	q.nextSeq++
+	q.queued[seq] = time.Now().UTC()
+	return seq
+}
+
+// dequeue - records the outcome of sending a queued event.
+func (list *TargetList) dequeue(id TargetID, seq uint64, err error) {
+	list.queuesMu.Lock()
+	defer list.queuesMu.Unlock()
+
+	// Queues of removed targets are dropped.
+	q, ok := list.queues[id]
+	if !ok {
+		return
+	}
+	delete(q.queued, seq)
+	q.failures[q.next] = err != nil
+	q.next = (q.next + 1) % recentDeliveries
+	if q.count < recentDeliveries {
+		q.count++
+	}
+}
+
+// Stats - returns the delivery statistics of each target.
+func (list *TargetList) Stats() map[TargetID]TargetStats {
+	ids := list.List()
+
+	list.queuesMu.Lock()
+	defer list.queuesMu.Unlock()
+
+	stats := make(map[TargetID]TargetStats, len(ids))
+	for _, id := range ids {
+		var s TargetStats
+		if q, ok := list.queues[id]; ok {
+			s.Queued = len(q.queued)
+			for _, t := range q.queued {
+				if s.OldestQueued.IsZero() || t.Before(s.OldestQueued) {
+					s.OldestQueued = t
+				}
+			}
+			failed := 0
+			for i := 0; i < q.count; i++ {
+				if q.failures[i] {
+					failed++
+				}
+			}
+			if q.count > 0 {
+				s.ErrorRate = float64(failed) / float64(q.count)
+			}
+		}
+		stats[id] = s
+	}
+	return stats
 }
 
 // Add - adds unique target to target list.
@@ -94,6 +190,12 @@ func (list *TargetList) Remove(targetids ...TargetID) <-chan TargetIDErr {
 		for _, id := range targetids {
 			delete(list.targets, id)
 		}
+
+		list.queuesMu.Lock()
+		for _, id := range targetids {
+			delete(list.queues, id)
+		}
+		list.queuesMu.Unlock()
 	}()
 
 	return errCh
@@ -128,7 +230,10 @@ func (list *TargetList) Send(event Event, targetIDs ...TargetID) <-chan TargetID
 				wg.Add(1)
 				go func(id TargetID, target Target) {
 					defer wg.Done()
-					if err := target.Send(event); err != nil {
+					seq := list.enqueue(id)
+					err := target.Send(event)
+					list.dequeue(id, seq, err)
+					if err != nil {
 						errCh <- TargetIDErr{
 							ID:  id,
 							Err: err,
@@ -145,5 +250,8 @@ func (list *TargetList) Send(event Event, targetIDs ...TargetID) <-chan TargetID
 
 // NewTargetList - creates TargetList.
 func NewTargetList() *TargetList {
-	return &TargetList{targets: make(map[TargetID]Target)}
+	return &TargetList{
+		targets: make(map[TargetID]Target),
+		queues:  make(map[TargetID]*targetQueue),
+	}
 }
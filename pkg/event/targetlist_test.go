@@ -270,3 +270,75 @@ func TestNewTargetList(t *testing.T) {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
 	}
 }
+
+type blockingTarget struct {
+	id      TargetID
+	release chan struct{}
+}
+
+func (target blockingTarget) ID() TargetID {
+	return target.id
+}
+
+func (target blockingTarget) Send(eventData Event) error {
+	<-target.release
+	return nil
+}
+
+func (target blockingTarget) Close() error {
+	return nil
+}
+
+func TestTargetListStats(t *testing.T) {
+	slowTarget := blockingTarget{TargetID{"1", "webhook"}, make(chan struct{})}
+	failingTarget := &ExampleTarget{TargetID{"2", "amqp"}, true, false}
+
+	targetList := NewTargetList()
+	if err := targetList.Add(slowTarget); err != nil {
+		panic(err)
+	}
+	if err := targetList.Add(failingTarget); err != nil {
+		panic(err)
+	}
+
+	start := time.Now().UTC()
+	errChs := []<-chan TargetIDErr{
+		targetList.Send(Event{}, slowTarget.id, failingTarget.id),
+		targetList.Send(Event{}, slowTarget.id),
+	}
+
+	// Wait for the events to be queued to the slow target.
+	var stats map[TargetID]TargetStats
+	for i := 0; i < 100; i++ {
+		stats = targetList.Stats()
+		if stats[slowTarget.id].Queued == 2 && stats[failingTarget.id].ErrorRate == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	expected := map[TargetID]TargetStats{
+		slowTarget.id:    {Queued: 2, OldestQueued: stats[slowTarget.id].OldestQueued},
+		failingTarget.id: {ErrorRate: 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, stats)
+	}
+	if stats[slowTarget.id].OldestQueued.Before(start) {
+		t.Fatalf("expected oldest queued event after %v, got %v", start, stats[slowTarget.id].OldestQueued)
+	}
+
+	close(slowTarget.release)
+	for _, errCh := range errChs {
+		for range errCh {
+		}
+	}
+
+	stats = targetList.Stats()
+	expected = map[TargetID]TargetStats{
+		slowTarget.id:    {},
+		failingTarget.id: {ErrorRate: 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, stats)
+	}
+}
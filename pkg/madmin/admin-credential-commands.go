@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Effects of an admin policy statement.
+const (
+	AdminPolicyAllow = "Allow"
+	AdminPolicyDeny  = "Deny"
+)
+
+// AdminPolicyStatement - allows or denies a list of admin actions,
+// such as "admin:ServerInfo". An action ending with "*" matches all
+// actions with the same prefix.
+type AdminPolicyStatement struct {
+	Effect string   `json:"Effect"`
+	Action []string `json:"Action"`
+}
+
+// AdminPolicy - admin actions permitted to an admin credential. An
+// action is permitted if a statement allows it and none denies it.
+type AdminPolicy struct {
+	Statement []AdminPolicyStatement `json:"Statement"`
+}
+
+// AdminCredential - credential restricted by a policy to some admin
+// actions. The secret key is never returned by the server.
+type AdminCredential struct {
+	AccessKey string      `json:"accessKey"`
+	SecretKey string      `json:"secretKey,omitempty"`
+	Policy    AdminPolicy `json:"policy"`
+}
+
+// SetAdminCredential - adds or replaces, on all servers, a credential
+// which can only call the admin actions permitted by its policy.
+func (adm *AdminClient) SetAdminCredential(cred AdminCredential) error {
+	body, err := json.Marshal(cred)
+	if err != nil {
+		return err
+	}
+
+	ebody, err := EncryptServerConfigData(adm.secretAccessKey, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath: "/v1/credentials/admin",
+		content: ebody,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RemoveAdminCredential - removes an admin credential from all
+// servers.
+func (adm *AdminClient) RemoveAdminCredential(accessKey string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	resp, err := adm.executeMethod("DELETE", requestData{
+		relPath:     "/v1/credentials/admin",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListAdminCredentials - returns the admin credentials along with
+// their policy, without their secret key.
+func (adm *AdminClient) ListAdminCredentials() ([]AdminCredential, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/credentials/admin"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []AdminCredential
+	err = json.Unmarshal(respBytes, &creds)
+	return creds, err
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AdmissionStatus - state of the admission control of a server.
+// Requests over MaxRequests wait in a queue of at most MaxQueued
+// requests for up to QueueTimeout, and are rejected when the queue is
+// full or the timeout expires. A MaxRequests of 0 disables the limit.
+type AdmissionStatus struct {
+	MaxRequests  int           `json:"maxRequests"`
+	MaxQueued    int           `json:"maxQueued"`
+	QueueTimeout time.Duration `json:"queueTimeout"`
+
+	InflightRequests int `json:"inflightRequests"`
+	QueuedRequests   int `json:"queuedRequests"`
+	// Requests rejected since the server started.
+	RejectedRequests uint64 `json:"rejectedRequests"`
+}
+
+// ServerAdmissionStatus - state of the admission control of a server.
+type ServerAdmissionStatus struct {
+	Addr  string `json:"addr"`
+	Error string `json:"error,omitempty"`
+	AdmissionStatus
+}
+
+// AdmissionStatus - returns the state of the admission control of
+// each server.
+func (adm *AdminClient) AdmissionStatus() ([]ServerAdmissionStatus, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/admission"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []ServerAdmissionStatus
+	err = json.Unmarshal(respBytes, &servers)
+	return servers, err
+}
+
+// SetMaxRequests - sets the maximum number of requests served at once
+// by each server, 0 disables the limit. The limit is not saved, the
+// servers restart with the limit set by MINIO_MAX_REQUESTS.
+func (adm *AdminClient) SetMaxRequests(limit int) error {
+	queryValues := url.Values{}
+	queryValues.Set("maxRequests", strconv.Itoa(limit))
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/admission",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
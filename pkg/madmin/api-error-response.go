@@ -46,6 +46,10 @@ type ErrorResponse struct {
 	// Region where the bucket is located. This header is returned
 	// only in HEAD bucket and ListObjects response.
 	Region string
+
+	// Additional details about the error, for instance every
+	// problem found while validating a configuration.
+	Details []string `xml:"-"`
 }
 
 // Error - Returns HTTP error string
@@ -52,6 +52,9 @@ type AdminClient struct {
 
 	endpointURL url.URL
 
+	// Path the admin API is served under.
+	apiPathPrefix string
+
 	// Indicate whether we are using https or not
 	secure bool
 
@@ -64,6 +67,19 @@ type AdminClient struct {
 
 	// Random seed.
 	random *rand.Rand
+
+	// KMS wrapping the keys encrypting configs instead of the
+	// secret key, if set.
+	configKMS      ConfigKMS
+	configKMSKeyID string
+
+	// Fail requests whose response may be signed by the server if
+	// it is not.
+	requireSignedResponses bool
+
+	// Token confirming destructive admin actions, sent with all
+	// requests if set.
+	confirmationToken string
 }
 
 // Global constants.
@@ -107,6 +123,8 @@ func privateNew(endpoint, accessKeyID, secretAccessKey string, secure bool) (*Ad
 		secure: secure,
 		// Save endpoint URL, user agent for future uses.
 		endpointURL: *endpointURL,
+		// Admin API is served under its default path.
+		apiPathPrefix: libraryAdminURLPrefix,
 		// Instantiate http client and bucket location cache.
 		httpClient: &http.Client{
 			Transport: http.DefaultTransport,
@@ -152,6 +170,12 @@ func (c *AdminClient) SetCustomTransport(customHTTPTransport http.RoundTripper)
 	}
 }
 
+// SetAPIPathPrefix - sets the path the admin API is served under, for
+// servers setting MINIO_ADMIN_API_PATH. Defaults to "/minio/admin".
+func (c *AdminClient) SetAPIPathPrefix(prefix string) {
+	c.apiPathPrefix = strings.TrimSuffix(prefix, "/")
+}
+
 // TraceOn - enable HTTP tracing.
 func (c *AdminClient) TraceOn(outputStream io.Writer) {
 	// if outputStream is nil then default to os.Stdout.
@@ -401,6 +425,9 @@ func (c AdminClient) newRequest(method string, reqData requestData) (req *http.R
 	for k, v := range reqData.customHeaders {
 		req.Header.Set(k, v[0])
 	}
+	if c.confirmationToken != "" {
+		req.Header.Set(confirmationHeader, c.confirmationToken)
+	}
 	if length := len(reqData.content); length > 0 {
 		req.ContentLength = int64(length)
 	}
@@ -417,7 +444,7 @@ func (c AdminClient) makeTargetURL(r requestData) (*url.URL, error) {
 	host := c.endpointURL.Host
 	scheme := c.endpointURL.Scheme
 
-	urlStr := scheme + "://" + host + libraryAdminURLPrefix + r.relPath
+	urlStr := scheme + "://" + host + c.apiPathPrefix + r.relPath
 
 	// If there are any query values, add them to the end.
 	if len(r.queryValues) > 0 {
@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DiskBitrot - number of reads of a disk which detected bitrot, that
+// is data whose checksum does not match the one it was written with.
+type DiskBitrot struct {
+	Path         string    `json:"path"`
+	Count        uint64    `json:"count"`
+	LastDetected time.Time `json:"lastDetected"`
+}
+
+// ServerBitrotStats - bitrot detected on the disks of a server since
+// its counters were last reset, or since it started. Disks without
+// bitrot are not reported.
+type ServerBitrotStats struct {
+	Addr  string       `json:"addr"`
+	Error string       `json:"error,omitempty"`
+	Since time.Time    `json:"since"`
+	Disks []DiskBitrot `json:"disks,omitempty"`
+}
+
+// BitrotStats - returns the bitrot detected on the disks of each
+// server. A disk whose count keeps rising is likely to fail.
+func (adm *AdminClient) BitrotStats() (servers []ServerBitrotStats, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/bitrot"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &servers)
+	return servers, err
+}
+
+// ResetBitrotStats - resets the bitrot counters of all servers.
+func (adm *AdminClient) ResetBitrotStats() error {
+	resp, err := adm.executeMethod("POST", requestData{relPath: "/v1/bitrot"})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SimulatedNodeFailure - node simulating being offline and the time
+// it recovers at, zero if it recovered.
+type SimulatedNodeFailure struct {
+	Node         string    `json:"node"`
+	OfflineUntil time.Time `json:"offlineUntil"`
+}
+
+// SimulateNodeOffline - Makes the given node, by its address such as
+// "host:9000", simulate being offline for the given duration, at most
+// an hour, after which it recovers by itself. A duration of 0 makes it
+// recover right away. Only available on test clusters whose servers
+// enable chaos testing.
+func (adm *AdminClient) SimulateNodeOffline(node string, duration time.Duration) (failure SimulatedNodeFailure, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("duration", duration.String())
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/chaos",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return failure, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return failure, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return failure, err
+	}
+
+	err = json.Unmarshal(respBytes, &failure)
+	return failure, err
+}
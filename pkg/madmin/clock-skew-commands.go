@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClockSkewTolerance - maximum difference allowed between the time a
+// request was signed at and the time of the server, along with the
+// bounds it may be set within and the current time of the server.
+// Requests signed further in the past or the future fail with the
+// RequestTimeTooSkewed error.
+type ClockSkewTolerance struct {
+	MaxSkew     time.Duration `json:"maxSkew"`
+	DefaultSkew time.Duration `json:"defaultSkew"`
+	MinAllowed  time.Duration `json:"minAllowed"`
+	MaxAllowed  time.Duration `json:"maxAllowed"`
+	ServerTime  time.Time     `json:"serverTime"`
+}
+
+// ClockSkewTolerance - returns the maximum clock skew allowed between
+// clients and the server answering, along with its current time.
+func (adm *AdminClient) ClockSkewTolerance() (tolerance ClockSkewTolerance, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/clockskew"})
+	defer closeResponse(resp)
+	if err != nil {
+		return tolerance, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return tolerance, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return tolerance, err
+	}
+
+	err = json.Unmarshal(respBytes, &tolerance)
+	return tolerance, err
+}
+
+// SetClockSkewTolerance - sets the maximum clock skew allowed between
+// clients and all servers, which must be within the bounds reported by
+// ClockSkewTolerance. A zero skew restores the default.
+func (adm *AdminClient) SetClockSkewTolerance(maxSkew time.Duration) error {
+	queryValues := url.Values{}
+	queryValues.Set("maxSkew", maxSkew.String())
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/clockskew",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// CompressionConfig - gzip compression of the objects returned by GET
+// requests to the clients accepting it. Only objects of the given
+// content types, such as `text/*` or `application/json`, whose size is
+// between MinSize and MaxSize are compressed, a zero MaxSize meaning
+// no limit. Empty content types and a nil MinSize select the server
+// defaults, a zero MinSize compresses objects of any size.
+type CompressionConfig struct {
+	Enabled      bool     `json:"enabled"`
+	ContentTypes []string `json:"contentTypes,omitempty"`
+	MinSize      *int64   `json:"minSize,omitempty"`
+	MaxSize      int64    `json:"maxSize,omitempty"`
+}
+
+// CompressionStats - GET responses compressed by a server since it
+// started.
+type CompressionStats struct {
+	// GET requests of clients accepting gzip while compression is
+	// enabled, and those whose response was compressed
+	Requests   uint64  `json:"requests"`
+	Compressed uint64  `json:"compressed"`
+	HitRate    float64 `json:"hitRate"`
+	// Bytes of the compressed objects before and after compression
+	BytesIn  uint64 `json:"bytesIn"`
+	BytesOut uint64 `json:"bytesOut"`
+}
+
+// ServerCompressionStats - GET responses compressed by a server.
+type ServerCompressionStats struct {
+	Addr  string `json:"addr"`
+	Error string `json:"error,omitempty"`
+	CompressionStats
+}
+
+// CompressionPolicy - compression of GET responses in effect, the
+// defaults being filled in, along with the responses compressed by
+// each server.
+type CompressionPolicy struct {
+	CompressionConfig
+	Servers []ServerCompressionStats `json:"servers"`
+}
+
+// CompressionPolicy - returns the compression of GET responses in
+// effect along with the responses compressed by each server.
+func (adm *AdminClient) CompressionPolicy() (policy CompressionPolicy, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/compression"})
+	defer closeResponse(resp)
+	if err != nil {
+		return policy, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return policy, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return policy, err
+	}
+
+	err = json.Unmarshal(respBytes, &policy)
+	return policy, err
+}
+
+// SetCompressionConfig - sets the compression of GET responses of all
+// servers, replacing the previous one.
+func (adm *AdminClient) SetCompressionConfig(config CompressionConfig) error {
+	content, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/compression",
+		content: content,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
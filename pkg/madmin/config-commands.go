@@ -26,12 +26,18 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/minio/minio/pkg/quick"
 	"github.com/minio/sio"
 	"golang.org/x/crypto/argon2"
 )
 
+// Header of the reply to a config update holding the warnings of the
+// server about the config as a JSON array.
+const configWarningsHeader = "X-Minio-Config-Warnings"
+
 // EncryptServerConfigData - encrypts server config data.
 func EncryptServerConfigData(password string, data []byte) ([]byte, error) {
 	salt := make([]byte, 32)
@@ -74,9 +80,38 @@ func DecryptServerConfigData(password string, data io.Reader) ([]byte, error) {
 
 // GetConfig - returns the config.json of a minio setup, incoming data is encrypted.
 func (adm *AdminClient) GetConfig() ([]byte, error) {
+	return adm.getConfig("application/json", "")
+}
+
+// GetConfigYAML - returns the config of a minio setup as YAML.
+func (adm *AdminClient) GetConfigYAML() ([]byte, error) {
+	return adm.getConfig(yamlContentType, "")
+}
+
+// GetConfigSection - returns a section of the config.json of a minio
+// setup, i.e. one of its top level keys such as "notify", as a partial
+// config accepted by PatchConfig.
+func (adm *AdminClient) GetConfigSection(section string) ([]byte, error) {
+	return adm.getConfig("application/json", section)
+}
+
+func (adm *AdminClient) getConfig(accept, section string) ([]byte, error) {
+	customHeaders := http.Header{"Accept": []string{accept}}
+	if adm.configKMS != nil {
+		customHeaders.Set(configEncryptionHeader, configEncryptionKMS)
+	}
+
+	queryValues := url.Values{}
+	if section != "" {
+		queryValues.Set("section", section)
+	}
+
 	// Execute GET on /minio/admin/v1/config to get config of a setup.
-	resp, err := adm.executeMethod("GET",
-		requestData{relPath: "/v1/config"})
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:       "/v1/config",
+		queryValues:   queryValues,
+		customHeaders: customHeaders,
+	})
 	defer closeResponse(resp)
 	if err != nil {
 		return nil, err
@@ -87,23 +122,94 @@ func (adm *AdminClient) GetConfig() ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
+	if adm.configKMS != nil {
+		return DecryptServerConfigDataKMS(adm.configKMS, resp.Body)
+	}
 	return DecryptServerConfigData(adm.secretAccessKey, resp.Body)
 }
 
-// SetConfig - set config supplied as config.json for the setup.
-func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
-	const maxConfigJSONSize = 256 * 1024 // 256KiB
+const (
+	// Content type of configs in YAML.
+	yamlContentType = "application/yaml"
 
-	// Read configuration bytes
+	maxConfigJSONSize = 256 * 1024 // 256KiB
+)
+
+// readConfig - reads at most maxConfigJSONSize bytes of config.
+func readConfig(config io.Reader) ([]byte, error) {
 	configBuf := make([]byte, maxConfigJSONSize+1)
 	n, err := io.ReadFull(config, configBuf)
 	if err == nil {
-		return fmt.Errorf("too large file")
+		return nil, fmt.Errorf("too large file")
 	}
 	if err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return configBuf[:n], nil
+}
+
+// SetConfig - set config supplied as config.json for the setup.
+// Returns a ServiceSignalError if some servers did not acknowledge the
+// restart applying it.
+func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
+	configBytes, err := readConfigJSON(config)
+	if err != nil {
 		return err
 	}
-	configBytes := configBuf[:n]
+
+	_, err = adm.setConfig("PUT", configBytes, "application/json", false)
+	return err
+}
+
+// SetConfigWithWarnings - same as SetConfig, also returns the warnings
+// of the server about settings of the config which are legal but
+// risky, such as unencrypted notification targets. The config is
+// saved in spite of warnings.
+func (adm *AdminClient) SetConfigWithWarnings(config io.Reader) (warnings []string, err error) {
+	configBytes, err := readConfigJSON(config)
+	if err != nil {
+		return nil, err
+	}
+
+	respBytes, warnings, err := adm.sendConfig("PUT", configBytes, "application/json", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	acks, err := unmarshalServiceSignalAcks(respBytes)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, checkServiceSignalAcks(acks)
+}
+
+// ConfigReadiness - whether a server came back up with a new config.
+type ConfigReadiness struct {
+	Addr  string `json:"addr"`
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+// SetConfigWaitForReady - set config supplied as config.json for the
+// setup and wait for the servers to come back up with it. Returns
+// whether each server, but the one which served the request, is
+// running with the new config. That server restarts once it replied.
+func (adm *AdminClient) SetConfigWaitForReady(config io.Reader) ([]ConfigReadiness, error) {
+	configBytes, err := readConfigJSON(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return adm.setConfig("PUT", configBytes, "application/json", true)
+}
+
+// readConfigJSON - reads and checks a config.json.
+func readConfigJSON(config io.Reader) ([]byte, error) {
+	// Read configuration bytes
+	configBytes, err := readConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	type configVersion struct {
 		Version string `json:"version,omitempty"`
@@ -112,31 +218,94 @@ func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
 
 	// Check if read data is in json format
 	if err = json.Unmarshal(configBytes, &cfg); err != nil {
-		return errors.New("Invalid JSON format: " + err.Error())
+		return nil, errors.New("Invalid JSON format: " + err.Error())
 	}
 
 	// Check if the provided json file has "version" key set
 	if cfg.Version == "" {
-		return errors.New("Missing or unset \"version\" key in json file")
+		return nil, errors.New("Missing or unset \"version\" key in json file")
 	}
 	// Validate there are no duplicate keys in the JSON
 	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
-		return errors.New("Duplicate key in json file: " + err.Error())
+		return nil, errors.New("Duplicate key in json file: " + err.Error())
 	}
 
-	econfigBytes, err := EncryptServerConfigData(adm.secretAccessKey, configBytes)
+	return configBytes, nil
+}
+
+// SetConfigYAML - set config supplied as YAML for the setup, the
+// server validates it the same way as a config.json.
+func (adm *AdminClient) SetConfigYAML(config io.Reader) (err error) {
+	configBytes, err := readConfig(config)
 	if err != nil {
 		return err
 	}
 
-	reqData := requestData{
-		relPath: "/v1/config",
-		content: econfigBytes,
+	_, err = adm.setConfig("PUT", configBytes, yamlContentType, false)
+	return err
+}
+
+// PatchConfig - update some sections of the config of the setup,
+// leaving the others unchanged. The config holds a subset of the top
+// level keys of config.json, e.g. `{"storageclass": {...}}`.
+func (adm *AdminClient) PatchConfig(config io.Reader) (err error) {
+	configBytes, err := readConfig(config)
+	if err != nil {
+		return err
 	}
 
-	// Execute PUT on /minio/admin/v1/config to set config.
-	resp, err := adm.executeMethod("PUT", reqData)
+	var sections map[string]json.RawMessage
+	if err = json.Unmarshal(configBytes, &sections); err != nil {
+		return errors.New("Invalid JSON format: " + err.Error())
+	}
+	if _, ok := sections["version"]; ok {
+		return errors.New("The \"version\" key cannot be updated")
+	}
+	// Validate there are no duplicate keys in the JSON
+	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
+		return errors.New("Duplicate key in json file: " + err.Error())
+	}
+
+	_, err = adm.setConfig("PATCH", configBytes, "application/json", false)
+	return err
+}
+
+// SetConfigWithRestartDelay - set config supplied as config.json for
+// the setup, the servers restart to apply it after the delay, rounded
+// to seconds, unless CancelConfigRestart is called with the token of
+// the returned restart. The delay is between 1s and 5m.
+func (adm *AdminClient) SetConfigWithRestartDelay(config io.Reader, delay time.Duration) (restart ScheduledRestart, err error) {
+	if delay < time.Second {
+		return restart, ErrInvalidArgument("Restart delay must be at least one second")
+	}
+
+	configBytes, err := readConfigJSON(config)
+	if err != nil {
+		return restart, err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("restartDelay", (delay / time.Second * time.Second).String())
+	respBytes, _, err := adm.sendConfig("PUT", configBytes, "application/json", queryValues)
+	if err != nil {
+		return restart, err
+	}
+
+	err = json.Unmarshal(respBytes, &restart)
+	return restart, err
+}
+
+// CancelConfigRestart - cancels the delayed restart applying a config
+// set by SetConfigWithRestartDelay. The config stays saved, servers
+// run with their current config until they are restarted.
+func (adm *AdminClient) CancelConfigRestart(token string) error {
+	queryValues := url.Values{}
+	queryValues.Set("token", token)
 
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/config/restart/cancel",
+		queryValues: queryValues,
+	})
 	defer closeResponse(resp)
 	if err != nil {
 		return err
@@ -148,3 +317,182 @@ func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
 
 	return nil
 }
+
+// setConfig - sends a config with the given method, returns the
+// readiness of the servers if waitForReady is set. Otherwise returns
+// a ServiceSignalError if some servers did not acknowledge the restart
+// applying the config.
+func (adm *AdminClient) setConfig(method string, configBytes []byte, contentType string, waitForReady bool) ([]ConfigReadiness, error) {
+	queryValues := url.Values{}
+	if waitForReady {
+		queryValues.Set("waitForReady", "")
+	}
+
+	respBytes, _, err := adm.sendConfig(method, configBytes, contentType, queryValues)
+	if err != nil {
+		return nil, err
+	}
+
+	if !waitForReady {
+		acks, err := unmarshalServiceSignalAcks(respBytes)
+		if err != nil {
+			return nil, err
+		}
+		return nil, checkServiceSignalAcks(acks)
+	}
+
+	var readiness []ConfigReadiness
+	err = json.Unmarshal(respBytes, &readiness)
+	return readiness, err
+}
+
+// sendConfig - encrypts and sends a config with the given method and
+// query values, returns the body of the reply along with the warnings
+// of the server about the config.
+func (adm *AdminClient) sendConfig(method string, configBytes []byte, contentType string, queryValues url.Values) (respBytes []byte, warnings []string, err error) {
+	customHeaders := http.Header{"Content-Type": []string{contentType}}
+
+	var econfigBytes []byte
+	if adm.configKMS != nil {
+		customHeaders.Set(configEncryptionHeader, configEncryptionKMS)
+		econfigBytes, err = EncryptServerConfigDataKMS(adm.configKMS, adm.configKMSKeyID, configBytes)
+	} else {
+		econfigBytes, err = EncryptServerConfigData(adm.secretAccessKey, configBytes)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reqData := requestData{
+		relPath:       "/v1/config",
+		queryValues:   queryValues,
+		content:       econfigBytes,
+		customHeaders: customHeaders,
+	}
+
+	// Execute PUT or PATCH on /minio/admin/v1/config to set config.
+	resp, err := adm.executeMethod(method, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, httpRespToErrorResponse(resp)
+	}
+
+	// Warnings are only informative, ignore a malformed header.
+	if v := resp.Header.Get(configWarningsHeader); v != "" {
+		json.Unmarshal([]byte(v), &warnings)
+	}
+
+	respBytes, err = ioutil.ReadAll(resp.Body)
+	return respBytes, warnings, err
+}
+
+// ConfigEnvOverride - a config field whose value is taken from
+// environment variables instead of config.json.
+type ConfigEnvOverride struct {
+	Field   string   `json:"field"`
+	EnvVars []string `json:"envVars"`
+}
+
+// GetConfigEnvOverrides - returns config fields overridden by the
+// environment, changes to these fields through SetConfig are ignored.
+func (adm *AdminClient) GetConfigEnvOverrides() (overrides []ConfigEnvOverride, err error) {
+	// Execute GET on /minio/admin/v1/config/env to get overridden fields.
+	resp, err := adm.executeMethod("GET",
+		requestData{relPath: "/v1/config/env"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &overrides)
+	return overrides, err
+}
+
+// ServerConfigChecksum - checksum of the config a server runs with.
+type ServerConfigChecksum struct {
+	Addr       string `json:"addr"`
+	Checksum   string `json:"checksum,omitempty"`
+	Consistent bool   `json:"consistent"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ConfigConsistency - whether all servers run with the saved config.
+// Servers running with another config are listed as divergent,
+// servers which could not be reached only have an error.
+type ConfigConsistency struct {
+	Consistent bool                   `json:"consistent"`
+	Checksum   string                 `json:"checksum"`
+	Servers    []ServerConfigChecksum `json:"servers"`
+	Divergent  []string               `json:"divergent"`
+}
+
+// ConfigConsistency - compares the checksum of the config each server
+// runs with to the checksum of the saved config, to detect servers
+// which missed a config change.
+func (adm *AdminClient) ConfigConsistency() (consistency ConfigConsistency, err error) {
+	resp, err := adm.executeMethod("GET",
+		requestData{relPath: "/v1/config/consistency"})
+	defer closeResponse(resp)
+	if err != nil {
+		return consistency, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return consistency, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return consistency, err
+	}
+
+	err = json.Unmarshal(respBytes, &consistency)
+	return consistency, err
+}
+
+// ConfigStatus - whether the config a server runs with matches the
+// saved config.
+type ConfigStatus struct {
+	Consistent      bool      `json:"consistent"`
+	RunningChecksum string    `json:"runningChecksum"`
+	SavedChecksum   string    `json:"savedChecksum"`
+	LastSaved       time.Time `json:"lastSaved,omitempty"`
+}
+
+// ConfigStatus - compares the checksum of the config the server runs
+// with to the checksum of the saved config, and returns when the config
+// was last saved.
+func (adm *AdminClient) ConfigStatus() (status ConfigStatus, err error) {
+	resp, err := adm.executeMethod("GET",
+		requestData{relPath: "/v1/config/status"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
@@ -0,0 +1,131 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/minio/sio"
+)
+
+const (
+	// Header selecting how configs are encrypted in transit.
+	configEncryptionHeader = "X-Minio-Config-Encryption"
+
+	// Configs are encrypted with a key wrapped by a KMS.
+	configEncryptionKMS = "kms"
+
+	// Maximum size of the key envelope of a config encrypted
+	// with a KMS wrapped key.
+	maxConfigKeyEnvelopeSize = 64 * 1024
+)
+
+// Context bound to the keys encrypting configs.
+var configKMSContext = map[string]string{"minio": "config"}
+
+var errConfigKeyEnvelopeTooLarge = errors.New("config key envelope is too large")
+
+// ConfigKMS - a key management service which wraps the keys encrypting
+// server configs, such that config encryption does not depend on the
+// secret key. Its methods follow the KMS used for server side
+// encryption, e.g. Vault.
+type ConfigKMS interface {
+	// GenerateKey generates a new key using the master key
+	// referenced by keyID, and returns it along with the key
+	// sealed by the master key.
+	GenerateKey(keyID string, context map[string]string) (key [32]byte, sealedKey []byte, err error)
+
+	// UnsealKey unseals a key generated by GenerateKey with the
+	// same keyID and context.
+	UnsealKey(keyID string, sealedKey []byte, context map[string]string) (key [32]byte, err error)
+}
+
+// configKeyEnvelope - the sealed key of a config, stored in front of
+// the encrypted config.
+type configKeyEnvelope struct {
+	KeyID     string `json:"keyID"`
+	SealedKey []byte `json:"sealedKey"`
+}
+
+// SetConfigKMS - encrypt configs exchanged with the server with keys
+// wrapped by the given KMS master key, instead of the secret key. The
+// server must be configured with a KMS sharing the master key.
+func (adm *AdminClient) SetConfigKMS(kms ConfigKMS, keyID string) {
+	adm.configKMS = kms
+	adm.configKMSKeyID = keyID
+}
+
+// EncryptServerConfigDataKMS - encrypts server config data with a new
+// key generated by the KMS master key keyID.
+func EncryptServerConfigDataKMS(kms ConfigKMS, keyID string, data []byte) ([]byte, error) {
+	key, sealedKey, err := kms.GenerateKey(keyID, configKMSContext)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := json.Marshal(configKeyEnvelope{KeyID: keyID, SealedKey: sealedKey})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = binary.Write(&buf, binary.BigEndian, uint32(len(envelope))); err != nil {
+		return nil, err
+	}
+	buf.Write(envelope)
+	if _, err = sio.Encrypt(&buf, bytes.NewReader(data), sio.Config{Key: key[:]}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecryptServerConfigDataKMS - decrypts server config data encrypted
+// by EncryptServerConfigDataKMS.
+func DecryptServerConfigDataKMS(kms ConfigKMS, data io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(data, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > maxConfigKeyEnvelopeSize {
+		return nil, errConfigKeyEnvelopeTooLarge
+	}
+
+	envelopeBytes := make([]byte, size)
+	if _, err := io.ReadFull(data, envelopeBytes); err != nil {
+		return nil, err
+	}
+	var envelope configKeyEnvelope
+	if err := json.Unmarshal(envelopeBytes, &envelope); err != nil {
+		return nil, err
+	}
+
+	key, err := kms.UnsealKey(envelope.KeyID, envelope.SealedKey, configKMSContext)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := sio.DecryptReader(data, sio.Config{Key: key[:]})
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(decrypted)
+}
@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ConfigSectionChange - old and new values of a changed config
+// section, secrets redacted.
+type ConfigSectionChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// ConfigChange - change of the config persisted by a server.
+type ConfigChange struct {
+	Event    string                         `json:"event"`
+	Time     time.Time                      `json:"time"`
+	Server   string                         `json:"server"`
+	Summary  string                         `json:"summary"`
+	Sections []string                       `json:"sections"`
+	Changes  map[string]ConfigSectionChange `json:"changes"`
+}
+
+// ConfigChangeInfo - a config change, or the error which ended the
+// watch.
+type ConfigChangeInfo struct {
+	Change ConfigChange
+	Err    error
+}
+
+// WatchConfig - returns a channel receiving the config changes
+// persisted by the server until doneCh is closed. The channel is
+// closed when the watch ends, after an error if the server ended it.
+func (adm *AdminClient) WatchConfig(doneCh <-chan struct{}) (<-chan ConfigChangeInfo, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/config/watch"})
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	changeCh := make(chan ConfigChangeInfo)
+	go func() {
+		defer close(changeCh)
+		defer closeResponse(resp)
+
+		// Unblock the decoder once done.
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		go func() {
+			select {
+			case <-doneCh:
+				resp.Body.Close()
+			case <-stopCh:
+			}
+		}()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var info ConfigChangeInfo
+			if err := decoder.Decode(&info.Change); err != nil {
+				info = ConfigChangeInfo{Err: err}
+			}
+			select {
+			case <-doneCh:
+				return
+			default:
+			}
+			select {
+			case changeCh <- info:
+			case <-doneCh:
+				return
+			}
+			if info.Err != nil {
+				return
+			}
+		}
+	}()
+	return changeCh, nil
+}
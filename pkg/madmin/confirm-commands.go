@@ -0,0 +1,85 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Destructive admin actions which servers may require a confirmation
+// token for.
+const (
+	ConfirmServiceStop    = "service-stop"
+	ConfirmServiceRestart = "service-restart"
+	ConfirmSetCredentials = "set-credentials"
+)
+
+// Header carrying the confirmation token of a destructive admin
+// request.
+const confirmationHeader = "X-Minio-Admin-Confirmation"
+
+// AdminConfirmation - token confirming a destructive admin action
+// until it expires, and whether the server requires it.
+type AdminConfirmation struct {
+	Action   string    `json:"action"`
+	Token    string    `json:"token"`
+	Expiry   time.Time `json:"expiry"`
+	Required bool      `json:"required"`
+}
+
+// ConfirmAction - Requests a token confirming the given destructive
+// action, such as ConfirmServiceStop, for the credentials of the
+// client. Servers configured to require it reject the action unless
+// the token was set with SetConfirmationToken. A token confirms
+// requests sent to any server until it expires. Tokens guard against
+// destructive requests sent by mistake, not against a leaked key.
+func (adm *AdminClient) ConfirmAction(action string) (confirmation AdminConfirmation, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("action", action)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/confirm",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return confirmation, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return confirmation, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return confirmation, err
+	}
+
+	err = json.Unmarshal(respBytes, &confirmation)
+	return confirmation, err
+}
+
+// SetConfirmationToken - sends the given confirmation token, obtained
+// from ConfirmAction, with the following requests. An empty token
+// stops sending it.
+func (adm *AdminClient) SetConfirmationToken(token string) {
+	adm.confirmationToken = token
+}
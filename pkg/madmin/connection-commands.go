@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// ClientConnections - open connections of a client IP.
+type ClientConnections struct {
+	IP          string `json:"ip"`
+	Connections int    `json:"connections"`
+}
+
+// ServerConnections - open connections of each client IP of a server,
+// the clients with the most connections first. Limit is the maximum
+// number of open connections per client IP, 0 if unlimited, and
+// Rejected the number of connections rejected since the server
+// started.
+type ServerConnections struct {
+	Addr     string              `json:"addr"`
+	Error    string              `json:"error,omitempty"`
+	Limit    int                 `json:"limit"`
+	Rejected uint64              `json:"rejected"`
+	Clients  []ClientConnections `json:"clients,omitempty"`
+}
+
+// Connections - returns the open connections of each client IP of
+// each server.
+func (adm *AdminClient) Connections() ([]ServerConnections, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/connections"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []ServerConnections
+	err = json.Unmarshal(respBytes, &servers)
+	return servers, err
+}
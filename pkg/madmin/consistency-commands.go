@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ConsistencyNodeResult - outcome of the reads served by a server
+// during a consistency test.
+type ConsistencyNodeResult struct {
+	Addr string `json:"addr"`
+	// Last error returned by the server
+	Error string `json:"error,omitempty"`
+	Reads int    `json:"reads"`
+	// Reads which failed, did not find the object or returned
+	// other data than written
+	Failed  int `json:"failed"`
+	Missing int `json:"missing"`
+	Stale   int `json:"stale"`
+}
+
+// ConsistencyTestResult - outcome of a consistency test, Consistent
+// is set when every server read back every object as written.
+type ConsistencyTestResult struct {
+	Objects    int                     `json:"objects"`
+	Consistent bool                    `json:"consistent"`
+	Nodes      []ConsistencyNodeResult `json:"nodes"`
+}
+
+// ConsistencyTest - writes the given number of temporary objects and
+// reads each back from all servers right after writing it, reporting
+// the servers which served missing or stale data. A zero number of
+// objects uses the server default.
+func (adm *AdminClient) ConsistencyTest(objects int) (result ConsistencyTestResult, err error) {
+	queryValues := url.Values{}
+	if objects > 0 {
+		queryValues.Set("objects", strconv.Itoa(objects))
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/consistency/test",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(respBytes, &result)
+	return result, err
+}
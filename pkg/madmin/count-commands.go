@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Summaries of an object count.
+const (
+	ObjectCountRunning  = "running"
+	ObjectCountFinished = "finished"
+	ObjectCountStopped  = "stopped"
+)
+
+// BucketObjectCount - number of objects in a bucket.
+type BucketObjectCount struct {
+	Bucket string `json:"bucket"`
+	Count  int64  `json:"count"`
+}
+
+// ObjectCountStatus - progress of an object count. Count is the
+// number of objects counted so far, it is the object count of the
+// cluster once the count is finished.
+type ObjectCountStatus struct {
+	ClientToken   string              `json:"clientToken"`
+	Summary       string              `json:"summary"`
+	FailureDetail string              `json:"detail,omitempty"`
+	StartTime     time.Time           `json:"startTime"`
+	EndTime       time.Time           `json:"endTime,omitempty"`
+	Count         int64               `json:"count"`
+	Buckets       []BucketObjectCount `json:"buckets,omitempty"`
+}
+
+// CountObjects - starts counting the objects of the cluster, or of the
+// given bucket if not empty, by walking the namespace, or returns the
+// progress of the count identified by clientToken. forceStart stops a
+// running count to start a new one.
+func (adm *AdminClient) CountObjects(bucket, clientToken string, forceStart bool) (status ObjectCountStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	} else {
+		if bucket != "" {
+			queryValues.Set("bucket", bucket)
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/count",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
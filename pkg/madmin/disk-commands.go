@@ -0,0 +1,286 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DiskTestResult - outcome of a write, read and delete cycle on a
+// disk, latencies are only set for the steps which succeeded.
+type DiskTestResult struct {
+	Node          string        `json:"node"`
+	Disk          string        `json:"disk"`
+	Success       bool          `json:"success"`
+	Error         string        `json:"error,omitempty"`
+	WriteLatency  time.Duration `json:"writeLatency"`
+	ReadLatency   time.Duration `json:"readLatency"`
+	DeleteLatency time.Duration `json:"deleteLatency"`
+}
+
+// DiskUsage - capacity of a disk, in bytes. Used space is tracked by
+// the server and may differ from the capacity minus the free space,
+// which is what the used percentage is computed from.
+type DiskUsage struct {
+	Path            string  `json:"path"`
+	Error           string  `json:"error,omitempty"`
+	Total           uint64  `json:"total"`
+	Used            uint64  `json:"used"`
+	Free            uint64  `json:"free"`
+	UsedPercent     float64 `json:"usedPercent"`
+	ApproachingFull bool    `json:"approachingFull"`
+}
+
+// ServerDisksUsage - usage of the disks of a server.
+type ServerDisksUsage struct {
+	Addr  string      `json:"addr"`
+	Error string      `json:"error,omitempty"`
+	Disks []DiskUsage `json:"disks,omitempty"`
+}
+
+// TestDisk - Writes data to the disk of the node, e.g. "/data1" of
+// "10.0.0.1:9000", reads it back and deletes it. A failing disk is
+// reported in the result, not as an error.
+func (adm *AdminClient) TestDisk(node, disk string) (result DiskTestResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("disk", disk)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/disk/test",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(respBytes, &result)
+	return result, err
+}
+
+// DisksUsage - returns the capacity, used and free space of the disks
+// of each server. Disks whose used space reached threshold percent of
+// their capacity are flagged as approaching full, a threshold of 0
+// uses the default of 90 percent.
+func (adm *AdminClient) DisksUsage(threshold int) (servers []ServerDisksUsage, err error) {
+	queryValues := url.Values{}
+	if threshold > 0 {
+		queryValues.Set("threshold", strconv.Itoa(threshold))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/disks/usage",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &servers)
+	return servers, err
+}
+
+// DiskLayout - expected and actual position of a disk in the erasure
+// sets, from the identifiers of the format.json of the disks. A disk
+// is misplaced if it carries the identifier of another position,
+// usually because disks were mounted in the wrong order or on the
+// wrong server. The actual position is -1 if unknown.
+type DiskLayout struct {
+	Path         string `json:"path"`
+	ExpectedSet  int    `json:"expectedSet"`
+	ExpectedDisk int    `json:"expectedDisk"`
+	ExpectedUUID string `json:"expectedUUID"`
+	UUID         string `json:"uuid,omitempty"`
+	ActualSet    int    `json:"actualSet"`
+	ActualDisk   int    `json:"actualDisk"`
+	Misplaced    bool   `json:"misplaced"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ServerDisksLayout - layout of the disks of a server.
+type ServerDisksLayout struct {
+	Addr  string       `json:"addr"`
+	Error string       `json:"error,omitempty"`
+	Disks []DiskLayout `json:"disks,omitempty"`
+}
+
+// DisksLayout - layout of the disks of each server, consistent if no
+// disk is misplaced.
+type DisksLayout struct {
+	Consistent bool                `json:"consistent"`
+	Servers    []ServerDisksLayout `json:"servers"`
+}
+
+// DisksLayout - returns the expected and actual position in the
+// erasure sets of the disks of each server.
+func (adm *AdminClient) DisksLayout() (layout DisksLayout, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/disks/layout"})
+	defer closeResponse(resp)
+	if err != nil {
+		return layout, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return layout, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return layout, err
+	}
+
+	err = json.Unmarshal(respBytes, &layout)
+	return layout, err
+}
+
+// Reasons a node or a disk is offline.
+const (
+	// The node cannot be reached over the network.
+	OfflineReasonNetwork = "network-unreachable"
+	// The disk fails with I/O errors, is missing or is full.
+	OfflineReasonDiskIO = "disk-io-error"
+	// The disk is unformatted, or its format does not match the
+	// format of the deployment.
+	OfflineReasonFormat = "format-mismatch"
+	// No error was observed, e.g. since the server started.
+	OfflineReasonUnknown = "unknown"
+)
+
+// DiskStatus - whether the disk of an endpoint is online, or else why
+// it is offline along with the last error observed and the time the
+// disk has been offline since.
+type DiskStatus struct {
+	Endpoint      string    `json:"endpoint"`
+	Online        bool      `json:"online"`
+	Reason        string    `json:"reason,omitempty"`
+	LastError     string    `json:"lastError,omitempty"`
+	LastErrorTime time.Time `json:"lastErrorTime,omitempty"`
+	OfflineSince  time.Time `json:"offlineSince,omitempty"`
+}
+
+// NodeStatus - whether a node and each of its disks are online, as
+// seen by the server answering the request.
+type NodeStatus struct {
+	Node          string       `json:"node"`
+	Online        bool         `json:"online"`
+	Reason        string       `json:"reason,omitempty"`
+	LastError     string       `json:"lastError,omitempty"`
+	LastErrorTime time.Time    `json:"lastErrorTime,omitempty"`
+	Disks         []DiskStatus `json:"disks"`
+}
+
+// NodeStatus - returns whether the given node, as host:port, and each
+// of its disks are online, with the reason the offline ones are.
+func (adm *AdminClient) NodeStatus(node string) (status NodeStatus, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/node/" + node + "/status"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// DiskDrainStatus - progress of the heal of the erasure set of a disk
+// about to be removed. The disk is safe to remove once the heal
+// finished without failures and all the other disks of its erasure set
+// are online, otherwise Reason tells why it is not.
+type DiskDrainStatus struct {
+	ClientToken      string    `json:"clientToken"`
+	Node             string    `json:"node"`
+	Disk             string    `json:"disk"`
+	ErasureSet       int       `json:"erasureSet"`
+	Summary          string    `json:"summary"`
+	FailureDetail    string    `json:"detail,omitempty"`
+	StartTime        time.Time `json:"startTime"`
+	ItemsHealed      int64     `json:"itemsHealed"`
+	ItemsFailed      int64     `json:"itemsFailed"`
+	ObjectsSkipped   int64     `json:"objectsSkipped"`
+	SafeToRemove     bool      `json:"safeToRemove"`
+	Reason           string    `json:"reason,omitempty"`
+	OfflineSetDrives []string  `json:"offlineSetDrives,omitempty"`
+}
+
+// DrainDisk - Starts healing the erasure set of the disk of the node,
+// e.g. "/data1" of "10.0.0.1:9000", so that all its objects are fully
+// redundant on the other disks of the set before the disk is pulled.
+// With an empty clientToken a drain is started, otherwise the progress
+// of the drain of that client token is returned.
+func (adm *AdminClient) DrainDisk(node, disk, clientToken string) (status DiskDrainStatus, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("disk", disk)
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/disk/drain",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
@@ -0,0 +1,72 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// FederatedBucket - a bucket of the federation along with the servers
+// of the cluster serving it, as host:port.
+type FederatedBucket struct {
+	Name    string    `json:"name"`
+	Hosts   []string  `json:"hosts"`
+	Created time.Time `json:"created"`
+	Local   bool      `json:"local"`
+}
+
+// FederationStatus - state of the federation seen by a server. The
+// buckets are only listed if the federation store is reachable.
+// Unregistered holds the buckets of this cluster without a record in
+// the federation store, and Orphaned the buckets recorded as served by
+// this cluster which it does not have, both of which are unreachable
+// through the other clusters.
+type FederationStatus struct {
+	Enabled      bool              `json:"enabled"`
+	Domain       string            `json:"domain,omitempty"`
+	LocalIPs     []string          `json:"localIPs,omitempty"`
+	Reachable    bool              `json:"reachable"`
+	Error        string            `json:"error,omitempty"`
+	Buckets      []FederatedBucket `json:"buckets,omitempty"`
+	Unregistered []string          `json:"unregistered,omitempty"`
+	Orphaned     []string          `json:"orphaned,omitempty"`
+}
+
+// FederationStatus - returns the state of the federation seen by the
+// server.
+func (adm *AdminClient) FederationStatus() (status FederationStatus, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/federation"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
@@ -19,7 +19,9 @@ package madmin
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 )
 
 // SetCredsReq - xml to send to the server to set new credentials
@@ -29,28 +31,25 @@ type SetCredsReq struct {
 }
 
 // SetCredentials - Call Set Credentials API to set new access and
-// secret keys in the specified Minio server
+// secret keys in the specified Minio server. If any server in the
+// cluster fails to load the new credentials, the update is rolled
+// back and an error is returned.
 func (adm *AdminClient) SetCredentials(access, secret string) error {
-	// Setup request's body
-	body, err := json.Marshal(SetCredsReq{access, secret})
-	if err != nil {
-		return err
-	}
+	return adm.setCredentials(access, secret, false)
+}
 
-	ebody, err := EncryptServerConfigData(adm.secretAccessKey, body)
-	if err != nil {
-		return err
-	}
+// ForceSetCredentials - Same as SetCredentials but keeps the new
+// credentials even if some servers in the cluster fail to load them.
+func (adm *AdminClient) ForceSetCredentials(access, secret string) error {
+	return adm.setCredentials(access, secret, true)
+}
 
-	// Setup new request
-	reqData := requestData{
-		relPath: "/v1/config/credential",
-		content: ebody,
+func (adm *AdminClient) setCredentials(access, secret string, force bool) error {
+	queryValues := url.Values{}
+	if force {
+		queryValues.Set("force", "")
 	}
-
-	// Execute GET on bucket to list objects.
-	resp, err := adm.executeMethod("PUT", reqData)
-
+	resp, err := adm.putCredentials(access, secret, queryValues)
 	defer closeResponse(resp)
 	if err != nil {
 		return err
@@ -64,3 +63,77 @@ func (adm *AdminClient) SetCredentials(access, secret string) error {
 
 	return nil
 }
+
+// Credential subsystems reported by SetCredentialsDryRun.
+const (
+	CredentialSubsystemPeers        = "peers"
+	CredentialSubsystemNotification = "notification"
+	CredentialSubsystemFederation   = "federation"
+	CredentialSubsystemBrowser      = "browser"
+	CredentialSubsystemPresigned    = "presigned"
+	CredentialSubsystemConfigHook   = "config-hook"
+)
+
+// CredentialReference - subsystem depending on the current
+// credentials of the server.
+type CredentialReference struct {
+	Subsystem string `json:"subsystem"`
+	Detail    string `json:"detail"`
+}
+
+// CredentialsImpact - impact of setting new credentials, reported
+// without setting them.
+type CredentialsImpact struct {
+	AccessKeyChanged bool                  `json:"accessKeyChanged"`
+	SecretKeyChanged bool                  `json:"secretKeyChanged"`
+	References       []CredentialReference `json:"references"`
+	// Conflicts are problems the new credentials would cause.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// SetCredentialsDryRun - validates the given access and secret keys
+// and reports the subsystems depending on the current credentials,
+// which would need the new ones, without setting them.
+func (adm *AdminClient) SetCredentialsDryRun(access, secret string) (impact CredentialsImpact, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("dryRun", "")
+	resp, err := adm.putCredentials(access, secret, queryValues)
+	defer closeResponse(resp)
+	if err != nil {
+		return impact, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return impact, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return impact, err
+	}
+
+	err = json.Unmarshal(respBytes, &impact)
+	return impact, err
+}
+
+// putCredentials - sends the given access and secret keys encrypted
+// with the current secret key to the set credentials API.
+func (adm *AdminClient) putCredentials(access, secret string, queryValues url.Values) (*http.Response, error) {
+	// Setup request's body
+	body, err := json.Marshal(SetCredsReq{access, secret})
+	if err != nil {
+		return nil, err
+	}
+
+	ebody, err := EncryptServerConfigData(adm.secretAccessKey, body)
+	if err != nil {
+		return nil, err
+	}
+
+	reqData := requestData{
+		relPath:     "/v1/config/credential",
+		queryValues: queryValues,
+		content:     ebody,
+	}
+	return adm.executeMethod("PUT", reqData)
+}
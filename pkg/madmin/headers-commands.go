@@ -0,0 +1,71 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// SetResponseHeaders - sets the custom headers added to all responses
+// of all servers, replacing the previous ones. No headers removes them.
+func (adm *AdminClient) SetResponseHeaders(headers map[string]string) error {
+	content, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/headers",
+		content: content,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ResponseHeaders - returns the custom headers added to all responses.
+func (adm *AdminClient) ResponseHeaders() (map[string]string, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/headers"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers map[string]string
+	if err = json.Unmarshal(respBytes, &headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
@@ -18,18 +18,53 @@
 package madmin
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"time"
 )
 
+// HealScanMode - represents the type of scan performed on object
+// data while healing.
+type HealScanMode int
+
+const (
+	// HealDeepScan reads every part and verifies its bitrot
+	// checksum, the default.
+	HealDeepScan HealScanMode = iota
+	// HealQuickScan only checks that all parts of an object are
+	// present and have the expected size, which is considerably
+	// cheaper but misses silent corruption.
+	HealQuickScan
+)
+
 // HealOpts - collection of options for a heal sequence
 type HealOpts struct {
-	Recursive bool `json:"recursive"`
-	DryRun    bool `json:"dryRun"`
+	Recursive bool         `json:"recursive"`
+	DryRun    bool         `json:"dryRun"`
+	ScanMode  HealScanMode `json:"scanMode"`
+	// Resume continues a recursive heal from the checkpoint left by
+	// a previous interrupted heal of the same path.
+	Resume bool `json:"resume"`
+	// MaxConcurrency caps the number of objects healed in parallel
+	// by a recursive heal, zero heals one object at a time.
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// NotifyARN is the ARN of a notification target, as listed in
+	// the SQSARN of the server properties, receiving events when
+	// the heal starts, progresses and ends.
+	NotifyARN string `json:"notifyARN,omitempty"`
+	// NotifyInterval is the time between two progress events sent
+	// to NotifyARN, zero sends one every minute.
+	NotifyInterval time.Duration `json:"notifyInterval,omitempty"`
+	// ErasureSet, when not nil, restricts the heal to the objects
+	// stored on the erasure set with this zero-based index, which is
+	// much faster than healing the whole path after replacing a
+	// disk of that set.
+	ErasureSet *int `json:"erasureSet,omitempty"`
 }
 
 // HealStartSuccess - holds information about a successfully started
@@ -48,6 +83,17 @@ type HealTaskStatus struct {
 	HealSettings  HealOpts  `json:"settings"`
 	NumDisks      int       `json:"numDisks"`
 
+	// Number of objects healed in parallel.
+	Concurrency int `json:"concurrency"`
+
+	// Number of object parts found with a bitrot checksum
+	// mismatch, only reported by a deep scan.
+	ChecksumFailures int64 `json:"checksumFailures"`
+
+	// Number of objects of other erasure sets skipped by a heal
+	// restricted to one erasure set.
+	ObjectsSkipped int64 `json:"objectsSkipped"`
+
 	Items []HealResultItem `json:"items,omitempty"`
 }
 
@@ -71,6 +117,53 @@ const (
 	DriveStateMissing        = "missing"
 )
 
+// HealResultFilter - category of heal result items returned by a heal
+// status request.
+type HealResultFilter string
+
+// Heal result filter constants
+const (
+	// Items which could not be healed, or still have corrupt or
+	// missing drives after heal.
+	HealFilterFailed HealResultFilter = "failed"
+	// Items with corrupt drives before heal.
+	HealFilterCorrupted HealResultFilter = "corrupted"
+	// Items with missing drives before heal.
+	HealFilterMissing HealResultFilter = "missing"
+)
+
+// IsValid - returns whether the filter is a known one, the empty
+// filter matching all items.
+func (f HealResultFilter) IsValid() bool {
+	switch f {
+	case "", HealFilterFailed, HealFilterCorrupted, HealFilterMissing:
+		return true
+	}
+	return false
+}
+
+// HealResultSort - order of heal result items returned by a heal
+// status request, scan order by default.
+type HealResultSort string
+
+// Heal result sort constants
+const (
+	// Items which could not be healed or remain unreadable first,
+	// then items by increasing number of drives they can still
+	// lose.
+	HealSortSeverity HealResultSort = "severity"
+)
+
+// IsValid - returns whether the sort order is a known one, the empty
+// one keeping the scan order.
+func (s HealResultSort) IsValid() bool {
+	switch s {
+	case "", HealSortSeverity:
+		return true
+	}
+	return false
+}
+
 // HealDriveInfo - struct for an individual drive info item.
 type HealDriveInfo struct {
 	UUID     string `json:"uuid"`
@@ -97,6 +190,9 @@ type HealResultItem struct {
 		Drives []HealDriveInfo `json:"drives"`
 	} `json:"after"`
 	ObjectSize int64 `json:"objectSize"`
+	// Number of drives on which a bitrot checksum mismatch was
+	// found, only reported by a deep scan.
+	ChecksumFailures int `json:"checksumFailures,omitempty"`
 }
 
 // GetMissingCounts - returns the number of missing disks before
@@ -185,11 +281,6 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 		return healStart, healTaskStatus, err
 	}
 
-	path := fmt.Sprintf("/v1/heal/%s", bucket)
-	if bucket != "" && prefix != "" {
-		path += "/" + prefix
-	}
-
 	// execute POST request to heal api
 	queryVals := make(url.Values)
 	if clientToken != "" {
@@ -201,7 +292,7 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 	}
 
 	resp, err := adm.executeMethod("POST", requestData{
-		relPath:     path,
+		relPath:     healPath(bucket, prefix),
 		content:     body,
 		queryValues: queryVals,
 	})
@@ -221,9 +312,181 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 
 	// Was it a status request?
 	if clientToken == "" {
+		// Once the server started the response to keep the
+		// connection alive, errors come with a 200 status.
+		var errResp ErrorResponse
+		if json.Unmarshal(respBytes, &errResp) == nil && errResp.Code != "" {
+			return healStart, healTaskStatus, errResp
+		}
 		err = json.Unmarshal(respBytes, &healStart)
 	} else {
 		err = json.Unmarshal(respBytes, &healTaskStatus)
 	}
 	return healStart, healTaskStatus, err
 }
+
+// HealStatus - fetches the heal result items of the heal sequence of
+// the given client token, like Heal, returning only the items
+// matching filter. Items not matching it are not returned by later
+// status requests either.
+func (adm *AdminClient) HealStatus(bucket, prefix, clientToken string,
+	filter HealResultFilter) (healTaskStatus HealTaskStatus, err error) {
+	return adm.HealStatusSorted(bucket, prefix, clientToken, filter, "")
+}
+
+// HealStatusSorted - fetches the heal result items of the heal
+// sequence of the given client token like HealStatus, in the given
+// order. Items are sorted within each reply, which holds the items
+// healed since the previous status request.
+func (adm *AdminClient) HealStatusSorted(bucket, prefix, clientToken string,
+	filter HealResultFilter, sortBy HealResultSort) (healTaskStatus HealTaskStatus, err error) {
+
+	queryVals := make(url.Values)
+	queryVals.Set("clientToken", clientToken)
+	if filter != "" {
+		queryVals.Set("filter", string(filter))
+	}
+	if sortBy != "" {
+		queryVals.Set("sortBy", string(sortBy))
+	}
+
+	// Heal status replies listing many result items are compressed
+	// by the server, the transport does not decompress them as the
+	// encoding is requested explicitly.
+	customHeaders := make(http.Header)
+	customHeaders.Set("Accept-Encoding", "gzip")
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:       healPath(bucket, prefix),
+		queryValues:   queryVals,
+		customHeaders: customHeaders,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return healTaskStatus, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return healTaskStatus, httpRespToErrorResponse(resp)
+	}
+
+	var body io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return healTaskStatus, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+
+	respBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return healTaskStatus, err
+	}
+
+	err = json.Unmarshal(respBytes, &healTaskStatus)
+	return healTaskStatus, err
+}
+
+// healPath - returns the heal API path of a bucket and prefix.
+func healPath(bucket, prefix string) string {
+	path := fmt.Sprintf("/v1/heal/%s", bucket)
+	if bucket != "" && prefix != "" {
+		path += "/" + prefix
+	}
+	return path
+}
+
+// HealPause - suspends the heal sequence of the given client token,
+// which keeps its progress and reports a `paused` summary until it is
+// resumed with HealResume.
+func (adm *AdminClient) HealPause(clientToken string) error {
+	return adm.healAction(clientToken, "pause")
+}
+
+// HealResume - resumes the paused heal sequence of the given client
+// token where it was suspended.
+func (adm *AdminClient) HealResume(clientToken string) error {
+	return adm.healAction(clientToken, "resume")
+}
+
+func (adm *AdminClient) healAction(clientToken, action string) error {
+	queryVals := make(url.Values)
+	queryVals.Set("action", action)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/heal/" + clientToken,
+		queryValues: queryVals,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// AutoHealStatus - state of the heal sequence launched automatically
+// after drives came back online or were replaced.
+type AutoHealStatus struct {
+	Enabled          bool      `json:"enabled"`
+	Leader           bool      `json:"leader"`
+	Active           bool      `json:"active"`
+	TriggeredBy      []string  `json:"triggeredBy,omitempty"`
+	Summary          string    `json:"summary,omitempty"`
+	FailureDetail    string    `json:"detail,omitempty"`
+	StartTime        time.Time `json:"startTime,omitempty"`
+	ItemsHealed      int64     `json:"itemsHealed"`
+	ChecksumFailures int64     `json:"checksumFailures"`
+}
+
+// AutoHealStatus - Fetches the state of the most recent automatic
+// heal. Automatic heals are only launched when enabled with
+// SetAutoHeal, by the leader server which reports their progress.
+func (adm *AdminClient) AutoHealStatus() (status AutoHealStatus, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/heal/auto"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// SetAutoHeal - enables or disables the heal of all buckets launched
+// automatically after drives came back online or were replaced.
+func (adm *AdminClient) SetAutoHeal(enable bool) error {
+	queryValues := url.Values{}
+	queryValues.Set("enable", "off")
+	if enable {
+		queryValues.Set("enable", "on")
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/autoheal",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -67,11 +69,14 @@ type StorageInfo struct {
 // ServerProperties holds some of the server's information such as uptime,
 // version, region, ..
 type ServerProperties struct {
-	Uptime   time.Duration `json:"uptime"`
-	Version  string        `json:"version"`
-	CommitID string        `json:"commitID"`
-	Region   string        `json:"region"`
-	SQSARN   []string      `json:"sqsARN"`
+	Uptime         time.Duration `json:"uptime"`
+	UptimeSeconds  float64       `json:"uptimeSeconds"`
+	Version        string        `json:"version"`
+	CommitID       string        `json:"commitID"`
+	Region         string        `json:"region"`
+	SQSARN         []string      `json:"sqsARN"`
+	ConfigChecksum string        `json:"configChecksum,omitempty"`
+	SafeMode       bool          `json:"safeMode,omitempty"`
 }
 
 // ServerConnStats holds network information
@@ -83,8 +88,9 @@ type ServerConnStats struct {
 // ServerHTTPMethodStats holds total number of HTTP operations from/to the server,
 // including the average duration the call was spent.
 type ServerHTTPMethodStats struct {
-	Count       uint64 `json:"count"`
-	AvgDuration string `json:"avgDuration"`
+	Count             uint64  `json:"count"`
+	AvgDuration       string  `json:"avgDuration"`
+	AvgDurationMillis float64 `json:"avgDurationMillis"`
 }
 
 // ServerHTTPStats holds all type of http operations performed to/from the server
@@ -102,6 +108,18 @@ type ServerHTTPStats struct {
 	SuccessDELETEStats ServerHTTPMethodStats `json:"successDELETEs"`
 }
 
+// ServerLoad holds the current load of a server. Score synthesizes
+// it into a value comparable across servers, higher when busier, so
+// that load balancers can weight traffic away from hot servers. A
+// component close to 1 means the resource it measures is saturated.
+type ServerLoad struct {
+	ActiveRequests int     `json:"activeRequests"`
+	CPUs           int     `json:"cpus"`
+	LoadAverage    float64 `json:"loadAverage"`
+	DiskQueueDepth float64 `json:"diskQueueDepth"`
+	Score          float64 `json:"score"`
+}
+
 // ServerInfoData holds storage, connections and other
 // information of a given server
 type ServerInfoData struct {
@@ -109,6 +127,7 @@ type ServerInfoData struct {
 	ConnStats   ServerConnStats  `json:"network"`
 	HTTPStats   ServerHTTPStats  `json:"http"`
 	Properties  ServerProperties `json:"server"`
+	Load        ServerLoad       `json:"load"`
 }
 
 // ServerInfo holds server information result of one node
@@ -140,6 +159,10 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 		return nil, err
 	}
 
+	if err = adm.verifyResponseSignature(resp, respBytes); err != nil {
+		return nil, err
+	}
+
 	err = json.Unmarshal(respBytes, &serversInfo)
 	if err != nil {
 		return nil, err
@@ -147,3 +170,297 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 
 	return serversInfo, nil
 }
+
+// ServerInfoRollup holds the server information of all nodes
+// aggregated into cluster totals.
+type ServerInfoRollup struct {
+	Servers        int             `json:"servers"`
+	OnlineServers  int             `json:"onlineServers"`
+	OfflineServers int             `json:"offlineServers"`
+	ConnStats      ServerConnStats `json:"network"`
+	HTTPStats      ServerHTTPStats `json:"http"`
+	MinUptime      time.Duration   `json:"minUptime"`
+	MaxUptime      time.Duration   `json:"maxUptime"`
+	AvgUptime      time.Duration   `json:"avgUptime"`
+	OnlineDisks    int             `json:"onlineDisks"`
+	OfflineDisks   int             `json:"offlineDisks"`
+}
+
+// ServerInfoRollup - returns the server information of all nodes
+// aggregated into cluster totals: summed bytes and request counts,
+// uptime bounds and disks.
+func (adm *AdminClient) ServerInfoRollup() (rollup ServerInfoRollup, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("rollup", "true")
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/info",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return rollup, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return rollup, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return rollup, err
+	}
+
+	if err = adm.verifyResponseSignature(resp, respBytes); err != nil {
+		return rollup, err
+	}
+
+	err = json.Unmarshal(respBytes, &rollup)
+	return rollup, err
+}
+
+// Sort orders supported by BucketsUsage.
+const (
+	BucketsSortByName = "name"
+	BucketsSortBySize = "size"
+)
+
+// BucketUsageInfo - holds the creation time and usage of a bucket.
+type BucketUsageInfo struct {
+	Name         string    `json:"name"`
+	Created      time.Time `json:"created"`
+	ObjectsCount uint64    `json:"objectsCount"`
+	Size         uint64    `json:"size"`
+}
+
+// BucketsUsage - holds a page of buckets usage information.
+type BucketsUsage struct {
+	Buckets     []BucketUsageInfo `json:"buckets"`
+	Total       int               `json:"total"`
+	IsTruncated bool              `json:"isTruncated"`
+}
+
+// BucketsUsage - Fetches a page of at most limit buckets starting at
+// offset, with their object count and total size. sortBy is one of
+// BucketsSortByName or BucketsSortBySize, a zero limit uses the server
+// default.
+func (adm *AdminClient) BucketsUsage(sortBy string, offset, limit int) (usage BucketsUsage, err error) {
+	queryValues := url.Values{}
+	if sortBy != "" {
+		queryValues.Set("sortBy", sortBy)
+	}
+	if offset > 0 {
+		queryValues.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		queryValues.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/buckets",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return usage, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return usage, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return usage, err
+	}
+
+	err = json.Unmarshal(respBytes, &usage)
+	return usage, err
+}
+
+// Sources a storage class parity can be configured from.
+const (
+	StorageClassFromEnv     = "env"
+	StorageClassFromConfig  = "config"
+	StorageClassFromDefault = "default"
+)
+
+// StorageClassParity - holds the data and parity shard counts in
+// effect for a storage class and where they were configured from.
+type StorageClassParity struct {
+	Name   string `json:"name"`
+	Data   int    `json:"data"`
+	Parity int    `json:"parity"`
+	Source string `json:"source"`
+}
+
+// StorageClassInfo - holds the effective erasure configuration of
+// each storage class.
+type StorageClassInfo struct {
+	SetDriveCount int                  `json:"setDriveCount"`
+	Classes       []StorageClassParity `json:"classes"`
+}
+
+// StorageClassInfo - Fetches the data and parity shard counts in
+// effect for the STANDARD and REDUCED_REDUNDANCY storage classes.
+func (adm *AdminClient) StorageClassInfo() (info StorageClassInfo, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/storageclass"})
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(respBytes, &info)
+	return info, err
+}
+
+// ServerAPIErrorStats - count of error responses sent by a server,
+// indexed by API error code.
+type ServerAPIErrorStats struct {
+	Error  string            `json:"error"`
+	Addr   string            `json:"addr"`
+	Counts map[string]uint64 `json:"counts"`
+}
+
+// APIErrorStats - Fetches the count of error responses sent by each
+// server, indexed by API error code such as "AccessDenied".
+func (adm *AdminClient) APIErrorStats() ([]ServerAPIErrorStats, error) {
+	return adm.apiErrorStats("GET")
+}
+
+// ResetAPIErrorStats - Resets the count of error responses of each
+// server to zero, returning the counts read before the reset.
+func (adm *AdminClient) ResetAPIErrorStats() ([]ServerAPIErrorStats, error) {
+	return adm.apiErrorStats("POST")
+}
+
+// apiErrorStats - fetches the count of error responses of each server,
+// resetting them with the POST method.
+func (adm *AdminClient) apiErrorStats(method string) ([]ServerAPIErrorStats, error) {
+	resp, err := adm.executeMethod(method, requestData{relPath: "/v1/stats/errors"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []ServerAPIErrorStats
+	if err = json.Unmarshal(respBytes, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// SlowRequest - a request which exceeded the latency budget of its
+// method.
+type SlowRequest struct {
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	Path       string        `json:"path"`
+	StatusCode int           `json:"statusCode"`
+	Duration   time.Duration `json:"duration"`
+	Budget     time.Duration `json:"budget"`
+}
+
+// ServerSlowRequests - most recent slow requests served by a server.
+type ServerSlowRequests struct {
+	Error    string        `json:"error"`
+	Addr     string        `json:"addr"`
+	Requests []SlowRequest `json:"requests"`
+}
+
+// SlowRequests - Fetches the most recent requests of each server which
+// exceeded the latency budget of their method, most recent first.
+// Budgets are set on the server with MINIO_SLOW_REQUEST_BUDGET.
+func (adm *AdminClient) SlowRequests() ([]ServerSlowRequests, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/stats/slow"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []ServerSlowRequests
+	if err = json.Unmarshal(respBytes, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// MetricsSample - connection and HTTP statistics of a server at a
+// point in time.
+type MetricsSample struct {
+	Time      time.Time       `json:"time"`
+	ConnStats ServerConnStats `json:"network"`
+	HTTPStats ServerHTTPStats `json:"http"`
+}
+
+// ServerMetricsHistory - metrics samples of a server.
+type ServerMetricsHistory struct {
+	Error   string          `json:"error"`
+	Addr    string          `json:"addr"`
+	Samples []MetricsSample `json:"samples"`
+}
+
+// MetricsHistory - Fetches the connection and HTTP statistics of each
+// server sampled between from and to, oldest first. A zero from or to
+// leaves the range open on that side. Servers keep samples for the
+// retention set with MINIO_METRICS_HISTORY.
+func (adm *AdminClient) MetricsHistory(from, to time.Time) ([]ServerMetricsHistory, error) {
+	queryValues := url.Values{}
+	if !from.IsZero() {
+		queryValues.Set("from", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		queryValues.Set("to", to.Format(time.RFC3339))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/metrics/history",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []ServerMetricsHistory
+	if err = json.Unmarshal(respBytes, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
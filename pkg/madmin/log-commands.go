@@ -0,0 +1,114 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LogLevels - default log level of a server and levels of the
+// subsystems which override it.
+type LogLevels struct {
+	Default    string            `json:"default"`
+	Subsystems map[string]string `json:"subsystems,omitempty"`
+}
+
+// GetLogLevels - returns the log levels of the server.
+func (adm *AdminClient) GetLogLevels() (levels LogLevels, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/loglevel"})
+	defer closeResponse(resp)
+	if err != nil {
+		return levels, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return levels, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return levels, err
+	}
+
+	err = json.Unmarshal(respBytes, &levels)
+	return levels, err
+}
+
+// SetLogLevel - sets the log level, one of "debug", "info", "error" or
+// "fatal", of a subsystem on all servers. An empty subsystem sets the
+// default level. An empty level removes the level of the subsystem,
+// or restores "error" as the default level. The change takes effect
+// immediately.
+func (adm *AdminClient) SetLogLevel(subsystem, level string) error {
+	queryValues := url.Values{}
+	if subsystem != "" {
+		queryValues.Set("subsystem", subsystem)
+	}
+	queryValues.Set("level", level)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/loglevel",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// LogsBundle - returns a zip archive, meant to be attached to support
+// cases, holding the most recent logs of each server, their server
+// information and the config of the setup with its secrets redacted.
+// A zero number of lines includes the default number of log entries
+// of each server, a non zero since includes the entries logged during
+// that duration only.
+func (adm *AdminClient) LogsBundle(lines int, since time.Duration) ([]byte, error) {
+	queryValues := url.Values{}
+	if lines > 0 {
+		queryValues.Set("lines", strconv.Itoa(lines))
+	}
+	if since > 0 {
+		queryValues.Set("since", since.String())
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/logs/bundle",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,105 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PartSizeRange - number of parts uploaded whose size is at least Min
+// bytes and less than Max bytes, Max is 0 for the largest parts.
+type PartSizeRange struct {
+	Min   int64  `json:"min"`
+	Max   int64  `json:"max,omitempty"`
+	Parts uint64 `json:"parts"`
+}
+
+// PartSizeStats - parts of multipart uploads uploaded to a server since
+// it started, and uploads whose completion was rejected because a part
+// was smaller than the minimum part size.
+type PartSizeStats struct {
+	Parts           uint64          `json:"parts"`
+	Bytes           uint64          `json:"bytes"`
+	RejectedUploads uint64          `json:"rejectedUploads"`
+	PartsBySize     []PartSizeRange `json:"partsBySize"`
+}
+
+// ServerPartSizeStats - part size stats of a server.
+type ServerPartSizeStats struct {
+	Addr  string `json:"addr"`
+	Error string `json:"error,omitempty"`
+	PartSizeStats
+}
+
+// MultipartPolicy - minimum size of the parts of multipart uploads
+// apart from the last one, DefaultMinPartSize unless configured
+// otherwise, along with the part size stats of each server.
+type MultipartPolicy struct {
+	MinPartSize        int64                 `json:"minPartSize"`
+	DefaultMinPartSize int64                 `json:"defaultMinPartSize"`
+	Servers            []ServerPartSizeStats `json:"servers"`
+}
+
+// MultipartPolicy - returns the minimum part size of multipart uploads
+// and the sizes of the parts uploaded to each server.
+func (adm *AdminClient) MultipartPolicy() (policy MultipartPolicy, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/multipart/policy"})
+	defer closeResponse(resp)
+	if err != nil {
+		return policy, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return policy, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return policy, err
+	}
+
+	err = json.Unmarshal(respBytes, &policy)
+	return policy, err
+}
+
+// SetMinPartSize - sets the minimum size, in bytes, of the parts of
+// multipart uploads apart from the last one on all servers. Uploads
+// with smaller parts fail to complete. A size of 0 restores the
+// default of 5MiB.
+func (adm *AdminClient) SetMinPartSize(size int64) error {
+	queryValues := url.Values{}
+	queryValues.Set("minPartSize", strconv.FormatInt(size, 10))
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/multipart/policy",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
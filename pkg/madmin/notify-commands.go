@@ -0,0 +1,99 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// NotifyReplayResult - number of queued events replayed, and of those
+// which failed again and are kept queued.
+type NotifyReplayResult struct {
+	Replayed int `json:"replayed"`
+	Failed   int `json:"failed"`
+}
+
+// NotifyReplay - Redrives events which could not be delivered to
+// their notification targets. Events are only queued when the server
+// runs with MINIO_NOTIFY_REPLAY=on.
+func (adm *AdminClient) NotifyReplay() (result NotifyReplayResult, err error) {
+	resp, err := adm.executeMethod("POST", requestData{relPath: "/v1/notify/replay"})
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(respBytes, &result)
+	return result, err
+}
+
+// NotifyQueue - backlog of events being sent to a notification
+// target by a server.
+type NotifyQueue struct {
+	ARN string `json:"arn"`
+	// Number of events being sent to the target.
+	Queued int `json:"queued"`
+	// Age of the oldest event being sent, zero if none.
+	OldestEventAge time.Duration `json:"oldestEventAge"`
+	// Fraction of the most recent deliveries which failed.
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// ServerNotifyQueues - backlogs of the notification targets of a
+// server.
+type ServerNotifyQueues struct {
+	Addr   string        `json:"addr"`
+	Error  string        `json:"error,omitempty"`
+	Queues []NotifyQueue `json:"queues,omitempty"`
+}
+
+// NotifyQueues - returns, for each server, the number of events being
+// sent to each notification target, the age of the oldest of them and
+// the delivery error rate of the target.
+func (adm *AdminClient) NotifyQueues() ([]ServerNotifyQueues, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/notify/queues"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var queues []ServerNotifyQueues
+	err = json.Unmarshal(respBytes, &queues)
+	return queues, err
+}
@@ -0,0 +1,187 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PolicySimulationArgs - a candidate bucket policy and the request
+// to evaluate it against.
+type PolicySimulationArgs struct {
+	// Bucket policy, as set with PutBucketPolicy.
+	Policy json.RawMessage `json:"policy"`
+
+	// Access key of the requester, empty for anonymous requests.
+	Principal string `json:"principal"`
+
+	// Action of the request, e.g. "s3:GetObject".
+	Action string `json:"action"`
+
+	// Resource of the request, e.g. "mybucket/myobject", with or
+	// without the "arn:aws:s3:::" prefix.
+	Resource string `json:"resource"`
+
+	// Condition values of the request, e.g. "SourceIp".
+	ConditionValues map[string][]string `json:"conditionValues,omitempty"`
+}
+
+// PolicySimulationResult - outcome of evaluating a bucket policy.
+type PolicySimulationResult struct {
+	Allowed bool `json:"allowed"`
+
+	// Index of the statement which decided the outcome, -1 if
+	// none did: the owner is allowed unless a statement denies,
+	// anybody else is denied unless a statement allows.
+	StatementIndex int `json:"statementIndex"`
+
+	// Statement which decided the outcome, if any.
+	Statement json.RawMessage `json:"statement,omitempty"`
+}
+
+// SimulatePolicy - Evaluates a bucket policy against a request without
+// applying the policy, to check its effect before setting it.
+func (adm *AdminClient) SimulatePolicy(args PolicySimulationArgs) (result PolicySimulationResult, err error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return result, err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/policy/simulate",
+		content: body,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(respBytes, &result)
+	return result, err
+}
+
+// AccessExplanation - outcome of the authorization of a request by
+// the policy applied to its bucket.
+type AccessExplanation struct {
+	Allowed bool `json:"allowed"`
+
+	// Whether the principal is the owner, i.e. the root account,
+	// which is allowed unless a statement denies.
+	IsOwner bool `json:"isOwner"`
+
+	// Whether a policy is applied to the bucket. Without one, only
+	// the owner is allowed.
+	HasBucketPolicy bool `json:"hasBucketPolicy"`
+
+	// Index of the statement of the bucket policy which decided the
+	// outcome, -1 if none did.
+	StatementIndex int `json:"statementIndex"`
+
+	// Statement which decided the outcome, if any.
+	Statement json.RawMessage `json:"statement,omitempty"`
+}
+
+// ExplainAccess - Evaluates a request against the policy currently
+// applied to its bucket, to find out why a principal, given by its
+// access key or empty for anonymous requests, can or cannot perform
+// an action, e.g. "s3:GetObject", on a bucket or an object.
+func (adm *AdminClient) ExplainAccess(principal, bucket, object, action string) (explanation AccessExplanation, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("principal", principal)
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+	queryValues.Set("action", action)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/access/explain",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return explanation, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return explanation, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return explanation, err
+	}
+
+	err = json.Unmarshal(respBytes, &explanation)
+	return explanation, err
+}
+
+// PostPolicyValidation - outcome of the validation of a POST policy,
+// as embedded in the form of presigned POST uploads.
+type PostPolicyValidation struct {
+	// Whether uploads using the policy can succeed, i.e. the policy
+	// parses and has no errors.
+	Valid bool `json:"valid"`
+
+	// Expiration of the policy, if it parses.
+	Expiration time.Time `json:"expiration,omitempty"`
+
+	// Errors making uploads using the policy fail.
+	Errors []string `json:"errors,omitempty"`
+
+	// Conditions which are never checked or which leave uploads
+	// unconstrained.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ValidatePostPolicy - Validates a POST policy, either the JSON
+// document or its base64 encoding as sent in the form, the same way
+// the server parses it for presigned POST uploads, without issuing
+// any upload.
+func (adm *AdminClient) ValidatePostPolicy(policy []byte) (validation PostPolicyValidation, err error) {
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/presign/validate",
+		content: policy,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return validation, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return validation, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return validation, err
+	}
+
+	err = json.Unmarshal(respBytes, &validation)
+	return validation, err
+}
@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// RateLimit - cap on the request rate of a tenant, a bucket or a
+// prefix of a bucket.
+type RateLimit struct {
+	Bucket            string  `json:"bucket"`
+	Prefix            string  `json:"prefix,omitempty"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+}
+
+// RateLimitStatus - rate limit of a tenant along with the request
+// rate observed by a server.
+type RateLimitStatus struct {
+	RateLimit
+	ObservedRate float64 `json:"observedRate"`
+}
+
+// ServerRateLimits - rate limits of tenants on a server.
+type ServerRateLimits struct {
+	Addr   string            `json:"addr"`
+	Error  string            `json:"error,omitempty"`
+	Limits []RateLimitStatus `json:"limits,omitempty"`
+}
+
+// SetRateLimit - sets the maximum number of requests per second on the
+// given bucket or prefix of a bucket, on all servers. Each server
+// enforces the limit on the requests it receives, so a cluster of N
+// servers admits up to N times the limit. Requests over the limit are
+// rejected with a 429 status. A zero rate removes the limit.
+func (adm *AdminClient) SetRateLimit(limit RateLimit) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", limit.Bucket)
+	queryValues.Set("prefix", limit.Prefix)
+	queryValues.Set("requestsPerSecond", strconv.FormatFloat(limit.RequestsPerSecond, 'f', -1, 64))
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/ratelimit",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RateLimits - returns the rate limits of tenants along with the
+// request rate observed by each server.
+func (adm *AdminClient) RateLimits() ([]ServerRateLimits, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/ratelimit"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits []ServerRateLimits
+	err = json.Unmarshal(respBytes, &limits)
+	return limits, err
+}
@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ActiveRequest - a request being served by a server.
+type ActiveRequest struct {
+	ID         string        `json:"id"`
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	Path       string        `json:"path"`
+	RemoteHost string        `json:"remoteHost"`
+	Duration   time.Duration `json:"duration"`
+	Cancelled  bool          `json:"cancelled"`
+}
+
+// ServerActiveRequests - requests being served by a server.
+type ServerActiveRequests struct {
+	Error    string          `json:"error"`
+	Addr     string          `json:"addr"`
+	Requests []ActiveRequest `json:"requests"`
+}
+
+// ActiveRequests - Fetches the requests being served by each server,
+// oldest first.
+func (adm *AdminClient) ActiveRequests() ([]ServerActiveRequests, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/requests/active"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []ServerActiveRequests
+	if err = json.Unmarshal(respBytes, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// CancelRequest - Cancels the request with the given ID, as returned by
+// ActiveRequests. Reading the request body and writing the response
+// fail from then on, so the request ends with an error.
+func (adm *AdminClient) CancelRequest(id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	resp, err := adm.executeMethod("DELETE", requestData{
+		relPath:     "/v1/requests/active",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
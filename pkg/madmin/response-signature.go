@@ -0,0 +1,123 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// Headers carrying the signature of responses signed by the server.
+const (
+	responseDateHeader      = "X-Minio-Response-Date"
+	responseSignatureHeader = "X-Minio-Response-Signature"
+
+	responseSignatureAlgorithm = "AWS4-HMAC-SHA256"
+	responseDateFormat         = "20060102T150405Z"
+)
+
+var (
+	// ErrResponseNotSigned - returned when signed responses are
+	// required and the server did not sign the response.
+	ErrResponseNotSigned = errors.New("response is not signed by the server")
+
+	// ErrResponseSignatureMismatch - returned when the signature of
+	// a response does not match its body, which was tampered with
+	// or not signed with the secret key of the client.
+	ErrResponseSignatureMismatch = errors.New("response signature does not match")
+)
+
+// RequireSignedResponses - makes the requests whose response may be
+// signed by the server, such as ServerInfo, fail if the response is
+// not signed. Signed responses are always verified. The server signs
+// responses with its secret key when MINIO_ADMIN_SIGN_RESPONSES is on,
+// the client must use the same credentials to verify them.
+func (adm *AdminClient) RequireSignedResponses(require bool) {
+	adm.requireSignedResponses = require
+}
+
+// sumHMAC calculate hmac between two input byte array.
+func sumHMAC(key []byte, data []byte) []byte {
+	hash := hmac.New(sha256.New, key)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// verifyResponseSignature - verifies the signature of the body of
+// resp, which the server computes like the signature of a signature
+// V4 request over the method and path of the request and the SHA256
+// of the body.
+func (adm *AdminClient) verifyResponseSignature(resp *http.Response, body []byte) error {
+	authHeader := resp.Header.Get(responseSignatureHeader)
+	if authHeader == "" {
+		if adm.requireSignedResponses {
+			return ErrResponseNotSigned
+		}
+		return nil
+	}
+
+	t, err := time.Parse(responseDateFormat, resp.Header.Get(responseDateHeader))
+	if err != nil {
+		return ErrResponseSignatureMismatch
+	}
+
+	// Header is "AWS4-HMAC-SHA256 Credential=<access-key>/<scope>, Signature=<signature>"
+	// where scope is "<date>/<region>/s3/aws4_request".
+	fields := strings.SplitN(strings.TrimPrefix(authHeader, responseSignatureAlgorithm+" "), ", ", 2)
+	if len(fields) != 2 || !strings.HasPrefix(fields[0], "Credential=") || !strings.HasPrefix(fields[1], "Signature=") {
+		return ErrResponseSignatureMismatch
+	}
+	credential := strings.SplitN(strings.TrimPrefix(fields[0], "Credential="), "/", 2)
+	if len(credential) != 2 {
+		return ErrResponseSignatureMismatch
+	}
+	scope := credential[1]
+	scopeFields := strings.Split(scope, "/")
+	if len(scopeFields) != 4 || scopeFields[0] != t.Format("20060102") {
+		return ErrResponseSignatureMismatch
+	}
+	region := scopeFields[1]
+
+	canonicalResponse := strings.Join([]string{
+		resp.Request.Method,
+		resp.Request.URL.Path,
+		hex.EncodeToString(sum256(body)),
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		responseSignatureAlgorithm,
+		t.Format(responseDateFormat),
+		scope,
+		hex.EncodeToString(sum256([]byte(canonicalResponse))),
+	}, "\n")
+
+	signingKey := sumHMAC([]byte("AWS4"+adm.secretAccessKey), []byte(t.Format("20060102")))
+	for _, s := range []string{region, "s3", "aws4_request"} {
+		signingKey = sumHMAC(signingKey, []byte(s))
+	}
+	expected := hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(fields[1], "Signature="))) {
+		return ErrResponseSignatureMismatch
+	}
+	return nil
+}
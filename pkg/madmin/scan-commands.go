@@ -0,0 +1,349 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Summaries of a duplicates scan.
+const (
+	DuplicateScanRunning  = "running"
+	DuplicateScanFinished = "finished"
+	DuplicateScanStopped  = "stopped"
+)
+
+// DuplicateObject - an object found by a duplicates scan.
+type DuplicateObject struct {
+	Bucket string `json:"bucket"`
+	Object string `json:"object"`
+}
+
+// DuplicateGroup - objects sharing the same content.
+type DuplicateGroup struct {
+	// hex encoded SHA256 of the content
+	Hash    string            `json:"hash"`
+	Size    int64             `json:"size"`
+	Objects []DuplicateObject `json:"objects"`
+}
+
+// DuplicateScanStatus - progress of a duplicates scan, groups are
+// only reported once the scan is finished.
+type DuplicateScanStatus struct {
+	ClientToken    string           `json:"clientToken"`
+	Summary        string           `json:"summary"`
+	FailureDetail  string           `json:"detail,omitempty"`
+	StartTime      time.Time        `json:"startTime"`
+	EndTime        time.Time        `json:"endTime,omitempty"`
+	ObjectsScanned int64            `json:"objectsScanned"`
+	ObjectsHashed  int64            `json:"objectsHashed"`
+	Groups         []DuplicateGroup `json:"groups,omitempty"`
+}
+
+// ScanDuplicates - starts a read-only scan of the given (possibly
+// empty) bucket and prefix finding objects with identical content,
+// or returns the progress of the scan identified by clientToken.
+// forceStart stops a running scan to start a new one.
+func (adm *AdminClient) ScanDuplicates(bucket, prefix, clientToken string, forceStart bool) (status DuplicateScanStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	} else {
+		if bucket != "" {
+			queryValues.Set("bucket", bucket)
+		}
+		if prefix != "" {
+			queryValues.Set("prefix", prefix)
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/scan/duplicates",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// Summaries of an orphan scan.
+const (
+	OrphanScanRunning  = "running"
+	OrphanScanFinished = "finished"
+	OrphanScanStopped  = "stopped"
+)
+
+// OrphanShard - a data shard found on a disk which is not referenced
+// by the metadata of any object.
+type OrphanShard struct {
+	Disk      string `json:"disk"`
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	Part      string `json:"part"`
+	Size      int64  `json:"size"`
+	Reclaimed bool   `json:"reclaimed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// OrphanScanStatus - progress of an orphan scan. Orphans are counted
+// as they are found, only the first ones are listed.
+type OrphanScanStatus struct {
+	ClientToken    string        `json:"clientToken"`
+	Summary        string        `json:"summary"`
+	FailureDetail  string        `json:"detail,omitempty"`
+	StartTime      time.Time     `json:"startTime"`
+	EndTime        time.Time     `json:"endTime,omitempty"`
+	Reclaim        bool          `json:"reclaim"`
+	ObjectsScanned int64         `json:"objectsScanned"`
+	OrphanCount    int64         `json:"orphanCount"`
+	OrphanSize     int64         `json:"orphanSize"`
+	ReclaimedSize  int64         `json:"reclaimedSize"`
+	Orphans        []OrphanShard `json:"orphans,omitempty"`
+}
+
+// ScanOrphans - starts a scan of the disks of the given (possibly
+// empty) bucket finding data shards which are not referenced by the
+// metadata of any object, or returns the progress of the scan
+// identified by clientToken. Orphaned shards are only deleted if
+// reclaim is set. forceStart stops a running scan to start a new one.
+func (adm *AdminClient) ScanOrphans(bucket, clientToken string, reclaim, forceStart bool) (status OrphanScanStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	} else {
+		if bucket != "" {
+			queryValues.Set("bucket", bucket)
+		}
+		if reclaim {
+			queryValues.Set("reclaim", "true")
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/scan/orphans",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// Checksum algorithms of a checksum scan.
+const (
+	ChecksumMD5    = "md5"
+	ChecksumSHA256 = "sha256"
+	ChecksumCRC32C = "crc32c"
+)
+
+// Summaries of a checksum scan.
+const (
+	ChecksumScanRunning  = "running"
+	ChecksumScanFinished = "finished"
+	ChecksumScanStopped  = "stopped"
+)
+
+// ObjectChecksum - checksum of the content of an object.
+type ObjectChecksum struct {
+	Object string `json:"object"`
+	Size   int64  `json:"size"`
+	// hex encoded checksum of the content
+	Checksum string `json:"checksum"`
+}
+
+// ChecksumScanStatus - progress of a checksum scan along with the
+// checksums computed from Offset. The scan is complete once it is
+// not running and Offset plus the number of checksums returned
+// equals ObjectsChecksummed.
+type ChecksumScanStatus struct {
+	ClientToken        string           `json:"clientToken"`
+	Summary            string           `json:"summary"`
+	FailureDetail      string           `json:"detail,omitempty"`
+	StartTime          time.Time        `json:"startTime"`
+	EndTime            time.Time        `json:"endTime,omitempty"`
+	Bucket             string           `json:"bucket"`
+	Prefix             string           `json:"prefix"`
+	Algorithm          string           `json:"algorithm"`
+	ObjectsChecksummed int64            `json:"objectsChecksummed"`
+	Offset             int64            `json:"offset"`
+	Checksums          []ObjectChecksum `json:"checksums,omitempty"`
+}
+
+// ComputeChecksums - starts computing the checksum of each object of
+// the given bucket and (possibly empty) prefix with the given
+// algorithm, MD5 by default, or returns the progress of the scan
+// identified by clientToken along with the checksums from offset.
+// Checksums before offset are considered received and cannot be
+// requested again. forceStart stops a running scan to start a new
+// one.
+func (adm *AdminClient) ComputeChecksums(bucket, prefix, algorithm, clientToken string, offset int64, forceStart bool) (status ChecksumScanStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+		queryValues.Set("offset", strconv.FormatInt(offset, 10))
+	} else {
+		queryValues.Set("bucket", bucket)
+		if prefix != "" {
+			queryValues.Set("prefix", prefix)
+		}
+		if algorithm != "" {
+			queryValues.Set("algorithm", algorithm)
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/checksum",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// Summaries of a fsck.
+const (
+	FsckRunning  = "running"
+	FsckFinished = "finished"
+	FsckStopped  = "stopped"
+)
+
+// Severities of a fsck finding.
+const (
+	// The object or disk cannot be read, or its data is corrupted.
+	FsckCritical = "critical"
+	// Data is missing or misplaced, heal may fix it.
+	FsckWarning = "warning"
+	// Harmless leftover, such as an empty directory.
+	FsckInfo = "info"
+)
+
+// FsckFinding - an inconsistency found on a disk by a fsck.
+type FsckFinding struct {
+	Severity string `json:"severity"`
+	Disk     string `json:"disk"`
+	Bucket   string `json:"bucket,omitempty"`
+	Object   string `json:"object,omitempty"`
+	Detail   string `json:"detail"`
+}
+
+// FsckStatus - progress of a fsck. Findings are counted per severity
+// as they are found, only the first ones are listed.
+type FsckStatus struct {
+	ClientToken    string        `json:"clientToken"`
+	Summary        string        `json:"summary"`
+	FailureDetail  string        `json:"detail,omitempty"`
+	StartTime      time.Time     `json:"startTime"`
+	EndTime        time.Time     `json:"endTime,omitempty"`
+	DisksScanned   int64         `json:"disksScanned"`
+	ObjectsScanned int64         `json:"objectsScanned"`
+	CriticalCount  int64         `json:"criticalCount"`
+	WarningCount   int64         `json:"warningCount"`
+	InfoCount      int64         `json:"infoCount"`
+	Findings       []FsckFinding `json:"findings,omitempty"`
+}
+
+// Fsck - starts a read-only consistency check of the disks of the
+// given (possibly empty) bucket, or returns the progress of the check
+// identified by clientToken. Nothing is repaired. forceStart stops a
+// running check to start a new one.
+func (adm *AdminClient) Fsck(bucket, clientToken string, forceStart bool) (status FsckStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	} else {
+		if bucket != "" {
+			queryValues.Set("bucket", bucket)
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/fsck",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
@@ -0,0 +1,148 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ScannerSchedule - schedule of the background scans computing the
+// disk usage reported by StorageInfo.
+type ScannerSchedule struct {
+	// Time between the end of a scan and the start of the next one.
+	Interval time.Duration `json:"interval"`
+
+	// Pause after each entry scanned, slowing scans down.
+	Delay time.Duration `json:"delay"`
+
+	// Hours of the day, in UTC, during which scans run. Scans pause
+	// outside of them. Equal hours let scans run at any time.
+	ActiveHoursStart int `json:"activeHoursStart"`
+	ActiveHoursEnd   int `json:"activeHoursEnd"`
+}
+
+// ScannerProgress - progress of the scans of a drive, or of the
+// backend directory of a single node setup.
+type ScannerProgress struct {
+	Path           string        `json:"path"`
+	Running        bool          `json:"running"`
+	ScanStart      time.Time     `json:"scanStart,omitempty"`
+	EntriesScanned uint64        `json:"entriesScanned"`
+	LastCompletion time.Time     `json:"lastCompletion,omitempty"`
+	LastDuration   time.Duration `json:"lastDuration,omitempty"`
+}
+
+// ServerScannerProgress - progress of the scans of a server.
+type ServerScannerProgress struct {
+	Addr  string            `json:"addr"`
+	Error string            `json:"error,omitempty"`
+	Scans []ScannerProgress `json:"scans,omitempty"`
+}
+
+// ScannerStatus - schedule of the background scans along with their
+// progress on each server.
+type ScannerStatus struct {
+	Schedule ScannerSchedule         `json:"schedule"`
+	Servers  []ServerScannerProgress `json:"servers"`
+}
+
+// ServerUsageRefresh - whether the background scans of a server
+// completed again after a refresh request.
+type ServerUsageRefresh struct {
+	Addr      string `json:"addr"`
+	Refreshed bool   `json:"refreshed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// UsageRefresh - disk usage of the deployment once the background
+// scans of all servers ran again, or a timeout elapsed.
+type UsageRefresh struct {
+	Used    uint64               `json:"used"`
+	Servers []ServerUsageRefresh `json:"servers"`
+}
+
+// SetScannerSchedule - sets the schedule of the background scans of
+// all servers.
+func (adm *AdminClient) SetScannerSchedule(schedule ScannerSchedule) error {
+	content, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/scanner",
+		content: content,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ScannerStatus - returns the schedule of the background scans along
+// with their progress on each server.
+func (adm *AdminClient) ScannerStatus() (status ScannerStatus, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/scanner"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// RefreshUsage - makes the background scans of all servers run again
+// right away, and returns the disk usage once they completed or a
+// timeout elapsed.
+func (adm *AdminClient) RefreshUsage() (refresh UsageRefresh, err error) {
+	resp, err := adm.executeMethod("POST", requestData{relPath: "/v1/usage/refresh"})
+	defer closeResponse(resp)
+	if err != nil {
+		return refresh, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return refresh, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return refresh, err
+	}
+
+	err = json.Unmarshal(respBytes, &refresh)
+	return refresh, err
+}
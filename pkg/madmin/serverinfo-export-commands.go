@@ -0,0 +1,104 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ServerInfoExportConfig - periodic export of the server information
+// of all servers, as returned by ServerInfo, to objects of a bucket.
+type ServerInfoExportConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Time between two snapshots.
+	Interval time.Duration `json:"interval"`
+
+	// Bucket and prefix of the snapshot objects, each snapshot is
+	// written to an object named after the time it was taken.
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ServerInfoExporterState - state of the export on a server. Only
+// one server of a deployment takes the snapshots.
+type ServerInfoExporterState struct {
+	Addr      string `json:"addr"`
+	Error     string `json:"error,omitempty"`
+	Exporting bool   `json:"exporting"`
+
+	LastSnapshot      time.Time `json:"lastSnapshot,omitempty"`
+	LastObject        string    `json:"lastObject,omitempty"`
+	LastSnapshotError string    `json:"lastSnapshotError,omitempty"`
+}
+
+// ServerInfoExportStatus - configuration of the export along with its
+// state on each server.
+type ServerInfoExportStatus struct {
+	Config  ServerInfoExportConfig    `json:"config"`
+	Servers []ServerInfoExporterState `json:"servers"`
+}
+
+// SetServerInfoExport - enables, disables or changes the periodic
+// export of the server information.
+func (adm *AdminClient) SetServerInfoExport(config ServerInfoExportConfig) error {
+	content, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/info/export",
+		content: content,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ServerInfoExportStatus - returns the configuration of the periodic
+// export of the server information along with the last snapshot
+// taken.
+func (adm *AdminClient) ServerInfoExportStatus() (status ServerInfoExportStatus, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/info/export"})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -30,10 +31,34 @@ type ServerVersion struct {
 	CommitID string `json:"commitID"`
 }
 
+// Modes of a minio process reported by service status.
+const (
+	ServiceModeServer  = "server"
+	ServiceModeGateway = "gateway"
+)
+
+// GatewayBackendStatus - reachability of the backend of a gateway.
+type GatewayBackendStatus struct {
+	Type    string        `json:"type"`
+	Online  bool          `json:"online"`
+	Error   string        `json:"error,omitempty"`
+	Latency time.Duration `json:"latency,omitempty"`
+}
+
 // ServiceStatus - contains the response of service status API
 type ServiceStatus struct {
 	ServerVersion ServerVersion `json:"serverVersion"`
 	Uptime        time.Duration `json:"uptime"`
+	UptimeSeconds float64       `json:"uptimeSeconds"`
+
+	// Either ServiceModeServer or ServiceModeGateway, along with
+	// the status of the backend of gateways.
+	Mode    string                `json:"mode"`
+	Backend *GatewayBackendStatus `json:"backend,omitempty"`
+
+	// Starting is set while the server is still initializing its
+	// backend, the uptime is then zero.
+	Starting bool `json:"starting,omitempty"`
 }
 
 // ServiceStatus - Connect to a minio server and call Service Status
@@ -69,19 +94,92 @@ const (
 	ServiceActionValueRestart ServiceActionValue = "restart"
 	// ServiceActionValueStop represents stop action
 	ServiceActionValueStop = "stop"
+	// ServiceActionValueCancelRestart represents cancellation of
+	// a scheduled restart
+	ServiceActionValueCancelRestart = "cancel-restart"
 )
 
 // ServiceAction - represents POST body for service action APIs
 type ServiceAction struct {
 	Action ServiceActionValue `json:"action"`
+	// Delay before a restart takes effect, restarts immediately
+	// if zero
+	DelaySeconds int64 `json:"delaySeconds,omitempty"`
+}
+
+// ScheduledRestart - time at which a scheduled restart takes effect,
+// along with the token cancelling it for a restart applying a config.
+type ScheduledRestart struct {
+	RestartTime time.Time `json:"restartTime"`
+	CancelToken string    `json:"cancelToken,omitempty"`
+}
+
+// ServiceSignalAck - whether a server acknowledged a restart or stop.
+// A server which did not acknowledge it keeps running, with its
+// current config in case of a restart.
+type ServiceSignalAck struct {
+	Addr         string `json:"addr"`
+	Acknowledged bool   `json:"acknowledged"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ServiceSignalError - returned when some servers did not acknowledge
+// the restart applying a new config.
+type ServiceSignalError struct {
+	Acks []ServiceSignalAck
+}
+
+func (e ServiceSignalError) Error() string {
+	var addrs []string
+	for _, ack := range e.Acks {
+		if !ack.Acknowledged {
+			addrs = append(addrs, ack.Addr)
+		}
+	}
+	return "Servers did not acknowledge the restart: " + strings.Join(addrs, ", ")
+}
+
+// checkServiceSignalAcks - returns a ServiceSignalError if some
+// servers did not acknowledge a restart or stop.
+func checkServiceSignalAcks(acks []ServiceSignalAck) error {
+	for _, ack := range acks {
+		if !ack.Acknowledged {
+			return ServiceSignalError{Acks: acks}
+		}
+	}
+	return nil
+}
+
+// decodeServiceSignalAcks - decodes the servers acknowledging a
+// restart or stop, older servers reply without them.
+func decodeServiceSignalAcks(resp *http.Response) ([]ServiceSignalAck, error) {
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalServiceSignalAcks(respBytes)
+}
+
+// unmarshalServiceSignalAcks - decodes the servers acknowledging a
+// restart or stop from the body of a reply, which is empty for older
+// servers.
+func unmarshalServiceSignalAcks(respBytes []byte) ([]ServiceSignalAck, error) {
+	if len(respBytes) == 0 {
+		return nil, nil
+	}
+
+	var acks []ServiceSignalAck
+	err := json.Unmarshal(respBytes, &acks)
+	return acks, err
 }
 
 // ServiceSendAction - Call Service Restart/Stop API to restart/stop a
-// Minio server
-func (adm *AdminClient) ServiceSendAction(action ServiceActionValue) error {
-	body, err := json.Marshal(ServiceAction{action})
+// Minio server. Returns whether each server acknowledged the restart
+// or stop, the reply is empty when cancelling a scheduled restart.
+func (adm *AdminClient) ServiceSendAction(action ServiceActionValue) ([]ServiceSignalAck, error) {
+	body, err := json.Marshal(ServiceAction{Action: action})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Request API to Restart server
@@ -91,11 +189,49 @@ func (adm *AdminClient) ServiceSendAction(action ServiceActionValue) error {
 	})
 	defer closeResponse(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return httpRespToErrorResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
 	}
-	return nil
+	return decodeServiceSignalAcks(resp)
+}
+
+// ServiceScheduleRestart - Schedules the restart of the Minio server
+// after the delay, rounded to seconds, giving load balancers time to
+// drain it. Returns the time of the restart.
+func (adm *AdminClient) ServiceScheduleRestart(delay time.Duration) (restart ScheduledRestart, err error) {
+	if delay < time.Second {
+		return restart, ErrInvalidArgument("Restart delay must be at least one second")
+	}
+
+	body, err := json.Marshal(ServiceAction{
+		Action:       ServiceActionValueRestart,
+		DelaySeconds: int64(delay / time.Second),
+	})
+	if err != nil {
+		return restart, err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/service",
+		content: body,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return restart, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return restart, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return restart, err
+	}
+
+	err = json.Unmarshal(respBytes, &restart)
+	return restart, err
 }
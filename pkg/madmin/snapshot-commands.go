@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Summaries of a snapshot.
+const (
+	SnapshotRunning  = "running"
+	SnapshotFinished = "finished"
+	SnapshotStopped  = "stopped"
+)
+
+// SnapshotEntry - an object of a snapshot manifest.
+type SnapshotEntry struct {
+	Object  string    `json:"object"`
+	Size    int64     `json:"size"`
+	ETag    string    `json:"etag"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// SnapshotStatus - progress of a snapshot of the objects of a bucket
+// at SnapshotTime. Objects modified after it are not in the manifest
+// and are counted in ObjectsChanged. The manifest is complete once
+// the snapshot is not running and Offset plus the number of entries
+// returned equals ObjectsListed.
+type SnapshotStatus struct {
+	ClientToken    string    `json:"clientToken"`
+	Summary        string    `json:"summary"`
+	FailureDetail  string    `json:"detail,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime,omitempty"`
+	SnapshotTime   time.Time `json:"snapshotTime"`
+	Bucket         string    `json:"bucket"`
+	ObjectsListed  int64     `json:"objectsListed"`
+	ObjectsChanged int64     `json:"objectsChanged"`
+	Offset         int64     `json:"offset"`
+}
+
+// Snapshot - starts a point-in-time manifest of the objects of the
+// given bucket, or returns the progress of the snapshot identified by
+// clientToken along with the manifest entries from offset. Entries
+// before offset are considered received and cannot be requested
+// again. forceStart stops a running snapshot to start a new one.
+func (adm *AdminClient) Snapshot(bucket, clientToken string, offset int64, forceStart bool) (status SnapshotStatus, entries []SnapshotEntry, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+		queryValues.Set("offset", strconv.FormatInt(offset, 10))
+	} else {
+		queryValues.Set("bucket", bucket)
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/snapshot",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, nil, httpRespToErrorResponse(resp)
+	}
+
+	// The status is followed by the manifest entries, one per line.
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&status); err != nil {
+		return status, nil, err
+	}
+	for {
+		var entry SnapshotEntry
+		if err = decoder.Decode(&entry); err == io.EOF {
+			return status, entries, nil
+		} else if err != nil {
+			return status, nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Summaries of a speedtest.
+const (
+	SpeedtestRunning  = "running"
+	SpeedtestFinished = "finished"
+	SpeedtestStopped  = "stopped"
+)
+
+// SpeedtestStats - throughput and latency of the writes or reads of
+// a speedtest.
+type SpeedtestStats struct {
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+	// bytes per second
+	Throughput uint64        `json:"throughput"`
+	AvgLatency time.Duration `json:"avgLatency"`
+	MaxLatency time.Duration `json:"maxLatency"`
+}
+
+// SpeedtestNodeResult - outcome of a speedtest run by a server.
+type SpeedtestNodeResult struct {
+	Addr  string         `json:"addr"`
+	Error string         `json:"error,omitempty"`
+	Done  bool           `json:"done"`
+	Write SpeedtestStats `json:"write"`
+	Read  SpeedtestStats `json:"read"`
+}
+
+// SpeedtestStatus - progress of a speedtest. Write and Read aggregate
+// the results of all servers once the speedtest finished.
+type SpeedtestStatus struct {
+	ClientToken   string                `json:"clientToken"`
+	Summary       string                `json:"summary"`
+	FailureDetail string                `json:"detail,omitempty"`
+	StartTime     time.Time             `json:"startTime"`
+	EndTime       time.Time             `json:"endTime,omitempty"`
+	Size          int64                 `json:"size"`
+	Concurrency   int                   `json:"concurrency"`
+	Duration      time.Duration         `json:"duration"`
+	Nodes         []SpeedtestNodeResult `json:"nodes"`
+	Write         SpeedtestStats        `json:"write"`
+	Read          SpeedtestStats        `json:"read"`
+}
+
+// Speedtest - starts a benchmark on all servers, each writing objects
+// of the given size with the given concurrency during duration, then
+// reading them back during duration, or returns the progress of the
+// speedtest identified by clientToken. Zero values use the server
+// defaults. forceStart stops a running speedtest to start a new one.
+func (adm *AdminClient) Speedtest(size int64, concurrency int, duration time.Duration, clientToken string, forceStart bool) (status SpeedtestStatus, err error) {
+	queryValues := url.Values{}
+	if clientToken != "" {
+		queryValues.Set("clientToken", clientToken)
+	} else {
+		if size > 0 {
+			queryValues.Set("size", strconv.FormatInt(size, 10))
+		}
+		if concurrency > 0 {
+			queryValues.Set("concurrency", strconv.Itoa(concurrency))
+		}
+		if duration > 0 {
+			queryValues.Set("duration", duration.String())
+		}
+		if forceStart {
+			queryValues.Set("forceStart", "true")
+		}
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/speedtest",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
+
+// NetPerfLink - latency and bandwidth of the link from a server to
+// another one. A link is slow if it is anomalously slower than the
+// median of all links.
+type NetPerfLink struct {
+	Target string `json:"target"`
+	Error  string `json:"error,omitempty"`
+	// average and maximum round trip time
+	Latency    time.Duration `json:"latency"`
+	MaxLatency time.Duration `json:"maxLatency"`
+	// bytes per second
+	Bandwidth uint64 `json:"bandwidth"`
+	Slow      bool   `json:"slow"`
+}
+
+// ServerNetPerf - links from a server to all other servers.
+type ServerNetPerf struct {
+	Addr  string        `json:"addr"`
+	Error string        `json:"error,omitempty"`
+	Links []NetPerfLink `json:"links,omitempty"`
+}
+
+// NetPerf - latency and bandwidth matrix of the links between all
+// servers, along with the median latency and bandwidth of all links.
+type NetPerf struct {
+	Size            int64           `json:"size"`
+	MedianLatency   time.Duration   `json:"medianLatency"`
+	MedianBandwidth uint64          `json:"medianBandwidth"`
+	Servers         []ServerNetPerf `json:"servers"`
+}
+
+// NetPerf - has each server measure the latency of its links to all
+// other servers, and their bandwidth by sending size bytes over each
+// link. A zero size uses the server default.
+func (adm *AdminClient) NetPerf(size int64) (perf NetPerf, err error) {
+	queryValues := url.Values{}
+	if size > 0 {
+		queryValues.Set("size", strconv.FormatInt(size, 10))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/netperf",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return perf, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return perf, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return perf, err
+	}
+
+	err = json.Unmarshal(respBytes, &perf)
+	return perf, err
+}
@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// BucketStorageClass - default storage class of the objects written to
+// a bucket without a storage class.
+type BucketStorageClass struct {
+	Bucket       string `json:"bucket"`
+	StorageClass string `json:"storageClass"`
+}
+
+// ParityInfo - parity of the standard storage class applied to new
+// writes, and the range it may be set to.
+type ParityInfo struct {
+	SetDriveCount int    `json:"setDriveCount"`
+	Parity        int    `json:"parity"`
+	Source        string `json:"source"`
+	DefaultParity int    `json:"defaultParity"`
+	MinParity     int    `json:"minParity"`
+	MaxParity     int    `json:"maxParity"`
+}
+
+// SetBucketStorageClass - sets the default storage class, STANDARD or
+// REDUCED_REDUNDANCY, of the objects written to the given bucket
+// without a storage class, on all servers. An empty storage class
+// removes the default.
+func (adm *AdminClient) SetBucketStorageClass(bucket, storageClass string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("storageClass", storageClass)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/bucket/storageclass",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// BucketStorageClasses - returns the default storage class of each
+// bucket which has one.
+func (adm *AdminClient) BucketStorageClasses() ([]BucketStorageClass, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/bucket/storageclass"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var classes []BucketStorageClass
+	err = json.Unmarshal(respBytes, &classes)
+	return classes, err
+}
+
+// SetParity - sets the parity of the standard storage class applied to
+// new writes on all servers, existing objects keep their parity until
+// rewritten or healed. A zero parity restores the default.
+func (adm *AdminClient) SetParity(parity int) error {
+	queryValues := url.Values{}
+	queryValues.Set("parity", strconv.Itoa(parity))
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/parity",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// Parity - returns the parity of the standard storage class applied to
+// new writes, and the range it may be set to.
+func (adm *AdminClient) Parity() (info ParityInfo, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/parity"})
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(respBytes, &info)
+	return info, err
+}
@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// TLSCertificate - certificate of the chain served by a server over
+// TLS.
+type TLSCertificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// ServerTLSReload - certificate chain a server serves after reloading
+// its certificate files, or the error which prevented it.
+type ServerTLSReload struct {
+	Addr         string           `json:"addr"`
+	Error        string           `json:"error,omitempty"`
+	Certificates []TLSCertificate `json:"certificates,omitempty"`
+}
+
+// ReloadTLSCerts - makes all servers reload their TLS certificate and
+// private key files, without restarting. Servers which fail to load
+// them keep serving their current certificate.
+func (adm *AdminClient) ReloadTLSCerts() ([]ServerTLSReload, error) {
+	resp, err := adm.executeMethod("POST", requestData{relPath: "/v1/tls/reload"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var reloads []ServerTLSReload
+	err = json.Unmarshal(respBytes, &reloads)
+	return reloads, err
+}
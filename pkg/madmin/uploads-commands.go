@@ -0,0 +1,69 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// BucketUploadsUsage - in-progress multipart uploads of a bucket. An
+// empty bucket holds the uploads created by older servers, which did
+// not record their bucket.
+type BucketUploadsUsage struct {
+	Bucket string `json:"bucket"`
+	Count  int64  `json:"count"`
+
+	// Size of the parts uploaded so far.
+	Size int64 `json:"size"`
+
+	// Last modification time of the least recently modified upload.
+	OldestModTime time.Time `json:"oldestModTime"`
+}
+
+// UploadsUsage - count and size of the in-progress multipart uploads
+// of the cluster, per bucket and in total.
+type UploadsUsage struct {
+	Count   int64                `json:"count"`
+	Size    int64                `json:"size"`
+	Buckets []BucketUploadsUsage `json:"buckets"`
+}
+
+// UploadsUsage - returns the count and size of the in-progress
+// multipart uploads, whose parts use storage until the uploads are
+// completed or aborted.
+func (adm *AdminClient) UploadsUsage() (usage UploadsUsage, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/uploads/usage"})
+	defer closeResponse(resp)
+	if err != nil {
+		return usage, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return usage, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return usage, err
+	}
+
+	err = json.Unmarshal(respBytes, &usage)
+	return usage, err
+}
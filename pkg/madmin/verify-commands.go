@@ -0,0 +1,213 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ObjectShardState - state of the data of a part of an object on a
+// disk, with the bitrot checksum recorded for it on the disk.
+type ObjectShardState struct {
+	Part      string `json:"part"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ObjectDiskState - metadata and data of an object as found on one
+// disk of its erasure set.
+type ObjectDiskState struct {
+	Index            int                `json:"index"`
+	Disk             string             `json:"disk,omitempty"`
+	Consistent       bool               `json:"consistent"`
+	Error            string             `json:"error,omitempty"`
+	MetadataChecksum string             `json:"metadataChecksum,omitempty"`
+	ModTime          time.Time          `json:"modTime"`
+	Size             int64              `json:"size"`
+	ETag             string             `json:"etag,omitempty"`
+	ErasureIndex     int                `json:"erasureIndex,omitempty"`
+	Shards           []ObjectShardState `json:"shards,omitempty"`
+}
+
+// ObjectVerifyResult - whether all disks of the erasure set of an
+// object hold the same metadata and intact data for it.
+type ObjectVerifyResult struct {
+	Bucket         string            `json:"bucket"`
+	Object         string            `json:"object"`
+	Consistent     bool              `json:"consistent"`
+	Disks          []ObjectDiskState `json:"disks"`
+	DivergentDisks []int             `json:"divergentDisks,omitempty"`
+}
+
+// VerifyObject - reads the metadata and data of the object from every
+// disk of its erasure set and reports whether they agree. Nothing is
+// repaired, use Heal for that.
+func (adm *AdminClient) VerifyObject(bucket, object string) (result ObjectVerifyResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/object/verify",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(respBytes, &result)
+	return result, err
+}
+
+// ObjectHealDetail - erasure state of an object on every disk of its
+// erasure set, along with whether heal can repair the divergent disks
+// and the reason it cannot.
+type ObjectHealDetail struct {
+	ObjectVerifyResult
+
+	DataBlocks   int `json:"dataBlocks"`
+	ParityBlocks int `json:"parityBlocks"`
+
+	// Erasure index of the shards each disk should hold, as recorded
+	// in the reference metadata.
+	Distribution []int `json:"distribution,omitempty"`
+
+	// Disks holding the reference metadata and intact shards, from
+	// which heal rebuilds the others.
+	HealthyDisks int `json:"healthyDisks"`
+
+	Healable bool   `json:"healable"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// HealObjectDetail - inspects the metadata and data of the object on
+// every disk of its erasure set, as VerifyObject does, and reports
+// whether heal can repair it. Nothing is repaired, use Heal for that.
+func (adm *AdminClient) HealObjectDetail(bucket, object string) (detail ObjectHealDetail, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/heal/detail",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return detail, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return detail, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return detail, err
+	}
+
+	err = json.Unmarshal(respBytes, &detail)
+	return detail, err
+}
+
+// States of a bucket reported by VerifyBuckets.
+const (
+	// The bucket exists on all online disks along with its config
+	// files.
+	BucketVerifyOK = "ok"
+	// The bucket or its config files are missing on some disks, from
+	// which they are recreated out of the quorum of the other disks.
+	BucketVerifyInconsistent = "inconsistent"
+	// Config files are left over from a bucket which no longer
+	// exists on any disk, they are removed.
+	BucketVerifyOrphaned = "orphaned-metadata"
+	// The bucket exists on too few disks to be recovered from quorum,
+	// nothing is repaired, use Heal to inspect it.
+	BucketVerifyNoQuorum = "no-quorum"
+	// The bucket could not be verified.
+	BucketVerifyFailed = "failed"
+)
+
+// BucketVerifyResult - findings on a bucket, along with whether they
+// were repaired.
+type BucketVerifyResult struct {
+	Bucket string `json:"bucket"`
+	State  string `json:"state"`
+	// Online disks not holding the bucket
+	MissingDisks []string `json:"missingDisks,omitempty"`
+	// Config files missing or corrupt on some disks
+	MetadataFiles []string `json:"metadataFiles,omitempty"`
+	Repaired      bool     `json:"repaired"`
+	Detail        string   `json:"detail,omitempty"`
+}
+
+// BucketVerifyReport - findings on every bucket found on any disk or
+// having config files.
+type BucketVerifyReport struct {
+	DryRun bool `json:"dryRun"`
+	// Disks which could not be listed
+	OfflineDisks int                  `json:"offlineDisks"`
+	Buckets      []BucketVerifyResult `json:"buckets"`
+}
+
+// VerifyBuckets - checks that every bucket and its config files exist
+// on all disks, recreating them from quorum where they are missing and
+// removing the config files of buckets which no longer exist. Nothing
+// is repaired on a dry run. Narrower and faster than a heal of all
+// buckets, objects are not inspected.
+func (adm *AdminClient) VerifyBuckets(dryRun bool) (report BucketVerifyReport, err error) {
+	queryValues := url.Values{}
+	if dryRun {
+		queryValues.Set("dryRun", "")
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/bucket/verify",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return report, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return report, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return report, err
+	}
+
+	err = json.Unmarshal(respBytes, &report)
+	return report, err
+}
@@ -184,6 +184,50 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyEvaluate(t *testing.T) {
+	allowStatement := NewStatement(
+		Allow,
+		NewPrincipal("*"),
+		NewActionSet(GetObjectAction, PutObjectAction),
+		NewResourceSet(NewResource("mybucket", "/*")),
+		condition.NewFunctions(),
+	)
+	denyStatement := NewStatement(
+		Deny,
+		NewPrincipal("*"),
+		NewActionSet(PutObjectAction),
+		NewResourceSet(NewResource("mybucket", "/private/*")),
+		condition.NewFunctions(),
+	)
+	case1Policy := Policy{
+		Version:    DefaultVersion,
+		Statements: []Statement{allowStatement, denyStatement},
+	}
+
+	testCases := []struct {
+		args                   Args
+		expectedResult         bool
+		expectedStatementIndex int
+	}{
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, 0},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, false, 1},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "private/myobject", IsOwner: true}, false, 1},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, -1},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsOwner: true}, true, -1},
+	}
+
+	for i, testCase := range testCases {
+		result, statementIndex := case1Policy.Evaluate(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+		if statementIndex != testCase.expectedStatementIndex {
+			t.Fatalf("case %v: expected statement: %v, got: %v\n", i+1, testCase.expectedStatementIndex, statementIndex)
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,